@@ -1,13 +1,16 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"github.com/Hvaekar/med-account/cmd/account/handler"
+	"github.com/Hvaekar/med-account/cmd/account/worker"
 	"github.com/Hvaekar/med-account/config"
 	"github.com/Hvaekar/med-account/internal/account"
 	"github.com/Hvaekar/med-account/pkg/amazon"
 	"github.com/Hvaekar/med-account/pkg/broker"
 	"github.com/Hvaekar/med-account/pkg/broker/kafka"
+	"github.com/Hvaekar/med-account/pkg/licence"
 	"github.com/Hvaekar/med-account/pkg/logger"
 	"github.com/Hvaekar/med-account/pkg/server"
 	"github.com/Hvaekar/med-account/pkg/server/ginmiddleware"
@@ -43,12 +46,15 @@ func Serve() *cli.Command {
 			awsSession := aws.CreateSession()
 
 			// create app
+			// workers stop when the command context is cancelled on shutdown
 			router, db, _, _, err := CreateApp(
+				ctx.Context,
 				cfg,
 				log,
 				aws,
 				s3manager.NewUploader(awsSession),
 				s3manager.NewDownloader(awsSession),
+				licence.NewRegistry(&cfg.Licence),
 			)
 			if err != nil {
 				return fmt.Errorf("create app: %w", err)
@@ -91,11 +97,13 @@ func Serve() *cli.Command {
 }
 
 func CreateApp(
+	ctx context.Context,
 	cfg *config.Config,
 	log logger.Logger,
 	aws *amazon.AWS,
 	s3Uploader s3manageriface.UploaderAPI,
 	s3Downloader s3manageriface.DownloaderAPI,
+	licenceChecker licence.Checker,
 ) (*gin.Engine, storage.Storage, *amazon.S3, broker.MessageBroker, error) {
 	// connect to postgres storage
 	psqlStorage := postgres.NewPostgres(&cfg.Postgres)
@@ -120,7 +128,7 @@ func CreateApp(
 
 	psql := account.NewPostgresStorage(psqlStorage)
 
-	basicH := handler.NewBasicHandler(log, cfg, psql, awsS3, kafkaMB)
+	basicH := handler.NewBasicHandler(log, cfg, psql, awsS3, kafkaMB, licenceChecker)
 	authH := handler.NewAuthHandler(basicH)
 	accountH := handler.NewAccountHandler(basicH)
 	fileH := handler.NewFileHandler(basicH)
@@ -129,16 +137,42 @@ func CreateApp(
 	addressH := handler.NewAddressHandler(basicH)
 	langH := handler.NewLanguageHandler(basicH)
 	profileH := handler.NewProfileHandler(basicH)
+	privacyH := handler.NewPrivacyHandler(basicH)
+	exportH := handler.NewExportHandler(basicH)
 	patientH := handler.NewPatientHandler(basicH)
 	metalComponentH := handler.NewMetalComponentHandler(basicH)
+	measurementH := handler.NewMeasurementHandler(basicH)
+	allergyH := handler.NewAllergyHandler(basicH)
+	conditionH := handler.NewConditionHandler(basicH)
+	medicationH := handler.NewMedicationHandler(basicH)
+	vaccinationH := handler.NewVaccinationHandler(basicH)
+	emergencyContactH := handler.NewEmergencyContactHandler(basicH)
+	insuranceH := handler.NewInsuranceHandler(basicH)
+	familyHistoryH := handler.NewFamilyHistoryHandler(basicH)
+	lifestyleH := handler.NewLifestyleHandler(basicH)
 	adminH := handler.NewPatientAdminHandler(basicH)
+	patientSpecialistH := handler.NewPatientSpecialistHandler(basicH)
+	patientHistoryH := handler.NewPatientHistoryHandler(basicH)
 	specialistH := handler.NewSpecialistHandler(basicH)
 	specializationH := handler.NewSpecializationHandler(basicH)
 	educationH := handler.NewEducationHandler(basicH)
+	courseH := handler.NewEducationalCourseHandler(basicH)
 	experienceH := handler.NewExperienceHandler(basicH)
 	associationH := handler.NewAssociationHandler(basicH)
 	patentH := handler.NewPatentHandler(basicH)
 	publicationH := handler.NewPublicationHandler(basicH)
+	teachingH := handler.NewTeachingHandler(basicH)
+	speechH := handler.NewSpeechHandler(basicH)
+	licenceH := handler.NewLicenceHandler(basicH)
+	organizationH := handler.NewOrganizationHandler(basicH)
+	organizationLicenceH := handler.NewOrganizationLicenceHandler(basicH)
+	organizationMemberH := handler.NewOrganizationMemberHandler(basicH)
+	organizationInvitationH := handler.NewOrganizationInvitationHandler(basicH)
+	verificationH := handler.NewVerificationHandler(basicH)
+	scheduleH := handler.NewScheduleHandler(basicH)
+	reviewH := handler.NewReviewHandler(basicH)
+	moderationH := handler.NewModerationHandler(basicH)
+	fhirH := handler.NewFHIRHandler(basicH)
 
 	router.Use(
 		gin.Recovery(),
@@ -159,18 +193,78 @@ func CreateApp(
 	addressH.InitRoutes(arg)
 	langH.InitRoutes(arg)
 	profileH.InitRoutes(arg)
+	privacyH.InitRoutes(arg)
+	exportH.InitRoutes(arg)
+	organizationInvitationH.InitAccountRoutes(arg)
 	prg := patientH.InitRoutes(router)
 	metalComponentH.InitRoutes(prg)
+	measurementH.InitRoutes(prg)
+	allergyH.InitRoutes(prg)
+	conditionH.InitRoutes(prg)
+	medicationH.InitRoutes(prg)
+	vaccinationH.InitRoutes(prg)
+	emergencyContactH.InitRoutes(prg)
+	insuranceH.InitRoutes(prg)
+	familyHistoryH.InitRoutes(prg)
+	lifestyleH.InitRoutes(prg)
 	adminH.InitRoutes(prg)
+	patientSpecialistH.InitRoutes(prg)
+	patientHistoryH.InitRoutes(prg)
+	reviewH.InitRoutes(prg)
 	srg := specialistH.InitRoutes(router)
 	specializationH.InitRoutes(srg)
 	educationH.InitRoutes(srg)
+	courseH.InitRoutes(srg)
 	experienceH.InitRoutes(srg)
 	associationH.InitRoutes(srg)
 	patentH.InitRoutes(srg)
 	publicationH.InitRoutes(srg)
+	teachingH.InitRoutes(srg)
+	speechH.InitRoutes(srg)
+	licenceH.InitRoutes(srg)
+	verificationH.InitRoutes(srg)
+	scheduleH.InitRoutes(srg)
+	scheduleH.InitSpecialistsRoutes(router)
+	reviewH.InitSpecialistRoutes(srg)
+	reviewH.InitSpecialistsRoutes(router)
+	metalComponentH.InitSpecialistRoutes(srg)
+	measurementH.InitSpecialistRoutes(srg)
+	allergyH.InitSpecialistRoutes(srg)
+	conditionH.InitSpecialistRoutes(srg)
+	medicationH.InitSpecialistRoutes(srg)
+	vaccinationH.InitSpecialistRoutes(srg)
+	emergencyContactH.InitSpecialistRoutes(srg)
+	lifestyleH.InitSpecialistRoutes(srg)
+	patientSpecialistH.InitSpecialistRoutes(srg)
+	org := organizationH.InitRoutes(router)
+	organizationLicenceH.InitRoutes(org)
+	organizationMemberH.InitRoutes(org)
+	organizationInvitationH.InitRoutes(org)
+	moderationH.InitRoutes(router)
+	insuranceH.InitServiceRoutes(router)
+	fhirH.InitRoutes(router)
 
 	pprof.Register(router)
 
+	// licence expiry reminders
+	if cfg.Licence.RemindInterval > 0 {
+		go worker.NewLicenceReminder(log, &cfg.Licence, psql, kafkaMB).Run(ctx)
+	}
+
+	// insurance policy expiry notifications
+	if cfg.Insurance.RemindInterval > 0 {
+		go worker.NewInsuranceReminder(log, &cfg.Insurance, psql, kafkaMB).Run(ctx)
+	}
+
+	// personal data export archives
+	if cfg.Export.Interval > 0 {
+		go worker.NewAccountExporter(log, &cfg.Export, psql, awsS3, kafkaMB).Run(ctx)
+	}
+
+	// duplicate patient profiles detection
+	if cfg.Duplicate.Interval > 0 {
+		go worker.NewDuplicateDetector(log, &cfg.Duplicate, psql, kafkaMB).Run(ctx)
+	}
+
 	return router, psqlStorage, awsS3, kafkaMB, nil
 }
@@ -161,7 +161,7 @@ func (h *AccountHandler) UpdatePhoto(c *gin.Context) {
 }
 
 func (h *AccountHandler) GetAccounts(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 
 	var req model.ListAccountsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -176,11 +176,18 @@ func (h *AccountHandler) GetAccounts(c *gin.Context) {
 		return
 	}
 
+	for _, v := range accs {
+		if err := h.applyAccountPrivacy(c, a.ID, v); err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
 	h.sendOK(c, http.StatusOK, model.ListAccounts{Accounts: accs})
 }
 
 func (h *AccountHandler) GetAccount(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 
 	accID, err := CheckParamInt64(c, "id")
 	if err != nil {
@@ -188,11 +195,16 @@ func (h *AccountHandler) GetAccount(c *gin.Context) {
 		return
 	}
 
-	a, err := h.storage.GetAccountByID(c, accID)
+	acc, err := h.storage.GetAccountByID(c, accID)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
 	}
 
-	h.sendOK(c, http.StatusOK, a)
+	if err := h.applyAccountPrivacy(c, a.ID, acc); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, acc)
 }
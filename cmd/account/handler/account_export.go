@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ExportHandler struct {
+	*BasicHandler
+}
+
+func NewExportHandler(basicHandler *BasicHandler) *ExportHandler {
+	return &ExportHandler{BasicHandler: basicHandler}
+}
+
+func (h *ExportHandler) InitRoutes(r gin.IRouter) {
+	e := r.Group("/export")
+	{
+		e.POST("", h.AddExport)
+		e.GET("", h.GetExports)
+		e.GET("/:export_id", h.GetExport)
+		e.GET("/:export_id/download", h.DownloadExport)
+	}
+}
+
+// AddExport only queues the job, the archive is built in background and the account is notified when it is ready.
+func (h *ExportHandler) AddExport(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	e, err := h.storage.AddAccountExport(c, a.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrExportInProgress, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ExportAddKey, model.ExportMessage{AccountID: a.ID, Export: e}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusAccepted, e)
+}
+
+func (h *ExportHandler) GetExports(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	es, err := h.storage.GetAccountExports(c, a.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.ListExports{Exports: es})
+}
+
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	exportID, err := CheckParamInt64(c, "export_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.GetAccountExportByID(c, exportID, a.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, e)
+}
+
+func (h *ExportHandler) DownloadExport(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	exportID, err := CheckParamInt64(c, "export_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.GetAccountExportByID(c, exportID, a.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if e.Status != model.ExportStatusReady || e.FileKey == nil {
+		h.sendError(c, ErrExportNotReady, http.StatusBadRequest)
+		return
+	}
+
+	o, err := h.s3.GetObject(c, *e.FileKey)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+	defer o.Body.Close()
+
+	var size int64 = -1
+	if o.ContentLength != nil {
+		size = *o.ContentLength
+	}
+
+	headers := map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=\"export-%d.zip\"", e.ID),
+	}
+
+	c.DataFromReader(http.StatusOK, size, "application/zip", o.Body, headers)
+}
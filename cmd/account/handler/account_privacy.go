@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PrivacyHandler struct {
+	*BasicHandler
+}
+
+func NewPrivacyHandler(basicHandler *BasicHandler) *PrivacyHandler {
+	return &PrivacyHandler{BasicHandler: basicHandler}
+}
+
+func (h *PrivacyHandler) InitRoutes(r gin.IRouter) {
+	p := r.Group("/privacy")
+	{
+		p.GET("", h.GetPrivacySettings)
+		p.PUT("", h.UpdatePrivacySettings)
+	}
+}
+
+func (h *PrivacyHandler) GetPrivacySettings(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	ps, err := h.storage.GetPrivacySettings(c, a.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, ps)
+}
+
+func (h *PrivacyHandler) UpdatePrivacySettings(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	var req model.UpdatePrivacySettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ps, err := h.storage.UpdatePrivacySettings(c, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.PrivacyUpdateKey, model.IDMessage{ID: a.ID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, ps)
+}
+
+func (h *BasicHandler) getPrivacy(c *gin.Context, viewerID int64, ownerID int64) (*model.PrivacySettings, model.PrivacyRelation, error) {
+	if viewerID == ownerID {
+		return nil, model.PrivacyRelation{Self: true}, nil
+	}
+
+	ps, err := h.storage.GetPrivacySettings(c, ownerID)
+	if err != nil {
+		return nil, model.PrivacyRelation{}, err
+	}
+
+	r, err := h.storage.GetPrivacyRelation(c, viewerID, ownerID)
+	if err != nil {
+		return nil, model.PrivacyRelation{}, err
+	}
+	r.User = true
+
+	return ps, *r, nil
+}
+
+func (h *BasicHandler) applyAccountPrivacy(c *gin.Context, viewerID int64, a *model.Account) error {
+	ps, r, err := h.getPrivacy(c, viewerID, a.ID)
+	if err != nil {
+		return err
+	}
+
+	a.ApplyPrivacy(ps, r)
+
+	return nil
+}
+
+func (h *BasicHandler) applyPatientPrivacy(c *gin.Context, viewerID int64, p *model.Patient) error {
+	ownerID := p.AccountID
+	if ownerID == 0 {
+		ownerID = p.OwnerID
+	}
+
+	ps, r, err := h.getPrivacy(c, viewerID, ownerID)
+	if err != nil {
+		return err
+	}
+
+	p.ApplyPrivacy(ps, r)
+
+	return nil
+}
+
+func (h *BasicHandler) applySpecialistPrivacy(c *gin.Context, viewerID int64, s *model.Specialist) error {
+	ps, r, err := h.getPrivacy(c, viewerID, s.AccountID)
+	if err != nil {
+		return err
+	}
+
+	s.ApplyPrivacy(ps, r)
+
+	return nil
+}
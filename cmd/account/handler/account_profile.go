@@ -4,10 +4,15 @@ import (
 	"github.com/Hvaekar/med-account/pkg/broker"
 	"github.com/Hvaekar/med-account/pkg/jwt"
 	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
+// handoverCodeLength is the number of random bytes in a handover code, it is sent hex-encoded
+const handoverCodeLength = 32
+
 type ProfileHandler struct {
 	*BasicHandler
 }
@@ -21,7 +26,10 @@ func (h *ProfileHandler) InitRoutes(r gin.IRouter) {
 	{
 		e.GET("", h.GetProfiles)
 
+		e.POST("/patient", h.AddDependentPatientProfile)
+		e.PUT("/patient/:profile_id", h.UpdateDependentPatientProfile)
 		e.PUT("/patient/:profile_id/verify", h.VerifyPatientProfile)
+		e.PUT("/patient/:profile_id/handover", h.HandOverPatientProfile)
 		e.GET("/patient/:profile_id/select", h.SelectPatientProfile)
 		e.DELETE("/patient/:profile_id", h.DeletePatientProfile)
 
@@ -35,6 +43,69 @@ func (h *ProfileHandler) GetProfiles(c *gin.Context) {
 	h.sendOK(c, http.StatusOK, a.Profiles)
 }
 
+func (h *ProfileHandler) AddDependentPatientProfile(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	var req model.AddDependentPatient
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.Birthday.Time.After(time.Now()) {
+		h.sendError(c, ErrInvalidField, http.StatusBadRequest)
+		return
+	}
+
+	p, err := h.storage.AddDependentPatientProfile(c, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.AccountPatientMessage{AdminID: a.ID, PatientID: p.ID, PermissionEdit: true}
+	if err := h.broker.SendMessage(broker.PatientAddKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, p)
+}
+
+// UpdateDependentPatientProfile changes the name, sex and birthday of a dependent that was not handed over yet.
+func (h *ProfileHandler) UpdateDependentPatientProfile(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	profileID, err := CheckParamInt64(c, "profile_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateDependentPatient
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.Birthday.Time.After(time.Now()) {
+		h.sendError(c, ErrInvalidField, http.StatusBadRequest)
+		return
+	}
+
+	p, err := h.storage.UpdateDependentPatientProfile(c, a.ID, profileID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.AccountPatientMessage{AdminID: a.ID, PatientID: p.ID}
+	if err := h.broker.SendMessage(broker.PatientUpdateKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, p)
+}
+
 func (h *ProfileHandler) VerifyPatientProfile(c *gin.Context) {
 	a := c.MustGet("current_account").(*model.Account)
 
@@ -96,6 +167,36 @@ func (h *ProfileHandler) SelectPatientProfile(c *gin.Context) {
 	h.sendOK(c, http.StatusOK, model.Token{Access: *accessToken})
 }
 
+// HandOverPatientProfile issues a code, the dependent registers with it to take over the profile.
+func (h *ProfileHandler) HandOverPatientProfile(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	profileID, err := CheckParamInt64(c, "profile_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	code, err := utils.NewToken(handoverCodeLength)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	ho := model.PatientHandover{
+		PatientID: *profileID,
+		Code:      code,
+		ExpiresAt: time.Now().Add(h.cfg.Verify.HandoverCodeExpiresAt),
+	}
+
+	if err := h.storage.SetPatientProfileHandover(c, a.ID, profileID, ho.Code, ho.ExpiresAt); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, ho)
+}
+
 func (h *ProfileHandler) DeletePatientProfile(c *gin.Context) {
 	a := c.MustGet("current_account").(*model.Account)
 
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"github.com/Hvaekar/med-account/pkg/broker"
 	"github.com/Hvaekar/med-account/pkg/jwt"
 	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strings"
@@ -42,6 +44,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	a, err := h.storage.Register(c, &req)
 	if err != nil {
+		if req.HandoverCode != nil && errors.Is(err, storage.ErrNotFound) {
+			h.sendError(c, ErrHandoverCode, http.StatusBadRequest)
+			return
+		}
+
 		h.sendError(c, err, http.StatusBadRequest)
 		return
 	}
@@ -50,6 +57,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		h.log.Error(err)
 	}
 
+	if req.HandoverCode != nil {
+		msg := model.AccountPatientMessage{AdminID: a.ID, PatientID: a.Profiles.PatientProfileID}
+		if err := h.broker.SendMessage(broker.PatientHandoverKey, msg); err != nil {
+			h.log.Error(err)
+		}
+	}
+
 	payload := model.TokenPayload{
 		AccountID:    a.ID,
 		PatientID:    a.Profiles.PatientProfileID,
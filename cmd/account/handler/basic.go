@@ -5,6 +5,7 @@ import (
 	"github.com/Hvaekar/med-account/internal/account"
 	"github.com/Hvaekar/med-account/pkg/amazon"
 	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/licence"
 	"github.com/Hvaekar/med-account/pkg/logger"
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/Hvaekar/med-account/pkg/storage"
@@ -18,10 +19,11 @@ type BasicHandler struct {
 	storage account.Storage
 	s3      *amazon.S3
 	broker  broker.MessageBroker
+	licence licence.Checker
 }
 
-func NewBasicHandler(log logger.Logger, cfg *config.Config, storage account.Storage, s3 *amazon.S3, broker broker.MessageBroker) *BasicHandler {
-	return &BasicHandler{log: log, cfg: cfg, storage: storage, s3: s3, broker: broker}
+func NewBasicHandler(log logger.Logger, cfg *config.Config, storage account.Storage, s3 *amazon.S3, broker broker.MessageBroker, licence licence.Checker) *BasicHandler {
+	return &BasicHandler{log: log, cfg: cfg, storage: storage, s3: s3, broker: broker, licence: licence}
 }
 
 func (h *BasicHandler) sendError(ctx *gin.Context, err error, code int) {
@@ -45,3 +47,18 @@ func (h *BasicHandler) sendError(ctx *gin.Context, err error, code int) {
 func (h *BasicHandler) sendOK(ctx *gin.Context, code int, val any) {
 	ctx.JSON(code, val)
 }
+
+func (h *BasicHandler) checkAccountFiles(c *gin.Context, accountID int64, files []int64) error {
+	for _, v := range files {
+		f, err := h.storage.GetFileByID(c, v)
+		if err != nil {
+			return err
+		}
+
+		if f.AccountID != accountID {
+			return ErrNoPermissions
+		}
+	}
+
+	return nil
+}
@@ -3,10 +3,40 @@ package handler
 import "errors"
 
 var (
-	ErrNoPermissions = errors.New("you have no permissions here")
-	ErrMissingParam  = errors.New("missing incoming parameter")
-	ErrInvalidParam  = errors.New("invalid input parameter")
-	ErrInvalidField  = errors.New("invalid input field")
-	ErrNoToken       = errors.New("request does not contain a token")
-	ErrLimitExceeded = errors.New("limit is exceeded")
+	ErrNoPermissions   = errors.New("you have no permissions here")
+	ErrMissingParam    = errors.New("missing incoming parameter")
+	ErrInvalidParam    = errors.New("invalid input parameter")
+	ErrInvalidField    = errors.New("invalid input field")
+	ErrNoToken         = errors.New("request does not contain a token")
+	ErrLimitExceeded   = errors.New("limit is exceeded")
+	ErrMemberExists    = errors.New("account is already a member of the organization")
+	ErrNoComment       = errors.New("comment is required")
+	ErrLicenceExists   = errors.New("licence with this number already exists")
+	ErrLicenceStatus   = errors.New("licence status can not be changed this way")
+	ErrLicenceInactive = errors.New("licence is not active in the registry")
+	ErrLicenceExpired  = errors.New("licence is expired, renew it before reactivating")
+	ErrInvalidICD10    = errors.New("unknown icd-10 code")
+	ErrPrescriber      = errors.New("prescribing specialist not found")
+	ErrFinishDate      = errors.New("finish date is before start date")
+	ErrNoBirthday      = errors.New("patient birthday is not set")
+	ErrPolicyExists    = errors.New("insurance policy with this number already exists")
+	ErrPolicyOverlap   = errors.New("insurance policy overlaps another policy of the same coverage type")
+	ErrHandoverCode    = errors.New("handover code is invalid or expired")
+	ErrRelative        = errors.New("relative profile is not administered by the account")
+
+	ErrScheduleLocation = errors.New("location is not an organization or address of the specialist")
+	ErrScheduleExists   = errors.New("schedule for this location already exists")
+
+	ErrReviewNotAllowed = errors.New("only patients of the specialist can review them")
+	ErrReviewExists     = errors.New("review of this specialist already exists")
+
+	ErrDuplicateReviewed = errors.New("duplicate is already reviewed")
+	ErrMergeSurvivor     = errors.New("survivor must be one of the duplicate profiles")
+	ErrMergeAccount      = errors.New("profile of a registered account can only be the survivor")
+
+	ErrExportInProgress = errors.New("previous export is not finished yet")
+	ErrExportNotReady   = errors.New("export is not ready")
+
+	ErrFHIRFormat      = errors.New("only json format is supported")
+	ErrFHIRSearchParam = errors.New("required search parameter is missing or invalid")
 )
@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/fhir"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type FHIRHandler struct {
+	*BasicHandler
+}
+
+func NewFHIRHandler(basicHandler *BasicHandler) *FHIRHandler {
+	return &FHIRHandler{BasicHandler: basicHandler}
+}
+
+func (h *FHIRHandler) InitRoutes(r gin.IRouter) {
+	f := r.Group("/fhir", h.CheckFHIRFormat(), h.IdentifyAccount(), h.CheckAccountRoles(model.AccountRoleService, model.AccountRoleAdmin))
+	{
+		f.GET("/Patient", h.SearchPatients)
+		f.GET("/Patient/:id", h.GetPatient)
+		f.GET("/Observation", h.SearchObservations)
+		f.GET("/Device", h.SearchDevices)
+		f.GET("/Practitioner", h.SearchPractitioners)
+		f.GET("/Practitioner/:id", h.GetPractitioner)
+		f.GET("/PractitionerRole", h.SearchPractitionerRoles)
+		f.GET("/PractitionerRole/:id", h.GetPractitionerRole)
+	}
+}
+
+// CheckFHIRFormat accepts the _format values meaning json, an unescaped "+" arrives as a space.
+func (h *FHIRHandler) CheckFHIRFormat() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch strings.ReplaceAll(c.Query("_format"), " ", "+") {
+		case "", "json", "application/json", "application/fhir+json":
+			c.Next()
+		default:
+			h.sendFHIRError(c, ErrFHIRFormat, http.StatusNotAcceptable)
+			c.Abort()
+		}
+	}
+}
+
+func (h *FHIRHandler) GetPatient(c *gin.Context) {
+	p, err := h.getFHIRPatient(c, c.Param("id"))
+	if err != nil {
+		h.sendFHIRError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewPatient(p))
+}
+
+// SearchPatients supports _id and identifier, only our own patient identifiers are known.
+func (h *FHIRHandler) SearchPatients(c *gin.Context) {
+	value := c.Query("_id")
+	if value == "" {
+		var system string
+		system, value = fhir.ParseToken(c.Query("identifier"))
+		if system != "" && system != fhir.SystemPatient {
+			h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c)))
+			return
+		}
+	}
+
+	if value == "" {
+		h.sendFHIRError(c, ErrFHIRSearchParam, http.StatusBadRequest)
+		return
+	}
+
+	p, err := h.getFHIRPatient(c, value)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c)))
+			return
+		}
+
+		h.sendFHIRError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c), fhir.NewPatient(p)))
+}
+
+func (h *FHIRHandler) SearchObservations(c *gin.Context) {
+	p, ok := h.searchFHIRPatient(c)
+	if !ok {
+		return
+	}
+
+	var rs []fhir.Resource
+	if p != nil {
+		for _, v := range fhir.NewObservations(p) {
+			rs = append(rs, v)
+		}
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c), rs...))
+}
+
+func (h *FHIRHandler) SearchDevices(c *gin.Context) {
+	p, ok := h.searchFHIRPatient(c)
+	if !ok {
+		return
+	}
+
+	var rs []fhir.Resource
+	if p != nil {
+		for _, v := range fhir.NewDevices(p) {
+			rs = append(rs, v)
+		}
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c), rs...))
+}
+
+func (h *FHIRHandler) GetPractitioner(c *gin.Context) {
+	s, err := h.getFHIRSpecialist(c, c.Param("id"))
+	if err != nil {
+		h.sendFHIRError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewPractitioner(s))
+}
+
+// SearchPractitioners supports _id and identifier, a value without system matches both our ids and licence numbers.
+func (h *FHIRHandler) SearchPractitioners(c *gin.Context) {
+	system, value := fhir.ParseToken(c.Query("identifier"))
+	if id := c.Query("_id"); id != "" {
+		system, value = fhir.SystemPractitioner, id
+	}
+
+	if value == "" {
+		h.sendFHIRError(c, ErrFHIRSearchParam, http.StatusBadRequest)
+		return
+	}
+
+	var ids []int64
+	if system == "" || system == fhir.SystemPractitioner {
+		if id, ok := fhir.ParseReference("Practitioner", value); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	if system == "" || system == fhir.SystemLicence {
+		licensed, err := h.storage.GetSpecialistIDsByLicenceNumber(c, value)
+		if err != nil {
+			h.sendFHIRError(c, err, http.StatusInternalServerError)
+			return
+		}
+
+		for _, v := range licensed {
+			if len(ids) > 0 && ids[0] == v {
+				continue
+			}
+
+			ids = append(ids, v)
+		}
+	}
+
+	var rs []fhir.Resource
+	for _, v := range ids {
+		s, err := h.storage.GetSpecialistByID(c, v)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+
+			h.sendFHIRError(c, err, http.StatusInternalServerError)
+			return
+		}
+
+		rs = append(rs, fhir.NewPractitioner(s))
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c), rs...))
+}
+
+func (h *FHIRHandler) GetPractitionerRole(c *gin.Context) {
+	s, err := h.getFHIRSpecialist(c, c.Param("id"))
+	if err != nil {
+		h.sendFHIRError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewPractitionerRole(s))
+}
+
+func (h *FHIRHandler) SearchPractitionerRoles(c *gin.Context) {
+	ref := c.Query("practitioner")
+	if ref == "" {
+		h.sendFHIRError(c, ErrFHIRSearchParam, http.StatusBadRequest)
+		return
+	}
+
+	s, err := h.getFHIRSpecialist(c, ref)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c)))
+			return
+		}
+
+		h.sendFHIRError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendFHIR(c, http.StatusOK, fhir.NewSearchSet(h.fhirBaseURL(c), fhir.NewPractitionerRole(s)))
+}
+
+func (h *FHIRHandler) getFHIRPatient(c *gin.Context, ref string) (*model.Patient, error) {
+	id, ok := fhir.ParseReference("Patient", ref)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	return h.storage.GetPatientByID(c, id)
+}
+
+func (h *FHIRHandler) getFHIRSpecialist(c *gin.Context, ref string) (*model.Specialist, error) {
+	id, ok := fhir.ParseReference("Practitioner", ref)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	return h.storage.GetSpecialistByID(c, id)
+}
+
+// searchFHIRPatient resolves the patient (or subject) search parameter, nil without error means nothing matches.
+func (h *FHIRHandler) searchFHIRPatient(c *gin.Context) (*model.Patient, bool) {
+	ref := c.Query("patient")
+	if ref == "" {
+		ref = c.Query("subject")
+	}
+
+	if ref == "" {
+		h.sendFHIRError(c, ErrFHIRSearchParam, http.StatusBadRequest)
+		return nil, false
+	}
+
+	p, err := h.getFHIRPatient(c, ref)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, true
+		}
+
+		h.sendFHIRError(c, err, http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return p, true
+}
+
+func (h *FHIRHandler) fhirBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + c.Request.Host + "/fhir"
+}
+
+func (h *FHIRHandler) sendFHIR(c *gin.Context, code int, val any) {
+	c.Header("Content-Type", fhir.ContentType)
+	h.sendOK(c, code, val)
+}
+
+func (h *FHIRHandler) sendFHIRError(c *gin.Context, err error, code int) {
+	_ = c.Error(err)
+
+	if errors.Is(err, storage.ErrNotFound) {
+		code = http.StatusNotFound
+	}
+
+	issue := fhir.IssueCodeException
+	switch code {
+	case http.StatusBadRequest:
+		issue = fhir.IssueCodeInvalid
+	case http.StatusNotFound:
+		issue = fhir.IssueCodeNotFound
+	case http.StatusNotAcceptable:
+		issue = fhir.IssueCodeNotSupported
+	}
+
+	h.sendFHIR(c, code, fhir.NewOperationOutcome(issue, err.Error()))
+}
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"github.com/Hvaekar/med-account/pkg/jwt"
 	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strings"
@@ -169,3 +171,90 @@ func (h *BasicHandler) CheckPatientAdminPermissions(permission string) gin.Handl
 		return
 	}
 }
+
+func (h *BasicHandler) CheckPatientSpecialist() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s := c.MustGet("current_specialist").(*model.Specialist)
+
+		pID, err := CheckParamInt64(c, "patient_id")
+		if err != nil {
+			h.sendError(c, err, http.StatusBadRequest)
+			c.Abort()
+			return
+		}
+
+		if _, err := h.storage.GetPatientSpecialistByID(c, pID, s.ID); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+				c.Abort()
+				return
+			}
+
+			h.sendError(c, err, http.StatusInternalServerError)
+			c.Abort()
+			return
+		}
+
+		p, err := h.storage.GetPatientByID(c, pID)
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			c.Abort()
+			return
+		}
+
+		c.Set("current_patient", p)
+		c.Next()
+	}
+}
+
+func (h *BasicHandler) CheckOrganizationRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a := c.MustGet("current_account").(*model.Account)
+
+		orgID, err := CheckParamInt64(c, "organization_id")
+		if err != nil {
+			h.sendError(c, err, http.StatusBadRequest)
+			c.Abort()
+			return
+		}
+
+		o := GetAccountOrganizationByID(a.Organizations, *orgID)
+		if o == nil {
+			h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+			c.Abort()
+			return
+		}
+
+		if len(roles) == 0 {
+			c.Set("current_organization", o)
+			c.Next()
+			return
+		}
+
+		for _, v := range roles {
+			if o.Role == v {
+				c.Set("current_organization", o)
+				c.Next()
+				return
+			}
+		}
+
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		c.Abort()
+		return
+	}
+}
+
+func (h *BasicHandler) CheckAccountRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a := c.MustGet("current_account").(*model.Account)
+
+		if !a.HasRole(roles...) {
+			h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,415 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/icd10"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ModerationHandler struct {
+	*BasicHandler
+}
+
+func NewModerationHandler(basicHandler *BasicHandler) *ModerationHandler {
+	return &ModerationHandler{BasicHandler: basicHandler}
+}
+
+func (h *ModerationHandler) InitRoutes(r gin.IRouter) {
+	m := r.Group("/moderation", h.IdentifyAccount())
+	{
+		v := m.Group("/verifications", h.CheckAccountRoles(model.AccountRoleModerator, model.AccountRoleAdmin))
+		{
+			v.GET("", h.GetVerifications)
+			v.GET("/:verification_id", h.GetVerification)
+			v.POST("/:verification_id/approve", h.ApproveVerification)
+			v.POST("/:verification_id/reject", h.RejectVerification)
+		}
+
+		d := m.Group("/duplicates", h.CheckAccountRoles(model.AccountRoleModerator, model.AccountRoleAdmin))
+		{
+			d.GET("", h.GetDuplicates)
+			d.GET("/:duplicate_id", h.GetDuplicate)
+			d.POST("/:duplicate_id/merge", h.MergeDuplicate)
+			d.POST("/:duplicate_id/dismiss", h.DismissDuplicate)
+		}
+
+		rv := m.Group("/reviews", h.CheckAccountRoles(model.AccountRoleModerator, model.AccountRoleAdmin))
+		{
+			rv.GET("", h.GetReviews)
+			rv.GET("/:review_id", h.GetReview)
+			rv.POST("/:review_id/approve", h.ApproveReview)
+			rv.POST("/:review_id/reject", h.RejectReview)
+		}
+
+		ds := m.Group("/diseases", h.CheckAccountRoles(model.AccountRoleModerator, model.AccountRoleAdmin))
+		{
+			ds.GET("/:disease_id/icd10", h.GetDiseaseICD10Codes)
+			ds.PUT("/:disease_id/icd10", h.UpdateDiseaseICD10Codes)
+		}
+
+		ro := m.Group("/roles", h.CheckAccountRoles(model.AccountRoleAdmin))
+		{
+			ro.POST("", h.AddRole)
+			ro.DELETE("/:account_id/:role", h.DeleteRole)
+		}
+	}
+}
+
+func (h *ModerationHandler) GetVerifications(c *gin.Context) {
+	var req model.ListVerificationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	vs, err := h.storage.GetVerifications(c, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListVerifications{Verifications: vs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *ModerationHandler) GetVerification(c *gin.Context) {
+	vID, err := CheckParamInt64(c, "verification_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.storage.GetVerificationByID(c, vID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, v)
+}
+
+func (h *ModerationHandler) ApproveVerification(c *gin.Context) {
+	h.reviewVerification(c, model.VerificationStatusApproved, broker.VerificationApprovedKey)
+}
+
+func (h *ModerationHandler) RejectVerification(c *gin.Context) {
+	h.reviewVerification(c, model.VerificationStatusRejected, broker.VerificationRejectedKey)
+}
+
+func (h *ModerationHandler) reviewVerification(c *gin.Context, status string, key string) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	vID, err := CheckParamInt64(c, "verification_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.ReviewVerification
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	// a rejection has to tell the specialist what is wrong
+	if status == model.VerificationStatusRejected && (req.Comment == nil || *req.Comment == "") {
+		h.sendError(c, ErrNoComment, http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.storage.ReviewVerification(c, vID, a.ID, status, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(key, v); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, v)
+}
+
+func (h *ModerationHandler) GetDuplicates(c *gin.Context) {
+	var req model.ListPatientDuplicatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	ds, err := h.storage.GetPatientDuplicates(c, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListPatientDuplicates{Duplicates: ds}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *ModerationHandler) GetDuplicate(c *gin.Context) {
+	dID, err := CheckParamInt64(c, "duplicate_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	d, err := h.storage.GetPatientDuplicateByID(c, dID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, d)
+}
+
+func (h *ModerationHandler) MergeDuplicate(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	dID, err := CheckParamInt64(c, "duplicate_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.MergePatientDuplicate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	d, err := h.storage.GetPatientDuplicateByID(c, dID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if d.Status != model.DuplicateStatusPending || d.ProfileID == nil || d.DuplicateProfileID == nil {
+		h.sendError(c, ErrDuplicateReviewed, http.StatusBadRequest)
+		return
+	}
+
+	var mergedID int64
+	switch req.SurvivorID {
+	case *d.ProfileID:
+		mergedID = *d.DuplicateProfileID
+	case *d.DuplicateProfileID:
+		mergedID = *d.ProfileID
+	default:
+		h.sendError(c, ErrMergeSurvivor, http.StatusBadRequest)
+		return
+	}
+
+	for _, v := range d.Profiles {
+		if v.ID == mergedID && v.AccountID != nil {
+			h.sendError(c, ErrMergeAccount, http.StatusBadRequest)
+			return
+		}
+	}
+
+	d, err = h.storage.MergePatientDuplicate(c, dID, a.ID, req.SurvivorID, mergedID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.PatientMergeMessage{DuplicateID: d.ID, SurvivorID: req.SurvivorID, MergedID: mergedID}
+	if err := h.broker.SendMessage(broker.PatientMergedKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, d)
+}
+
+func (h *ModerationHandler) DismissDuplicate(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	dID, err := CheckParamInt64(c, "duplicate_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	d, err := h.storage.DismissPatientDuplicate(c, dID, a.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, d)
+}
+
+func (h *ModerationHandler) GetReviews(c *gin.Context) {
+	var req model.ListReviewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	rs, err := h.storage.GetReviews(c, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListReviews{Reviews: rs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *ModerationHandler) GetReview(c *gin.Context) {
+	rID, err := CheckParamInt64(c, "review_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	rv, err := h.storage.GetReviewByID(c, rID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, rv)
+}
+
+func (h *ModerationHandler) ApproveReview(c *gin.Context) {
+	h.moderateReview(c, model.ReviewStatusApproved, broker.ReviewApprovedKey)
+}
+
+func (h *ModerationHandler) RejectReview(c *gin.Context) {
+	h.moderateReview(c, model.ReviewStatusRejected, broker.ReviewRejectedKey)
+}
+
+func (h *ModerationHandler) moderateReview(c *gin.Context, status string, key string) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	rID, err := CheckParamInt64(c, "review_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.ModerateReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	// the author has to know why the review is not published
+	if status == model.ReviewStatusRejected && (req.Comment == nil || *req.Comment == "") {
+		h.sendError(c, ErrNoComment, http.StatusBadRequest)
+		return
+	}
+
+	rv, err := h.storage.ModerateReview(c, rID, a.ID, status, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(key, model.ReviewMessage{SpecialistID: rv.SpecialistID, Review: rv}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, rv)
+}
+
+func (h *ModerationHandler) GetDiseaseICD10Codes(c *gin.Context) {
+	diseaseID, err := CheckParamInt64(c, "disease_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	d, err := h.storage.GetDiseaseICD10Codes(c, diseaseID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, d)
+}
+
+func (h *ModerationHandler) UpdateDiseaseICD10Codes(c *gin.Context) {
+	diseaseID, err := CheckParamInt64(c, "disease_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateDiseaseICD10Codes
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	for _, v := range req.Codes {
+		if !icd10.Valid(v) {
+			h.sendError(c, ErrInvalidICD10, http.StatusBadRequest)
+			return
+		}
+	}
+
+	d, err := h.storage.UpdateDiseaseICD10Codes(c, diseaseID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, d)
+}
+
+func (h *ModerationHandler) AddRole(c *gin.Context) {
+	var req model.AddAccountRole
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.AddAccountRole(c, &req); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.AccountRoleMessage{AccountID: req.AccountID, Role: req.Role}
+	if err := h.broker.SendMessage(broker.AccountRoleAddKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, "")
+}
+
+func (h *ModerationHandler) DeleteRole(c *gin.Context) {
+	accountID, err := CheckParamInt64(c, "account_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	role, err := CheckParamString(c, "role")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeleteAccountRole(c, accountID, role); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.AccountRoleMessage{AccountID: *accountID, Role: *role}
+	if err := h.broker.SendMessage(broker.AccountRoleDeleteKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type OrganizationHandler struct {
+	*BasicHandler
+}
+
+func NewOrganizationHandler(basicHandler *BasicHandler) *OrganizationHandler {
+	return &OrganizationHandler{BasicHandler: basicHandler}
+}
+
+func (h *OrganizationHandler) InitRoutes(r gin.IRouter) *gin.RouterGroup {
+	o := r.Group("/organizations", h.IdentifyAccount())
+	{
+		o.POST("", h.AddOrganization)
+		o.GET("", h.GetOrganizations)
+		o.GET("/:organization_id", h.GetOrganization)
+		o.PUT("/:organization_id", h.CheckOrganizationRoles(model.OrganizationRoleOwner, model.OrganizationRoleManager), h.UpdateOrganization)
+		o.DELETE("/:organization_id", h.CheckOrganizationRoles(model.OrganizationRoleOwner), h.DeleteOrganization)
+		o.GET("/:organization_id/experiences", h.CheckOrganizationRoles(), h.GetOrganizationExperiences)
+	}
+
+	return o
+}
+
+func (h *OrganizationHandler) AddOrganization(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	var req model.AddOrganization
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.LogoFileID != nil {
+		if err := h.checkAccountFiles(c, a.ID, []int64{*req.LogoFileID}); err != nil {
+			h.sendError(c, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	o, err := h.storage.AddOrganization(c, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.OrganizationAddKey, o); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, o)
+}
+
+func (h *OrganizationHandler) GetOrganizations(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	os := make([]*model.Organization, 0, len(a.Organizations))
+	for _, v := range a.Organizations {
+		o, err := h.storage.GetOrganizationByID(c, v.ID)
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+		os = append(os, o)
+	}
+
+	list := model.ListOrganizations{Organizations: os}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	orgID, err := CheckParamInt64(c, "organization_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	o, err := h.storage.GetOrganizationByID(c, orgID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, o)
+}
+
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	var req model.UpdateOrganization
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.LogoFileID != nil {
+		if err := h.checkAccountFiles(c, a.ID, []int64{*req.LogoFileID}); err != nil {
+			h.sendError(c, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	o, err := h.storage.UpdateOrganization(c, org.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.OrganizationUpdateKey, o); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, o)
+}
+
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	if err := h.storage.DeleteOrganization(c, org.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.OrganizationDeleteKey, model.IDMessage{ID: org.ID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *OrganizationHandler) GetOrganizationExperiences(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	es, err := h.storage.GetOrganizationExperiences(c, org.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListExperiences{Experiences: es}
+
+	h.sendOK(c, http.StatusOK, list)
+}
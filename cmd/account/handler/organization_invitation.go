@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type OrganizationInvitationHandler struct {
+	*BasicHandler
+}
+
+func NewOrganizationInvitationHandler(basicHandler *BasicHandler) *OrganizationInvitationHandler {
+	return &OrganizationInvitationHandler{BasicHandler: basicHandler}
+}
+
+func (h *OrganizationInvitationHandler) InitRoutes(r gin.IRouter) {
+	i := r.Group("/:organization_id/invitations", h.CheckOrganizationRoles(model.OrganizationRoleOwner, model.OrganizationRoleManager))
+	{
+		i.POST("", h.AddInvitation)
+		i.GET("", h.GetInvitations)
+		i.DELETE("/:invitation_id", h.DeleteInvitation)
+	}
+}
+
+func (h *OrganizationInvitationHandler) InitAccountRoutes(r gin.IRouter) {
+	i := r.Group("/invitations")
+	{
+		i.GET("", h.GetAccountInvitations)
+		i.POST("/:invitation_id/accept", h.AcceptInvitation)
+		i.POST("/:invitation_id/decline", h.DeclineInvitation)
+	}
+}
+
+func (h *OrganizationInvitationHandler) AddInvitation(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	var req model.AddOrganizationInvitation
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.Role == model.OrganizationRoleOwner && org.Role != model.OrganizationRoleOwner {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	i, err := h.storage.AddOrganizationInvitation(c, org.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.OrganizationInvitationAddKey, i); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, i)
+}
+
+func (h *OrganizationInvitationHandler) GetInvitations(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	is, err := h.storage.GetOrganizationInvitations(c, org.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListOrganizationInvitations{Invitations: is}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *OrganizationInvitationHandler) DeleteInvitation(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	iID, err := CheckParamInt64(c, "invitation_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeleteOrganizationInvitation(c, iID, org.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *OrganizationInvitationHandler) GetAccountInvitations(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	is, err := h.storage.GetAccountInvitations(c, a.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListOrganizationInvitations{Invitations: is}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *OrganizationInvitationHandler) AcceptInvitation(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	iID, err := CheckParamInt64(c, "invitation_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	i, err := h.storage.AcceptOrganizationInvitation(c, iID, a.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrMemberExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	m, err := h.storage.GetOrganizationMemberByID(c, i.OrganizationID, a.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.OrganizationInvitationAcceptedKey, i); err != nil {
+		h.log.Error(err)
+	}
+
+	msg := model.OrganizationMemberMessage{OrganizationID: i.OrganizationID, AccountID: a.ID, Role: m.Role}
+	if err := h.broker.SendMessage(broker.OrganizationMemberAddKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, m)
+}
+
+func (h *OrganizationInvitationHandler) DeclineInvitation(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	iID, err := CheckParamInt64(c, "invitation_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	i, err := h.storage.UpdateOrganizationInvitationStatus(c, iID, a.ID, model.InvitationStatusDeclined)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.OrganizationInvitationDeclinedKey, i); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, i)
+}
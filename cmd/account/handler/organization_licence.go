@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type OrganizationLicenceHandler struct {
+	*BasicHandler
+}
+
+func NewOrganizationLicenceHandler(basicHandler *BasicHandler) *OrganizationLicenceHandler {
+	return &OrganizationLicenceHandler{BasicHandler: basicHandler}
+}
+
+func (h *OrganizationLicenceHandler) InitRoutes(r gin.IRouter) {
+	l := r.Group("/:organization_id/licences")
+	{
+		l.POST("", h.CheckOrganizationRoles(model.OrganizationRoleOwner, model.OrganizationRoleManager), h.AddLicence)
+		l.GET("", h.GetLicences)
+		l.GET("/:licence_id", h.GetLicence)
+		l.PUT("/:licence_id", h.CheckOrganizationRoles(model.OrganizationRoleOwner, model.OrganizationRoleManager), h.UpdateLicence)
+		l.DELETE("/:licence_id", h.CheckOrganizationRoles(model.OrganizationRoleOwner, model.OrganizationRoleManager), h.DeleteLicence)
+	}
+}
+
+func (h *OrganizationLicenceHandler) AddLicence(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	var req model.AddOrganizationLicence
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.AddOrganizationLicence(c, org.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusCreated, l)
+}
+
+func (h *OrganizationLicenceHandler) GetLicences(c *gin.Context) {
+	orgID, err := CheckParamInt64(c, "organization_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ls, err := h.storage.GetOrganizationLicences(c, orgID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListOrganizationLicences{Licences: ls}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *OrganizationLicenceHandler) GetLicence(c *gin.Context) {
+	orgID, err := CheckParamInt64(c, "organization_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.GetOrganizationLicenceByID(c, lID, orgID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, l)
+}
+
+func (h *OrganizationLicenceHandler) UpdateLicence(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateOrganizationLicence
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.UpdateOrganizationLicence(c, lID, org.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, l)
+}
+
+func (h *OrganizationLicenceHandler) DeleteLicence(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeleteOrganizationLicence(c, lID, org.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
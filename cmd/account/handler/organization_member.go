@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type OrganizationMemberHandler struct {
+	*BasicHandler
+}
+
+func NewOrganizationMemberHandler(basicHandler *BasicHandler) *OrganizationMemberHandler {
+	return &OrganizationMemberHandler{BasicHandler: basicHandler}
+}
+
+func (h *OrganizationMemberHandler) InitRoutes(r gin.IRouter) {
+	m := r.Group("/:organization_id/members")
+	{
+		m.GET("", h.CheckOrganizationRoles(), h.GetMembers)
+		m.GET("/:account_id", h.CheckOrganizationRoles(), h.GetMember)
+		m.PUT("/:account_id", h.CheckOrganizationRoles(model.OrganizationRoleOwner, model.OrganizationRoleManager), h.UpdateMember)
+		m.DELETE("/:account_id", h.CheckOrganizationRoles(), h.DeleteMember)
+	}
+}
+
+func (h *OrganizationMemberHandler) GetMembers(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	ms, err := h.storage.GetOrganizationMembers(c, org.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListOrganizationMembers{Members: ms}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *OrganizationMemberHandler) GetMember(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	accountID, err := CheckParamInt64(c, "account_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.GetOrganizationMemberByID(c, org.ID, accountID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, m)
+}
+
+func (h *OrganizationMemberHandler) UpdateMember(c *gin.Context) {
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	accountID, err := CheckParamInt64(c, "account_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateOrganizationMember
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.GetOrganizationMemberByID(c, org.ID, accountID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	// only owners can grant or take away the owner role
+	if (req.Role == model.OrganizationRoleOwner || m.Role == model.OrganizationRoleOwner) && org.Role != model.OrganizationRoleOwner {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	m, err = h.storage.UpdateOrganizationMember(c, org.ID, accountID, &req)
+	if err != nil {
+		if errors.Is(err, model.ErrLastOwner) {
+			h.sendError(c, err, http.StatusBadRequest)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.OrganizationMemberMessage{OrganizationID: org.ID, AccountID: *accountID, Role: m.Role}
+	if err := h.broker.SendMessage(broker.OrganizationMemberUpdateKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, m)
+}
+
+func (h *OrganizationMemberHandler) DeleteMember(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	org := c.MustGet("current_organization").(*model.AccountOrganization)
+
+	accountID, err := CheckParamInt64(c, "account_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.GetOrganizationMemberByID(c, org.ID, accountID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	// any member can leave, managers can remove staff, only owners can remove owners
+	if *accountID != a.ID {
+		switch {
+		case org.Role == model.OrganizationRoleOwner:
+		case org.Role == model.OrganizationRoleManager && m.Role != model.OrganizationRoleOwner:
+		default:
+			h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.storage.DeleteOrganizationMember(c, org.ID, accountID); err != nil {
+		if errors.Is(err, model.ErrLastOwner) {
+			h.sendError(c, err, http.StatusBadRequest)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.OrganizationMemberMessage{OrganizationID: org.ID, AccountID: *accountID}
+	if err := h.broker.SendMessage(broker.OrganizationMemberDeleteKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
 type PatientHandler struct {
@@ -42,9 +43,15 @@ func (h *PatientHandler) InitRoutes(r gin.IRouter) *gin.RouterGroup {
 }
 
 func (h *PatientHandler) GetPatientProfile(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
 
+	if err := h.applyPatientPrivacy(c, a.ID, p); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+	p.SetIndicators(time.Now())
+
 	h.sendOK(c, http.StatusOK, p)
 }
 
@@ -82,17 +89,25 @@ func (h *PatientHandler) UpdatePatientProfile(c *gin.Context) {
 		req.DisabilityFiles = FilterFilesByID(files, req.DisabilityFiles)
 	}
 
-	p, err := h.storage.UpdatePatientProfile(c, p.ID, req)
+	ms := model.NewProfileMeasurements(p, &req)
+
+	p, err := h.storage.UpdatePatientProfile(c, p.ID, a.ID, req)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
 	}
 
+	if err := h.recordProfileMeasurements(c, p.ID, a.ID, ms); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+	p.SetIndicators(time.Now())
+
 	h.sendOK(c, http.StatusOK, p)
 }
 
 func (h *PatientHandler) GetPatients(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 
 	var req model.ListPatientsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -107,13 +122,21 @@ func (h *PatientHandler) GetPatients(c *gin.Context) {
 		return
 	}
 
+	for _, v := range ps {
+		if err := h.applyPatientPrivacy(c, a.ID, v); err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+		v.SetIndicators(time.Now())
+	}
+
 	list := model.ListPatients{Patients: ps}
 
 	h.sendOK(c, http.StatusOK, list)
 }
 
 func (h *PatientHandler) GetPatient(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 
 	pID, err := CheckParamInt64(c, "id")
 	if err != nil {
@@ -127,5 +150,11 @@ func (h *PatientHandler) GetPatient(c *gin.Context) {
 		return
 	}
 
+	if err := h.applyPatientPrivacy(c, a.ID, p); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+	p.SetIndicators(time.Now())
+
 	h.sendOK(c, http.StatusOK, p)
 }
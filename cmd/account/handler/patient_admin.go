@@ -27,6 +27,7 @@ func (h *PatientAdminHandler) InitRoutes(r gin.IRouter) {
 }
 
 func (h *PatientAdminHandler) AddAdmin(c *gin.Context) {
+	acc := c.MustGet("current_account").(*model.Account)
 	//a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
 
@@ -41,7 +42,7 @@ func (h *PatientAdminHandler) AddAdmin(c *gin.Context) {
 		return
 	}
 
-	a, err := h.storage.AddPatientProfileAdmin(c, p.ID, &req)
+	a, err := h.storage.AddPatientProfileAdmin(c, p.ID, acc.ID, &req)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
@@ -90,6 +91,7 @@ func (h *PatientAdminHandler) GetAdmin(c *gin.Context) {
 }
 
 func (h *PatientAdminHandler) UpdateAdmin(c *gin.Context) {
+	acc := c.MustGet("current_account").(*model.Account)
 	//a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
 
@@ -105,7 +107,7 @@ func (h *PatientAdminHandler) UpdateAdmin(c *gin.Context) {
 		return
 	}
 
-	a, err := h.storage.UpdatePatientProfileAdmin(c, p.ID, adminID, &req)
+	a, err := h.storage.UpdatePatientProfileAdmin(c, p.ID, acc.ID, adminID, &req)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
@@ -115,6 +117,7 @@ func (h *PatientAdminHandler) UpdateAdmin(c *gin.Context) {
 }
 
 func (h *PatientAdminHandler) DeleteAdmin(c *gin.Context) {
+	acc := c.MustGet("current_account").(*model.Account)
 	//a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
 
@@ -124,7 +127,7 @@ func (h *PatientAdminHandler) DeleteAdmin(c *gin.Context) {
 		return
 	}
 
-	if err := h.storage.DeletePatientProfileAdmin(c, p.ID, adminID); err != nil {
+	if err := h.storage.DeletePatientProfileAdmin(c, p.ID, acc.ID, adminID); err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AllergyHandler struct {
+	*BasicHandler
+}
+
+func NewAllergyHandler(basicHandler *BasicHandler) *AllergyHandler {
+	return &AllergyHandler{BasicHandler: basicHandler}
+}
+
+func (h *AllergyHandler) InitRoutes(r gin.IRouter) {
+	a := r.Group("/allergies")
+	{
+		a.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddAllergy)
+		a.GET("", h.GetAllergies)
+		a.GET("/:allergy_id", h.GetAllergy)
+		a.PUT("/:allergy_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateAllergy)
+		a.DELETE("/:allergy_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteAllergy)
+	}
+}
+
+func (h *AllergyHandler) InitSpecialistRoutes(r gin.IRouter) {
+	a := r.Group("/patients/:patient_id/allergies", h.CheckPatientSpecialist())
+	{
+		a.GET("", h.GetAllergies)
+		a.PUT("/:allergy_id/confirm", h.ConfirmAllergy)
+	}
+}
+
+func (h *AllergyHandler) AddAllergy(c *gin.Context) {
+	acc := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddAllergy
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	a, err := h.storage.AddPatientAllergy(c, p.ID, acc.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.AllergyAddKey, model.AllergyMessage{PatientID: p.ID, Allergy: a}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, a)
+}
+
+func (h *AllergyHandler) GetAllergies(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	as, err := h.storage.GetPatientAllergies(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListAllergies{Allergies: as}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *AllergyHandler) GetAllergy(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	aID, err := CheckParamInt64(c, "allergy_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	a, err := h.storage.GetPatientAllergyByID(c, aID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if a.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, a)
+}
+
+func (h *AllergyHandler) UpdateAllergy(c *gin.Context) {
+	acc := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	aID, err := CheckParamInt64(c, "allergy_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateAllergy
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	a, err := h.storage.UpdatePatientAllergy(c, aID, p.ID, acc.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.AllergyUpdateKey, model.AllergyMessage{PatientID: p.ID, Allergy: a}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, a)
+}
+
+func (h *AllergyHandler) ConfirmAllergy(c *gin.Context) {
+	acc := c.MustGet("current_account").(*model.Account)
+	s := c.MustGet("current_specialist").(*model.Specialist)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	aID, err := CheckParamInt64(c, "allergy_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	a, err := h.storage.ConfirmPatientAllergy(c, aID, p.ID, acc.ID, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.AllergyConfirmedKey, model.AllergyMessage{PatientID: p.ID, Allergy: a}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, a)
+}
+
+func (h *AllergyHandler) DeleteAllergy(c *gin.Context) {
+	acc := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	aID, err := CheckParamInt64(c, "allergy_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientAllergy(c, aID, p.ID, acc.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.AllergyDeleteKey, model.IDMessage{ID: *aID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/icd10"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ConditionHandler struct {
+	*BasicHandler
+}
+
+func NewConditionHandler(basicHandler *BasicHandler) *ConditionHandler {
+	return &ConditionHandler{BasicHandler: basicHandler}
+}
+
+func (h *ConditionHandler) InitRoutes(r gin.IRouter) {
+	cd := r.Group("/conditions")
+	{
+		cd.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddCondition)
+		cd.GET("", h.GetConditions)
+		cd.GET("/specialists", h.GetConditionSpecialists)
+		cd.GET("/:condition_id", h.GetCondition)
+		cd.PUT("/:condition_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateCondition)
+		cd.DELETE("/:condition_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteCondition)
+	}
+}
+
+func (h *ConditionHandler) InitSpecialistRoutes(r gin.IRouter) {
+	cd := r.Group("/patients/:patient_id/conditions", h.CheckPatientSpecialist())
+	{
+		cd.GET("", h.GetConditions)
+		cd.PUT("/:condition_id/confirm", h.ConfirmCondition)
+	}
+}
+
+func (h *ConditionHandler) AddCondition(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddCondition
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	if !icd10.Valid(req.ICD10) {
+		h.sendError(c, ErrInvalidICD10, http.StatusBadRequest)
+		return
+	}
+
+	cd, err := h.storage.AddPatientCondition(c, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ConditionAddKey, model.ConditionMessage{PatientID: p.ID, Condition: cd}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, cd)
+}
+
+func (h *ConditionHandler) GetConditions(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	cds, err := h.storage.GetPatientConditions(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListConditions{Conditions: cds}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *ConditionHandler) GetCondition(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	cdID, err := CheckParamInt64(c, "condition_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	cd, err := h.storage.GetPatientConditionByID(c, cdID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if cd.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, cd)
+}
+
+func (h *ConditionHandler) UpdateCondition(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	cdID, err := CheckParamInt64(c, "condition_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateCondition
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	if !icd10.Valid(req.ICD10) {
+		h.sendError(c, ErrInvalidICD10, http.StatusBadRequest)
+		return
+	}
+
+	cd, err := h.storage.UpdatePatientCondition(c, cdID, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ConditionUpdateKey, model.ConditionMessage{PatientID: p.ID, Condition: cd}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, cd)
+}
+
+func (h *ConditionHandler) ConfirmCondition(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	s := c.MustGet("current_specialist").(*model.Specialist)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	cdID, err := CheckParamInt64(c, "condition_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	cd, err := h.storage.ConfirmPatientCondition(c, cdID, p.ID, a.ID, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ConditionConfirmedKey, model.ConditionMessage{PatientID: p.ID, Condition: cd}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, cd)
+}
+
+func (h *ConditionHandler) DeleteCondition(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	cdID, err := CheckParamInt64(c, "condition_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientCondition(c, cdID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ConditionDeleteKey, model.IDMessage{ID: *cdID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *ConditionHandler) GetConditionSpecialists(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	ss, err := h.storage.GetPatientConditionSpecialists(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListConditionSpecialists{Specialists: ss}
+
+	h.sendOK(c, http.StatusOK, list)
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type EmergencyContactHandler struct {
+	*BasicHandler
+}
+
+func NewEmergencyContactHandler(basicHandler *BasicHandler) *EmergencyContactHandler {
+	return &EmergencyContactHandler{BasicHandler: basicHandler}
+}
+
+func (h *EmergencyContactHandler) InitRoutes(r gin.IRouter) {
+	r.GET("/emergency", h.GetEmergencySummary)
+
+	ec := r.Group("/emergency-contacts")
+	{
+		ec.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddEmergencyContact)
+		ec.GET("", h.GetEmergencyContacts)
+		ec.GET("/:contact_id", h.GetEmergencyContact)
+		ec.PUT("/:contact_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateEmergencyContact)
+		ec.DELETE("/:contact_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteEmergencyContact)
+	}
+}
+
+func (h *EmergencyContactHandler) InitSpecialistRoutes(r gin.IRouter) {
+	r.GET("/patients/:patient_id/emergency", h.CheckPatientSpecialist(), h.GetEmergencySummary)
+}
+
+func (h *EmergencyContactHandler) AddEmergencyContact(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddEmergencyContact
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.AddPatientEmergencyContact(c, p.ID, a.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrForeignKeyConstraintFail) {
+			h.sendError(c, ErrInvalidField, http.StatusBadRequest)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.EmergencyContactAddKey, model.EmergencyContactMessage{PatientID: p.ID, EmergencyContact: e}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, e)
+}
+
+func (h *EmergencyContactHandler) GetEmergencyContacts(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	es, err := h.storage.GetPatientEmergencyContacts(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListEmergencyContacts{EmergencyContacts: es}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *EmergencyContactHandler) GetEmergencyContact(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	eID, err := CheckParamInt64(c, "contact_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.GetPatientEmergencyContactByID(c, eID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if e.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, e)
+}
+
+func (h *EmergencyContactHandler) UpdateEmergencyContact(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	eID, err := CheckParamInt64(c, "contact_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateEmergencyContact
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.UpdatePatientEmergencyContact(c, eID, p.ID, a.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrForeignKeyConstraintFail) {
+			h.sendError(c, ErrInvalidField, http.StatusBadRequest)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.EmergencyContactUpdateKey, model.EmergencyContactMessage{PatientID: p.ID, EmergencyContact: e}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, e)
+}
+
+func (h *EmergencyContactHandler) DeleteEmergencyContact(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	eID, err := CheckParamInt64(c, "contact_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientEmergencyContact(c, eID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.EmergencyContactDeleteKey, model.IDMessage{ID: *eID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *EmergencyContactHandler) GetEmergencySummary(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	cs, err := h.storage.GetPatientConditions(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	status := model.MedicationStatusCurrent
+	ms, err := h.storage.GetPatientMedications(c, p.ID, &model.ListMedicationsRequest{Status: &status})
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.NewEmergencySummary(p, cs, ms))
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/icd10"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type FamilyHistoryHandler struct {
+	*BasicHandler
+}
+
+func NewFamilyHistoryHandler(basicHandler *BasicHandler) *FamilyHistoryHandler {
+	return &FamilyHistoryHandler{BasicHandler: basicHandler}
+}
+
+func (h *FamilyHistoryHandler) InitRoutes(r gin.IRouter) {
+	f := r.Group("/family_history")
+	{
+		f.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddFamilyHistory)
+		f.GET("", h.GetFamilyHistory)
+		f.GET("/:family_history_id", h.GetFamilyHistoryEntry)
+		f.PUT("/:family_history_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateFamilyHistory)
+		f.DELETE("/:family_history_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteFamilyHistory)
+	}
+}
+
+func (h *FamilyHistoryHandler) AddFamilyHistory(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddFamilyHistory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	if !icd10.Valid(req.ICD10) {
+		h.sendError(c, ErrInvalidICD10, http.StatusBadRequest)
+		return
+	}
+
+	if req.RelativeProfileID != nil && (*req.RelativeProfileID == p.ID || !CheckAccountPatientProfilesByID(a.Profiles, *req.RelativeProfileID)) {
+		h.sendError(c, ErrRelative, http.StatusBadRequest)
+		return
+	}
+
+	f, err := h.storage.AddPatientFamilyHistory(c, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.FamilyHistoryAddKey, model.FamilyHistoryMessage{PatientID: p.ID, FamilyHistory: f}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, f)
+}
+
+func (h *FamilyHistoryHandler) GetFamilyHistory(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	fs, err := h.storage.GetPatientFamilyHistory(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListFamilyHistory{FamilyHistory: fs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *FamilyHistoryHandler) GetFamilyHistoryEntry(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	fID, err := CheckParamInt64(c, "family_history_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	f, err := h.storage.GetPatientFamilyHistoryByID(c, fID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if f.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, f)
+}
+
+func (h *FamilyHistoryHandler) UpdateFamilyHistory(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	fID, err := CheckParamInt64(c, "family_history_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateFamilyHistory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	if !icd10.Valid(req.ICD10) {
+		h.sendError(c, ErrInvalidICD10, http.StatusBadRequest)
+		return
+	}
+
+	before, err := h.storage.GetPatientFamilyHistoryByID(c, fID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	// a link made earlier by another admin is kept as it is
+	relinked := req.RelativeProfileID != nil && (before.RelativeProfileID == nil || *before.RelativeProfileID != *req.RelativeProfileID)
+	if relinked && (*req.RelativeProfileID == p.ID || !CheckAccountPatientProfilesByID(a.Profiles, *req.RelativeProfileID)) {
+		h.sendError(c, ErrRelative, http.StatusBadRequest)
+		return
+	}
+
+	f, err := h.storage.UpdatePatientFamilyHistory(c, fID, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.FamilyHistoryUpdateKey, model.FamilyHistoryMessage{PatientID: p.ID, FamilyHistory: f}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, f)
+}
+
+func (h *FamilyHistoryHandler) DeleteFamilyHistory(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	fID, err := CheckParamInt64(c, "family_history_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientFamilyHistory(c, fID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.FamilyHistoryDeleteKey, model.IDMessage{ID: *fID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PatientHistoryHandler struct {
+	*BasicHandler
+}
+
+func NewPatientHistoryHandler(basicHandler *BasicHandler) *PatientHistoryHandler {
+	return &PatientHistoryHandler{BasicHandler: basicHandler}
+}
+
+// InitRoutes is limited to the accounts allowed to change the profile, history keeps values hidden by privacy settings
+func (h *PatientHistoryHandler) InitRoutes(r gin.IRouter) {
+	hs := r.Group("/history", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit))
+	{
+		hs.GET("", h.GetHistory)
+		hs.GET("/snapshot", h.GetSnapshot)
+	}
+}
+
+func (h *PatientHistoryHandler) GetHistory(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.ListPatientHistoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	hs, err := h.storage.GetPatientHistory(c, p.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListPatientHistory{History: hs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *PatientHistoryHandler) GetSnapshot(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.PatientSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	current, err := h.storage.GetPatientHistoryRecords(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	later, err := h.storage.GetPatientHistoryAfter(c, p.ID, *req.At)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.NewPatientSnapshot(*req.At, current, later))
+}
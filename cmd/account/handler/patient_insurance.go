@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type InsuranceHandler struct {
+	*BasicHandler
+}
+
+func NewInsuranceHandler(basicHandler *BasicHandler) *InsuranceHandler {
+	return &InsuranceHandler{BasicHandler: basicHandler}
+}
+
+func (h *InsuranceHandler) InitRoutes(r gin.IRouter) {
+	in := r.Group("/insurance")
+	{
+		in.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddInsurancePolicy)
+		in.GET("", h.GetInsurancePolicies)
+		in.GET("/:policy_id", h.GetInsurancePolicy)
+		in.PUT("/:policy_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateInsurancePolicy)
+		in.DELETE("/:policy_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteInsurancePolicy)
+	}
+}
+
+func (h *InsuranceHandler) InitServiceRoutes(r gin.IRouter) {
+	s := r.Group("/service", h.IdentifyAccount(), h.CheckAccountRoles(model.AccountRoleService, model.AccountRoleAdmin))
+	{
+		s.GET("/patients/:patient_id/insurance", h.GetActiveCoverage)
+	}
+}
+
+func (h *InsuranceHandler) AddInsurancePolicy(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddInsurancePolicy
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkInsurancePolicy(c, a.ID, p.ID, nil, &req); err != nil {
+		return
+	}
+
+	in, err := h.storage.AddPatientInsurancePolicy(c, p.ID, a.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrPolicyExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.InsurancePolicyAddKey, model.InsurancePolicyMessage{PatientID: p.ID, Policy: in}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, in)
+}
+
+func (h *InsuranceHandler) GetInsurancePolicies(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	ins, err := h.storage.GetPatientInsurancePolicies(c, p.ID, false)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListInsurancePolicies{Policies: ins}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *InsuranceHandler) GetInsurancePolicy(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	inID, err := CheckParamInt64(c, "policy_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	in, err := h.storage.GetPatientInsurancePolicyByID(c, inID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if in.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, in)
+}
+
+func (h *InsuranceHandler) UpdateInsurancePolicy(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	inID, err := CheckParamInt64(c, "policy_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateInsurancePolicy
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkInsurancePolicy(c, a.ID, p.ID, inID, (*model.AddInsurancePolicy)(&req)); err != nil {
+		return
+	}
+
+	in, err := h.storage.UpdatePatientInsurancePolicy(c, inID, p.ID, a.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrPolicyExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.InsurancePolicyUpdateKey, model.InsurancePolicyMessage{PatientID: p.ID, Policy: in}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, in)
+}
+
+func (h *InsuranceHandler) DeleteInsurancePolicy(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	inID, err := CheckParamInt64(c, "policy_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientInsurancePolicy(c, inID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.InsurancePolicyDeleteKey, model.IDMessage{ID: *inID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *InsuranceHandler) GetActiveCoverage(c *gin.Context) {
+	pID, err := CheckParamInt64(c, "patient_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	p, err := h.storage.GetPatientByID(c, pID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	ins, err := h.storage.GetPatientInsurancePolicies(c, p.ID, true)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListInsurancePolicies{Policies: ins}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+// checkInsurancePolicy validates dates, card scans and overlapping with the other policies,
+// the error is already sent to the client when it is returned.
+func (h *InsuranceHandler) checkInsurancePolicy(c *gin.Context, accountID int64, patientID int64, policyID *int64, req *model.AddInsurancePolicy) error {
+	if !req.ValidDates() {
+		h.sendError(c, ErrFinishDate, http.StatusBadRequest)
+		return ErrFinishDate
+	}
+
+	if err := h.checkAccountFiles(c, accountID, req.Files); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return err
+	}
+
+	var excludeID interface{}
+	if policyID != nil {
+		excludeID = *policyID
+	}
+
+	overlaps, err := h.storage.HasOverlappingPatientInsurancePolicy(c, patientID, excludeID, req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return err
+	}
+
+	if overlaps {
+		h.sendError(c, ErrPolicyOverlap, http.StatusConflict)
+		return ErrPolicyOverlap
+	}
+
+	return nil
+}
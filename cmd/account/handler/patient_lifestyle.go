@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type LifestyleHandler struct {
+	*BasicHandler
+}
+
+func NewLifestyleHandler(basicHandler *BasicHandler) *LifestyleHandler {
+	return &LifestyleHandler{BasicHandler: basicHandler}
+}
+
+func (h *LifestyleHandler) InitRoutes(r gin.IRouter) {
+	l := r.Group("/lifestyle")
+	{
+		l.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddLifestyleRecord)
+		l.GET("", h.GetLifestyleRecords)
+		l.GET("/current", h.GetCurrentLifestyle)
+		l.GET("/:record_id", h.GetLifestyleRecord)
+		l.PUT("/:record_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateLifestyleRecord)
+		l.DELETE("/:record_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteLifestyleRecord)
+	}
+}
+
+func (h *LifestyleHandler) InitSpecialistRoutes(r gin.IRouter) {
+	l := r.Group("/patients/:patient_id/lifestyle", h.CheckPatientSpecialist())
+	{
+		l.GET("", h.GetLifestyleRecords)
+		l.GET("/current", h.GetCurrentLifestyle)
+	}
+}
+
+func (h *LifestyleHandler) AddLifestyleRecord(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddLifestyleRecord
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Normalize(time.Now()); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	r, err := h.storage.AddPatientLifestyleRecord(c, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.LifestyleRecordAddKey, model.LifestyleRecordMessage{PatientID: p.ID, Record: r}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, r)
+}
+
+func (h *LifestyleHandler) GetLifestyleRecords(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rs, err := h.storage.GetPatientLifestyleRecords(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListLifestyleRecords{Records: rs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *LifestyleHandler) GetCurrentLifestyle(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rs, err := h.storage.GetPatientLifestyleRecords(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	summary := model.NewLifestyleSummary(rs)
+	if summary == nil {
+		summary = &model.LifestyleSummary{}
+	}
+
+	h.sendOK(c, http.StatusOK, summary)
+}
+
+func (h *LifestyleHandler) GetLifestyleRecord(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rID, err := CheckParamInt64(c, "record_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	r, err := h.storage.GetPatientLifestyleRecordByID(c, rID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if r.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, r)
+}
+
+func (h *LifestyleHandler) UpdateLifestyleRecord(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rID, err := CheckParamInt64(c, "record_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateLifestyleRecord
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Normalize(time.Now()); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	r, err := h.storage.UpdatePatientLifestyleRecord(c, rID, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.LifestyleRecordUpdateKey, model.LifestyleRecordMessage{PatientID: p.ID, Record: r}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, r)
+}
+
+func (h *LifestyleHandler) DeleteLifestyleRecord(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rID, err := CheckParamInt64(c, "record_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientLifestyleRecord(c, rID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.LifestyleRecordDeleteKey, model.IDMessage{ID: *rID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type MeasurementHandler struct {
+	*BasicHandler
+}
+
+func NewMeasurementHandler(basicHandler *BasicHandler) *MeasurementHandler {
+	return &MeasurementHandler{BasicHandler: basicHandler}
+}
+
+func (h *MeasurementHandler) InitRoutes(r gin.IRouter) {
+	m := r.Group("/measurements")
+	{
+		m.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddMeasurement)
+		m.GET("", h.GetMeasurements)
+		m.GET("/chart", h.GetMeasurementChart)
+		m.GET("/:measurement_id", h.GetMeasurement)
+		m.DELETE("/:measurement_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteMeasurement)
+	}
+}
+
+// InitSpecialistRoutes lets specialists chosen by the patient read the history
+func (h *MeasurementHandler) InitSpecialistRoutes(r gin.IRouter) {
+	m := r.Group("/patients/:patient_id/measurements", h.CheckPatientSpecialist())
+	{
+		m.GET("", h.GetMeasurements)
+		m.GET("/chart", h.GetMeasurementChart)
+	}
+}
+
+func (h *MeasurementHandler) AddMeasurement(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddMeasurement
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Normalize(time.Now()); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.AddPatientMeasurement(c, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusCreated, m)
+}
+
+func (h *MeasurementHandler) GetMeasurements(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.ListMeasurementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ms, err := h.storage.GetPatientMeasurements(c, p.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListMeasurements{Measurements: ms}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *MeasurementHandler) GetMeasurementChart(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.MeasurementChartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	ps, err := h.storage.GetPatientMeasurementChart(c, p.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	chart := model.MeasurementChart{
+		Type:     req.Type,
+		Unit:     model.MeasurementUnit(req.Type),
+		Interval: req.Interval,
+		Points:   ps,
+	}
+
+	h.sendOK(c, http.StatusOK, chart)
+}
+
+func (h *MeasurementHandler) GetMeasurement(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	mID, err := CheckParamInt64(c, "measurement_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.GetPatientMeasurementByID(c, mID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if m.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, m)
+}
+
+func (h *MeasurementHandler) DeleteMeasurement(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	mID, err := CheckParamInt64(c, "measurement_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientMeasurement(c, mID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+// recordProfileMeasurements keeps the history in sync with body and vision values set through the profile
+func (h *BasicHandler) recordProfileMeasurements(c *gin.Context, patientID int64, accountID int64, ms []*model.AddMeasurement) error {
+	now := time.Now()
+
+	for _, v := range ms {
+		if err := v.Normalize(now); err != nil {
+			return err
+		}
+
+		if _, err := h.storage.AddPatientMeasurement(c, patientID, accountID, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type MedicationHandler struct {
+	*BasicHandler
+}
+
+func NewMedicationHandler(basicHandler *BasicHandler) *MedicationHandler {
+	return &MedicationHandler{BasicHandler: basicHandler}
+}
+
+func (h *MedicationHandler) InitRoutes(r gin.IRouter) {
+	md := r.Group("/medications")
+	{
+		md.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddMedication)
+		md.GET("", h.GetMedications)
+		md.GET("/:medication_id", h.GetMedication)
+		md.PUT("/:medication_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateMedication)
+		md.PUT("/:medication_id/stop", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.StopMedication)
+		md.DELETE("/:medication_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteMedication)
+	}
+}
+
+func (h *MedicationHandler) InitSpecialistRoutes(r gin.IRouter) {
+	md := r.Group("/patients/:patient_id/medications", h.CheckPatientSpecialist())
+	{
+		md.GET("", h.GetMedications)
+	}
+}
+
+func (h *MedicationHandler) AddMedication(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddMedication
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if !req.ValidDates() {
+		h.sendError(c, ErrFinishDate, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.AddPatientMedication(c, p.ID, a.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrForeignKeyConstraintFail) {
+			h.sendError(c, ErrPrescriber, http.StatusBadRequest)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.MedicationAddKey, model.MedicationMessage{PatientID: p.ID, Medication: m}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, m)
+}
+
+func (h *MedicationHandler) GetMedications(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.ListMedicationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ms, err := h.storage.GetPatientMedications(c, p.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListMedications{Medications: ms}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *MedicationHandler) GetMedication(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	mID, err := CheckParamInt64(c, "medication_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.GetPatientMedicationByID(c, mID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if m.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, m)
+}
+
+func (h *MedicationHandler) UpdateMedication(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	mID, err := CheckParamInt64(c, "medication_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateMedication
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if !req.ValidDates() {
+		h.sendError(c, ErrFinishDate, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.storage.UpdatePatientMedication(c, mID, p.ID, a.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrForeignKeyConstraintFail) {
+			h.sendError(c, ErrPrescriber, http.StatusBadRequest)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.MedicationUpdateKey, model.MedicationMessage{PatientID: p.ID, Medication: m}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, m)
+}
+
+func (h *MedicationHandler) StopMedication(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	mID, err := CheckParamInt64(c, "medication_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.StopMedication
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare(time.Now())
+
+	m, err := h.storage.GetPatientMedicationByID(c, mID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if m.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	if req.Finish.Time.Before(m.Start.Time) {
+		h.sendError(c, ErrFinishDate, http.StatusBadRequest)
+		return
+	}
+
+	m, err = h.storage.StopPatientMedication(c, mID, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.MedicationStopKey, model.MedicationMessage{PatientID: p.ID, Medication: m}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, m)
+}
+
+func (h *MedicationHandler) DeleteMedication(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	mID, err := CheckParamInt64(c, "medication_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientMedication(c, mID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.MedicationDeleteKey, model.IDMessage{ID: *mID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
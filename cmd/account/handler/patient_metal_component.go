@@ -4,6 +4,7 @@ import (
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
 type MetalComponentHandler struct {
@@ -19,14 +20,23 @@ func (h *MetalComponentHandler) InitRoutes(r gin.IRouter) {
 	{
 		mc.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddMetalComponent)
 		mc.GET("", h.GetMetalComponents)
+		mc.GET("/mri_screening", h.GetMRIScreening)
 		mc.GET("/:metal_component_id", h.GetMetalComponent)
 		mc.PUT("/:metal_component_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateMetalComponent)
 		mc.DELETE("/:metal_component_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteMetalComponent)
 	}
 }
 
+func (h *MetalComponentHandler) InitSpecialistRoutes(r gin.IRouter) {
+	mc := r.Group("/patients/:patient_id/metal_components", h.CheckPatientSpecialist())
+	{
+		mc.GET("", h.GetMetalComponents)
+		mc.GET("/mri_screening", h.GetMRIScreening)
+	}
+}
+
 func (h *MetalComponentHandler) AddMetalComponent(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
 
 	var req model.AddMetalComponent
@@ -34,8 +44,19 @@ func (h *MetalComponentHandler) AddMetalComponent(c *gin.Context) {
 		h.sendError(c, err, http.StatusBadRequest)
 		return
 	}
+	req.Prepare()
+
+	if req.Implanted != nil && req.Implanted.Time.After(time.Now()) {
+		h.sendError(c, ErrInvalidField, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkAccountFiles(c, a.ID, req.Files); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
 
-	mc, err := h.storage.AddMetalComponent(c, p.ID, &req)
+	mc, err := h.storage.AddMetalComponent(c, p.ID, a.ID, &req)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
@@ -59,6 +80,19 @@ func (h *MetalComponentHandler) GetMetalComponents(c *gin.Context) {
 	h.sendOK(c, http.StatusOK, list)
 }
 
+// GetMRIScreening summarises the implanted devices for a radiologist before the scan.
+func (h *MetalComponentHandler) GetMRIScreening(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	mcs, err := h.storage.GetMetalComponents(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.NewMRIScreening(mcs))
+}
+
 func (h *MetalComponentHandler) GetMetalComponent(c *gin.Context) {
 	//a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
@@ -84,7 +118,7 @@ func (h *MetalComponentHandler) GetMetalComponent(c *gin.Context) {
 }
 
 func (h *MetalComponentHandler) UpdateMetalComponent(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
 
 	mcID, err := CheckParamInt64(c, "metal_component_id")
@@ -98,8 +132,19 @@ func (h *MetalComponentHandler) UpdateMetalComponent(c *gin.Context) {
 		h.sendError(c, err, http.StatusBadRequest)
 		return
 	}
+	req.Prepare()
 
-	mc, err := h.storage.UpdateMetalComponent(c, mcID, p.ID, &req)
+	if req.Implanted != nil && req.Implanted.Time.After(time.Now()) {
+		h.sendError(c, ErrInvalidField, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkAccountFiles(c, a.ID, req.Files); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	mc, err := h.storage.UpdateMetalComponent(c, mcID, p.ID, a.ID, &req)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
@@ -109,7 +154,7 @@ func (h *MetalComponentHandler) UpdateMetalComponent(c *gin.Context) {
 }
 
 func (h *MetalComponentHandler) DeleteMetalComponent(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 	p := c.MustGet("current_patient").(*model.Patient)
 
 	mcID, err := CheckParamInt64(c, "metal_component_id")
@@ -118,7 +163,7 @@ func (h *MetalComponentHandler) DeleteMetalComponent(c *gin.Context) {
 		return
 	}
 
-	if err := h.storage.DeleteMetalComponent(c, mcID, p.ID); err != nil {
+	if err := h.storage.DeleteMetalComponent(c, mcID, p.ID, a.ID); err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PatientSpecialistHandler struct {
+	*BasicHandler
+}
+
+func NewPatientSpecialistHandler(basicHandler *BasicHandler) *PatientSpecialistHandler {
+	return &PatientSpecialistHandler{BasicHandler: basicHandler}
+}
+
+func (h *PatientSpecialistHandler) InitRoutes(r gin.IRouter) {
+	s := r.Group("/specialists")
+	{
+		s.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddSpecialist)
+		s.GET("", h.GetSpecialists)
+		s.GET("/:specialist_id", h.GetSpecialist)
+		s.DELETE("/:specialist_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteSpecialist)
+	}
+}
+
+func (h *PatientSpecialistHandler) InitSpecialistRoutes(r gin.IRouter) {
+	r.PUT("/patients/:patient_id/confirm", h.CheckPatientSpecialist(), h.ConfirmPatient)
+}
+
+func (h *PatientSpecialistHandler) AddSpecialist(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddPatientSpecialist
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	s, err := h.storage.AddPatientSpecialist(c, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.PatientSpecialistMessage{PatientID: p.ID, SpecialistID: req.SpecialistID}
+	if err := h.broker.SendMessage(broker.PatientSpecialistAddKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, s)
+}
+
+func (h *PatientSpecialistHandler) GetSpecialists(c *gin.Context) {
+	//a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	ss, err := h.storage.GetPatientSpecialists(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListPatientSpecialists{Specialists: ss}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *PatientSpecialistHandler) GetSpecialist(c *gin.Context) {
+	//a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	sID, err := CheckParamInt64(c, "specialist_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	s, err := h.storage.GetPatientSpecialistByID(c, p.ID, sID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, s)
+}
+
+func (h *PatientSpecialistHandler) DeleteSpecialist(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	sID, err := CheckParamInt64(c, "specialist_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientSpecialist(c, p.ID, a.ID, sID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.PatientSpecialistMessage{PatientID: p.ID, SpecialistID: *sID}
+	if err := h.broker.SendMessage(broker.PatientSpecialistDeleteKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+// ConfirmPatient is the specialist confirming they treat the patient who added them
+func (h *PatientSpecialistHandler) ConfirmPatient(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	s := c.MustGet("current_specialist").(*model.Specialist)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	ps, err := h.storage.ConfirmPatientSpecialist(c, p.ID, a.ID, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	msg := model.PatientSpecialistMessage{PatientID: p.ID, SpecialistID: s.ID}
+	if err := h.broker.SendMessage(broker.PatientSpecialistConfirmKey, msg); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, ps)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type VaccinationHandler struct {
+	*BasicHandler
+}
+
+func NewVaccinationHandler(basicHandler *BasicHandler) *VaccinationHandler {
+	return &VaccinationHandler{BasicHandler: basicHandler}
+}
+
+func (h *VaccinationHandler) InitRoutes(r gin.IRouter) {
+	v := r.Group("/vaccinations")
+	{
+		v.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddVaccination)
+		v.GET("", h.GetVaccinations)
+		v.GET("/schedule", h.GetVaccinationSchedule)
+		v.GET("/overdue", h.GetOverdueVaccinations)
+		v.GET("/:vaccination_id", h.GetVaccination)
+		v.PUT("/:vaccination_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateVaccination)
+		v.DELETE("/:vaccination_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteVaccination)
+	}
+}
+
+func (h *VaccinationHandler) InitSpecialistRoutes(r gin.IRouter) {
+	v := r.Group("/patients/:patient_id/vaccinations", h.CheckPatientSpecialist())
+	{
+		v.GET("", h.GetVaccinations)
+		v.GET("/overdue", h.GetOverdueVaccinations)
+	}
+}
+
+func (h *VaccinationHandler) AddVaccination(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddVaccination
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	if err := h.checkAccountFiles(c, a.ID, req.Files); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.storage.AddPatientVaccination(c, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.VaccinationAddKey, model.VaccinationMessage{PatientID: p.ID, Vaccination: v}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, v)
+}
+
+func (h *VaccinationHandler) GetVaccinations(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	vs, err := h.storage.GetPatientVaccinations(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListVaccinations{Vaccinations: vs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *VaccinationHandler) GetVaccination(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	vID, err := CheckParamInt64(c, "vaccination_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.storage.GetPatientVaccinationByID(c, vID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if v.PatientID != p.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, v)
+}
+
+func (h *VaccinationHandler) UpdateVaccination(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	vID, err := CheckParamInt64(c, "vaccination_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateVaccination
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+
+	if err := h.checkAccountFiles(c, a.ID, req.Files); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.storage.UpdatePatientVaccination(c, vID, p.ID, a.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.VaccinationUpdateKey, model.VaccinationMessage{PatientID: p.ID, Vaccination: v}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, v)
+}
+
+func (h *VaccinationHandler) DeleteVaccination(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	vID, err := CheckParamInt64(c, "vaccination_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeletePatientVaccination(c, vID, p.ID, a.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.VaccinationDeleteKey, model.IDMessage{ID: *vID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *VaccinationHandler) GetVaccinationSchedule(c *gin.Context) {
+	schedule, err := h.vaccinationSchedule(c)
+	if err != nil {
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, schedule)
+}
+
+func (h *VaccinationHandler) GetOverdueVaccinations(c *gin.Context) {
+	schedule, err := h.vaccinationSchedule(c)
+	if err != nil {
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, schedule.Overdue())
+}
+
+func (h *VaccinationHandler) vaccinationSchedule(c *gin.Context) (*model.VaccinationSchedule, error) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	if p.Birthday == nil || !p.Birthday.Valid {
+		h.sendError(c, ErrNoBirthday, http.StatusBadRequest)
+		return nil, ErrNoBirthday
+	}
+
+	vs, err := h.storage.GetPatientVaccinations(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return nil, err
+	}
+
+	return model.NewVaccinationSchedule(p.Birthday.Time, vs, time.Now()), nil
+}
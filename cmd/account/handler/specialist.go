@@ -4,6 +4,7 @@ import (
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"time"
 )
 
 type SpecialistHandler struct {
@@ -39,6 +40,13 @@ func (h *SpecialistHandler) GetSpecialistProfile(c *gin.Context) {
 	//a := c.MustGet("current_account").(*model.Account)
 	s := c.MustGet("current_specialist").(*model.Specialist)
 
+	s.CME = model.NewCMESummary(s.EducationalCourses, h.cfg.CME.RequiredPoints, h.cfg.CME.PeriodYears, time.Now())
+
+	if err := h.setSpecialistRatings(c, s); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
 	h.sendOK(c, http.StatusOK, s)
 }
 
@@ -62,7 +70,7 @@ func (h *SpecialistHandler) UpdateSpecialistProfileMain(c *gin.Context) {
 }
 
 func (h *SpecialistHandler) GetSpecialists(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 
 	var req model.ListSpecialistsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -77,11 +85,29 @@ func (h *SpecialistHandler) GetSpecialists(c *gin.Context) {
 		return
 	}
 
-	h.sendOK(c, http.StatusOK, model.ListSpecialists{Specialists: ss})
+	for _, v := range ss {
+		if err := h.applySpecialistPrivacy(c, a.ID, v); err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.setSpecialistRatings(c, ss...); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	fs, err := h.storage.GetSpecialistFacets(c, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.ListSpecialists{Specialists: ss, Facets: fs})
 }
 
 func (h *SpecialistHandler) GetSpecialist(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 
 	sID, err := CheckParamInt64(c, "id")
 	if err != nil {
@@ -95,5 +121,39 @@ func (h *SpecialistHandler) GetSpecialist(c *gin.Context) {
 		return
 	}
 
+	s.CME = model.NewCMESummary(s.EducationalCourses, h.cfg.CME.RequiredPoints, h.cfg.CME.PeriodYears, time.Now())
+
+	if err := h.setSpecialistRatings(c, s); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.applySpecialistPrivacy(c, a.ID, s); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
 	h.sendOK(c, http.StatusOK, s)
 }
+
+// setSpecialistRatings adds the rating of the approved reviews, zero for the specialists without them
+func (h *SpecialistHandler) setSpecialistRatings(c *gin.Context, ss ...*model.Specialist) error {
+	ids := make([]int64, 0, len(ss))
+	for _, v := range ss {
+		ids = append(ids, v.ID)
+	}
+
+	rs, err := h.storage.GetSpecialistRatings(c, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range ss {
+		v.Rating = rs[v.ID]
+		if v.Rating == nil {
+			v.Rating = &model.SpecialistRating{}
+		}
+	}
+
+	return nil
+}
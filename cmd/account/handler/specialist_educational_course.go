@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type EducationalCourseHandler struct {
+	*BasicHandler
+}
+
+func NewEducationalCourseHandler(basicHandler *BasicHandler) *EducationalCourseHandler {
+	return &EducationalCourseHandler{BasicHandler: basicHandler}
+}
+
+func (h *EducationalCourseHandler) InitRoutes(r gin.IRouter) {
+	mc := r.Group("/courses")
+	{
+		mc.POST("", h.AddEducationalCourse)
+		mc.GET("", h.GetEducationalCourses)
+		mc.GET("/points", h.GetCoursePoints)
+		mc.GET("/:course_id", h.GetEducationalCourse)
+		mc.PUT("/:course_id", h.UpdateEducationalCourse)
+		mc.DELETE("/:course_id", h.DeleteEducationalCourse)
+	}
+}
+
+func (h *EducationalCourseHandler) AddEducationalCourse(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.AddEducationalCourse
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Files) > 0 {
+		files, err := h.storage.GetFiles(c, a.ID)
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+
+		req.Files = FilterFilesByID(files, req.Files)
+	}
+
+	e, err := h.storage.AddSpecialistProfileEducationalCourse(c, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusCreated, e)
+}
+
+func (h *EducationalCourseHandler) GetEducationalCourses(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	cs, err := h.storage.GetSpecialistProfileEducationalCourses(c, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListEducationalCourses{EducationalCourses: cs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *EducationalCourseHandler) GetCoursePoints(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.CoursePointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ps, err := h.storage.GetSpecialistProfileCoursePoints(c, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListCoursePoints{Periods: ps}
+	for _, v := range ps {
+		list.Total += v.Points
+	}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *EducationalCourseHandler) GetEducationalCourse(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	cID, err := CheckParamInt64(c, "course_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.GetSpecialistProfileEducationalCourseByID(c, cID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if e.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, e)
+}
+
+func (h *EducationalCourseHandler) UpdateEducationalCourse(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	cID, err := CheckParamInt64(c, "course_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateEducationalCourse
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Files) > 0 {
+		files, err := h.storage.GetFiles(c, a.ID)
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+
+		req.Files = FilterFilesByID(files, req.Files)
+	}
+
+	e, err := h.storage.UpdateSpecialistProfileEducationalCourse(c, cID, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, e)
+}
+
+func (h *EducationalCourseHandler) DeleteEducationalCourse(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	cID, err := CheckParamInt64(c, "course_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeleteSpecialistProfileEducationalCourse(c, cID, s.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
@@ -26,7 +26,7 @@ func (h *ExperienceHandler) InitRoutes(r gin.IRouter) {
 }
 
 func (h *ExperienceHandler) AddExperience(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 	s := c.MustGet("current_specialist").(*model.Specialist)
 
 	var req model.AddExperience
@@ -35,6 +35,11 @@ func (h *ExperienceHandler) AddExperience(c *gin.Context) {
 		return
 	}
 
+	if req.OrganizationID != nil && GetAccountOrganizationByID(a.Organizations, *req.OrganizationID) == nil {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
 	e, err := h.storage.AddSpecialistProfileExperience(c, s.ID, &req)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
@@ -90,7 +95,7 @@ func (h *ExperienceHandler) GetExperience(c *gin.Context) {
 }
 
 func (h *ExperienceHandler) UpdateExperience(c *gin.Context) {
-	//a := c.MustGet("current_account").(*model.Account)
+	a := c.MustGet("current_account").(*model.Account)
 	s := c.MustGet("current_specialist").(*model.Specialist)
 
 	eID, err := CheckParamInt64(c, "experience_id")
@@ -105,6 +110,11 @@ func (h *ExperienceHandler) UpdateExperience(c *gin.Context) {
 		return
 	}
 
+	if req.OrganizationID != nil && GetAccountOrganizationByID(a.Organizations, *req.OrganizationID) == nil {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
 	e, err := h.storage.UpdateSpecialistProfileExperience(c, eID, s.ID, &req)
 	if err != nil {
 		h.sendError(c, err, http.StatusInternalServerError)
@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/licence"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type LicenceHandler struct {
+	*BasicHandler
+}
+
+func NewLicenceHandler(basicHandler *BasicHandler) *LicenceHandler {
+	return &LicenceHandler{BasicHandler: basicHandler}
+}
+
+func (h *LicenceHandler) InitRoutes(r gin.IRouter) {
+	l := r.Group("/licences")
+	{
+		l.POST("", h.AddLicence)
+		l.GET("", h.GetLicences)
+		l.GET("/:licence_id", h.GetLicence)
+		l.PUT("/:licence_id", h.UpdateLicence)
+		l.PUT("/:licence_id/status", h.UpdateLicenceStatus)
+		l.POST("/:licence_id/check", h.CheckLicence)
+		l.DELETE("/:licence_id", h.DeleteLicence)
+	}
+}
+
+func (h *LicenceHandler) AddLicence(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.AddLicence
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.checkRegistry(c, req.Number)
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.AddSpecialistProfileLicence(c, s.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrLicenceExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if res != nil {
+		l, err = h.storage.UpdateSpecialistProfileLicenceCheck(c, l.ID, licenceMatches(l, res))
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.broker.SendMessage(broker.LicenceAddKey, model.LicenceMessage{ProfileID: s.ID, Licence: l}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, l)
+}
+
+func (h *LicenceHandler) GetLicences(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	ls, err := h.storage.GetSpecialistProfileLicences(c, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListLicences{Licences: ls}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *LicenceHandler) GetLicence(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.GetSpecialistProfileLicenceByID(c, lID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if l.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, l)
+}
+
+func (h *LicenceHandler) UpdateLicence(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateLicence
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.checkRegistry(c, req.Number)
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.UpdateSpecialistProfileLicence(c, lID, s.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrLicenceExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if res != nil {
+		l, err = h.storage.UpdateSpecialistProfileLicenceCheck(c, l.ID, licenceMatches(l, res))
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.broker.SendMessage(broker.LicenceUpdateKey, model.LicenceMessage{ProfileID: s.ID, Licence: l}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, l)
+}
+
+func (h *LicenceHandler) UpdateLicenceStatus(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateLicenceStatus
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.GetSpecialistProfileLicenceByID(c, lID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if l.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	if l.Status == req.Status {
+		h.sendOK(c, http.StatusOK, l)
+		return
+	}
+
+	if !licenceStatusAllowed(l.Status, req.Status) {
+		h.sendError(c, ErrLicenceStatus, http.StatusBadRequest)
+		return
+	}
+
+	// a licence is reactivated only once it is renewed and the registry confirms it is active
+	var res *licence.Result
+	if req.Status == model.LicenceStatusActive {
+		if licenceExpired(l, time.Now()) {
+			h.sendError(c, ErrLicenceExpired, http.StatusBadRequest)
+			return
+		}
+
+		res, err = h.licence.Check(c, l.Number)
+		if err != nil {
+			if errors.Is(err, licence.ErrNotFound) {
+				h.sendError(c, err, http.StatusBadRequest)
+				return
+			}
+
+			h.sendError(c, err, http.StatusServiceUnavailable)
+			return
+		}
+
+		if res.Status != model.LicenceStatusActive {
+			h.sendError(c, ErrLicenceInactive, http.StatusBadRequest)
+			return
+		}
+	}
+
+	l, err = h.storage.UpdateSpecialistProfileLicenceStatus(c, lID, s.ID, req.Status)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if res != nil {
+		l, err = h.storage.UpdateSpecialistProfileLicenceCheck(c, l.ID, licenceMatches(l, res))
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := h.broker.SendMessage(broker.LicenceStatusKey, model.LicenceMessage{ProfileID: s.ID, Licence: l}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, l)
+}
+
+func (h *LicenceHandler) CheckLicence(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.storage.GetSpecialistProfileLicenceByID(c, lID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if l.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.licence.Check(c, l.Number)
+	if err != nil && !errors.Is(err, licence.ErrNotFound) {
+		h.sendError(c, err, http.StatusServiceUnavailable)
+		return
+	}
+
+	l, err = h.storage.UpdateSpecialistProfileLicenceCheck(c, l.ID, res != nil && licenceMatches(l, res))
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, l)
+}
+
+func (h *LicenceHandler) DeleteLicence(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	lID, err := CheckParamInt64(c, "licence_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = h.storage.DeleteSpecialistProfileLicence(c, lID, s.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.LicenceDeleteKey, model.IDMessage{ID: *lID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+// checkRegistry rejects numbers unknown to the registry; an unreachable or
+// unconfigured registry does not block the request, the licence just stays unverified.
+func (h *LicenceHandler) checkRegistry(c *gin.Context, number string) (*licence.Result, error) {
+	res, err := h.licence.Check(c, number)
+	if err != nil {
+		if errors.Is(err, licence.ErrNotFound) {
+			return nil, err
+		}
+
+		h.log.Warn(err)
+		return nil, nil
+	}
+
+	return res, nil
+}
+
+func licenceMatches(l *model.Licence, res *licence.Result) bool {
+	return res.Status == model.LicenceStatusActive && strings.EqualFold(strings.TrimSpace(res.FullName), strings.TrimSpace(l.FullName))
+}
+
+// licenceStatusAllowed lets the owner suspend or close the licence and reactivate a suspended or expired one, closed is final
+func licenceStatusAllowed(from string, to string) bool {
+	if from == model.LicenceStatusClosed {
+		return false
+	}
+
+	switch to {
+	case model.LicenceStatusActive:
+		return from == model.LicenceStatusSuspended || from == model.LicenceStatusExpired
+	case model.LicenceStatusClosed:
+		return true
+	case model.LicenceStatusSuspended:
+		return from == model.LicenceStatusActive
+	default:
+		return false
+	}
+}
+
+// licenceExpired reports whether the expiry date of the licence is before the day of now
+func licenceExpired(l *model.Licence, now time.Time) bool {
+	if l.Expires == nil || !l.Expires.Valid {
+		return false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return l.Expires.Time.Before(today)
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ReviewHandler struct {
+	*BasicHandler
+}
+
+func NewReviewHandler(basicHandler *BasicHandler) *ReviewHandler {
+	return &ReviewHandler{BasicHandler: basicHandler}
+}
+
+func (h *ReviewHandler) InitRoutes(r gin.IRouter) {
+	rv := r.Group("/reviews")
+	{
+		rv.POST("", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.AddReview)
+		rv.GET("", h.GetReviews)
+		rv.GET("/:review_id", h.GetReview)
+		rv.PUT("/:review_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.UpdateReview)
+		rv.DELETE("/:review_id", h.CheckPatientAdminPermissions(model.PatientAdminPermissionEdit), h.DeleteReview)
+	}
+}
+
+func (h *ReviewHandler) InitSpecialistRoutes(r gin.IRouter) {
+	rv := r.Group("/reviews")
+	{
+		rv.GET("", h.GetSpecialistProfileReviews)
+		rv.PUT("/:review_id/reply", h.ReplyReview)
+		rv.DELETE("/:review_id/reply", h.DeleteReviewReply)
+	}
+}
+
+func (h *ReviewHandler) InitSpecialistsRoutes(r gin.IRouter) {
+	r.GET("/specialists/:id/reviews", h.IdentifyAccount(), h.GetSpecialistReviews)
+}
+
+func (h *ReviewHandler) AddReview(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	var req model.AddReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkReviewAllowed(c, a.ID, p.ID, req.SpecialistID); err != nil {
+		if errors.Is(err, ErrReviewNotAllowed) {
+			h.sendError(c, err, http.StatusForbidden)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	rv, err := h.storage.AddReview(c, p.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrReviewExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ReviewAddKey, model.ReviewMessage{SpecialistID: rv.SpecialistID, Review: rv}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, rv)
+}
+
+func (h *ReviewHandler) GetReviews(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rs, err := h.storage.GetPatientReviews(c, p.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.ListReviews{Reviews: rs})
+}
+
+func (h *ReviewHandler) GetReview(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rID, err := CheckParamInt64(c, "review_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	rv, err := h.storage.GetReviewByID(c, rID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if rv.PatientID != p.ID {
+		h.sendError(c, storage.ErrNotFound, http.StatusNotFound)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, rv)
+}
+
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rID, err := CheckParamInt64(c, "review_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	rv, err := h.storage.UpdateReview(c, rID, p.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ReviewUpdateKey, model.ReviewMessage{SpecialistID: rv.SpecialistID, Review: rv}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, rv)
+}
+
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	p := c.MustGet("current_patient").(*model.Patient)
+
+	rID, err := CheckParamInt64(c, "review_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeleteReview(c, rID, p.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ReviewDeleteKey, model.IDMessage{ID: *rID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *ReviewHandler) GetSpecialistProfileReviews(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	h.getApprovedReviews(c, a.ID, s.ID)
+}
+
+func (h *ReviewHandler) ReplyReview(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.ReplyReview
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	h.replyReview(c, s.ID, &req.Text)
+}
+
+func (h *ReviewHandler) DeleteReviewReply(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	h.replyReview(c, s.ID, nil)
+}
+
+func (h *ReviewHandler) GetSpecialistReviews(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	sID, err := CheckParamInt64(c, "id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	h.getApprovedReviews(c, a.ID, *sID)
+}
+
+func (h *ReviewHandler) getApprovedReviews(c *gin.Context, viewerID int64, specialistID int64) {
+	var req model.ListReviewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+	req.Prepare()
+	req.SpecialistID = specialistID
+	req.Status = model.ReviewStatusApproved
+
+	rs, err := h.storage.GetReviews(c, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	for _, v := range rs {
+		ps, r, err := h.getPrivacy(c, viewerID, v.AuthorAccountID)
+		if err != nil {
+			h.sendError(c, err, http.StatusInternalServerError)
+			return
+		}
+
+		v.ApplyPrivacy(ps, r)
+	}
+
+	h.sendOK(c, http.StatusOK, model.ListReviews{Reviews: rs})
+}
+
+func (h *ReviewHandler) replyReview(c *gin.Context, specialistID int64, reply *string) {
+	a := c.MustGet("current_account").(*model.Account)
+
+	rID, err := CheckParamInt64(c, "review_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	rv, err := h.storage.ReplyReview(c, rID, specialistID, reply)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ReviewReplyKey, model.ReviewMessage{SpecialistID: specialistID, Review: rv}); err != nil {
+		h.log.Error(err)
+	}
+
+	ps, r, err := h.getPrivacy(c, a.ID, rv.AuthorAccountID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+	rv.ApplyPrivacy(ps, r)
+
+	h.sendOK(c, http.StatusOK, rv)
+}
+
+// checkReviewAllowed lets the patients the specialist confirmed treating review them, the specialist can not review themselves
+func (h *ReviewHandler) checkReviewAllowed(c *gin.Context, accountID int64, patientID int64, specialistID int64) error {
+	ps, err := h.storage.GetPatientSpecialistByID(c, patientID, specialistID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrReviewNotAllowed
+		}
+		return err
+	}
+
+	if ps.ConfirmedAt == nil {
+		return ErrReviewNotAllowed
+	}
+
+	s, err := h.storage.GetSpecialistByID(c, specialistID)
+	if err != nil {
+		return err
+	}
+
+	if s.AccountID == accountID {
+		return ErrReviewNotAllowed
+	}
+
+	return nil
+}
@@ -0,0 +1,411 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ScheduleHandler struct {
+	*BasicHandler
+}
+
+func NewScheduleHandler(basicHandler *BasicHandler) *ScheduleHandler {
+	return &ScheduleHandler{BasicHandler: basicHandler}
+}
+
+func (h *ScheduleHandler) InitRoutes(r gin.IRouter) {
+	sc := r.Group("/schedules")
+	{
+		sc.POST("", h.AddSchedule)
+		sc.GET("", h.GetSchedules)
+		sc.GET("/:schedule_id", h.GetSchedule)
+		sc.PUT("/:schedule_id", h.UpdateSchedule)
+		sc.DELETE("/:schedule_id", h.DeleteSchedule)
+	}
+
+	se := r.Group("/schedule_exceptions")
+	{
+		se.POST("", h.AddScheduleException)
+		se.GET("", h.GetScheduleExceptions)
+		se.GET("/:exception_id", h.GetScheduleException)
+		se.PUT("/:exception_id", h.UpdateScheduleException)
+		se.DELETE("/:exception_id", h.DeleteScheduleException)
+	}
+}
+
+// InitSpecialistsRoutes opens the schedules of any specialist for booking
+func (h *ScheduleHandler) InitSpecialistsRoutes(r gin.IRouter) {
+	ss := r.Group("/specialists/:id", h.IdentifyAccount())
+	{
+		ss.GET("/schedules", h.GetSpecialistSchedules)
+		ss.GET("/availability", h.GetAvailability)
+	}
+}
+
+func (h *ScheduleHandler) AddSchedule(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.AddSchedule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkScheduleLocation(c, s, req.OrganizationID, req.AddressID); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	sc, err := h.storage.AddSpecialistSchedule(c, s.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrScheduleExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ScheduleAddKey, model.ScheduleMessage{SpecialistID: s.ID, Schedule: sc}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, sc)
+}
+
+func (h *ScheduleHandler) GetSchedules(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	ss, err := h.storage.GetSpecialistSchedules(c, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.ListSchedules{Schedules: ss})
+}
+
+func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	scID, err := CheckParamInt64(c, "schedule_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	sc, err := h.storage.GetSpecialistScheduleByID(c, scID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if sc.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, sc)
+}
+
+func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	scID, err := CheckParamInt64(c, "schedule_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateSchedule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkScheduleLocation(c, s, req.OrganizationID, req.AddressID); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	sc, err := h.storage.UpdateSpecialistSchedule(c, scID, s.ID, &req)
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUniqueFails) {
+			h.sendError(c, ErrScheduleExists, http.StatusConflict)
+			return
+		}
+
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ScheduleUpdateKey, model.ScheduleMessage{SpecialistID: s.ID, Schedule: sc}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, sc)
+}
+
+func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	scID, err := CheckParamInt64(c, "schedule_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = h.storage.DeleteSpecialistSchedule(c, scID, s.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ScheduleDeleteKey, model.IDMessage{ID: *scID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *ScheduleHandler) AddScheduleException(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.AddScheduleException
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkSchedule(c, s.ID, req.ScheduleID); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.AddSpecialistScheduleException(c, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ScheduleExceptionAddKey, model.ScheduleExceptionMessage{SpecialistID: s.ID, Exception: e}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, e)
+}
+
+func (h *ScheduleHandler) GetScheduleExceptions(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	es, err := h.storage.GetSpecialistScheduleExceptions(c, s.ID, nil, nil)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.ListScheduleExceptions{Exceptions: es})
+}
+
+func (h *ScheduleHandler) GetScheduleException(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	eID, err := CheckParamInt64(c, "exception_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.GetSpecialistScheduleExceptionByID(c, eID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if e.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, e)
+}
+
+func (h *ScheduleHandler) UpdateScheduleException(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	eID, err := CheckParamInt64(c, "exception_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateScheduleException
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkSchedule(c, s.ID, req.ScheduleID); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.storage.UpdateSpecialistScheduleException(c, eID, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ScheduleExceptionUpdateKey, model.ScheduleExceptionMessage{SpecialistID: s.ID, Exception: e}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, e)
+}
+
+func (h *ScheduleHandler) DeleteScheduleException(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	eID, err := CheckParamInt64(c, "exception_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = h.storage.DeleteSpecialistScheduleException(c, eID, s.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.ScheduleExceptionDeleteKey, model.IDMessage{ID: *eID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *ScheduleHandler) GetSpecialistSchedules(c *gin.Context) {
+	sID, err := CheckParamInt64(c, "id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ss, err := h.storage.GetSpecialistSchedules(c, sID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, model.ListSchedules{Schedules: ss})
+}
+
+// GetAvailability returns the free slots, the bookings are kept by the booking service and are not subtracted here
+func (h *ScheduleHandler) GetAvailability(c *gin.Context) {
+	sID, err := CheckParamInt64(c, "id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.AvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	ss, err := h.storage.GetSpecialistSchedules(c, sID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	es, err := h.storage.GetSpecialistScheduleExceptions(c, sID, &req.From, &req.To)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	a, err := model.NewAvailability(ss, es, &req, time.Now())
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, a)
+}
+
+// checkScheduleLocation allows the organizations the specialist is a member of and the own addresses
+func (h *ScheduleHandler) checkScheduleLocation(c *gin.Context, s *model.Specialist, organizationID *int64, addressID *int64) error {
+	if organizationID != nil {
+		if _, err := h.storage.GetOrganizationMemberByID(c, *organizationID, s.AccountID); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return ErrScheduleLocation
+			}
+			return err
+		}
+	}
+
+	if addressID != nil {
+		a, err := h.storage.GetAddressByID(c, *addressID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return ErrScheduleLocation
+			}
+			return err
+		}
+
+		if a.AccountID != s.AccountID {
+			return ErrScheduleLocation
+		}
+	}
+
+	return nil
+}
+
+func (h *ScheduleHandler) checkSchedule(c *gin.Context, specialistID int64, scheduleID *int64) error {
+	if scheduleID == nil {
+		return nil
+	}
+
+	sc, err := h.storage.GetSpecialistScheduleByID(c, *scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if sc.ProfileID != specialistID {
+		return ErrNoPermissions
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SpeechHandler struct {
+	*BasicHandler
+}
+
+func NewSpeechHandler(basicHandler *BasicHandler) *SpeechHandler {
+	return &SpeechHandler{BasicHandler: basicHandler}
+}
+
+func (h *SpeechHandler) InitRoutes(r gin.IRouter) {
+	mc := r.Group("/speeches")
+	{
+		mc.POST("", h.AddSpeech)
+		mc.GET("", h.GetSpeeches)
+		mc.GET("/:speech_id", h.GetSpeech)
+		mc.PUT("/:speech_id", h.UpdateSpeech)
+		mc.DELETE("/:speech_id", h.DeleteSpeech)
+	}
+}
+
+func (h *SpeechHandler) AddSpeech(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.AddSpeech
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	sp, err := h.storage.AddSpecialistProfileSpeech(c, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.SpeechAddKey, sp); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, sp)
+}
+
+func (h *SpeechHandler) GetSpeeches(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	sps, err := h.storage.GetSpecialistProfileSpeeches(c, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListSpeeches{Speeches: sps}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *SpeechHandler) GetSpeech(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	spID, err := CheckParamInt64(c, "speech_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	sp, err := h.storage.GetSpecialistProfileSpeechByID(c, spID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if sp.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, sp)
+}
+
+func (h *SpeechHandler) UpdateSpeech(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	spID, err := CheckParamInt64(c, "speech_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateSpeech
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	sp, err := h.storage.UpdateSpecialistProfileSpeech(c, spID, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.SpeechUpdateKey, sp); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, sp)
+}
+
+func (h *SpeechHandler) DeleteSpeech(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	spID, err := CheckParamInt64(c, "speech_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = h.storage.DeleteSpecialistProfileSpeech(c, spID, s.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.SpeechDeleteKey, model.IDMessage{ID: *spID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type TeachingHandler struct {
+	*BasicHandler
+}
+
+func NewTeachingHandler(basicHandler *BasicHandler) *TeachingHandler {
+	return &TeachingHandler{BasicHandler: basicHandler}
+}
+
+func (h *TeachingHandler) InitRoutes(r gin.IRouter) {
+	mc := r.Group("/teaching")
+	{
+		mc.POST("", h.AddTeaching)
+		mc.GET("", h.GetTeachings)
+		mc.GET("/:teaching_id", h.GetTeaching)
+		mc.PUT("/:teaching_id", h.UpdateTeaching)
+		mc.DELETE("/:teaching_id", h.DeleteTeaching)
+	}
+}
+
+func (h *TeachingHandler) AddTeaching(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.AddTeaching
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.storage.AddSpecialistProfileTeaching(c, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.TeachingAddKey, t); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, t)
+}
+
+func (h *TeachingHandler) GetTeachings(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	ts, err := h.storage.GetSpecialistProfileTeachings(c, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListTeachings{Teachings: ts}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *TeachingHandler) GetTeaching(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	tID, err := CheckParamInt64(c, "teaching_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.storage.GetSpecialistProfileTeachingByID(c, tID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if t.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, t)
+}
+
+func (h *TeachingHandler) UpdateTeaching(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	tID, err := CheckParamInt64(c, "teaching_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	var req model.UpdateTeaching
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.storage.UpdateSpecialistProfileTeaching(c, tID, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.TeachingUpdateKey, t); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, t)
+}
+
+func (h *TeachingHandler) DeleteTeaching(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	tID, err := CheckParamInt64(c, "teaching_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = h.storage.DeleteSpecialistProfileTeaching(c, tID, s.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.TeachingDeleteKey, model.IDMessage{ID: *tID}); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
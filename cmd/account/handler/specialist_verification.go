@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type VerificationHandler struct {
+	*BasicHandler
+}
+
+func NewVerificationHandler(basicHandler *BasicHandler) *VerificationHandler {
+	return &VerificationHandler{BasicHandler: basicHandler}
+}
+
+func (h *VerificationHandler) InitRoutes(r gin.IRouter) {
+	v := r.Group("/verifications")
+	{
+		v.POST("", h.AddVerification)
+		v.GET("", h.GetVerifications)
+		v.GET("/:verification_id", h.GetVerification)
+		v.DELETE("/:verification_id", h.DeleteVerification)
+	}
+}
+
+func (h *VerificationHandler) AddVerification(c *gin.Context) {
+	a := c.MustGet("current_account").(*model.Account)
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	var req model.AddVerification
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	profileID, err := h.getVerificationEntityProfileID(c, req.Entity, req.EntityID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if profileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.checkAccountFiles(c, a.ID, req.Files); err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.storage.AddVerification(c, s.ID, &req)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.broker.SendMessage(broker.VerificationAddKey, v); err != nil {
+		h.log.Error(err)
+	}
+
+	h.sendOK(c, http.StatusCreated, v)
+}
+
+func (h *VerificationHandler) GetVerifications(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	vs, err := h.storage.GetSpecialistVerifications(c, s.ID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	list := model.ListVerifications{Verifications: vs}
+
+	h.sendOK(c, http.StatusOK, list)
+}
+
+func (h *VerificationHandler) GetVerification(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	vID, err := CheckParamInt64(c, "verification_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.storage.GetVerificationByID(c, vID)
+	if err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	if v.ProfileID != s.ID {
+		h.sendError(c, ErrNoPermissions, http.StatusBadRequest)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, v)
+}
+
+func (h *VerificationHandler) DeleteVerification(c *gin.Context) {
+	s := c.MustGet("current_specialist").(*model.Specialist)
+
+	vID, err := CheckParamInt64(c, "verification_id")
+	if err != nil {
+		h.sendError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storage.DeleteVerification(c, vID, s.ID); err != nil {
+		h.sendError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOK(c, http.StatusOK, "")
+}
+
+func (h *VerificationHandler) getVerificationEntityProfileID(c *gin.Context, entity string, id int64) (int64, error) {
+	switch entity {
+	case model.VerificationEntityEducation:
+		e, err := h.storage.GetSpecialistProfileEducationByID(c, id)
+		if err != nil {
+			return 0, err
+		}
+		return e.ProfileID, nil
+	case model.VerificationEntityAssociation:
+		a, err := h.storage.GetSpecialistProfileAssociationByID(c, id)
+		if err != nil {
+			return 0, err
+		}
+		return a.ProfileID, nil
+	case model.VerificationEntityExperience:
+		e, err := h.storage.GetSpecialistProfileExperienceByID(c, id)
+		if err != nil {
+			return 0, err
+		}
+		return e.ProfileID, nil
+	case model.VerificationEntityCourse:
+		e, err := h.storage.GetSpecialistProfileEducationalCourseByID(c, id)
+		if err != nil {
+			return 0, err
+		}
+		return e.ProfileID, nil
+	}
+
+	return 0, ErrInvalidField
+}
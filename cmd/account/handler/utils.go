@@ -96,3 +96,28 @@ func FilterFilesByID(validFiles []*model.File, checkFiles []*model.File) []*mode
 
 	return files
 }
+
+func GetAccountOrganizationByID(organizations []*model.AccountOrganization, id int64) *model.AccountOrganization {
+	for _, v := range organizations {
+		if v.ID == id {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// CheckAccountPatientProfilesByID tells if the account administers the patient profile, its own one included
+func CheckAccountPatientProfilesByID(profiles model.ListProfiles, id int64) bool {
+	if profiles.PatientProfileID == id {
+		return true
+	}
+
+	for _, v := range profiles.Patients {
+		if v.ID == id && v.Verified {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,283 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"github.com/Hvaekar/med-account/config"
+	"github.com/Hvaekar/med-account/internal/account"
+	"github.com/Hvaekar/med-account/pkg/amazon"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/export"
+	"github.com/Hvaekar/med-account/pkg/logger"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/utils"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const exportKeyLength = 20
+
+type AccountExporter struct {
+	log     logger.Logger
+	cfg     *config.Export
+	storage account.Storage
+	s3      *amazon.S3
+	broker  broker.MessageBroker
+}
+
+func NewAccountExporter(log logger.Logger, cfg *config.Export, storage account.Storage, s3 *amazon.S3, broker broker.MessageBroker) *AccountExporter {
+	return &AccountExporter{log: log, cfg: cfg, storage: storage, s3: s3, broker: broker}
+}
+
+func (e *AccountExporter) Run(ctx context.Context) {
+	runEvery(ctx, e.log, e.cfg.Interval, e.Export)
+}
+
+// Export removes expired archives and builds the requested ones, a failed job is not retried.
+func (e *AccountExporter) Export(c context.Context) error {
+	expired, err := e.storage.ExpireAccountExports(c)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range expired {
+		if v.FileKey == nil {
+			continue
+		}
+
+		if err := e.s3.DeleteObject(c, *v.FileKey); err != nil {
+			e.log.Error(err)
+		}
+	}
+
+	exports, err := e.storage.ClaimAccountExports(c, time.Now().Add(-e.cfg.RetryAfter))
+	if err != nil {
+		return err
+	}
+
+	for _, v := range exports {
+		key, size, err := e.build(c, v.AccountID)
+		if err != nil {
+			e.log.Error(err)
+
+			failed, err := e.storage.SetAccountExportFailed(c, v.ID, err.Error())
+			if err != nil {
+				return err
+			}
+
+			if err := e.broker.SendMessage(broker.ExportFailedKey, model.ExportMessage{AccountID: v.AccountID, Export: failed}); err != nil {
+				e.log.Error(err)
+			}
+
+			continue
+		}
+
+		ready, err := e.storage.SetAccountExportReady(c, v.ID, key, size, time.Now().Add(e.cfg.ExpiresIn))
+		if err != nil {
+			return err
+		}
+
+		if err := e.broker.SendMessage(broker.ExportReadyKey, model.ExportMessage{AccountID: v.AccountID, Export: ready}); err != nil {
+			e.log.Error(err)
+		}
+	}
+
+	return nil
+}
+
+// build writes the archive to a temporary file first, so large uploads are not kept in memory.
+func (e *AccountExporter) build(c context.Context, accountID int64) (string, int64, error) {
+	a, err := e.storage.GetAccountByID(c, accountID)
+	if err != nil {
+		return "", 0, fmt.Errorf("get account: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "export-*.zip")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	arch := export.NewArchive(f, a.Login)
+
+	if err := e.write(c, arch, a); err != nil {
+		return "", 0, err
+	}
+
+	if err := arch.Close(time.Now()); err != nil {
+		return "", 0, err
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	key := fmt.Sprintf("exports/%d/%s.zip", accountID, utils.RandString(exportKeyLength))
+	if err := e.s3.PutObject(c, key, f); err != nil {
+		return "", 0, fmt.Errorf("upload archive: %w", err)
+	}
+
+	return key, size, nil
+}
+
+func (e *AccountExporter) write(c context.Context, arch *export.Archive, a *model.Account) error {
+	if err := arch.AddJSON("account.json", "Account, contacts, languages and organizations", a); err != nil {
+		return err
+	}
+
+	privacy, err := e.storage.GetPrivacySettings(c, a.ID)
+	if err != nil {
+		return fmt.Errorf("get privacy settings: %w", err)
+	}
+
+	if err := arch.AddJSON("privacy.json", "Privacy settings", privacy); err != nil {
+		return err
+	}
+
+	patients := make([]int64, 0, len(a.Profiles.Patients)+1)
+	if a.Profiles.PatientProfileID != 0 {
+		patients = append(patients, a.Profiles.PatientProfileID)
+	}
+	for _, v := range a.Profiles.Patients {
+		if v.ID != a.Profiles.PatientProfileID {
+			patients = append(patients, v.ID)
+		}
+	}
+
+	for _, v := range patients {
+		p, err := e.patient(c, a.ID, v)
+		if err != nil {
+			return fmt.Errorf("get patient %d: %w", v, err)
+		}
+
+		title := fmt.Sprintf("Patient profile %d (%s)", p.ID, p.Relation)
+		if err := arch.AddJSON(fmt.Sprintf("patients/%d.json", p.ID), title, p); err != nil {
+			return err
+		}
+	}
+
+	if a.Profiles.SpecialistProfileID != 0 {
+		s, err := e.storage.GetSpecialistByID(c, a.Profiles.SpecialistProfileID)
+		if err != nil {
+			return fmt.Errorf("get specialist: %w", err)
+		}
+
+		vs, err := e.storage.GetSpecialistVerifications(c, s.ID)
+		if err != nil {
+			return fmt.Errorf("get specialist verifications: %w", err)
+		}
+
+		scs, err := e.storage.GetSpecialistSchedules(c, s.ID)
+		if err != nil {
+			return fmt.Errorf("get specialist schedules: %w", err)
+		}
+
+		exs, err := e.storage.GetSpecialistScheduleExceptions(c, s.ID, nil, nil)
+		if err != nil {
+			return fmt.Errorf("get specialist schedule exceptions: %w", err)
+		}
+
+		sp := model.ExportSpecialist{
+			Specialist:             s,
+			ListVerifications:      model.ListVerifications{Verifications: vs},
+			ListSchedules:          model.ListSchedules{Schedules: scs},
+			ListScheduleExceptions: model.ListScheduleExceptions{Exceptions: exs},
+		}
+		if err := arch.AddJSON("specialist.json", "Specialist profile", sp); err != nil {
+			return err
+		}
+	}
+
+	files, err := e.storage.GetFiles(c, a.ID)
+	if err != nil {
+		return fmt.Errorf("get files: %w", err)
+	}
+
+	if err := arch.AddJSON("files.json", "Uploaded files", model.ListFiles{Files: files}); err != nil {
+		return err
+	}
+
+	for _, v := range files {
+		if err := e.file(c, arch, v); err != nil {
+			return fmt.Errorf("get file %s: %w", v.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *AccountExporter) patient(c context.Context, accountID int64, id int64) (*model.ExportPatient, error) {
+	p, err := e.storage.GetPatientByID(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ep := model.ExportPatient{Patient: p, Relation: model.ExportRelationAdmin}
+	switch accountID {
+	case p.AccountID:
+		ep.Relation = model.ExportRelationSelf
+	case p.OwnerID:
+		ep.Relation = model.ExportRelationOwner
+	}
+
+	if ep.Measurements, err = e.storage.GetPatientMeasurements(c, id, &model.ListMeasurementsRequest{}); err != nil {
+		return nil, err
+	}
+
+	if ep.Conditions, err = e.storage.GetPatientConditions(c, id); err != nil {
+		return nil, err
+	}
+
+	if ep.Medications, err = e.storage.GetPatientMedications(c, id, &model.ListMedicationsRequest{}); err != nil {
+		return nil, err
+	}
+
+	if ep.Vaccinations, err = e.storage.GetPatientVaccinations(c, id); err != nil {
+		return nil, err
+	}
+
+	if ep.Policies, err = e.storage.GetPatientInsurancePolicies(c, id, false); err != nil {
+		return nil, err
+	}
+
+	if ep.Specialists, err = e.storage.GetPatientSpecialists(c, id); err != nil {
+		return nil, err
+	}
+
+	if ep.Records, err = e.storage.GetPatientLifestyleRecords(c, id); err != nil {
+		return nil, err
+	}
+
+	if ep.Reviews, err = e.storage.GetPatientReviews(c, id); err != nil {
+		return nil, err
+	}
+
+	if ep.History, err = e.storage.GetPatientHistory(c, id, &model.ListPatientHistoryRequest{}); err != nil {
+		return nil, err
+	}
+
+	return &ep, nil
+}
+
+func (e *AccountExporter) file(c context.Context, arch *export.Archive, f *model.File) error {
+	o, err := e.s3.GetObject(c, f.Name)
+	if err != nil {
+		return err
+	}
+	defer o.Body.Close()
+
+	title := f.Name
+	if f.Description != nil && strings.TrimSpace(*f.Description) != "" {
+		title = *f.Description
+	}
+
+	return arch.AddFile("files/"+f.Name, title, o.Body)
+}
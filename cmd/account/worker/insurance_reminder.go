@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/config"
+	"github.com/Hvaekar/med-account/internal/account"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/logger"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"time"
+)
+
+type InsuranceReminder struct {
+	log      logger.Logger
+	cfg      *config.Insurance
+	reminder *expiryReminder[*model.InsurancePolicy]
+}
+
+func NewInsuranceReminder(log logger.Logger, cfg *config.Insurance, storage account.Storage, mb broker.MessageBroker) *InsuranceReminder {
+	return &InsuranceReminder{
+		log: log,
+		cfg: cfg,
+		reminder: &expiryReminder[*model.InsurancePolicy]{
+			log:         log,
+			broker:      mb,
+			expiredKey:  broker.InsurancePolicyExpiredKey,
+			expiringKey: broker.InsurancePolicyExpiringKey,
+			expire:      storage.ExpireInsurancePolicies,
+			expiring:    storage.GetExpiringInsurancePolicies,
+			reminded: func(c context.Context, v *model.InsurancePolicy) error {
+				return storage.SetInsurancePolicyReminded(c, v.ID)
+			},
+			message: func(v *model.InsurancePolicy) any {
+				return model.InsurancePolicyMessage{PatientID: v.PatientID, Policy: v}
+			},
+		},
+	}
+}
+
+func (r *InsuranceReminder) Run(ctx context.Context) {
+	runEvery(ctx, r.log, r.cfg.RemindInterval, r.Remind)
+}
+
+// Remind notifies about expired policies once and about the ones expiring within RemindBefore.
+func (r *InsuranceReminder) Remind(c context.Context) error {
+	return r.reminder.remind(c, time.Now().Add(r.cfg.RemindBefore))
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/config"
+	"github.com/Hvaekar/med-account/internal/account"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/logger"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"time"
+)
+
+type LicenceReminder struct {
+	log      logger.Logger
+	cfg      *config.Licence
+	reminder *expiryReminder[*model.Licence]
+}
+
+func NewLicenceReminder(log logger.Logger, cfg *config.Licence, storage account.Storage, mb broker.MessageBroker) *LicenceReminder {
+	return &LicenceReminder{
+		log: log,
+		cfg: cfg,
+		reminder: &expiryReminder[*model.Licence]{
+			log:         log,
+			broker:      mb,
+			expiredKey:  broker.LicenceExpiredKey,
+			expiringKey: broker.LicenceExpiringKey,
+			expire:      storage.ExpireLicences,
+			expiring:    storage.GetExpiringLicences,
+			reminded: func(c context.Context, v *model.Licence) error {
+				return storage.SetLicenceReminded(c, v.ID)
+			},
+			message: func(v *model.Licence) any {
+				return model.LicenceMessage{ProfileID: v.ProfileID, Licence: v}
+			},
+		},
+	}
+}
+
+func (r *LicenceReminder) Run(ctx context.Context) {
+	runEvery(ctx, r.log, r.cfg.RemindInterval, r.Remind)
+}
+
+// Remind marks overdue licences as expired and notifies about the ones expiring within RemindBefore.
+func (r *LicenceReminder) Remind(c context.Context) error {
+	return r.reminder.remind(c, time.Now().Add(r.cfg.RemindBefore))
+}
@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/config"
+	"github.com/Hvaekar/med-account/internal/account"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/logger"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"time"
+)
+
+type DuplicateDetector struct {
+	log     logger.Logger
+	cfg     *config.Duplicate
+	storage account.Storage
+	broker  broker.MessageBroker
+}
+
+func NewDuplicateDetector(log logger.Logger, cfg *config.Duplicate, storage account.Storage, broker broker.MessageBroker) *DuplicateDetector {
+	return &DuplicateDetector{log: log, cfg: cfg, storage: storage, broker: broker}
+}
+
+func (d *DuplicateDetector) Run(ctx context.Context) {
+	runEvery(ctx, d.log, d.cfg.Interval, d.Detect)
+}
+
+// Detect scores the candidate pairs and sends the ones reaching Threshold to review, pairs already reviewed are skipped.
+// Only the profiles changed since the previous run are compared, the first run compares all of them.
+func (d *DuplicateDetector) Detect(c context.Context) error {
+	since, err := d.storage.GetPatientDuplicateScannedAt(c)
+	if err != nil {
+		return err
+	}
+
+	// changes committed after the previous run started may be stamped before it
+	if since != nil {
+		*since = since.Add(-d.cfg.ScanOverlap)
+	}
+
+	startedAt := time.Now()
+	candidates, err := d.storage.GetPatientDuplicateCandidates(c, since)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range candidates {
+		req := model.NewAddPatientDuplicate(v)
+		if req == nil || req.Score < d.cfg.Threshold {
+			continue
+		}
+
+		duplicate, err := d.storage.AddPatientDuplicate(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err := d.broker.SendMessage(broker.PatientDuplicateKey, duplicate); err != nil {
+			d.log.Error(err)
+		}
+	}
+
+	return d.storage.AddPatientDuplicateScan(c, startedAt)
+}
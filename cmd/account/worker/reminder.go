@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/broker"
+	"github.com/Hvaekar/med-account/pkg/logger"
+	"time"
+)
+
+// runEvery calls job on every tick of the interval until ctx is cancelled,
+// a failed run is logged and the job is tried again on the next tick.
+func runEvery(ctx context.Context, log logger.Logger, interval time.Duration, job func(c context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
+
+// expiryReminder notifies about records expired by expire and about the ones expiring before the deadline,
+// an expiring record is marked reminded only after its message was sent.
+type expiryReminder[T any] struct {
+	log         logger.Logger
+	broker      broker.MessageBroker
+	expiredKey  string
+	expiringKey string
+	expire      func(c context.Context) ([]T, error)
+	expiring    func(c context.Context, before time.Time) ([]T, error)
+	reminded    func(c context.Context, v T) error
+	message     func(v T) any
+}
+
+func (r *expiryReminder[T]) remind(c context.Context, before time.Time) error {
+	expired, err := r.expire(c)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range expired {
+		if err := r.broker.SendMessage(r.expiredKey, r.message(v)); err != nil {
+			r.log.Error(err)
+		}
+	}
+
+	expiring, err := r.expiring(c, before)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range expiring {
+		if err := r.broker.SendMessage(r.expiringKey, r.message(v)); err != nil {
+			r.log.Error(err)
+			continue
+		}
+
+		if err := r.reminded(c, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -7,13 +7,18 @@ import (
 )
 
 type Config struct {
-	Server   Server
-	Postgres Postgres
-	Logger   Logger
-	AWS      AWS
-	JWT      JWT
-	Verify   Verify
-	Kafka    Kafka
+	Server    Server
+	Postgres  Postgres
+	Logger    Logger
+	AWS       AWS
+	JWT       JWT
+	Verify    Verify
+	Kafka     Kafka
+	Licence   Licence
+	CME       CME
+	Insurance Insurance
+	Export    Export
+	Duplicate Duplicate
 }
 
 type Server struct {
@@ -70,8 +75,9 @@ type JWT struct {
 }
 
 type Verify struct {
-	VerifyCodeCookieName string
-	VerifyCodeExpiresAt  time.Duration
+	VerifyCodeCookieName  string
+	VerifyCodeExpiresAt   time.Duration
+	HandoverCodeExpiresAt time.Duration
 }
 
 type Kafka struct {
@@ -79,6 +85,35 @@ type Kafka struct {
 	Deadline time.Duration
 }
 
+type Licence struct {
+	RegistryURL     string
+	RegistryTimeout time.Duration
+	RemindBefore    time.Duration
+	RemindInterval  time.Duration
+}
+
+type Insurance struct {
+	RemindBefore   time.Duration
+	RemindInterval time.Duration
+}
+
+type Export struct {
+	Interval   time.Duration
+	ExpiresIn  time.Duration
+	RetryAfter time.Duration
+}
+
+type Duplicate struct {
+	Interval    time.Duration
+	Threshold   float64
+	ScanOverlap time.Duration
+}
+
+type CME struct {
+	RequiredPoints float64
+	PeriodYears    int
+}
+
 func Get(filename string, path ...string) (*Config, error) {
 	if len(path) == 0 {
 		viper.AddConfigPath("./config")
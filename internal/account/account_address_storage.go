@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddAddress(c context.Context, accountID interface{}, req *model.AddAddress) (*model.Address, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(accountAddressesTableName).
 		Columns(
@@ -37,7 +37,7 @@ func (s *PostgresStorage) AddAddress(c context.Context, accountID interface{}, r
 }
 
 func (s *PostgresStorage) GetAddresses(c context.Context, accountID interface{}) ([]*model.Address, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.addressResponseColumns()...).
 		From(accountAddressesTableName).
@@ -65,7 +65,7 @@ func (s *PostgresStorage) GetAddresses(c context.Context, accountID interface{})
 }
 
 func (s *PostgresStorage) GetAddressByID(c context.Context, id interface{}) (*model.Address, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.addressResponseColumns()...).
 		From(accountAddressesTableName).
@@ -81,7 +81,7 @@ func (s *PostgresStorage) GetAddressByID(c context.Context, id interface{}) (*mo
 }
 
 func (s *PostgresStorage) UpdateAddress(c context.Context, id interface{}, accountID interface{}, req *model.UpdateAddress) (*model.Address, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountAddressesTableName).
 		//Set("updated_at", time.Now()).
@@ -105,7 +105,7 @@ func (s *PostgresStorage) UpdateAddress(c context.Context, id interface{}, accou
 }
 
 func (s *PostgresStorage) UpdateAddressFields(c context.Context, id interface{}, accountID interface{}, req model.UpdateAddressFields) (*model.Address, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
 
 	res, err := psql.Update(accountAddressesTableName).
@@ -128,7 +128,7 @@ func (s *PostgresStorage) UpdateAddressFields(c context.Context, id interface{},
 }
 
 func (s *PostgresStorage) DeleteAddress(c context.Context, id interface{}, accountID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(accountAddressesTableName).
 		Where("id = ? AND account_id = ?", id, accountID).
@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddEmail(c context.Context, accountID interface{}, req *model.AddEmail) (*model.Email, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(accountEmailsTableName).
 		Columns(
@@ -35,7 +35,7 @@ func (s *PostgresStorage) AddEmail(c context.Context, accountID interface{}, req
 }
 
 func (s *PostgresStorage) GetEmails(c context.Context, accountID interface{}) ([]*model.Email, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.emailResponseColumns()...).
 		From(accountEmailsTableName).
@@ -63,7 +63,7 @@ func (s *PostgresStorage) GetEmails(c context.Context, accountID interface{}) ([
 }
 
 func (s *PostgresStorage) GetEmailByID(c context.Context, id interface{}) (*model.Email, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.emailResponseColumns()...).
 		From(accountEmailsTableName).
@@ -79,7 +79,7 @@ func (s *PostgresStorage) GetEmailByID(c context.Context, id interface{}) (*mode
 }
 
 func (s *PostgresStorage) UpdateEmail(c context.Context, id interface{}, accountID interface{}, req *model.UpdateEmail) (*model.Email, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountEmailsTableName).
 		//Set("updated_at", time.Now()).
@@ -103,7 +103,7 @@ func (s *PostgresStorage) UpdateEmail(c context.Context, id interface{}, account
 }
 
 func (s *PostgresStorage) UpdateEmailFields(c context.Context, id interface{}, accountID interface{}, req model.UpdateEmailFields) (*model.Email, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
 
 	res, err := psql.Update(accountEmailsTableName).
@@ -126,7 +126,7 @@ func (s *PostgresStorage) UpdateEmailFields(c context.Context, id interface{}, a
 }
 
 func (s *PostgresStorage) DeleteEmail(c context.Context, id interface{}, accountID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(accountEmailsTableName).
 		Where("id = ? AND account_id = ?", id, accountID).
@@ -0,0 +1,202 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"strings"
+	"time"
+)
+
+func (s *PostgresStorage) AddAccountExport(c context.Context, accountID interface{}) (*model.Export, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Insert(accountExportsTableName).
+		Columns("account_id", "status").
+		Values(accountID, model.ExportStatusPending).
+		Suffix("RETURNING " + strings.Join(s.accountExportResponseColumns(), ", ")).
+		QueryRowContext(c)
+
+	e, err := s.scanAccountExport(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return e, nil
+}
+
+func (s *PostgresStorage) GetAccountExports(c context.Context, accountID interface{}) ([]*model.Export, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.accountExportResponseColumns()...).
+		From(accountExportsTableName).
+		Where("account_id = ?", accountID).
+		OrderBy("created_at DESC", "id DESC").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	return s.scanAccountExports(rows)
+}
+
+func (s *PostgresStorage) GetAccountExportByID(c context.Context, id interface{}, accountID interface{}) (*model.Export, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.accountExportResponseColumns()...).
+		From(accountExportsTableName).
+		Where("id = ? AND account_id = ?", id, accountID).
+		QueryRowContext(c)
+
+	e, err := s.scanAccountExport(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return e, nil
+}
+
+// ClaimAccountExports marks pending jobs as processing, the ones processing since before retryBefore
+// are considered abandoned and claimed again.
+func (s *PostgresStorage) ClaimAccountExports(c context.Context, retryBefore time.Time) ([]*model.Export, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	claimable := psql.Select("id").
+		From(accountExportsTableName).
+		Where(squirrel.Or{
+			squirrel.Eq{"status": model.ExportStatusPending},
+			squirrel.And{
+				squirrel.Eq{"status": model.ExportStatusProcessing},
+				squirrel.Lt{"updated_at": retryBefore},
+			},
+		}).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	rows, err := psql.Update(accountExportsTableName).
+		Set("updated_at", time.Now()).
+		Set("status", model.ExportStatusProcessing).
+		Where(claimable.Prefix("id IN (").Suffix(")")).
+		Suffix("RETURNING " + strings.Join(s.accountExportResponseColumns(), ", ")).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	return s.scanAccountExports(rows)
+}
+
+func (s *PostgresStorage) SetAccountExportReady(c context.Context, id interface{}, fileKey string, size int64, expiresAt time.Time) (*model.Export, error) {
+	now := time.Now()
+
+	return s.updateAccountExport(c, id, map[string]interface{}{
+		"updated_at": now,
+		"status":     model.ExportStatusReady,
+		"file_key":   fileKey,
+		"size":       size,
+		"ready_at":   now,
+		"expires_at": expiresAt,
+	})
+}
+
+func (s *PostgresStorage) SetAccountExportFailed(c context.Context, id interface{}, reason string) (*model.Export, error) {
+	return s.updateAccountExport(c, id, map[string]interface{}{
+		"updated_at": time.Now(),
+		"status":     model.ExportStatusFailed,
+		"error":      reason,
+	})
+}
+
+// ExpireAccountExports returns the expired exports with their keys, so the archives can be removed from s3.
+func (s *PostgresStorage) ExpireAccountExports(c context.Context) ([]*model.Export, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Update(accountExportsTableName).
+		Set("updated_at", time.Now()).
+		Set("status", model.ExportStatusExpired).
+		Where(squirrel.Eq{"status": model.ExportStatusReady}).
+		Where("expires_at < ?", time.Now()).
+		Suffix("RETURNING " + strings.Join(s.accountExportResponseColumns(), ", ")).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	return s.scanAccountExports(rows)
+}
+
+func (s *PostgresStorage) updateAccountExport(c context.Context, id interface{}, fields map[string]interface{}) (*model.Export, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Update(accountExportsTableName).
+		SetMap(fields).
+		Where("id = ?", id).
+		Suffix("RETURNING " + strings.Join(s.accountExportResponseColumns(), ", ")).
+		QueryRowContext(c)
+
+	e, err := s.scanAccountExport(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return e, nil
+}
+
+func (s *PostgresStorage) accountExportResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "account_id",
+		pre + "status",
+		pre + "file_key",
+		pre + "size",
+		pre + "error",
+		pre + "ready_at",
+		pre + "expires_at",
+	}
+}
+
+func (s *PostgresStorage) scanAccountExports(rows *sql.Rows) ([]*model.Export, error) {
+	var es []*model.Export
+	for rows.Next() {
+		e, err := s.scanAccountExport(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		es = append(es, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return es, nil
+}
+
+func (s *PostgresStorage) scanAccountExport(row squirrel.RowScanner) (*model.Export, error) {
+	var e model.Export
+
+	if err := row.Scan(
+		&e.ID,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.AccountID,
+		&e.Status,
+		&e.FileKey,
+		&e.Size,
+		&e.Error,
+		&e.ReadyAt,
+		&e.ExpiresAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
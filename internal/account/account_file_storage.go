@@ -10,7 +10,7 @@ import (
 )
 
 func (s *PostgresStorage) AddFile(c context.Context, accountID interface{}, fileName *string) (*model.File, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(accountFilesTableName).
 		Columns("account_id", "name").
@@ -26,7 +26,7 @@ func (s *PostgresStorage) AddFile(c context.Context, accountID interface{}, file
 }
 
 func (s *PostgresStorage) GetFiles(c context.Context, accountID interface{}) ([]*model.File, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.fileResponseColumns()...).
 		From(accountFilesTableName).
@@ -54,7 +54,7 @@ func (s *PostgresStorage) GetFiles(c context.Context, accountID interface{}) ([]
 }
 
 func (s *PostgresStorage) GetPatientDisabilityFiles(c context.Context, patientID interface{}) ([]*model.File, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.fileResponseColumns(accountFilesTableName)...).
 		From(patientDisabilityFilesTableName).
@@ -83,7 +83,7 @@ func (s *PostgresStorage) GetPatientDisabilityFiles(c context.Context, patientID
 }
 
 func (s *PostgresStorage) GetFileByID(c context.Context, id interface{}) (*model.File, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.fileResponseColumns()...).
 		From(accountFilesTableName).
@@ -99,7 +99,7 @@ func (s *PostgresStorage) GetFileByID(c context.Context, id interface{}) (*model
 }
 
 func (s *PostgresStorage) GetFileByName(c context.Context, name interface{}) (*model.File, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.fileResponseColumns()...).
 		From(accountFilesTableName).
@@ -115,7 +115,7 @@ func (s *PostgresStorage) GetFileByName(c context.Context, name interface{}) (*m
 }
 
 func (s *PostgresStorage) UpdateFile(c context.Context, id interface{}, accountID interface{}, req *model.UpdateFile) (*model.File, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountFilesTableName).
 		Set("updated_at", time.Now()).
@@ -138,7 +138,7 @@ func (s *PostgresStorage) UpdateFile(c context.Context, id interface{}, accountI
 }
 
 func (s *PostgresStorage) UpdateFileFields(c context.Context, id interface{}, accountID interface{}, req model.UpdateFileFields) (*model.File, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	req["updated_at"] = time.Now()
 
 	res, err := psql.Update(accountFilesTableName).
@@ -161,7 +161,7 @@ func (s *PostgresStorage) UpdateFileFields(c context.Context, id interface{}, ac
 }
 
 func (s *PostgresStorage) DeleteFile(c context.Context, id interface{}, accountID interface{}) (*string, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Delete(accountFilesTableName).
 		Where("id = ? AND account_id = ?", id, accountID).
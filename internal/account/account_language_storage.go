@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddLanguage(c context.Context, accountID interface{}, req *model.AddLanguage) (*model.Language, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	_ = psql.Insert(accountLanguagesTableName).
 		Columns(
@@ -28,7 +28,7 @@ func (s *PostgresStorage) AddLanguage(c context.Context, accountID interface{},
 }
 
 func (s *PostgresStorage) GetLanguages(c context.Context, accountID interface{}) ([]*model.Language, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.languageResponseColumns()...).
 		From(accountLanguagesTableName).
@@ -56,7 +56,7 @@ func (s *PostgresStorage) GetLanguages(c context.Context, accountID interface{})
 }
 
 func (s *PostgresStorage) GetLanguageByCode(c context.Context, languageCode interface{}, accountID interface{}) (*model.Language, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.languageResponseColumns()...).
 		From(accountLanguagesTableName).
@@ -72,7 +72,7 @@ func (s *PostgresStorage) GetLanguageByCode(c context.Context, languageCode inte
 }
 
 func (s *PostgresStorage) UpdateLanguage(c context.Context, languageCode interface{}, accountID interface{}, req *model.UpdateLanguage) (*model.Language, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountLanguagesTableName).
 		//Set("updated_at", time.Now()).
@@ -95,7 +95,7 @@ func (s *PostgresStorage) UpdateLanguage(c context.Context, languageCode interfa
 }
 
 func (s *PostgresStorage) UpdateLanguageFields(c context.Context, languageCode interface{}, accountID interface{}, req model.UpdateLanguageFields) (*model.Language, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
 
 	res, err := psql.Update(accountLanguagesTableName).
@@ -118,7 +118,7 @@ func (s *PostgresStorage) UpdateLanguageFields(c context.Context, languageCode i
 }
 
 func (s *PostgresStorage) DeleteLanguage(c context.Context, languageCode interface{}, accountID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(accountLanguagesTableName).
 		Where("language = ? AND account_id = ?", languageCode, accountID).
@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddPhone(c context.Context, accountID interface{}, req *model.AddPhone) (*model.Phone, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(accountPhonesTableName).
 		Columns(
@@ -37,7 +37,7 @@ func (s *PostgresStorage) AddPhone(c context.Context, accountID interface{}, req
 }
 
 func (s *PostgresStorage) GetPhones(c context.Context, accountID interface{}) ([]*model.Phone, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.phoneResponseColumns()...).
 		From(accountPhonesTableName).
@@ -65,7 +65,7 @@ func (s *PostgresStorage) GetPhones(c context.Context, accountID interface{}) ([
 }
 
 func (s *PostgresStorage) GetPhoneByID(c context.Context, id interface{}) (*model.Phone, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.phoneResponseColumns()...).
 		From(accountPhonesTableName).
@@ -81,7 +81,7 @@ func (s *PostgresStorage) GetPhoneByID(c context.Context, id interface{}) (*mode
 }
 
 func (s *PostgresStorage) UpdatePhone(c context.Context, id interface{}, accountID interface{}, req *model.UpdatePhone) (*model.Phone, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountPhonesTableName).
 		//Set("updated_at", time.Now()).
@@ -105,7 +105,7 @@ func (s *PostgresStorage) UpdatePhone(c context.Context, id interface{}, account
 }
 
 func (s *PostgresStorage) UpdatePhoneFields(c context.Context, id interface{}, accountID interface{}, req model.UpdatePhoneFields) (*model.Phone, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
 
 	res, err := psql.Update(accountPhonesTableName).
@@ -128,7 +128,7 @@ func (s *PostgresStorage) UpdatePhoneFields(c context.Context, id interface{}, a
 }
 
 func (s *PostgresStorage) DeletePhone(c context.Context, id interface{}, accountID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(accountPhonesTableName).
 		Where("id = ? AND account_id = ?", id, accountID).
@@ -0,0 +1,142 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) GetPrivacySettings(c context.Context, accountID interface{}) (*model.PrivacySettings, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.privacySettingsResponseColumns()...).
+		From(accountPrivacySettingsTableName).
+		Where("account_id = ?", accountID).
+		QueryRowContext(c)
+
+	ps, err := s.scanPrivacySettings(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.DefaultPrivacySettings(), nil
+	}
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ps, nil
+}
+
+func (s *PostgresStorage) UpdatePrivacySettings(c context.Context, accountID interface{}, req *model.UpdatePrivacySettings) (*model.PrivacySettings, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	_, err := psql.Insert(accountPrivacySettingsTableName).
+		Columns(
+			"account_id",
+			"updated_at",
+			"names",
+			"birthday",
+			"photo",
+			"contacts",
+			"languages",
+			"specialist",
+		).
+		Values(
+			accountID,
+			time.Now(),
+			req.Names,
+			req.Birthday,
+			req.Photo,
+			req.Contacts,
+			req.Languages,
+			req.Specialist,
+		).
+		Suffix("ON CONFLICT (account_id) DO UPDATE SET " +
+			"updated_at = EXCLUDED.updated_at, " +
+			"names = EXCLUDED.names, " +
+			"birthday = EXCLUDED.birthday, " +
+			"photo = EXCLUDED.photo, " +
+			"contacts = EXCLUDED.contacts, " +
+			"languages = EXCLUDED.languages, " +
+			"specialist = EXCLUDED.specialist").
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPrivacySettings(c, accountID)
+}
+
+func (s *PostgresStorage) GetPrivacyRelation(c context.Context, viewerID interface{}, ownerID interface{}) (*model.PrivacyRelation, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	var r model.PrivacyRelation
+
+	var admins int64
+	if err := psql.Select("COUNT(*)").
+		From(accountsPatientProfilesTableName).
+		Join(patientProfilesTableName + " ON " + patientProfilesTableName + ".id = " + accountsPatientProfilesTableName + ".patient_profile_id").
+		Where(squirrel.Eq{
+			accountsPatientProfilesTableName + ".account_id": viewerID,
+			accountsPatientProfilesTableName + ".verified":   true,
+			patientProfilesTableName + ".account_id":         ownerID,
+		}).
+		QueryRowContext(c).
+		Scan(&admins); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	r.Admin = admins > 0
+
+	var specialists int64
+	if err := psql.Select("COUNT(*)").
+		From(patientSpecialistsTableName).
+		Join(patientProfilesTableName + " ON " + patientProfilesTableName + ".id = " + patientSpecialistsTableName + ".profile_id").
+		Join(specialistProfilesTableName + " ON " + specialistProfilesTableName + ".id = " + patientSpecialistsTableName + ".specialist_id").
+		Where(squirrel.Eq{
+			patientProfilesTableName + ".account_id":    ownerID,
+			specialistProfilesTableName + ".account_id": viewerID,
+		}).
+		QueryRowContext(c).
+		Scan(&specialists); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	r.Specialist = specialists > 0
+
+	return &r, nil
+}
+
+func (s *PostgresStorage) privacySettingsResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "account_id",
+		pre + "updated_at",
+		pre + "names",
+		pre + "birthday",
+		pre + "photo",
+		pre + "contacts",
+		pre + "languages",
+		pre + "specialist",
+	}
+}
+
+func (s *PostgresStorage) scanPrivacySettings(row squirrel.RowScanner) (*model.PrivacySettings, error) {
+	var ps model.PrivacySettings
+
+	if err := row.Scan(
+		&ps.AccountID,
+		&ps.UpdatedAt,
+		&ps.Names,
+		&ps.Birthday,
+		&ps.Photo,
+		&ps.Contacts,
+		&ps.Languages,
+		&ps.Specialist,
+	); err != nil {
+		return nil, err
+	}
+
+	return &ps, nil
+}
@@ -7,11 +7,13 @@ import (
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/Hvaekar/med-account/pkg/storage"
 	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Hvaekar/med-account/pkg/utils"
 	"github.com/Masterminds/squirrel"
+	"time"
 )
 
 func (s *PostgresStorage) GetPatientProfileID(c context.Context, accountID interface{}) (*int64, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select("id").
 		From(patientProfilesTableName).
@@ -27,7 +29,7 @@ func (s *PostgresStorage) GetPatientProfileID(c context.Context, accountID inter
 }
 
 func (s *PostgresStorage) GetSpecialistProfileID(c context.Context, accountID interface{}) (*int64, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select("id").
 		From(specialistProfilesTableName).
@@ -43,11 +45,12 @@ func (s *PostgresStorage) GetSpecialistProfileID(c context.Context, accountID in
 }
 
 func (s *PostgresStorage) GetPatientProfiles(c context.Context, accountID interface{}) ([]*model.AccountPatient, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.accountPatientResponseColumns()...).
 		From(accountsPatientProfilesTableName).
-		LeftJoin(accountsTableName+" ON "+accountsTableName+".id = "+accountsPatientProfilesTableName+".patient_profile_id").
+		LeftJoin(patientProfilesTableName+" ON "+patientProfilesTableName+".id = "+accountsPatientProfilesTableName+".patient_profile_id").
+		LeftJoin(accountsTableName+" ON "+accountsTableName+".id = "+patientProfilesTableName+".account_id").
 		Where(accountsPatientProfilesTableName+".account_id = ?", accountID).
 		QueryContext(c)
 	if err != nil {
@@ -72,7 +75,7 @@ func (s *PostgresStorage) GetPatientProfiles(c context.Context, accountID interf
 }
 
 func (s *PostgresStorage) VerifyPatientProfile(c context.Context, accountID interface{}, profileID interface{}) (*model.Patient, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountsPatientProfilesTableName).
 		//Set("updated_at", time.Now()).
@@ -94,8 +97,152 @@ func (s *PostgresStorage) VerifyPatientProfile(c context.Context, accountID inte
 	return s.GetPatientByID(c, profileID)
 }
 
-func (s *PostgresStorage) DeletePatientProfile(c context.Context, accountID interface{}, patientID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+func (s *PostgresStorage) AddDependentPatientProfile(c context.Context, ownerID interface{}, req *model.AddDependentPatient) (*model.Patient, error) {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	q := psql.Insert(patientProfilesTableName).
+		Columns(
+			"owner_id",
+			"first_name",
+			"father_name",
+			"last_name",
+			"sex",
+			"birthday",
+		).
+		Values(
+			ownerID,
+			req.FirstName,
+			storage.NullString(req.FatherName),
+			req.LastName,
+			req.Sex,
+			req.Birthday,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	_, err = psql.Insert(accountsPatientProfilesTableName).
+		Columns("account_id", "patient_profile_id", "permission_edit", "verified").
+		Values(ownerID, id, true, true).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientByID(c, id)
+}
+
+// UpdateDependentPatientProfile updates a profile that was not handed over yet, the account must be its admin with edit permission.
+func (s *PostgresStorage) UpdateDependentPatientProfile(c context.Context, accountID int64, patientID interface{}, req *model.UpdateDependentPatient) (*model.Patient, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceProfile}
+
+	_, err := changePatientResource(c, s, e, patientID, (*PostgresStorage).getPatientProfileRecord, func(st *PostgresStorage, r *model.PatientProfileRecord) (int64, error) {
+		return r.ID, st.updateDependentPatientProfile(c, accountID, patientID, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientByID(c, patientID)
+}
+
+func (s *PostgresStorage) updateDependentPatientProfile(c context.Context, accountID int64, patientID interface{}, req *model.UpdateDependentPatient) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientProfilesTableName).
+		Set("updated_at", time.Now()).
+		Set("first_name", req.FirstName).
+		Set("father_name", storage.NullString(req.FatherName)).
+		Set("last_name", req.LastName).
+		Set("sex", req.Sex).
+		Set("birthday", req.Birthday).
+		Where(squirrel.Eq{"id": patientID, "account_id": nil}).
+		Where(squirrel.Expr("EXISTS (SELECT 1 FROM "+accountsPatientProfilesTableName+" WHERE account_id = ? AND patient_profile_id = ? AND permission_edit)", accountID, patientID)).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) SetPatientProfileHandover(c context.Context, ownerID interface{}, patientID interface{}, code string, expiresAt time.Time) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientProfilesTableName).
+		Set("updated_at", time.Now()).
+		Set("handover_code", utils.HashToken(code)).
+		Set("handover_expires_at", expiresAt).
+		Where(squirrel.Eq{"id": patientID, "owner_id": ownerID, "account_id": nil}).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeletePatientProfile detaches the profile from the account. A dependent profile that was never handed over
+// is removed with its last admin, while other admins remain the ownership passes to one of them.
+func (s *PostgresStorage) DeletePatientProfile(c context.Context, accountID int64, patientID interface{}) error {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+	st := s.withTx(tx)
+
+	var profileAccountID *int64
+	err = psql.Select("account_id").
+		From(patientProfilesTableName).
+		Where("id = ?", patientID).
+		Suffix("FOR UPDATE").
+		QueryRowContext(c).
+		Scan(&profileAccountID)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	admin, err := st.GetPatientProfileAdminByID(c, patientID, accountID)
+	if err != nil {
+		return err
+	}
+
+	profile, err := st.getPatientProfileRecord(c, patientID)
+	if err != nil {
+		return err
+	}
 
 	res, err := psql.Delete(accountsPatientProfilesTableName).
 		Where("account_id = ? AND patient_profile_id = ?", accountID, patientID).
@@ -112,13 +259,64 @@ func (s *PostgresStorage) DeletePatientProfile(c context.Context, accountID inte
 		return storage.ErrNotFound
 	}
 
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAdmin}
+	if err := st.addPatientHistory(c, e, admin.ID, admin, nil); err != nil {
+		return err
+	}
+
+	if profileAccountID == nil {
+		// admins with edit permission go first to take over the ownership
+		var nextOwnerID *int64
+		err = psql.Select("account_id").
+			From(accountsPatientProfilesTableName).
+			Where("patient_profile_id = ?", patientID).
+			OrderBy("permission_edit DESC", "account_id").
+			Limit(1).
+			QueryRowContext(c).
+			Scan(&nextOwnerID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return postgres.ConvertError(err)
+		}
+
+		if nextOwnerID == nil {
+			if _, err := psql.Delete(patientProfilesTableName).Where("id = ?", patientID).ExecContext(c); err != nil {
+				return postgres.ConvertError(err)
+			}
+		} else {
+			_, err = psql.Update(patientProfilesTableName).
+				Set("updated_at", time.Now()).
+				Set("owner_id", *nextOwnerID).
+				Set("handover_code", nil).
+				Set("handover_expires_at", nil).
+				Where("id = ? AND owner_id = ?", patientID, accountID).
+				ExecContext(c)
+			if err != nil {
+				return postgres.ConvertError(err)
+			}
+
+			after, err := st.getPatientProfileRecord(c, patientID)
+			if err != nil {
+				return err
+			}
+
+			e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceProfile}
+			if err := st.addPatientHistory(c, e, profile.ID, profile, after); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return postgres.ConvertError(err)
+	}
+
 	return nil
 }
 
 func (s *PostgresStorage) accountPatientResponseColumns() []string {
 	fields := make([]string, 0)
 
-	fields = append(s.accountsPatientProfilesResponseColumns(accountsPatientProfilesTableName), s.accountPatientProfileResponseColumns(accountsTableName)...)
+	fields = append(s.accountsPatientProfilesResponseColumns(accountsPatientProfilesTableName), s.accountDependentPatientResponseColumns(patientProfilesTableName, accountsTableName)...)
 
 	return fields
 }
@@ -145,6 +343,15 @@ func (s *PostgresStorage) accountPatientProfileResponseColumns(prefixes ...strin
 	}
 }
 
+func (s *PostgresStorage) accountDependentPatientResponseColumns(profile string, account string) []string {
+	return []string{
+		"COALESCE(" + profile + ".first_name, " + account + ".first_name)",
+		"COALESCE(" + profile + ".father_name, " + account + ".father_name)",
+		"COALESCE(" + profile + ".last_name, " + account + ".last_name)",
+		account + ".photo",
+	}
+}
+
 func (s *PostgresStorage) scanAccountPatientProfile(row squirrel.RowScanner) (*model.AccountPatient, error) {
 	var p model.AccountPatient
 
@@ -0,0 +1,72 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+)
+
+func (s *PostgresStorage) GetAccountRoles(c context.Context, accountID interface{}) ([]string, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select("role").
+		From(accountRolesTableName).
+		Where("account_id = ?", accountID).
+		OrderBy("role").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return roles, nil
+}
+
+func (s *PostgresStorage) AddAccountRole(c context.Context, req *model.AddAccountRole) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	_, err := psql.Insert(accountRolesTableName).
+		Columns("account_id", "role").
+		Values(req.AccountID, req.Role).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) DeleteAccountRole(c context.Context, accountID interface{}, role interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(accountRolesTableName).
+		Where("account_id = ? AND role = ?", accountID, role).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Hvaekar/med-account/pkg/storage/postgres"
 	"github.com/Masterminds/squirrel"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
 	"strconv"
 	"time"
 )
@@ -59,38 +60,153 @@ type Storage interface {
 	UpdateLanguageFields(c context.Context, languageCode interface{}, accountID interface{}, req model.UpdateLanguageFields) (*model.Language, error)
 	DeleteLanguage(c context.Context, languageCode interface{}, accountID interface{}) error
 
+	GetPrivacySettings(c context.Context, accountID interface{}) (*model.PrivacySettings, error)
+	UpdatePrivacySettings(c context.Context, accountID interface{}, req *model.UpdatePrivacySettings) (*model.PrivacySettings, error)
+	GetPrivacyRelation(c context.Context, viewerID interface{}, ownerID interface{}) (*model.PrivacyRelation, error)
+
+	AddAccountExport(c context.Context, accountID interface{}) (*model.Export, error)
+	GetAccountExports(c context.Context, accountID interface{}) ([]*model.Export, error)
+	GetAccountExportByID(c context.Context, id interface{}, accountID interface{}) (*model.Export, error)
+	ClaimAccountExports(c context.Context, retryBefore time.Time) ([]*model.Export, error)
+	SetAccountExportReady(c context.Context, id interface{}, fileKey string, size int64, expiresAt time.Time) (*model.Export, error)
+	SetAccountExportFailed(c context.Context, id interface{}, reason string) (*model.Export, error)
+	ExpireAccountExports(c context.Context) ([]*model.Export, error)
+
+	GetAccountRoles(c context.Context, accountID interface{}) ([]string, error)
+	AddAccountRole(c context.Context, req *model.AddAccountRole) error
+	DeleteAccountRole(c context.Context, accountID interface{}, role interface{}) error
+
 	GetPatientProfileID(c context.Context, accountID interface{}) (*int64, error)
 	GetSpecialistProfileID(c context.Context, accountID interface{}) (*int64, error)
 	GetPatientProfiles(c context.Context, accountID interface{}) ([]*model.AccountPatient, error)
 	VerifyPatientProfile(c context.Context, accountID interface{}, profileID interface{}) (*model.Patient, error)
-	DeletePatientProfile(c context.Context, accountID interface{}, profileID interface{}) error
+	DeletePatientProfile(c context.Context, accountID int64, patientID interface{}) error
+	AddDependentPatientProfile(c context.Context, ownerID interface{}, req *model.AddDependentPatient) (*model.Patient, error)
+	UpdateDependentPatientProfile(c context.Context, accountID int64, patientID interface{}, req *model.UpdateDependentPatient) (*model.Patient, error)
+	SetPatientProfileHandover(c context.Context, ownerID interface{}, patientID interface{}, code string, expiresAt time.Time) error
 
 	AddSpecialistProfile(c context.Context, accountID interface{}, req *model.AddSpecialistProfile) (*model.Specialist, error)
 
 	GetPatientByID(c context.Context, id interface{}) (*model.Patient, error)
 	GetPatients(c context.Context, req *model.ListPatientsRequest) ([]*model.Patient, error)
-	UpdatePatientProfile(c context.Context, id interface{}, req model.UpdatePatientProfile) (*model.Patient, error)
+	UpdatePatientProfile(c context.Context, id interface{}, accountID int64, req model.UpdatePatientProfile) (*model.Patient, error)
 	UpdatePatientProfileFields(c context.Context, id interface{}, req model.UpdatePatientProfileFields) (*model.Patient, error)
 
-	AddPatientProfileAdmin(c context.Context, patientID interface{}, req *model.AddAdmin) (*model.PatientAdmin, error)
+	AddPatientProfileAdmin(c context.Context, patientID interface{}, accountID int64, req *model.AddAdmin) (*model.PatientAdmin, error)
 	GetPatientProfileAdmins(c context.Context, patientID interface{}) ([]*model.PatientAdmin, error)
 	GetPatientProfileAdminByID(c context.Context, patientID interface{}, adminID interface{}) (*model.PatientAdmin, error)
-	UpdatePatientProfileAdmin(c context.Context, patientID interface{}, adminID interface{}, req *model.UpdateAdmin) (*model.PatientAdmin, error)
+	UpdatePatientProfileAdmin(c context.Context, patientID interface{}, accountID int64, adminID interface{}, req *model.UpdateAdmin) (*model.PatientAdmin, error)
 	UpdatePatientProfileAdminFields(c context.Context, patientID interface{}, adminID interface{}, req model.UpdateAdminFields) (*model.PatientAdmin, error)
-	DeletePatientProfileAdmin(c context.Context, patientID interface{}, accountID interface{}) error
+	DeletePatientProfileAdmin(c context.Context, patientID interface{}, accountID int64, adminID interface{}) error
 
-	AddMetalComponent(c context.Context, patientID interface{}, req *model.AddMetalComponent) (*model.MetalComponent, error)
+	AddMetalComponent(c context.Context, patientID interface{}, accountID int64, req *model.AddMetalComponent) (*model.MetalComponent, error)
 	GetMetalComponents(c context.Context, patientID interface{}) ([]*model.MetalComponent, error)
 	GetMetalComponentByID(c context.Context, id interface{}) (*model.MetalComponent, error)
-	UpdateMetalComponent(c context.Context, id interface{}, patientID interface{}, req *model.UpdateMetalComponent) (*model.MetalComponent, error)
+	UpdateMetalComponent(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateMetalComponent) (*model.MetalComponent, error)
 	UpdateMetalComponentFields(c context.Context, id interface{}, patientID interface{}, req model.UpdateMetalComponentFields) (*model.MetalComponent, error)
-	DeleteMetalComponent(c context.Context, id interface{}, patientID interface{}) error
+	DeleteMetalComponent(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+	GetMetalComponentFiles(c context.Context, componentID interface{}) ([]*model.File, error)
+
+	AddPatientMeasurement(c context.Context, patientID interface{}, accountID int64, req *model.AddMeasurement) (*model.Measurement, error)
+	GetPatientMeasurements(c context.Context, patientID interface{}, req *model.ListMeasurementsRequest) ([]*model.Measurement, error)
+	GetPatientMeasurementByID(c context.Context, id interface{}) (*model.Measurement, error)
+	GetPatientMeasurementChart(c context.Context, patientID interface{}, req *model.MeasurementChartRequest) ([]*model.MeasurementChartPoint, error)
+	DeletePatientMeasurement(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+
+	AddPatientAllergy(c context.Context, patientID interface{}, accountID int64, req *model.AddAllergy) (*model.Allergy, error)
+	GetPatientAllergies(c context.Context, patientID interface{}) ([]*model.Allergy, error)
+	GetPatientAllergyByID(c context.Context, id interface{}) (*model.Allergy, error)
+	UpdatePatientAllergy(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateAllergy) (*model.Allergy, error)
+	UpdatePatientAllergyFields(c context.Context, id interface{}, patientID interface{}, req model.UpdateAllergyFields) (*model.Allergy, error)
+	ConfirmPatientAllergy(c context.Context, id interface{}, patientID interface{}, accountID int64, specialistID interface{}) (*model.Allergy, error)
+	DeletePatientAllergy(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+
+	AddPatientCondition(c context.Context, patientID interface{}, accountID int64, req *model.AddCondition) (*model.Condition, error)
+	GetPatientConditions(c context.Context, patientID interface{}) ([]*model.Condition, error)
+	GetPatientConditionByID(c context.Context, id interface{}) (*model.Condition, error)
+	UpdatePatientCondition(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateCondition) (*model.Condition, error)
+	ConfirmPatientCondition(c context.Context, id interface{}, patientID interface{}, accountID int64, specialistID interface{}) (*model.Condition, error)
+	DeletePatientCondition(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+	GetPatientConditionSpecialists(c context.Context, patientID interface{}) ([]*model.ConditionSpecialist, error)
+	GetDiseaseICD10Codes(c context.Context, diseaseID interface{}) (*model.DiseaseICD10Codes, error)
+	UpdateDiseaseICD10Codes(c context.Context, diseaseID interface{}, req *model.UpdateDiseaseICD10Codes) (*model.DiseaseICD10Codes, error)
+	AddPatientMedication(c context.Context, patientID interface{}, accountID int64, req *model.AddMedication) (*model.Medication, error)
+	GetPatientMedications(c context.Context, patientID interface{}, req *model.ListMedicationsRequest) ([]*model.Medication, error)
+	GetPatientMedicationByID(c context.Context, id interface{}) (*model.Medication, error)
+	UpdatePatientMedication(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateMedication) (*model.Medication, error)
+	StopPatientMedication(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.StopMedication) (*model.Medication, error)
+	DeletePatientMedication(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+	AddPatientVaccination(c context.Context, patientID interface{}, accountID int64, req *model.AddVaccination) (*model.Vaccination, error)
+	GetPatientVaccinations(c context.Context, patientID interface{}) ([]*model.Vaccination, error)
+	GetPatientVaccinationByID(c context.Context, id interface{}) (*model.Vaccination, error)
+	UpdatePatientVaccination(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateVaccination) (*model.Vaccination, error)
+	DeletePatientVaccination(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+	GetPatientVaccinationFiles(c context.Context, vaccinationID interface{}) ([]*model.File, error)
+	AddPatientEmergencyContact(c context.Context, patientID interface{}, accountID int64, req *model.AddEmergencyContact) (*model.EmergencyContact, error)
+	GetPatientEmergencyContacts(c context.Context, patientID interface{}) ([]*model.EmergencyContact, error)
+	GetPatientEmergencyContactByID(c context.Context, id interface{}) (*model.EmergencyContact, error)
+	UpdatePatientEmergencyContact(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateEmergencyContact) (*model.EmergencyContact, error)
+	DeletePatientEmergencyContact(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+	AddPatientInsurancePolicy(c context.Context, patientID interface{}, accountID int64, req *model.AddInsurancePolicy) (*model.InsurancePolicy, error)
+	GetPatientInsurancePolicies(c context.Context, patientID interface{}, active bool) ([]*model.InsurancePolicy, error)
+	GetPatientInsurancePolicyByID(c context.Context, id interface{}) (*model.InsurancePolicy, error)
+	UpdatePatientInsurancePolicy(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateInsurancePolicy) (*model.InsurancePolicy, error)
+	DeletePatientInsurancePolicy(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+	HasOverlappingPatientInsurancePolicy(c context.Context, patientID interface{}, excludeID interface{}, req *model.AddInsurancePolicy) (bool, error)
+	GetPatientInsurancePolicyFiles(c context.Context, policyID interface{}) ([]*model.File, error)
+	GetExpiringInsurancePolicies(c context.Context, before time.Time) ([]*model.InsurancePolicy, error)
+	SetInsurancePolicyReminded(c context.Context, id interface{}) error
+	ExpireInsurancePolicies(c context.Context) ([]*model.InsurancePolicy, error)
+
+	AddPatientFamilyHistory(c context.Context, patientID interface{}, accountID int64, req *model.AddFamilyHistory) (*model.FamilyHistory, error)
+	GetPatientFamilyHistory(c context.Context, patientID interface{}) ([]*model.FamilyHistory, error)
+	GetPatientFamilyHistoryByID(c context.Context, id interface{}) (*model.FamilyHistory, error)
+	UpdatePatientFamilyHistory(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateFamilyHistory) (*model.FamilyHistory, error)
+	DeletePatientFamilyHistory(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+
+	AddPatientLifestyleRecord(c context.Context, patientID interface{}, accountID int64, req *model.AddLifestyleRecord) (*model.LifestyleRecord, error)
+	GetPatientLifestyleRecords(c context.Context, patientID interface{}) ([]*model.LifestyleRecord, error)
+	GetPatientLifestyleRecordByID(c context.Context, id interface{}) (*model.LifestyleRecord, error)
+	UpdatePatientLifestyleRecord(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateLifestyleRecord) (*model.LifestyleRecord, error)
+	DeletePatientLifestyleRecord(c context.Context, id interface{}, patientID interface{}, accountID int64) error
+
+	GetPatientDuplicateScannedAt(c context.Context) (*time.Time, error)
+	AddPatientDuplicateScan(c context.Context, startedAt time.Time) error
+	GetPatientDuplicateCandidates(c context.Context, since *time.Time) ([]*model.PatientDuplicateCandidate, error)
+	AddPatientDuplicate(c context.Context, req *model.AddPatientDuplicate) (*model.PatientDuplicate, error)
+	GetPatientDuplicates(c context.Context, req *model.ListPatientDuplicatesRequest) ([]*model.PatientDuplicate, error)
+	GetPatientDuplicateByID(c context.Context, id interface{}) (*model.PatientDuplicate, error)
+	DismissPatientDuplicate(c context.Context, id interface{}, reviewerID interface{}) (*model.PatientDuplicate, error)
+	MergePatientDuplicate(c context.Context, id interface{}, reviewerID int64, survivorID interface{}, mergedID interface{}) (*model.PatientDuplicate, error)
+
+	AddPatientSpecialist(c context.Context, patientID interface{}, accountID int64, req *model.AddPatientSpecialist) (*model.PatientSpecialist, error)
+	GetPatientSpecialists(c context.Context, patientID interface{}) ([]*model.PatientSpecialist, error)
+	GetPatientSpecialistByID(c context.Context, patientID interface{}, specialistID interface{}) (*model.PatientSpecialist, error)
+	ConfirmPatientSpecialist(c context.Context, patientID interface{}, accountID int64, specialistID interface{}) (*model.PatientSpecialist, error)
+	DeletePatientSpecialist(c context.Context, patientID interface{}, accountID int64, specialistID interface{}) error
+
+	GetPatientHistoryRecords(c context.Context, patientID interface{}) (map[string][]map[string]interface{}, error)
+	GetPatientHistory(c context.Context, patientID interface{}, req *model.ListPatientHistoryRequest) ([]*model.PatientHistory, error)
+	GetPatientHistoryAfter(c context.Context, patientID interface{}, at time.Time) ([]*model.PatientHistory, error)
 
 	GetSpecialistByID(c context.Context, id interface{}) (*model.Specialist, error)
 	GetSpecialists(c context.Context, req *model.ListSpecialistsRequest) ([]*model.Specialist, error)
+	GetSpecialistFacets(c context.Context, req *model.ListSpecialistsRequest) (*model.SpecialistFacets, error)
 	UpdateSpecialistProfileMain(c context.Context, specialistID interface{}, req *model.UpdateSpecialistProfile) (*model.Specialist, error)
 	UpdateSpecialistProfileFields(c context.Context, id interface{}, req model.UpdateSpecialistProfileFields) (*model.Specialist, error)
 
+	AddSpecialistSchedule(c context.Context, specialistID interface{}, req *model.AddSchedule) (*model.Schedule, error)
+	GetSpecialistSchedules(c context.Context, specialistID interface{}) ([]*model.Schedule, error)
+	GetSpecialistScheduleByID(c context.Context, id interface{}) (*model.Schedule, error)
+	UpdateSpecialistSchedule(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateSchedule) (*model.Schedule, error)
+	DeleteSpecialistSchedule(c context.Context, id interface{}, specialistID interface{}) error
+
+	AddSpecialistScheduleException(c context.Context, specialistID interface{}, req *model.AddScheduleException) (*model.ScheduleException, error)
+	GetSpecialistScheduleExceptions(c context.Context, specialistID interface{}, from *pgtype.Date, to *pgtype.Date) ([]*model.ScheduleException, error)
+	GetSpecialistScheduleExceptionByID(c context.Context, id interface{}) (*model.ScheduleException, error)
+	UpdateSpecialistScheduleException(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateScheduleException) (*model.ScheduleException, error)
+	DeleteSpecialistScheduleException(c context.Context, id interface{}, specialistID interface{}) error
+
 	AddSpecialistProfileSpecialization(c context.Context, specialistID interface{}, req *model.AddSpecialization) (*model.Specialization, error)
 	GetSpecialistProfileSpecializations(c context.Context, specialistID interface{}) ([]*model.Specialization, error)
 	GetSpecialistProfileSpecialization(c context.Context, id interface{}, specialistID interface{}) (*model.Specialization, error)
@@ -132,10 +248,91 @@ type Storage interface {
 	UpdateSpecialistProfilePublicationLink(c context.Context, id interface{}, specialistID interface{}, req *model.UpdatePublicationLink) (*model.PublicationLink, error)
 	UpdateSpecialistProfilePublicationLinkFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdatePublicationLinkFields) (*model.PublicationLink, error)
 	DeleteSpecialistProfilePublicationLink(c context.Context, id interface{}, specialistID interface{}) error
+
+	AddSpecialistProfileTeaching(c context.Context, specialistID interface{}, req *model.AddTeaching) (*model.Teaching, error)
+	GetSpecialistProfileTeachings(c context.Context, specialistID interface{}) ([]*model.Teaching, error)
+	GetSpecialistProfileTeachingByID(c context.Context, id interface{}) (*model.Teaching, error)
+	UpdateSpecialistProfileTeaching(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateTeaching) (*model.Teaching, error)
+	UpdateSpecialistProfileTeachingFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateTeachingFields) (*model.Teaching, error)
+	DeleteSpecialistProfileTeaching(c context.Context, id interface{}, specialistID interface{}) error
+
+	AddSpecialistProfileSpeech(c context.Context, specialistID interface{}, req *model.AddSpeech) (*model.Speech, error)
+	GetSpecialistProfileSpeeches(c context.Context, specialistID interface{}) ([]*model.Speech, error)
+	GetSpecialistProfileSpeechByID(c context.Context, id interface{}) (*model.Speech, error)
+	UpdateSpecialistProfileSpeech(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateSpeech) (*model.Speech, error)
+	UpdateSpecialistProfileSpeechFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateSpeechFields) (*model.Speech, error)
+	DeleteSpecialistProfileSpeech(c context.Context, id interface{}, specialistID interface{}) error
+
+	AddSpecialistProfileEducationalCourse(c context.Context, specialistID interface{}, req *model.AddEducationalCourse) (*model.EducationalCourse, error)
+	GetSpecialistProfileEducationalCourses(c context.Context, specialistID interface{}) ([]*model.EducationalCourse, error)
+	GetSpecialistProfileEducationalCourseByID(c context.Context, id interface{}) (*model.EducationalCourse, error)
+	UpdateSpecialistProfileEducationalCourse(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateEducationalCourse) (*model.EducationalCourse, error)
+	UpdateSpecialistProfileEducationalCourseFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateEducationalCourseFields) (*model.EducationalCourse, error)
+	DeleteSpecialistProfileEducationalCourse(c context.Context, id interface{}, specialistID interface{}) error
+	GetSpecialistProfileCoursePoints(c context.Context, specialistID interface{}, req *model.CoursePointsRequest) ([]*model.CoursePoints, error)
+
+	AddSpecialistProfileLicence(c context.Context, specialistID interface{}, req *model.AddLicence) (*model.Licence, error)
+	GetSpecialistProfileLicences(c context.Context, specialistID interface{}) ([]*model.Licence, error)
+	GetSpecialistProfileLicenceByID(c context.Context, id interface{}) (*model.Licence, error)
+	GetSpecialistIDsByLicenceNumber(c context.Context, number interface{}) ([]int64, error)
+	UpdateSpecialistProfileLicence(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateLicence) (*model.Licence, error)
+	UpdateSpecialistProfileLicenceFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateLicenceFields) (*model.Licence, error)
+	UpdateSpecialistProfileLicenceStatus(c context.Context, id interface{}, specialistID interface{}, status string) (*model.Licence, error)
+	UpdateSpecialistProfileLicenceCheck(c context.Context, id interface{}, verified bool) (*model.Licence, error)
+	DeleteSpecialistProfileLicence(c context.Context, id interface{}, specialistID interface{}) error
+	GetExpiringLicences(c context.Context, before time.Time) ([]*model.Licence, error)
+	SetLicenceReminded(c context.Context, id interface{}) error
+	ExpireLicences(c context.Context) ([]*model.Licence, error)
+
+	AddVerification(c context.Context, specialistID interface{}, req *model.AddVerification) (*model.Verification, error)
+	GetSpecialistVerifications(c context.Context, specialistID interface{}) ([]*model.Verification, error)
+	GetVerifications(c context.Context, req *model.ListVerificationsRequest) ([]*model.Verification, error)
+	GetVerificationByID(c context.Context, id interface{}) (*model.Verification, error)
+	ReviewVerification(c context.Context, id interface{}, reviewerID interface{}, status string, req *model.ReviewVerification) (*model.Verification, error)
+	DeleteVerification(c context.Context, id interface{}, specialistID interface{}) error
+
+	AddReview(c context.Context, patientID interface{}, req *model.AddReview) (*model.Review, error)
+	GetPatientReviews(c context.Context, patientID interface{}) ([]*model.Review, error)
+	GetReviews(c context.Context, req *model.ListReviewsRequest) ([]*model.Review, error)
+	GetReviewByID(c context.Context, id interface{}) (*model.Review, error)
+	UpdateReview(c context.Context, id interface{}, patientID interface{}, req *model.UpdateReview) (*model.Review, error)
+	DeleteReview(c context.Context, id interface{}, patientID interface{}) error
+	ModerateReview(c context.Context, id interface{}, reviewerID interface{}, status string, req *model.ModerateReview) (*model.Review, error)
+	ReplyReview(c context.Context, id interface{}, specialistID interface{}, reply *string) (*model.Review, error)
+	GetSpecialistRatings(c context.Context, specialistIDs []int64) (map[int64]*model.SpecialistRating, error)
+
+	AddOrganization(c context.Context, accountID interface{}, req *model.AddOrganization) (*model.Organization, error)
+	GetOrganizationByID(c context.Context, id interface{}) (*model.Organization, error)
+	GetAccountOrganizations(c context.Context, accountID interface{}) ([]*model.AccountOrganization, error)
+	UpdateOrganization(c context.Context, id interface{}, req *model.UpdateOrganization) (*model.Organization, error)
+	UpdateOrganizationFields(c context.Context, id interface{}, req model.UpdateOrganizationFields) (*model.Organization, error)
+	DeleteOrganization(c context.Context, id interface{}) error
+	GetOrganizationExperiences(c context.Context, organizationID interface{}) ([]*model.Experience, error)
+
+	AddOrganizationLicence(c context.Context, organizationID interface{}, req *model.AddOrganizationLicence) (*model.OrganizationLicence, error)
+	GetOrganizationLicences(c context.Context, organizationID interface{}) ([]*model.OrganizationLicence, error)
+	GetOrganizationLicenceByID(c context.Context, id interface{}, organizationID interface{}) (*model.OrganizationLicence, error)
+	UpdateOrganizationLicence(c context.Context, id interface{}, organizationID interface{}, req *model.UpdateOrganizationLicence) (*model.OrganizationLicence, error)
+	UpdateOrganizationLicenceFields(c context.Context, id interface{}, organizationID interface{}, req model.UpdateOrganizationLicenceFields) (*model.OrganizationLicence, error)
+	DeleteOrganizationLicence(c context.Context, id interface{}, organizationID interface{}) error
+
+	GetOrganizationMembers(c context.Context, organizationID interface{}) ([]*model.OrganizationMember, error)
+	GetOrganizationMemberByID(c context.Context, organizationID interface{}, accountID interface{}) (*model.OrganizationMember, error)
+	UpdateOrganizationMember(c context.Context, organizationID interface{}, accountID interface{}, req *model.UpdateOrganizationMember) (*model.OrganizationMember, error)
+	DeleteOrganizationMember(c context.Context, organizationID interface{}, accountID interface{}) error
+
+	AddOrganizationInvitation(c context.Context, organizationID interface{}, invitedBy interface{}, req *model.AddOrganizationInvitation) (*model.OrganizationInvitation, error)
+	GetOrganizationInvitations(c context.Context, organizationID interface{}) ([]*model.OrganizationInvitation, error)
+	GetAccountInvitations(c context.Context, accountID interface{}) ([]*model.OrganizationInvitation, error)
+	GetOrganizationInvitationByID(c context.Context, id interface{}) (*model.OrganizationInvitation, error)
+	UpdateOrganizationInvitationStatus(c context.Context, id interface{}, accountID interface{}, status string) (*model.OrganizationInvitation, error)
+	AcceptOrganizationInvitation(c context.Context, id interface{}, accountID interface{}) (*model.OrganizationInvitation, error)
+	DeleteOrganizationInvitation(c context.Context, id interface{}, organizationID interface{}) error
 }
 
 type PostgresStorage struct {
 	*postgres.Postgres
+	tx *sql.Tx
 }
 
 func NewPostgresStorage(postgres *postgres.Postgres) *PostgresStorage {
@@ -146,8 +343,55 @@ func (s *PostgresStorage) SetFormat() squirrel.StatementBuilderType {
 	return squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 }
 
+// storageTx is a transaction begun by the storage, the one continuing an outer transaction leaves the end to its owner
+type storageTx struct {
+	*sql.Tx
+	nested bool
+}
+
+func (t *storageTx) Commit() error {
+	if t.nested {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *storageTx) Rollback() error {
+	if t.nested {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// beginTx starts a transaction, or continues the one the storage is bound to
+func (s *PostgresStorage) beginTx(c context.Context) (*storageTx, error) {
+	if s.tx != nil {
+		return &storageTx{Tx: s.tx, nested: true}, nil
+	}
+
+	tx, err := s.DB.BeginTx(c, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storageTx{Tx: tx}, nil
+}
+
+// withTx returns the storage running every query in the transaction
+func (s *PostgresStorage) withTx(tx *storageTx) *PostgresStorage {
+	return &PostgresStorage{Postgres: s.Postgres, tx: tx.Tx}
+}
+
+// runner is the transaction the storage is bound to, the database otherwise
+func (s *PostgresStorage) runner() squirrel.BaseRunner {
+	if s.tx != nil {
+		return s.tx
+	}
+	return s.DB
+}
+
 func (s *PostgresStorage) GetAccountByID(c context.Context, id interface{}) (*model.Account, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.accountResponseColumns()...).
 		From(accountsTableName).
@@ -178,11 +422,21 @@ func (s *PostgresStorage) GetAccountByID(c context.Context, id interface{}) (*mo
 		return nil, err
 	}
 
+	a.Organizations, err = s.GetAccountOrganizations(c, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Roles, err = s.GetAccountRoles(c, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return a, nil
 }
 
 func (s *PostgresStorage) GetAccountByLogin(c context.Context, login interface{}) (*model.Account, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.accountResponseColumns()...).
 		From(accountsTableName).
@@ -213,11 +467,21 @@ func (s *PostgresStorage) GetAccountByLogin(c context.Context, login interface{}
 		return nil, err
 	}
 
+	a.Organizations, err = s.GetAccountOrganizations(c, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Roles, err = s.GetAccountRoles(c, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return a, nil
 }
 
 func (s *PostgresStorage) GetAccounts(c context.Context, req *model.ListAccountsRequest) ([]*model.Account, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.accountResponseColumnsMain()...).
 		From(accountsTableName).
@@ -247,7 +511,7 @@ func (s *PostgresStorage) GetAccounts(c context.Context, req *model.ListAccounts
 }
 
 func (s *PostgresStorage) DeleteAccount(c context.Context, id interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(accountsTableName).
 		Where("id = ?", id).
@@ -268,7 +532,7 @@ func (s *PostgresStorage) DeleteAccount(c context.Context, id interface{}) error
 }
 
 func (s *PostgresStorage) UpdateAccountMain(c context.Context, id interface{}, req *model.UpdateAccount) (*model.Account, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountsTableName).
 		Set("updated_at", time.Now()).
@@ -298,7 +562,7 @@ func (s *PostgresStorage) UpdateAccountMain(c context.Context, id interface{}, r
 }
 
 func (s *PostgresStorage) UpdateAccountFields(c context.Context, id interface{}, req model.UpdateAccountFields) (*model.Account, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	req["updated_at"] = time.Now()
 
 	res, err := psql.Update(accountsTableName).
@@ -325,7 +589,7 @@ func (s *PostgresStorage) UpdateAccountFields(c context.Context, id interface{},
 }
 
 func (s *PostgresStorage) GetAccountFields(c context.Context, id interface{}, fields ...string) (map[string]interface{}, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(fields...).
 		From(accountsTableName).
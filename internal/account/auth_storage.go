@@ -1,14 +1,24 @@
 package account
 
 import (
+	"context"
 	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
 	"github.com/Hvaekar/med-account/pkg/storage/postgres"
 	"github.com/Hvaekar/med-account/pkg/utils"
+	"github.com/Masterminds/squirrel"
 	"github.com/gin-gonic/gin"
+	"time"
 )
 
 func (s *PostgresStorage) Register(c *gin.Context, req *model.RegisterRequest) (*model.Account, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
 
 	q := psql.Insert(accountsTableName).
 		Columns(
@@ -26,11 +36,83 @@ func (s *PostgresStorage) Register(c *gin.Context, req *model.RegisterRequest) (
 		return nil, postgres.ConvertError(err)
 	}
 
-	_ = psql.Insert(patientProfilesTableName).Columns("account_id").Values(id).QueryRowContext(c)
+	if req.HandoverCode != nil {
+		if err := s.withTx(tx).handOverPatientProfile(c, psql, id, *req.HandoverCode); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := psql.Insert(patientProfilesTableName).Columns("account_id").Values(id).ExecContext(c); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
 
 	return s.GetAccountByID(c, id)
 }
 
+// handOverPatientProfile attaches the dependent profile to the new account and moves its personal data to the account.
+// The profile history records the handover on behalf of the new account.
+func (s *PostgresStorage) handOverPatientProfile(c context.Context, psql squirrel.StatementBuilderType, accountID int64, code string) error {
+	var p model.AddDependentPatient
+	var profileID int64
+
+	row := psql.Select("id", "first_name", "father_name", "last_name", "sex", "birthday").
+		From(patientProfilesTableName).
+		Where(squirrel.Eq{"handover_code": utils.HashToken(code), "account_id": nil}).
+		Where("handover_expires_at > ?", time.Now()).
+		Suffix("FOR UPDATE").
+		QueryRowContext(c)
+
+	if err := row.Scan(&profileID, &p.FirstName, &p.FatherName, &p.LastName, &p.Sex, &p.Birthday); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	before, err := s.getPatientProfileRecord(c, profileID)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update(accountsTableName).
+		Set("first_name", p.FirstName).
+		Set("father_name", storage.NullString(p.FatherName)).
+		Set("last_name", p.LastName).
+		Set("sex", p.Sex).
+		Set("birthday", p.Birthday).
+		Where("id = ?", accountID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	_, err = psql.Update(patientProfilesTableName).
+		Set("updated_at", time.Now()).
+		Set("account_id", accountID).
+		Set("first_name", nil).
+		Set("father_name", nil).
+		Set("last_name", nil).
+		Set("sex", nil).
+		Set("birthday", nil).
+		Set("handover_code", nil).
+		Set("handover_expires_at", nil).
+		Where("id = ?", profileID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	after, err := s.getPatientProfileRecord(c, profileID)
+	if err != nil {
+		return err
+	}
+
+	e := patientHistoryEntry{patientID: profileID, accountID: accountID, resource: model.PatientHistoryResourceProfile}
+
+	return s.addPatientHistory(c, e, profileID, before, after)
+}
+
 func (s *PostgresStorage) Login(c *gin.Context, req *model.LoginRequest) (*model.Account, error) {
 	a, err := s.GetAccountByLogin(c, req.Login)
 	if err != nil {
@@ -8,23 +8,58 @@ const (
 	accountAddressesTableName        = "account_addresses"
 	accountLanguagesTableName        = "account_languages" // levels: a1, a2, b1, b2, c1, c2
 	accountsPatientProfilesTableName = "accounts_patient_profiles"
+	accountPrivacySettingsTableName  = "account_privacy_settings"
+	accountRolesTableName            = "account_roles"
+	accountExportsTableName          = "account_exports"
 
-	patientProfilesTableName        = "patient_profiles"
-	patientDisabilityFilesTableName = "patient_disability_files"
-	patientMetalComponentsTableName = "patient_metal_components"
+	patientProfilesTableName             = "patient_profiles"
+	patientDisabilityFilesTableName      = "patient_disability_files"
+	patientMetalComponentsTableName      = "patient_metal_components"
+	patientMetalComponentFilesTableName  = "patient_metal_component_files"
+	patientMeasurementsTableName         = "patient_measurements"
+	patientAllergiesTableName            = "patient_allergies"
+	patientConditionsTableName           = "patient_conditions"
+	patientMedicationsTableName          = "patient_medications"
+	patientVaccinationsTableName         = "patient_vaccinations"
+	patientVaccinationFilesTableName     = "patient_vaccination_files"
+	patientEmergencyContactsTableName    = "patient_emergency_contacts"
+	patientInsurancePoliciesTableName    = "patient_insurance_policies"
+	patientInsurancePolicyFilesTableName = "patient_insurance_policy_files"
+	patientSpecialistsTableName          = "patient_specialists"
+	patientHistoryTableName              = "patient_history"
+	patientFamilyHistoryTableName        = "patient_family_history"
+	patientLifestyleRecordsTableName     = "patient_lifestyle_records"
+	patientLifestyleHazardsTableName     = "patient_lifestyle_hazards"
+	patientDuplicatesTableName           = "patient_duplicates"
+	patientDuplicateScansTableName       = "patient_duplicate_scans"
 
-	specialistProfilesTableName        = "specialist_profiles"
-	specialistSpecializationsTableName = "specialist_specializations"
-	specialistCuresDiseasesTableName   = "specialist_cures_diseases"
-	specialistServicesTableName        = "specialist_services"
-	specialistEducationsTableName      = "specialist_educations"
-	specialistEducationFilesTableName  = "specialist_education_files"
-	//specialistEducationalTableName = "specialist_educational_courses"
-	//specialistEducationalFilesTableName = "specialist_educational_course_files"
-	//specialistLicencesTableName                  = "specialist_licences"
+	specialistProfilesTableName                  = "specialist_profiles"
+	specialistSpecializationsTableName           = "specialist_specializations"
+	specialistCuresDiseasesTableName             = "specialist_cures_diseases"
+	specialistServicesTableName                  = "specialist_services"
+	specialistEducationsTableName                = "specialist_educations"
+	specialistEducationFilesTableName            = "specialist_education_files"
+	specialistEducationalCoursesTableName        = "specialist_educational_courses"
+	specialistEducationalCourseFilesTableName    = "specialist_educational_course_files"
+	specialistLicencesTableName                  = "specialist_licences"
 	specialistExperiencesTableName               = "specialist_experiences"
 	specialistExperienceSpecializationsTableName = "specialist_experience_specializations"
 	specialistAssociationsTableName              = "specialist_associations"
 	specialistPatentsTableName                   = "specialist_patents"
 	specialistPublicationLinksTableName          = "specialist_publication_links"
+	specialistTeachingsTableName                 = "specialist_teachings"
+	specialistSpeechesTableName                  = "specialist_speeches"
+	specialistVerificationsTableName             = "specialist_verifications"
+	specialistVerificationFilesTableName         = "specialist_verification_files"
+	specialistSchedulesTableName                 = "specialist_schedules"
+	specialistScheduleHoursTableName             = "specialist_schedule_hours"
+	specialistScheduleExceptionsTableName        = "specialist_schedule_exceptions"
+	specialistReviewsTableName                   = "specialist_reviews"
+
+	organizationsTableName           = "organizations"
+	organizationLicencesTableName    = "organization_licences"
+	organizationMembersTableName     = "organization_members"
+	organizationInvitationsTableName = "organization_invitations"
+
+	diseaseICD10CodesTableName = "disease_icd10_codes"
 )
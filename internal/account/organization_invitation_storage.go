@@ -0,0 +1,219 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddOrganizationInvitation(c context.Context, organizationID interface{}, invitedBy interface{}, req *model.AddOrganizationInvitation) (*model.OrganizationInvitation, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(organizationInvitationsTableName).
+		Columns(
+			"organization_id",
+			"account_id",
+			"invited_by",
+			"role",
+		).
+		Values(
+			organizationID,
+			req.AccountID,
+			invitedBy,
+			req.Role,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetOrganizationInvitationByID(c, id)
+}
+
+func (s *PostgresStorage) GetOrganizationInvitations(c context.Context, organizationID interface{}) ([]*model.OrganizationInvitation, error) {
+	return s.getOrganizationInvitations(c, squirrel.Eq{organizationInvitationsTableName + ".organization_id": organizationID})
+}
+
+func (s *PostgresStorage) GetAccountInvitations(c context.Context, accountID interface{}) ([]*model.OrganizationInvitation, error) {
+	return s.getOrganizationInvitations(c, squirrel.Eq{
+		organizationInvitationsTableName + ".account_id": accountID,
+		organizationInvitationsTableName + ".status":     model.InvitationStatusPending,
+	})
+}
+
+func (s *PostgresStorage) getOrganizationInvitations(c context.Context, where squirrel.Eq) ([]*model.OrganizationInvitation, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.organizationInvitationResponseColumns()...).
+		From(organizationInvitationsTableName).
+		LeftJoin(organizationsTableName + " ON " + organizationsTableName + ".id = " + organizationInvitationsTableName + ".organization_id").
+		Where(where).
+		OrderBy(organizationInvitationsTableName + ".id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var is []*model.OrganizationInvitation
+	for rows.Next() {
+		i, err := s.scanOrganizationInvitation(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		is = append(is, i)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return is, nil
+}
+
+func (s *PostgresStorage) GetOrganizationInvitationByID(c context.Context, id interface{}) (*model.OrganizationInvitation, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.organizationInvitationResponseColumns()...).
+		From(organizationInvitationsTableName).
+		LeftJoin(organizationsTableName+" ON "+organizationsTableName+".id = "+organizationInvitationsTableName+".organization_id").
+		Where(organizationInvitationsTableName+".id = ?", id).
+		QueryRowContext(c)
+
+	i, err := s.scanOrganizationInvitation(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return i, nil
+}
+
+func (s *PostgresStorage) UpdateOrganizationInvitationStatus(c context.Context, id interface{}, accountID interface{}, status string) (*model.OrganizationInvitation, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(organizationInvitationsTableName).
+		Set("updated_at", time.Now()).
+		Set("status", status).
+		Where("id = ? AND account_id = ? AND status = ?", id, accountID, model.InvitationStatusPending).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetOrganizationInvitationByID(c, id)
+}
+
+// AcceptOrganizationInvitation accepts the invitation and adds the account to the members together,
+// the invitation stays pending if the account is already a member
+func (s *PostgresStorage) AcceptOrganizationInvitation(c context.Context, id interface{}, accountID interface{}) (*model.OrganizationInvitation, error) {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	var organizationID int64
+	var role string
+	if err := psql.Update(organizationInvitationsTableName).
+		Set("updated_at", time.Now()).
+		Set("status", model.InvitationStatusAccepted).
+		Where("id = ? AND account_id = ? AND status = ?", id, accountID, model.InvitationStatusPending).
+		Suffix("RETURNING \"organization_id\", \"role\"").
+		QueryRowContext(c).
+		Scan(&organizationID, &role); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	_, err = psql.Insert(organizationMembersTableName).
+		Columns("organization_id", "account_id", "role").
+		Values(organizationID, accountID, role).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetOrganizationInvitationByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteOrganizationInvitation(c context.Context, id interface{}, organizationID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(organizationInvitationsTableName).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) organizationInvitationResponseColumns() []string {
+	fields := make([]string, 0)
+
+	fields = append(s.organizationInvitationsResponseColumns(organizationInvitationsTableName), organizationsTableName+".name")
+
+	return fields
+}
+
+func (s *PostgresStorage) organizationInvitationsResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "organization_id",
+		pre + "account_id",
+		pre + "invited_by",
+		pre + "role",
+		pre + "status",
+	}
+}
+
+func (s *PostgresStorage) scanOrganizationInvitation(row squirrel.RowScanner) (*model.OrganizationInvitation, error) {
+	var i model.OrganizationInvitation
+
+	if err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.AccountID,
+		&i.InvitedBy,
+		&i.Role,
+		&i.Status,
+		&i.OrganizationName,
+	); err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
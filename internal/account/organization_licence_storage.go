@@ -0,0 +1,180 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+func (s *PostgresStorage) AddOrganizationLicence(c context.Context, organizationID interface{}, req *model.AddOrganizationLicence) (*model.OrganizationLicence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(organizationLicencesTableName).
+		Columns(
+			"organization_id",
+			"number",
+			"issued",
+			"expires",
+			"description",
+		).
+		Values(
+			organizationID,
+			req.Number,
+			req.Issued,
+			storage.NullDatePGX(req.Expires),
+			storage.NullString(req.Description),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetOrganizationLicenceByID(c, id, organizationID)
+}
+
+func (s *PostgresStorage) GetOrganizationLicences(c context.Context, organizationID interface{}) ([]*model.OrganizationLicence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.organizationLicenceResponseColumns()...).
+		From(organizationLicencesTableName).
+		Where("organization_id = ?", organizationID).
+		OrderBy("id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ls []*model.OrganizationLicence
+	for rows.Next() {
+		l, err := s.scanOrganizationLicence(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ls = append(ls, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ls, nil
+}
+
+func (s *PostgresStorage) GetOrganizationLicenceByID(c context.Context, id interface{}, organizationID interface{}) (*model.OrganizationLicence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.organizationLicenceResponseColumns()...).
+		From(organizationLicencesTableName).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		QueryRowContext(c)
+
+	l, err := s.scanOrganizationLicence(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return l, nil
+}
+
+func (s *PostgresStorage) UpdateOrganizationLicence(c context.Context, id interface{}, organizationID interface{}, req *model.UpdateOrganizationLicence) (*model.OrganizationLicence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(organizationLicencesTableName).
+		Set("number", req.Number).
+		Set("issued", req.Issued).
+		Set("expires", storage.NullDatePGX(req.Expires)).
+		Set("description", storage.NullString(req.Description)).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetOrganizationLicenceByID(c, id, organizationID)
+}
+
+func (s *PostgresStorage) UpdateOrganizationLicenceFields(c context.Context, id interface{}, organizationID interface{}, req model.UpdateOrganizationLicenceFields) (*model.OrganizationLicence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(organizationLicencesTableName).
+		SetMap(req).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetOrganizationLicenceByID(c, id, organizationID)
+}
+
+func (s *PostgresStorage) DeleteOrganizationLicence(c context.Context, id interface{}, organizationID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(organizationLicencesTableName).
+		Where("id = ? AND organization_id = ?", id, organizationID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) organizationLicenceResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "organization_id",
+		pre + "number",
+		pre + "issued",
+		pre + "expires",
+		pre + "description",
+	}
+}
+
+func (s *PostgresStorage) scanOrganizationLicence(row squirrel.RowScanner) (*model.OrganizationLicence, error) {
+	var l model.OrganizationLicence
+
+	if err := row.Scan(
+		&l.ID,
+		&l.OrganizationID,
+		&l.Number,
+		&l.Issued,
+		&l.Expires,
+		&l.Description,
+	); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
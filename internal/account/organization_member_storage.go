@@ -0,0 +1,204 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+func (s *PostgresStorage) GetOrganizationMembers(c context.Context, organizationID interface{}) ([]*model.OrganizationMember, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.organizationMemberResponseColumns()...).
+		From(organizationMembersTableName).
+		LeftJoin(accountsTableName+" ON "+accountsTableName+".id = "+organizationMembersTableName+".account_id").
+		Where(organizationMembersTableName+".organization_id = ?", organizationID).
+		OrderBy(organizationMembersTableName + ".created_at").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ms []*model.OrganizationMember
+	for rows.Next() {
+		m, err := s.scanOrganizationMember(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ms = append(ms, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ms, nil
+}
+
+func (s *PostgresStorage) GetOrganizationMemberByID(c context.Context, organizationID interface{}, accountID interface{}) (*model.OrganizationMember, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.organizationMemberResponseColumns()...).
+		From(organizationMembersTableName).
+		LeftJoin(accountsTableName+" ON "+accountsTableName+".id = "+organizationMembersTableName+".account_id").
+		Where(organizationMembersTableName+".organization_id = ? AND "+organizationMembersTableName+".account_id = ?", organizationID, accountID).
+		QueryRowContext(c)
+
+	m, err := s.scanOrganizationMember(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return m, nil
+}
+
+func (s *PostgresStorage) UpdateOrganizationMember(c context.Context, organizationID interface{}, accountID interface{}, req *model.UpdateOrganizationMember) (*model.OrganizationMember, error) {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	if req.Role != model.OrganizationRoleOwner {
+		if err := s.checkLastOrganizationOwner(c, psql, organizationID, accountID); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := psql.Update(organizationMembersTableName).
+		Set("role", req.Role).
+		Where("organization_id = ? AND account_id = ?", organizationID, accountID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetOrganizationMemberByID(c, organizationID, accountID)
+}
+
+func (s *PostgresStorage) DeleteOrganizationMember(c context.Context, organizationID interface{}, accountID interface{}) error {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	if err := s.checkLastOrganizationOwner(c, psql, organizationID, accountID); err != nil {
+		return err
+	}
+
+	res, err := psql.Delete(organizationMembersTableName).
+		Where("organization_id = ? AND account_id = ?", organizationID, accountID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+// checkLastOrganizationOwner locks the owners of the organization until the end of the transaction,
+// so the concurrent requests can not take away the role from the last owner
+func (s *PostgresStorage) checkLastOrganizationOwner(c context.Context, psql squirrel.StatementBuilderType, organizationID interface{}, accountID interface{}) error {
+	rows, err := psql.Select().
+		Column(squirrel.Expr("account_id = ?", accountID)).
+		From(organizationMembersTableName).
+		Where("organization_id = ? AND role = ?", organizationID, model.OrganizationRoleOwner).
+		Suffix("FOR UPDATE").
+		QueryContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var owner bool
+	var count int64
+	for rows.Next() {
+		var self bool
+		if err := rows.Scan(&self); err != nil {
+			return postgres.ConvertError(err)
+		}
+
+		owner = owner || self
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	if owner && count <= 1 {
+		return model.ErrLastOwner
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) organizationMemberResponseColumns() []string {
+	fields := make([]string, 0)
+
+	fields = append(s.organizationMembersResponseColumns(organizationMembersTableName), s.accountPatientProfileResponseColumns(accountsTableName)...)
+
+	return fields
+}
+
+func (s *PostgresStorage) organizationMembersResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "account_id",
+		pre + "organization_id",
+		pre + "role",
+		pre + "created_at",
+	}
+}
+
+func (s *PostgresStorage) scanOrganizationMember(row squirrel.RowScanner) (*model.OrganizationMember, error) {
+	var m model.OrganizationMember
+
+	if err := row.Scan(
+		&m.AccountID,
+		&m.OrganizationID,
+		&m.Role,
+		&m.CreatedAt,
+		&m.FirstName,
+		&m.FatherName,
+		&m.LastName,
+		&m.Photo,
+	); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
@@ -0,0 +1,272 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddOrganization(c context.Context, accountID interface{}, req *model.AddOrganization) (*model.Organization, error) {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	q := psql.Insert(organizationsTableName).
+		Columns(
+			"name",
+			"about",
+			"city_id",
+			"address",
+			"phone",
+			"email",
+			"website",
+			"logo_file_id",
+		).
+		Values(
+			req.Name,
+			storage.NullString(req.About),
+			storage.NullInt64(req.CityID),
+			storage.NullString(req.Address),
+			storage.NullString(req.Phone),
+			storage.NullString(req.Email),
+			storage.NullString(req.Website),
+			storage.NullInt64(req.LogoFileID),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	_, err = psql.Insert(organizationMembersTableName).
+		Columns("organization_id", "account_id", "role").
+		Values(id, accountID, model.OrganizationRoleOwner).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetOrganizationByID(c, id)
+}
+
+func (s *PostgresStorage) GetOrganizationByID(c context.Context, id interface{}) (*model.Organization, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.organizationResponseColumns()...).
+		From(organizationsTableName).
+		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+organizationsTableName+".logo_file_id").
+		Where(organizationsTableName+".id = ?", id).
+		QueryRowContext(c)
+
+	o, err := s.scanOrganization(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	o.Licences, err = s.GetOrganizationLicences(c, o.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (s *PostgresStorage) GetAccountOrganizations(c context.Context, accountID interface{}) ([]*model.AccountOrganization, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(
+		organizationsTableName+".id",
+		organizationsTableName+".name",
+		organizationMembersTableName+".role",
+	).
+		From(organizationMembersTableName).
+		LeftJoin(organizationsTableName+" ON "+organizationsTableName+".id = "+organizationMembersTableName+".organization_id").
+		Where(organizationMembersTableName+".account_id = ?", accountID).
+		OrderBy(organizationsTableName + ".id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var orgs []*model.AccountOrganization
+	for rows.Next() {
+		var o model.AccountOrganization
+		if err := rows.Scan(&o.ID, &o.Name, &o.Role); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		orgs = append(orgs, &o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return orgs, nil
+}
+
+func (s *PostgresStorage) UpdateOrganization(c context.Context, id interface{}, req *model.UpdateOrganization) (*model.Organization, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(organizationsTableName).
+		Set("updated_at", time.Now()).
+		Set("name", req.Name).
+		Set("about", storage.NullString(req.About)).
+		Set("city_id", storage.NullInt64(req.CityID)).
+		Set("address", storage.NullString(req.Address)).
+		Set("phone", storage.NullString(req.Phone)).
+		Set("email", storage.NullString(req.Email)).
+		Set("website", storage.NullString(req.Website)).
+		Set("logo_file_id", storage.NullInt64(req.LogoFileID)).
+		Where("id = ?", id).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetOrganizationByID(c, id)
+}
+
+func (s *PostgresStorage) UpdateOrganizationFields(c context.Context, id interface{}, req model.UpdateOrganizationFields) (*model.Organization, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+	req["updated_at"] = time.Now()
+
+	res, err := psql.Update(organizationsTableName).
+		SetMap(req).
+		Where("id = ?", id).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetOrganizationByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteOrganization(c context.Context, id interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(organizationsTableName).
+		Where("id = ?", id).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetOrganizationExperiences(c context.Context, organizationID interface{}) ([]*model.Experience, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.experienceResponseColumns()...).
+		From(specialistExperiencesTableName).
+		LeftJoin(specialistExperienceSpecializationsTableName+" ON "+specialistExperienceSpecializationsTableName+".experience_id = "+specialistExperiencesTableName+".id").
+		Where(specialistExperiencesTableName+".organization_id = ?", organizationID).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	e, err := s.scanExperiences(rows)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return e, nil
+}
+
+func (s *PostgresStorage) organizationResponseColumns() []string {
+	fields := make([]string, 0)
+
+	fields = append(s.organizationsResponseColumns(organizationsTableName), s.fileResponseColumns(accountFilesTableName)...)
+
+	return fields
+}
+
+func (s *PostgresStorage) organizationsResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "name",
+		pre + "about",
+		pre + "city_id",
+		pre + "address",
+		pre + "phone",
+		pre + "email",
+		pre + "website",
+	}
+}
+
+func (s *PostgresStorage) scanOrganization(row squirrel.RowScanner) (*model.Organization, error) {
+	var o model.Organization
+	var lf model.FileJoin
+
+	if err := row.Scan(
+		&o.ID,
+		&o.CreatedAt,
+		&o.UpdatedAt,
+		&o.Name,
+		&o.About,
+		&o.CityID,
+		&o.Address,
+		&o.Phone,
+		&o.Email,
+		&o.Website,
+
+		&lf.ID,
+		&lf.CreatedAt,
+		&lf.UpdatedAt,
+		&lf.AccountID,
+		&lf.Name,
+		&lf.Description,
+	); err != nil {
+		return nil, err
+	}
+
+	if lf.ID != nil {
+		f := lf.ConvertToFile()
+		o.Logo = &f
+	}
+
+	return &o, nil
+}
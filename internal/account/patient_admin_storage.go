@@ -8,8 +8,20 @@ import (
 	"github.com/Masterminds/squirrel"
 )
 
-func (s *PostgresStorage) AddPatientProfileAdmin(c context.Context, patientID interface{}, req *model.AddAdmin) (*model.PatientAdmin, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+func (s *PostgresStorage) AddPatientProfileAdmin(c context.Context, patientID interface{}, accountID int64, req *model.AddAdmin) (*model.PatientAdmin, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAdmin}
+
+	return changePatientResource(c, s, e, nil, patientAdminGetter(patientID), func(st *PostgresStorage, _ *model.PatientAdmin) (int64, error) {
+		r, err := st.addPatientProfileAdmin(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientProfileAdmin(c context.Context, patientID interface{}, req *model.AddAdmin) (*model.PatientAdmin, error) {
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Insert(accountsPatientProfilesTableName).
 		Columns(
@@ -39,7 +51,7 @@ func (s *PostgresStorage) AddPatientProfileAdmin(c context.Context, patientID in
 }
 
 func (s *PostgresStorage) GetPatientProfileAdmins(c context.Context, patientID interface{}) ([]*model.PatientAdmin, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.patientAdminsResponseColumns()...).
 		From(accountsPatientProfilesTableName).
@@ -68,7 +80,7 @@ func (s *PostgresStorage) GetPatientProfileAdmins(c context.Context, patientID i
 }
 
 func (s *PostgresStorage) GetPatientProfileAdminByID(c context.Context, patientID interface{}, adminID interface{}) (*model.PatientAdmin, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.patientAdminsResponseColumns()...).
 		From(accountsPatientProfilesTableName).
@@ -84,8 +96,27 @@ func (s *PostgresStorage) GetPatientProfileAdminByID(c context.Context, patientI
 	return admin, nil
 }
 
-func (s *PostgresStorage) UpdatePatientProfileAdmin(c context.Context, patientID interface{}, adminID interface{}, req *model.UpdateAdmin) (*model.PatientAdmin, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+// patientAdminGetter reads the admin of the patient by the account id it is recorded under in the history
+func patientAdminGetter(patientID interface{}) func(st *PostgresStorage, c context.Context, id interface{}) (*model.PatientAdmin, error) {
+	return func(st *PostgresStorage, c context.Context, id interface{}) (*model.PatientAdmin, error) {
+		return st.GetPatientProfileAdminByID(c, patientID, id)
+	}
+}
+
+func (s *PostgresStorage) UpdatePatientProfileAdmin(c context.Context, patientID interface{}, accountID int64, adminID interface{}, req *model.UpdateAdmin) (*model.PatientAdmin, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAdmin}
+
+	return changePatientResource(c, s, e, adminID, patientAdminGetter(patientID), func(st *PostgresStorage, _ *model.PatientAdmin) (int64, error) {
+		r, err := st.updatePatientProfileAdmin(c, patientID, adminID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientProfileAdmin(c context.Context, patientID interface{}, adminID interface{}, req *model.UpdateAdmin) (*model.PatientAdmin, error) {
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountsPatientProfilesTableName).
 		Set("permission_edit", req.PermissionEdit).
@@ -107,7 +138,7 @@ func (s *PostgresStorage) UpdatePatientProfileAdmin(c context.Context, patientID
 }
 
 func (s *PostgresStorage) UpdatePatientProfileAdminFields(c context.Context, patientID interface{}, adminID interface{}, req model.UpdateAdminFields) (*model.PatientAdmin, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(accountsPatientProfilesTableName).
 		SetMap(req).
@@ -128,8 +159,17 @@ func (s *PostgresStorage) UpdatePatientProfileAdminFields(c context.Context, pat
 	return s.GetPatientProfileAdminByID(c, patientID, adminID)
 }
 
-func (s *PostgresStorage) DeletePatientProfileAdmin(c context.Context, patientID interface{}, accountID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+func (s *PostgresStorage) DeletePatientProfileAdmin(c context.Context, patientID interface{}, accountID int64, adminID interface{}) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAdmin}
+
+	_, err := changePatientResource(c, s, e, adminID, patientAdminGetter(patientID), func(st *PostgresStorage, r *model.PatientAdmin) (int64, error) {
+		return r.ID, st.deletePatientProfileAdmin(c, patientID, adminID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientProfileAdmin(c context.Context, patientID interface{}, accountID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(accountsPatientProfilesTableName).
 		Where("account_id = ? AND patient_profile_id = ?", accountID, patientID).
@@ -0,0 +1,273 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientAllergy(c context.Context, patientID interface{}, accountID int64, req *model.AddAllergy) (*model.Allergy, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAllergy}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientAllergyByID, func(st *PostgresStorage, _ *model.Allergy) (int64, error) {
+		r, err := st.addPatientAllergy(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientAllergy(c context.Context, patientID interface{}, req *model.AddAllergy) (*model.Allergy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientAllergiesTableName).
+		Columns(
+			"profile_id",
+			"allergen_id",
+			"allergen",
+			"reaction_type",
+			"severity",
+			"description",
+		).
+		Values(
+			patientID,
+			storage.NullInt64(req.AllergenID),
+			storage.NullString(req.Allergen),
+			req.ReactionType,
+			req.Severity,
+			storage.NullString(req.Description),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientAllergyByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientAllergies(c context.Context, patientID interface{}) ([]*model.Allergy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientAllergyResponseColumns()...).
+		From(patientAllergiesTableName).
+		Where("profile_id = ?", patientID).
+		OrderBy("id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var as []*model.Allergy
+	for rows.Next() {
+		a, err := s.scanPatientAllergy(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		as = append(as, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return as, nil
+}
+
+func (s *PostgresStorage) GetPatientAllergyByID(c context.Context, id interface{}) (*model.Allergy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientAllergyResponseColumns()...).
+		From(patientAllergiesTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	a, err := s.scanPatientAllergy(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return a, nil
+}
+
+func (s *PostgresStorage) UpdatePatientAllergy(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateAllergy) (*model.Allergy, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAllergy}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientAllergyByID, func(st *PostgresStorage, _ *model.Allergy) (int64, error) {
+		r, err := st.updatePatientAllergy(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientAllergy(c context.Context, id interface{}, patientID interface{}, req *model.UpdateAllergy) (*model.Allergy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientAllergiesTableName).
+		Set("updated_at", time.Now()).
+		Set("allergen_id", storage.NullInt64(req.AllergenID)).
+		Set("allergen", storage.NullString(req.Allergen)).
+		Set("reaction_type", req.ReactionType).
+		Set("severity", req.Severity).
+		Set("description", storage.NullString(req.Description)).
+		Set("confirmed", false).
+		Set("confirmed_by", nil).
+		Set("confirmed_at", nil).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientAllergyByID(c, id)
+}
+
+func (s *PostgresStorage) UpdatePatientAllergyFields(c context.Context, id interface{}, patientID interface{}, req model.UpdateAllergyFields) (*model.Allergy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+	req["updated_at"] = time.Now()
+	req["confirmed"] = false
+	req["confirmed_by"] = nil
+	req["confirmed_at"] = nil
+
+	res, err := psql.Update(patientAllergiesTableName).
+		SetMap(req).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientAllergyByID(c, id)
+}
+
+func (s *PostgresStorage) ConfirmPatientAllergy(c context.Context, id interface{}, patientID interface{}, accountID int64, specialistID interface{}) (*model.Allergy, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAllergy}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientAllergyByID, func(st *PostgresStorage, _ *model.Allergy) (int64, error) {
+		r, err := st.confirmPatientAllergy(c, id, patientID, specialistID)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) confirmPatientAllergy(c context.Context, id interface{}, patientID interface{}, specialistID interface{}) (*model.Allergy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientAllergiesTableName).
+		Set("confirmed", true).
+		Set("confirmed_by", specialistID).
+		Set("confirmed_at", time.Now()).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientAllergyByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientAllergy(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceAllergy}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientAllergyByID, func(st *PostgresStorage, r *model.Allergy) (int64, error) {
+		return r.ID, st.deletePatientAllergy(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientAllergy(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientAllergiesTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientAllergyResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "allergen_id",
+		pre + "allergen",
+		pre + "reaction_type",
+		pre + "severity",
+		pre + "description",
+		pre + "confirmed",
+		pre + "confirmed_by",
+		pre + "confirmed_at",
+	}
+}
+
+func (s *PostgresStorage) scanPatientAllergy(row squirrel.RowScanner) (*model.Allergy, error) {
+	var a model.Allergy
+
+	if err := row.Scan(
+		&a.ID,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+		&a.PatientID,
+		&a.AllergenID,
+		&a.Allergen,
+		&a.ReactionType,
+		&a.Severity,
+		&a.Description,
+		&a.Confirmed,
+		&a.ConfirmedBy,
+		&a.ConfirmedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
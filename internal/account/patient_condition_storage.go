@@ -0,0 +1,369 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"sort"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientCondition(c context.Context, patientID interface{}, accountID int64, req *model.AddCondition) (*model.Condition, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceCondition}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientConditionByID, func(st *PostgresStorage, _ *model.Condition) (int64, error) {
+		r, err := st.addPatientCondition(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientCondition(c context.Context, patientID interface{}, req *model.AddCondition) (*model.Condition, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientConditionsTableName).
+		Columns(
+			"profile_id",
+			"icd10",
+			"disease_id",
+			"name",
+			"onset",
+			"status",
+		).
+		Values(
+			patientID,
+			req.ICD10,
+			storage.NullInt64(req.DiseaseID),
+			storage.NullString(req.Name),
+			storage.NullDatePGX(req.Onset),
+			req.Status,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientConditionByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientConditions(c context.Context, patientID interface{}) ([]*model.Condition, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientConditionResponseColumns()...).
+		From(patientConditionsTableName).
+		Where("profile_id = ?", patientID).
+		OrderBy("status", "onset DESC NULLS LAST", "id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var cs []*model.Condition
+	for rows.Next() {
+		cond, err := s.scanPatientCondition(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		cs = append(cs, cond)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return cs, nil
+}
+
+func (s *PostgresStorage) GetPatientConditionByID(c context.Context, id interface{}) (*model.Condition, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientConditionResponseColumns()...).
+		From(patientConditionsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	cond, err := s.scanPatientCondition(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return cond, nil
+}
+
+func (s *PostgresStorage) UpdatePatientCondition(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateCondition) (*model.Condition, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceCondition}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientConditionByID, func(st *PostgresStorage, _ *model.Condition) (int64, error) {
+		r, err := st.updatePatientCondition(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientCondition(c context.Context, id interface{}, patientID interface{}, req *model.UpdateCondition) (*model.Condition, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientConditionsTableName).
+		Set("updated_at", time.Now()).
+		Set("icd10", req.ICD10).
+		Set("disease_id", storage.NullInt64(req.DiseaseID)).
+		Set("name", storage.NullString(req.Name)).
+		Set("onset", storage.NullDatePGX(req.Onset)).
+		Set("status", req.Status).
+		Set("confirmed_by", squirrel.Expr("CASE WHEN icd10 = ? THEN confirmed_by END", req.ICD10)).
+		Set("confirmed_at", squirrel.Expr("CASE WHEN icd10 = ? THEN confirmed_at END", req.ICD10)).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientConditionByID(c, id)
+}
+
+func (s *PostgresStorage) ConfirmPatientCondition(c context.Context, id interface{}, patientID interface{}, accountID int64, specialistID interface{}) (*model.Condition, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceCondition}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientConditionByID, func(st *PostgresStorage, _ *model.Condition) (int64, error) {
+		r, err := st.confirmPatientCondition(c, id, patientID, specialistID)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) confirmPatientCondition(c context.Context, id interface{}, patientID interface{}, specialistID interface{}) (*model.Condition, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientConditionsTableName).
+		Set("confirmed_by", specialistID).
+		Set("confirmed_at", time.Now()).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientConditionByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientCondition(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceCondition}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientConditionByID, func(st *PostgresStorage, r *model.Condition) (int64, error) {
+		return r.ID, st.deletePatientCondition(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientCondition(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientConditionsTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetPatientConditionSpecialists finds specialists curing diseases of the patient's active conditions,
+// the ones covering more of them go first. A condition stands for its own disease and for the diseases
+// mapped to its ICD-10 category or subcategory in disease_icd10_codes.
+func (s *PostgresStorage) GetPatientConditionSpecialists(c context.Context, patientID interface{}) ([]*model.ConditionSpecialist, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(
+		specialistCuresDiseasesTableName+".profile_id",
+		specialistCuresDiseasesTableName+".disease_id",
+	).
+		Distinct().
+		From(specialistCuresDiseasesTableName).
+		Join(patientConditionsTableName+" ON "+patientConditionsTableName+".profile_id = ?", patientID).
+		LeftJoin(diseaseICD10CodesTableName+" ON "+diseaseICD10CodesTableName+".disease_id = "+specialistCuresDiseasesTableName+".disease_id").
+		Join(specialistProfilesTableName+" ON "+specialistProfilesTableName+".id = "+specialistCuresDiseasesTableName+".profile_id").
+		Join(accountsTableName+" ON "+accountsTableName+".id = "+specialistProfilesTableName+".account_id").
+		Where(squirrel.Eq{
+			patientConditionsTableName + ".status": model.ConditionStatusActive,
+			accountsTableName + ".deleted_at":      nil,
+		}).
+		Where(squirrel.Or{
+			squirrel.Expr(patientConditionsTableName + ".disease_id = " + specialistCuresDiseasesTableName + ".disease_id"),
+			squirrel.Expr(patientConditionsTableName + ".icd10 = " + diseaseICD10CodesTableName + ".icd10"),
+			squirrel.Expr(patientConditionsTableName + ".icd10 LIKE " + diseaseICD10CodesTableName + ".icd10 || '.%'"),
+		}).
+		OrderBy(specialistCuresDiseasesTableName+".profile_id", specialistCuresDiseasesTableName+".disease_id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	ss := make([]*model.ConditionSpecialist, 0)
+	specialists := make(map[int64]*model.ConditionSpecialist)
+	for rows.Next() {
+		var specialistID, diseaseID int64
+
+		if err := rows.Scan(&specialistID, &diseaseID); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		if _, ok := specialists[specialistID]; !ok {
+			specialists[specialistID] = &model.ConditionSpecialist{SpecialistID: specialistID}
+			ss = append(ss, specialists[specialistID])
+		}
+
+		specialists[specialistID].DiseaseIDs = append(specialists[specialistID].DiseaseIDs, diseaseID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	sort.SliceStable(ss, func(i, j int) bool { return len(ss[i].DiseaseIDs) > len(ss[j].DiseaseIDs) })
+
+	return ss, nil
+}
+
+func (s *PostgresStorage) GetDiseaseICD10Codes(c context.Context, diseaseID interface{}) (*model.DiseaseICD10Codes, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select("disease_id", "icd10").
+		From(diseaseICD10CodesTableName).
+		Where("disease_id = ?", diseaseID).
+		OrderBy("icd10").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	d := model.DiseaseICD10Codes{Codes: make([]string, 0)}
+	for rows.Next() {
+		var code string
+
+		if err := rows.Scan(&d.DiseaseID, &code); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		d.Codes = append(d.Codes, code)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if len(d.Codes) == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return &d, nil
+}
+
+// UpdateDiseaseICD10Codes replaces the ICD-10 codes mapped to the disease.
+func (s *PostgresStorage) UpdateDiseaseICD10Codes(c context.Context, diseaseID interface{}, req *model.UpdateDiseaseICD10Codes) (*model.DiseaseICD10Codes, error) {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	if _, err := psql.Delete(diseaseICD10CodesTableName).Where("disease_id = ?", diseaseID).ExecContext(c); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	q := psql.Insert(diseaseICD10CodesTableName).
+		Columns("disease_id", "icd10").
+		Suffix("ON CONFLICT DO NOTHING")
+	for _, v := range req.Codes {
+		q = q.Values(diseaseID, v)
+	}
+
+	if _, err := q.ExecContext(c); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetDiseaseICD10Codes(c, diseaseID)
+}
+
+func (s *PostgresStorage) patientConditionResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "icd10",
+		pre + "disease_id",
+		pre + "name",
+		pre + "onset",
+		pre + "status",
+		pre + "confirmed_by",
+		pre + "confirmed_at",
+	}
+}
+
+func (s *PostgresStorage) scanPatientCondition(row squirrel.RowScanner) (*model.Condition, error) {
+	var cond model.Condition
+
+	if err := row.Scan(
+		&cond.ID,
+		&cond.CreatedAt,
+		&cond.UpdatedAt,
+		&cond.PatientID,
+		&cond.ICD10,
+		&cond.DiseaseID,
+		&cond.Name,
+		&cond.Onset,
+		&cond.Status,
+		&cond.ConfirmedBy,
+		&cond.ConfirmedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &cond, nil
+}
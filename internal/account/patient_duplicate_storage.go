@@ -0,0 +1,509 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+// patientMergeTables lists the records moved to the surviving profile as they are, by their profile_id
+var patientMergeTables = []string{
+	patientMetalComponentsTableName,
+	patientMeasurementsTableName,
+	patientAllergiesTableName,
+	patientConditionsTableName,
+	patientMedicationsTableName,
+	patientVaccinationsTableName,
+	patientEmergencyContactsTableName,
+	patientFamilyHistoryTableName,
+	patientLifestyleRecordsTableName,
+	patientHistoryTableName,
+}
+
+// GetPatientDuplicateScannedAt returns when the last detection run started, nil when there was none.
+func (s *PostgresStorage) GetPatientDuplicateScannedAt(c context.Context) (*time.Time, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	var startedAt *time.Time
+	if err := psql.Select("MAX(started_at)").From(patientDuplicateScansTableName).QueryRowContext(c).Scan(&startedAt); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return startedAt, nil
+}
+
+func (s *PostgresStorage) AddPatientDuplicateScan(c context.Context, startedAt time.Time) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	_, err := psql.Insert(patientDuplicateScansTableName).
+		Columns("started_at", "finished_at").
+		Values(startedAt, time.Now()).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+// GetPatientDuplicateCandidates returns the pairs not checked yet that share the birthday or a verified contact,
+// one profile of the pair has to be a dependent since two registered accounts can not be merged.
+// When since is set only the pairs with a profile changed after it are compared, the others were scored by an earlier run.
+func (s *PostgresStorage) GetPatientDuplicateCandidates(c context.Context, since *time.Time) ([]*model.PatientDuplicateCandidate, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	columns := append(s.duplicateProfileResponseColumns("p1", "a1", "ph1", "e1"), s.duplicateProfileResponseColumns("p2", "a2", "ph2", "e2")...)
+
+	q := psql.Select(columns...).
+		From(patientProfilesTableName + " p1")
+
+	if since == nil {
+		q = q.Join(patientProfilesTableName + " p2 ON p2.id > p1.id AND (p1.account_id IS NULL OR p2.account_id IS NULL)")
+	} else {
+		// p1 is the changed profile, a pair of two changed ones is taken once in the id order
+		q = q.Join(patientProfilesTableName+" p2 ON p2.id <> p1.id AND (p1.account_id IS NULL OR p2.account_id IS NULL)").
+			Where("COALESCE(p1.updated_at > ? OR a1.updated_at > ?, false)", since, since).
+			Where("(p2.id > p1.id OR NOT COALESCE(p2.updated_at > ? OR a2.updated_at > ?, false))", since, since)
+	}
+
+	rows, err := q.
+		LeftJoin(accountsTableName+" a1 ON a1.id = p1.account_id").
+		LeftJoin(accountsTableName+" a2 ON a2.id = p2.account_id").
+		LeftJoin(accountPhonesTableName+" ph1 ON ph1.id = p1.phone_id AND ph1.verified").
+		LeftJoin(accountPhonesTableName+" ph2 ON ph2.id = p2.phone_id AND ph2.verified").
+		LeftJoin(accountEmailsTableName+" e1 ON e1.id = p1.email_id AND e1.verified").
+		LeftJoin(accountEmailsTableName+" e2 ON e2.id = p2.email_id AND e2.verified").
+		Where(squirrel.Eq{"a1.deleted_at": nil, "a2.deleted_at": nil}).
+		Where("(COALESCE(p1.birthday, a1.birthday) = COALESCE(p2.birthday, a2.birthday) "+
+			"OR ph1.code || ph1.phone = ph2.code || ph2.phone "+
+			"OR e1.email = e2.email)").
+		Where("NOT EXISTS (SELECT 1 FROM "+patientDuplicatesTableName+" d WHERE d.profile_id = LEAST(p1.id, p2.id) AND d.duplicate_profile_id = GREATEST(p1.id, p2.id))").
+		OrderBy("p1.id", "p2.id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var cds []*model.PatientDuplicateCandidate
+	for rows.Next() {
+		var a, b model.DuplicateProfile
+		if err := rows.Scan(append(s.duplicateProfileScanFields(&a), s.duplicateProfileScanFields(&b)...)...); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		// the pair is stored with the lower id first
+		if a.ID > b.ID {
+			a, b = b, a
+		}
+		cds = append(cds, &model.PatientDuplicateCandidate{Profile: &a, Duplicate: &b})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return cds, nil
+}
+
+func (s *PostgresStorage) AddPatientDuplicate(c context.Context, req *model.AddPatientDuplicate) (*model.PatientDuplicate, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientDuplicatesTableName).
+		Columns(
+			"profile_id",
+			"duplicate_profile_id",
+			"score",
+			"name_score",
+			"birthday_match",
+			"sex_match",
+			"phone_match",
+			"email_match",
+		).
+		Values(
+			req.ProfileID,
+			req.DuplicateProfileID,
+			req.Score,
+			req.NameScore,
+			req.BirthdayMatch,
+			req.SexMatch,
+			req.PhoneMatch,
+			req.EmailMatch,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientDuplicateByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientDuplicates(c context.Context, req *model.ListPatientDuplicatesRequest) ([]*model.PatientDuplicate, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientDuplicateResponseColumns()...).
+		From(patientDuplicatesTableName).
+		Where("status = ?", req.Status).
+		OrderBy("score DESC", "id").
+		Limit(req.Limit).
+		Offset(req.Offset()).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ds []*model.PatientDuplicate
+	for rows.Next() {
+		d, err := s.scanPatientDuplicate(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ds = append(ds, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	for _, v := range ds {
+		v.Profiles, err = s.getDuplicateProfiles(c, v.ProfileID, v.DuplicateProfileID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ds, nil
+}
+
+func (s *PostgresStorage) GetPatientDuplicateByID(c context.Context, id interface{}) (*model.PatientDuplicate, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientDuplicateResponseColumns()...).
+		From(patientDuplicatesTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	d, err := s.scanPatientDuplicate(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	d.Profiles, err = s.getDuplicateProfiles(c, d.ProfileID, d.DuplicateProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (s *PostgresStorage) DismissPatientDuplicate(c context.Context, id interface{}, reviewerID interface{}) (*model.PatientDuplicate, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	now := time.Now()
+	res, err := psql.Update(patientDuplicatesTableName).
+		Set("updated_at", now).
+		Set("status", model.DuplicateStatusDismissed).
+		Set("reviewer_id", reviewerID).
+		Set("reviewed_at", now).
+		Where("id = ? AND status = ?", id, model.DuplicateStatusPending).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientDuplicateByID(c, id)
+}
+
+// MergePatientDuplicate moves the records, disability files and admin links of the merged dependent profile
+// to the survivor and removes it, all in one transaction.
+func (s *PostgresStorage) MergePatientDuplicate(c context.Context, id interface{}, reviewerID int64, survivorID interface{}, mergedID interface{}) (*model.PatientDuplicate, error) {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	now := time.Now()
+	res, err := psql.Update(patientDuplicatesTableName).
+		Set("updated_at", now).
+		Set("status", model.DuplicateStatusMerged).
+		Set("reviewer_id", reviewerID).
+		Set("reviewed_at", now).
+		Set("survivor_id", survivorID).
+		Where("id = ? AND status = ?", id, model.DuplicateStatusPending).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	st := s.withTx(tx)
+
+	before, err := st.GetPatientHistoryRecords(c, survivorID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range patientMergeTables {
+		_, err := psql.Update(table).
+			Set("profile_id", survivorID).
+			Where("profile_id = ?", mergedID).
+			ExecContext(c)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+	}
+
+	// the same policy kept on both profiles stays only on the survivor
+	_, err = psql.Update(patientInsurancePoliciesTableName+" ip").
+		Set("profile_id", survivorID).
+		Where("ip.profile_id = ?", mergedID).
+		Where("NOT EXISTS (SELECT 1 FROM "+patientInsurancePoliciesTableName+" sp WHERE sp.profile_id = ? AND sp.insurer = ip.insurer AND sp.policy_number = ip.policy_number)", survivorID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	// the survivor keeps its own review of the same specialist
+	_, err = psql.Update(specialistReviewsTableName+" r").
+		Set("patient_id", survivorID).
+		Where("r.patient_id = ?", mergedID).
+		Where("NOT EXISTS (SELECT 1 FROM "+specialistReviewsTableName+" sr WHERE sr.patient_id = ? AND sr.specialist_id = r.specialist_id)", survivorID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	_, err = psql.Update(patientFamilyHistoryTableName).
+		Set("relative_profile_id", survivorID).
+		Where("relative_profile_id = ? AND profile_id <> ?", mergedID, survivorID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	_, err = psql.Insert(patientDisabilityFilesTableName).
+		Columns("profile_id", "file_id").
+		Select(psql.Select().Column(squirrel.Expr("?::BIGINT", survivorID)).Column("file_id").From(patientDisabilityFilesTableName).Where("profile_id = ?", mergedID)).
+		Suffix("ON CONFLICT DO NOTHING").
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	// a link the specialist confirmed for either profile stays confirmed
+	_, err = psql.Insert(patientSpecialistsTableName).
+		Columns("profile_id", "specialist_id", "created_at", "confirmed_at").
+		Select(psql.Select().Column(squirrel.Expr("?::BIGINT", survivorID)).Columns("specialist_id", "created_at", "confirmed_at").From(patientSpecialistsTableName).Where("profile_id = ?", mergedID)).
+		Suffix("ON CONFLICT (profile_id, specialist_id) DO UPDATE SET " +
+			"confirmed_at = COALESCE(" + patientSpecialistsTableName + ".confirmed_at, EXCLUDED.confirmed_at)").
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	// an account administering both profiles keeps the wider rights, the own account of the survivor needs no link
+	_, err = psql.Insert(accountsPatientProfilesTableName).
+		Columns("account_id", "patient_profile_id", "permission_edit", "verified").
+		Select(psql.Select("account_id").Column(squirrel.Expr("?::BIGINT", survivorID)).Columns("permission_edit", "verified").From(accountsPatientProfilesTableName).
+			Where("patient_profile_id = ?", mergedID).
+			Where("account_id <> COALESCE((SELECT account_id FROM "+patientProfilesTableName+" WHERE id = ?), 0)", survivorID)).
+		Suffix("ON CONFLICT (account_id, patient_profile_id) DO UPDATE SET " +
+			"permission_edit = " + accountsPatientProfilesTableName + ".permission_edit OR EXCLUDED.permission_edit, " +
+			"verified = " + accountsPatientProfilesTableName + ".verified OR EXCLUDED.verified").
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	// the moved history of the merged profile is followed by what the survivor received from it
+	after, err := st.GetPatientHistoryRecords(c, survivorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := st.addPatientHistoryChanges(c, survivorID, reviewerID, before, after); err != nil {
+		return nil, err
+	}
+
+	// other pairs of the merged profile are found again against the survivor on the next detection
+	_, err = psql.Update(patientProfilesTableName).
+		Set("updated_at", now).
+		Where("id = ?", survivorID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	_, err = psql.Delete(patientDuplicatesTableName).
+		Where("id <> ? AND status = ?", id, model.DuplicateStatusPending).
+		Where("(profile_id = ? OR duplicate_profile_id = ?)", mergedID, mergedID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	res, err = psql.Delete(patientProfilesTableName).
+		Where(squirrel.Eq{"id": mergedID, "account_id": nil}).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err = res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientDuplicateByID(c, id)
+}
+
+func (s *PostgresStorage) getDuplicateProfiles(c context.Context, ids ...*int64) ([]*model.DuplicateProfile, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	var existing []int64
+	for _, v := range ids {
+		if v != nil {
+			existing = append(existing, *v)
+		}
+	}
+
+	dps := make([]*model.DuplicateProfile, 0, len(existing))
+	if len(existing) == 0 {
+		return dps, nil
+	}
+
+	rows, err := psql.Select(s.duplicateProfileResponseColumns("p", "a", "ph", "e")...).
+		From(patientProfilesTableName + " p").
+		LeftJoin(accountsTableName + " a ON a.id = p.account_id").
+		LeftJoin(accountPhonesTableName + " ph ON ph.id = p.phone_id AND ph.verified").
+		LeftJoin(accountEmailsTableName + " e ON e.id = p.email_id AND e.verified").
+		Where(squirrel.Eq{"p.id": existing}).
+		OrderBy("p.id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dp model.DuplicateProfile
+		if err := rows.Scan(s.duplicateProfileScanFields(&dp)...); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		dps = append(dps, &dp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return dps, nil
+}
+
+func (s *PostgresStorage) patientDuplicateResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "duplicate_profile_id",
+		pre + "score",
+		pre + "name_score",
+		pre + "birthday_match",
+		pre + "sex_match",
+		pre + "phone_match",
+		pre + "email_match",
+		pre + "status",
+		pre + "reviewer_id",
+		pre + "reviewed_at",
+		pre + "survivor_id",
+	}
+}
+
+// duplicateProfileResponseColumns takes personal data from the dependent profile until it is handed over, like patientAccountResponseColumns
+func (s *PostgresStorage) duplicateProfileResponseColumns(profile string, account string, phone string, email string) []string {
+	return []string{
+		profile + ".id",
+		profile + ".account_id",
+		"COALESCE(" + profile + ".first_name, " + account + ".first_name)",
+		"COALESCE(" + profile + ".father_name, " + account + ".father_name)",
+		"COALESCE(" + profile + ".last_name, " + account + ".last_name)",
+		"COALESCE(" + profile + ".sex, " + account + ".sex)",
+		"COALESCE(" + profile + ".birthday, " + account + ".birthday)",
+		phone + ".code || " + phone + ".phone",
+		email + ".email",
+	}
+}
+
+func (s *PostgresStorage) duplicateProfileScanFields(dp *model.DuplicateProfile) []interface{} {
+	return []interface{}{
+		&dp.ID,
+		&dp.AccountID,
+		&dp.FirstName,
+		&dp.FatherName,
+		&dp.LastName,
+		&dp.Sex,
+		&dp.Birthday,
+		&dp.Phone,
+		&dp.Email,
+	}
+}
+
+func (s *PostgresStorage) scanPatientDuplicate(row squirrel.RowScanner) (*model.PatientDuplicate, error) {
+	var d model.PatientDuplicate
+
+	if err := row.Scan(
+		&d.ID,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.ProfileID,
+		&d.DuplicateProfileID,
+		&d.Score,
+		&d.NameScore,
+		&d.BirthdayMatch,
+		&d.SexMatch,
+		&d.PhoneMatch,
+		&d.EmailMatch,
+		&d.Status,
+		&d.ReviewerID,
+		&d.ReviewedAt,
+		&d.SurvivorID,
+	); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
@@ -0,0 +1,210 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientEmergencyContact(c context.Context, patientID interface{}, accountID int64, req *model.AddEmergencyContact) (*model.EmergencyContact, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceEmergencyContact}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientEmergencyContactByID, func(st *PostgresStorage, _ *model.EmergencyContact) (int64, error) {
+		r, err := st.addPatientEmergencyContact(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientEmergencyContact(c context.Context, patientID interface{}, req *model.AddEmergencyContact) (*model.EmergencyContact, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	var priority interface{} = squirrel.Expr("(SELECT COALESCE(MAX(priority), 0) + 1 FROM "+patientEmergencyContactsTableName+" WHERE profile_id = ?)", patientID)
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+
+	q := psql.Insert(patientEmergencyContactsTableName).
+		Columns(
+			"profile_id",
+			"name",
+			"relation",
+			"phone",
+			"account_id",
+			"priority",
+		).
+		Values(
+			patientID,
+			req.Name,
+			req.Relation,
+			req.Phone,
+			storage.NullInt64(req.AccountID),
+			priority,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientEmergencyContactByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientEmergencyContacts(c context.Context, patientID interface{}) ([]*model.EmergencyContact, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientEmergencyContactResponseColumns()...).
+		From(patientEmergencyContactsTableName).
+		Where("profile_id = ?", patientID).
+		OrderBy("priority", "id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var es []*model.EmergencyContact
+	for rows.Next() {
+		e, err := s.scanPatientEmergencyContact(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		es = append(es, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return es, nil
+}
+
+func (s *PostgresStorage) GetPatientEmergencyContactByID(c context.Context, id interface{}) (*model.EmergencyContact, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientEmergencyContactResponseColumns()...).
+		From(patientEmergencyContactsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	e, err := s.scanPatientEmergencyContact(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return e, nil
+}
+
+func (s *PostgresStorage) UpdatePatientEmergencyContact(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateEmergencyContact) (*model.EmergencyContact, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceEmergencyContact}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientEmergencyContactByID, func(st *PostgresStorage, _ *model.EmergencyContact) (int64, error) {
+		r, err := st.updatePatientEmergencyContact(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientEmergencyContact(c context.Context, id interface{}, patientID interface{}, req *model.UpdateEmergencyContact) (*model.EmergencyContact, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Update(patientEmergencyContactsTableName).
+		Set("updated_at", time.Now()).
+		Set("name", req.Name).
+		Set("relation", req.Relation).
+		Set("phone", req.Phone).
+		Set("account_id", storage.NullInt64(req.AccountID))
+
+	if req.Priority != nil {
+		q = q.Set("priority", *req.Priority)
+	}
+
+	res, err := q.Where("id = ? AND profile_id = ?", id, patientID).ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientEmergencyContactByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientEmergencyContact(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceEmergencyContact}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientEmergencyContactByID, func(st *PostgresStorage, r *model.EmergencyContact) (int64, error) {
+		return r.ID, st.deletePatientEmergencyContact(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientEmergencyContact(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientEmergencyContactsTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientEmergencyContactResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "name",
+		pre + "relation",
+		pre + "phone",
+		pre + "account_id",
+		pre + "priority",
+	}
+}
+
+func (s *PostgresStorage) scanPatientEmergencyContact(row squirrel.RowScanner) (*model.EmergencyContact, error) {
+	var e model.EmergencyContact
+
+	if err := row.Scan(
+		&e.ID,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.PatientID,
+		&e.Name,
+		&e.Relation,
+		&e.Phone,
+		&e.AccountID,
+		&e.Priority,
+	); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,212 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientFamilyHistory(c context.Context, patientID interface{}, accountID int64, req *model.AddFamilyHistory) (*model.FamilyHistory, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceFamilyHistory}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientFamilyHistoryByID, func(st *PostgresStorage, _ *model.FamilyHistory) (int64, error) {
+		r, err := st.addPatientFamilyHistory(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientFamilyHistory(c context.Context, patientID interface{}, req *model.AddFamilyHistory) (*model.FamilyHistory, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientFamilyHistoryTableName).
+		Columns(
+			"profile_id",
+			"relative",
+			"relative_profile_id",
+			"icd10",
+			"name",
+			"onset_age",
+			"deceased",
+			"description",
+		).
+		Values(
+			patientID,
+			req.Relative,
+			storage.NullInt64(req.RelativeProfileID),
+			req.ICD10,
+			storage.NullString(req.Name),
+			storage.NullInt64(req.OnsetAge),
+			req.Deceased,
+			storage.NullString(req.Description),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientFamilyHistoryByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientFamilyHistory(c context.Context, patientID interface{}) ([]*model.FamilyHistory, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientFamilyHistoryResponseColumns()...).
+		From(patientFamilyHistoryTableName).
+		Where("profile_id = ?", patientID).
+		OrderBy("relative", "id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var fs []*model.FamilyHistory
+	for rows.Next() {
+		f, err := s.scanPatientFamilyHistory(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		fs = append(fs, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return fs, nil
+}
+
+func (s *PostgresStorage) GetPatientFamilyHistoryByID(c context.Context, id interface{}) (*model.FamilyHistory, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientFamilyHistoryResponseColumns()...).
+		From(patientFamilyHistoryTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	f, err := s.scanPatientFamilyHistory(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return f, nil
+}
+
+func (s *PostgresStorage) UpdatePatientFamilyHistory(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateFamilyHistory) (*model.FamilyHistory, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceFamilyHistory}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientFamilyHistoryByID, func(st *PostgresStorage, _ *model.FamilyHistory) (int64, error) {
+		r, err := st.updatePatientFamilyHistory(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientFamilyHistory(c context.Context, id interface{}, patientID interface{}, req *model.UpdateFamilyHistory) (*model.FamilyHistory, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientFamilyHistoryTableName).
+		Set("updated_at", time.Now()).
+		Set("relative", req.Relative).
+		Set("relative_profile_id", storage.NullInt64(req.RelativeProfileID)).
+		Set("icd10", req.ICD10).
+		Set("name", storage.NullString(req.Name)).
+		Set("onset_age", storage.NullInt64(req.OnsetAge)).
+		Set("deceased", req.Deceased).
+		Set("description", storage.NullString(req.Description)).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientFamilyHistoryByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientFamilyHistory(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceFamilyHistory}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientFamilyHistoryByID, func(st *PostgresStorage, r *model.FamilyHistory) (int64, error) {
+		return r.ID, st.deletePatientFamilyHistory(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientFamilyHistory(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientFamilyHistoryTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientFamilyHistoryResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "relative",
+		pre + "relative_profile_id",
+		pre + "icd10",
+		pre + "name",
+		pre + "onset_age",
+		pre + "deceased",
+		pre + "description",
+	}
+}
+
+func (s *PostgresStorage) scanPatientFamilyHistory(row squirrel.RowScanner) (*model.FamilyHistory, error) {
+	var f model.FamilyHistory
+
+	if err := row.Scan(
+		&f.ID,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+		&f.PatientID,
+		&f.Relative,
+		&f.RelativeProfileID,
+		&f.ICD10,
+		&f.Name,
+		&f.OnsetAge,
+		&f.Deceased,
+		&f.Description,
+	); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
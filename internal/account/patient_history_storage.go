@@ -0,0 +1,363 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+// patientHistoryEntry is who changed which resource of the patient
+type patientHistoryEntry struct {
+	patientID interface{}
+	accountID int64
+	resource  string
+}
+
+// changePatientResource runs change in a transaction and records the resource state read by get before and after it
+// in the same transaction, so a change is never saved without its history. The id is nil for a created resource,
+// the change gets the state before it and returns the id of the resource it worked on. A resource get can not find
+// afterwards is recorded as deleted.
+func changePatientResource[T any](c context.Context, s *PostgresStorage, e patientHistoryEntry, id interface{}, get func(st *PostgresStorage, c context.Context, id interface{}) (T, error), change func(st *PostgresStorage, before T) (int64, error)) (T, error) {
+	var before, after T
+
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return after, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	st := s.withTx(tx)
+
+	if id != nil {
+		if before, err = get(st, c, id); err != nil {
+			return after, err
+		}
+	}
+
+	resourceID, err := change(st, before)
+	if err != nil {
+		return after, err
+	}
+
+	after, err = get(st, c, resourceID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return after, err
+	}
+
+	if err := st.addPatientHistory(c, e, resourceID, before, after); err != nil {
+		return after, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return after, postgres.ConvertError(err)
+	}
+
+	return after, nil
+}
+
+// addPatientHistory records the change of the resource, nothing is written when no field worth recording changed
+func (s *PostgresStorage) addPatientHistory(c context.Context, e patientHistoryEntry, resourceID int64, before interface{}, after interface{}) error {
+	req, err := model.NewAddPatientHistory(e.accountID, e.resource, resourceID, before, after)
+	if err != nil {
+		return err
+	}
+
+	if req == nil {
+		return nil
+	}
+
+	return s.AddPatientHistory(c, e.patientID, req)
+}
+
+// addPatientHistoryChanges records every resource that differs between two states collected by GetPatientHistoryRecords
+func (s *PostgresStorage) addPatientHistoryChanges(c context.Context, patientID interface{}, accountID int64, before map[string][]map[string]interface{}, after map[string][]map[string]interface{}) error {
+	resources := make(map[string]bool)
+	for k := range before {
+		resources[k] = true
+	}
+	for k := range after {
+		resources[k] = true
+	}
+
+	for resource := range resources {
+		e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: resource}
+
+		old := historyRecordsByID(before[resource])
+		for id, r := range historyRecordsByID(after[resource]) {
+			if err := s.addPatientHistory(c, e, id, old[id], r); err != nil {
+				return err
+			}
+			delete(old, id)
+		}
+
+		for id, r := range old {
+			if err := s.addPatientHistory(c, e, id, r, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func historyRecordsByID(rs []map[string]interface{}) map[int64]map[string]interface{} {
+	m := make(map[int64]map[string]interface{}, len(rs))
+	for _, r := range rs {
+		if id, ok := r["id"].(float64); ok {
+			m[int64(id)] = r
+		}
+	}
+
+	return m
+}
+
+func (s *PostgresStorage) AddPatientHistory(c context.Context, patientID interface{}, req *model.AddPatientHistory) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	changes, err := json.Marshal(req.Changes)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := json.Marshal(req.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert(patientHistoryTableName).
+		Columns(
+			"created_at",
+			"profile_id",
+			"account_id",
+			"resource",
+			"resource_id",
+			"action",
+			"changes",
+			"snapshot",
+		).
+		Values(
+			time.Now(),
+			patientID,
+			req.AccountID,
+			req.Resource,
+			req.ResourceID,
+			req.Action,
+			string(changes),
+			string(snapshot),
+		).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetPatientHistory(c context.Context, patientID interface{}, req *model.ListPatientHistoryRequest) ([]*model.PatientHistory, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(s.patientHistoryResponseColumns()...).
+		From(patientHistoryTableName).
+		Where("profile_id = ?", patientID)
+
+	if req.Resource != nil {
+		q = q.Where("resource = ?", *req.Resource)
+	}
+
+	if req.ResourceID != nil {
+		q = q.Where("resource_id = ?", *req.ResourceID)
+	}
+
+	if req.From != nil {
+		q = q.Where("created_at >= ?", *req.From)
+	}
+
+	if req.To != nil {
+		q = q.Where("created_at <= ?", *req.To)
+	}
+
+	rows, err := q.OrderBy("created_at DESC", "id DESC").QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	return s.scanPatientHistories(rows)
+}
+
+// GetPatientHistoryAfter returns the changes made strictly after at, the ones to undo to see the profile as of at.
+func (s *PostgresStorage) GetPatientHistoryAfter(c context.Context, patientID interface{}, at time.Time) ([]*model.PatientHistory, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientHistoryResponseColumns()...).
+		From(patientHistoryTableName).
+		Where("profile_id = ?", patientID).
+		Where("created_at > ?", at).
+		OrderBy("created_at DESC", "id DESC").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	return s.scanPatientHistories(rows)
+}
+
+// GetPatientHistoryRecords collects the current state of every versioned resource of the patient
+func (s *PostgresStorage) GetPatientHistoryRecords(c context.Context, patientID interface{}) (map[string][]map[string]interface{}, error) {
+	p, err := s.GetPatientByID(c, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := model.NewHistoryRecord(model.NewPatientProfileRecord(p))
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[string][]map[string]interface{}{
+		model.PatientHistoryResourceProfile: {profile},
+	}
+
+	add := func(resource string, v interface{}, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rs, err := model.NewHistoryRecords(v)
+		if err != nil {
+			return err
+		}
+		records[resource] = rs
+
+		return nil
+	}
+
+	mcs, err := s.GetMetalComponents(c, p.ID)
+	if err := add(model.PatientHistoryResourceMetalComponent, mcs, err); err != nil {
+		return nil, err
+	}
+
+	ms, err := s.GetPatientMeasurements(c, p.ID, &model.ListMeasurementsRequest{})
+	if err := add(model.PatientHistoryResourceMeasurement, ms, err); err != nil {
+		return nil, err
+	}
+
+	as, err := s.GetPatientAllergies(c, p.ID)
+	if err := add(model.PatientHistoryResourceAllergy, as, err); err != nil {
+		return nil, err
+	}
+
+	cds, err := s.GetPatientConditions(c, p.ID)
+	if err := add(model.PatientHistoryResourceCondition, cds, err); err != nil {
+		return nil, err
+	}
+
+	mds, err := s.GetPatientMedications(c, p.ID, &model.ListMedicationsRequest{})
+	if err := add(model.PatientHistoryResourceMedication, mds, err); err != nil {
+		return nil, err
+	}
+
+	vs, err := s.GetPatientVaccinations(c, p.ID)
+	if err := add(model.PatientHistoryResourceVaccination, vs, err); err != nil {
+		return nil, err
+	}
+
+	ecs, err := s.GetPatientEmergencyContacts(c, p.ID)
+	if err := add(model.PatientHistoryResourceEmergencyContact, ecs, err); err != nil {
+		return nil, err
+	}
+
+	ips, err := s.GetPatientInsurancePolicies(c, p.ID, false)
+	if err := add(model.PatientHistoryResourceInsurancePolicy, ips, err); err != nil {
+		return nil, err
+	}
+
+	fs, err := s.GetPatientFamilyHistory(c, p.ID)
+	if err := add(model.PatientHistoryResourceFamilyHistory, fs, err); err != nil {
+		return nil, err
+	}
+
+	lrs, err := s.GetPatientLifestyleRecords(c, p.ID)
+	if err := add(model.PatientHistoryResourceLifestyle, lrs, err); err != nil {
+		return nil, err
+	}
+
+	ss, err := s.GetPatientSpecialists(c, p.ID)
+	if err := add(model.PatientHistoryResourceSpecialist, ss, err); err != nil {
+		return nil, err
+	}
+
+	ads, err := s.GetPatientProfileAdmins(c, p.ID)
+	if err := add(model.PatientHistoryResourceAdmin, ads, err); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (s *PostgresStorage) patientHistoryResponseColumns() []string {
+	return []string{
+		"id",
+		"created_at",
+		"profile_id",
+		"account_id",
+		"resource",
+		"resource_id",
+		"action",
+		"changes",
+		"snapshot",
+	}
+}
+
+func (s *PostgresStorage) scanPatientHistories(rows *sql.Rows) ([]*model.PatientHistory, error) {
+	var hs []*model.PatientHistory
+	for rows.Next() {
+		h, err := s.scanPatientHistory(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		hs = append(hs, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return hs, nil
+}
+
+func (s *PostgresStorage) scanPatientHistory(row squirrel.RowScanner) (*model.PatientHistory, error) {
+	var h model.PatientHistory
+	var changes, snapshot []byte
+
+	if err := row.Scan(
+		&h.ID,
+		&h.CreatedAt,
+		&h.PatientID,
+		&h.AccountID,
+		&h.Resource,
+		&h.ResourceID,
+		&h.Action,
+		&changes,
+		&snapshot,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(changes, &h.Changes); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(snapshot, &h.Snapshot); err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
@@ -0,0 +1,370 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"strings"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientInsurancePolicy(c context.Context, patientID interface{}, accountID int64, req *model.AddInsurancePolicy) (*model.InsurancePolicy, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceInsurancePolicy}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientInsurancePolicyByID, func(st *PostgresStorage, _ *model.InsurancePolicy) (int64, error) {
+		r, err := st.addPatientInsurancePolicy(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientInsurancePolicy(c context.Context, patientID interface{}, req *model.AddInsurancePolicy) (*model.InsurancePolicy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientInsurancePoliciesTableName).
+		Columns(
+			"profile_id",
+			"insurer",
+			"policy_number",
+			"coverage_type",
+			"valid_from",
+			"valid_to",
+		).
+		Values(
+			patientID,
+			req.Insurer,
+			req.PolicyNumber,
+			req.CoverageType,
+			req.ValidFrom,
+			storage.NullDatePGX(req.ValidTo),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := s.updatePatientInsurancePolicyFiles(c, id, req.Files); err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientInsurancePolicyByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientInsurancePolicies(c context.Context, patientID interface{}, active bool) ([]*model.InsurancePolicy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(s.patientInsurancePolicyResponseColumns()...).
+		From(patientInsurancePoliciesTableName).
+		Where("profile_id = ?", patientID)
+
+	if active {
+		q = q.Where("valid_from <= CURRENT_DATE AND (valid_to IS NULL OR valid_to >= CURRENT_DATE)")
+	}
+
+	rows, err := q.OrderBy("valid_from DESC", "id DESC").QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	ps, err := s.scanPatientInsurancePolicies(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range ps {
+		v.Files, err = s.GetPatientInsurancePolicyFiles(c, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ps, nil
+}
+
+func (s *PostgresStorage) GetPatientInsurancePolicyByID(c context.Context, id interface{}) (*model.InsurancePolicy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientInsurancePolicyResponseColumns()...).
+		From(patientInsurancePoliciesTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	p, err := s.scanPatientInsurancePolicy(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	p.Files, err = s.GetPatientInsurancePolicyFiles(c, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *PostgresStorage) UpdatePatientInsurancePolicy(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateInsurancePolicy) (*model.InsurancePolicy, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceInsurancePolicy}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientInsurancePolicyByID, func(st *PostgresStorage, _ *model.InsurancePolicy) (int64, error) {
+		r, err := st.updatePatientInsurancePolicy(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientInsurancePolicy(c context.Context, id interface{}, patientID interface{}, req *model.UpdateInsurancePolicy) (*model.InsurancePolicy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientInsurancePoliciesTableName).
+		Set("updated_at", time.Now()).
+		Set("insurer", req.Insurer).
+		Set("policy_number", req.PolicyNumber).
+		Set("coverage_type", req.CoverageType).
+		Set("valid_from", req.ValidFrom).
+		Set("valid_to", storage.NullDatePGX(req.ValidTo)).
+		Set("reminded_at", nil).
+		Set("expired_at", nil).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := s.updatePatientInsurancePolicyFiles(c, id, req.Files); err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientInsurancePolicyByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientInsurancePolicy(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceInsurancePolicy}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientInsurancePolicyByID, func(st *PostgresStorage, r *model.InsurancePolicy) (int64, error) {
+		return r.ID, st.deletePatientInsurancePolicy(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientInsurancePolicy(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientInsurancePoliciesTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// HasOverlappingPatientInsurancePolicy checks whether another policy of the same coverage type
+// is valid at any day of the given period, open ended periods last forever.
+func (s *PostgresStorage) HasOverlappingPatientInsurancePolicy(c context.Context, patientID interface{}, excludeID interface{}, req *model.AddInsurancePolicy) (bool, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select("1").
+		From(patientInsurancePoliciesTableName).
+		Where(squirrel.Eq{"profile_id": patientID, "coverage_type": req.CoverageType}).
+		Where("valid_from <= COALESCE(?::DATE, 'infinity'::DATE)", storage.NullDatePGX(req.ValidTo)).
+		Where("COALESCE(valid_to, 'infinity'::DATE) >= ?", req.ValidFrom)
+
+	if excludeID != nil {
+		q = q.Where("id <> ?", excludeID)
+	}
+
+	var exists bool
+	if err := psql.Select().Column(squirrel.Expr("EXISTS(?)", q)).QueryRowContext(c).Scan(&exists); err != nil {
+		return false, postgres.ConvertError(err)
+	}
+
+	return exists, nil
+}
+
+func (s *PostgresStorage) GetPatientInsurancePolicyFiles(c context.Context, policyID interface{}) ([]*model.File, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.fileResponseColumns(accountFilesTableName)...).
+		From(patientInsurancePolicyFilesTableName).
+		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+patientInsurancePolicyFilesTableName+".file_id").
+		Where(patientInsurancePolicyFilesTableName+".policy_id = ?", policyID).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var files []*model.File
+	for rows.Next() {
+		file, err := s.scanFile(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return files, nil
+}
+
+func (s *PostgresStorage) GetExpiringInsurancePolicies(c context.Context, before time.Time) ([]*model.InsurancePolicy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientInsurancePolicyResponseColumns()...).
+		From(patientInsurancePoliciesTableName).
+		Where(squirrel.Eq{"reminded_at": nil, "expired_at": nil}).
+		Where("valid_to IS NOT NULL AND valid_to >= CURRENT_DATE AND valid_to <= ?", before).
+		OrderBy("valid_to").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	return s.scanPatientInsurancePolicies(rows)
+}
+
+func (s *PostgresStorage) SetInsurancePolicyReminded(c context.Context, id interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientInsurancePoliciesTableName).
+		Set("reminded_at", time.Now()).
+		Where("id = ?", id).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) ExpireInsurancePolicies(c context.Context) ([]*model.InsurancePolicy, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Update(patientInsurancePoliciesTableName).
+		Set("expired_at", time.Now()).
+		Where(squirrel.Eq{"expired_at": nil}).
+		Where("valid_to IS NOT NULL AND valid_to < CURRENT_DATE").
+		Suffix("RETURNING " + strings.Join(s.patientInsurancePolicyResponseColumns(), ", ")).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	return s.scanPatientInsurancePolicies(rows)
+}
+
+func (s *PostgresStorage) updatePatientInsurancePolicyFiles(c context.Context, policyID interface{}, files []int64) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	if _, err := psql.Delete(patientInsurancePolicyFilesTableName).Where("policy_id = ?", policyID).ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	fq := psql.Insert(patientInsurancePolicyFilesTableName).Columns("policy_id", "file_id")
+	for _, v := range files {
+		fq = fq.Values(policyID, v)
+	}
+
+	if _, err := fq.ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientInsurancePolicyResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "insurer",
+		pre + "policy_number",
+		pre + "coverage_type",
+		pre + "valid_from",
+		pre + "valid_to",
+	}
+}
+
+func (s *PostgresStorage) scanPatientInsurancePolicies(rows *sql.Rows) ([]*model.InsurancePolicy, error) {
+	var ps []*model.InsurancePolicy
+	for rows.Next() {
+		p, err := s.scanPatientInsurancePolicy(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ps = append(ps, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ps, nil
+}
+
+func (s *PostgresStorage) scanPatientInsurancePolicy(row squirrel.RowScanner) (*model.InsurancePolicy, error) {
+	var p model.InsurancePolicy
+
+	if err := row.Scan(
+		&p.ID,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.PatientID,
+		&p.Insurer,
+		&p.PolicyNumber,
+		&p.CoverageType,
+		&p.ValidFrom,
+		&p.ValidTo,
+	); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,284 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientLifestyleRecord(c context.Context, patientID interface{}, accountID int64, req *model.AddLifestyleRecord) (*model.LifestyleRecord, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceLifestyle}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientLifestyleRecordByID, func(st *PostgresStorage, _ *model.LifestyleRecord) (int64, error) {
+		r, err := st.addPatientLifestyleRecord(c, patientID, accountID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientLifestyleRecord(c context.Context, patientID interface{}, accountID interface{}, req *model.AddLifestyleRecord) (*model.LifestyleRecord, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientLifestyleRecordsTableName).
+		Columns(
+			"profile_id",
+			"effective_date",
+			"smoking_status",
+			"pack_years",
+			"alcohol_units",
+			"activity_minutes",
+			"diet",
+			"entered_by",
+		).
+		Values(
+			patientID,
+			storage.NullDatePGX(req.EffectiveDate),
+			storage.NullString(req.SmokingStatus),
+			storage.NullFloat64(req.PackYears),
+			storage.NullInt64(req.AlcoholUnits),
+			storage.NullInt64(req.ActivityMinutes),
+			storage.NullString(req.Diet),
+			accountID,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := s.updatePatientLifestyleHazards(c, id, req.OccupationalHazards); err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientLifestyleRecordByID(c, id)
+}
+
+// GetPatientLifestyleRecords returns the records newest first
+func (s *PostgresStorage) GetPatientLifestyleRecords(c context.Context, patientID interface{}) ([]*model.LifestyleRecord, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientLifestyleRecordResponseColumns()...).
+		From(patientLifestyleRecordsTableName).
+		Where("profile_id = ?", patientID).
+		OrderBy("effective_date DESC", "id DESC").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var rs []*model.LifestyleRecord
+	for rows.Next() {
+		r, err := s.scanPatientLifestyleRecord(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		rs = append(rs, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	for _, v := range rs {
+		v.OccupationalHazards, err = s.getPatientLifestyleHazards(c, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rs, nil
+}
+
+func (s *PostgresStorage) GetPatientLifestyleRecordByID(c context.Context, id interface{}) (*model.LifestyleRecord, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientLifestyleRecordResponseColumns()...).
+		From(patientLifestyleRecordsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	r, err := s.scanPatientLifestyleRecord(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	r.OccupationalHazards, err = s.getPatientLifestyleHazards(c, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *PostgresStorage) UpdatePatientLifestyleRecord(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateLifestyleRecord) (*model.LifestyleRecord, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceLifestyle}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientLifestyleRecordByID, func(st *PostgresStorage, _ *model.LifestyleRecord) (int64, error) {
+		r, err := st.updatePatientLifestyleRecord(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientLifestyleRecord(c context.Context, id interface{}, patientID interface{}, req *model.UpdateLifestyleRecord) (*model.LifestyleRecord, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientLifestyleRecordsTableName).
+		Set("updated_at", time.Now()).
+		Set("effective_date", storage.NullDatePGX(req.EffectiveDate)).
+		Set("smoking_status", storage.NullString(req.SmokingStatus)).
+		Set("pack_years", storage.NullFloat64(req.PackYears)).
+		Set("alcohol_units", storage.NullInt64(req.AlcoholUnits)).
+		Set("activity_minutes", storage.NullInt64(req.ActivityMinutes)).
+		Set("diet", storage.NullString(req.Diet)).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := s.updatePatientLifestyleHazards(c, id, req.OccupationalHazards); err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientLifestyleRecordByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientLifestyleRecord(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceLifestyle}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientLifestyleRecordByID, func(st *PostgresStorage, r *model.LifestyleRecord) (int64, error) {
+		return r.ID, st.deletePatientLifestyleRecord(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientLifestyleRecord(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientLifestyleRecordsTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) getPatientLifestyleHazards(c context.Context, recordID interface{}) ([]string, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select("hazard").
+		From(patientLifestyleHazardsTableName).
+		Where("record_id = ?", recordID).
+		OrderBy("hazard").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var hazards []string
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		hazards = append(hazards, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return hazards, nil
+}
+
+func (s *PostgresStorage) updatePatientLifestyleHazards(c context.Context, recordID interface{}, hazards []string) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	if _, err := psql.Delete(patientLifestyleHazardsTableName).Where("record_id = ?", recordID).ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	if len(hazards) == 0 {
+		return nil
+	}
+
+	q := psql.Insert(patientLifestyleHazardsTableName).Columns("record_id", "hazard")
+	for _, v := range hazards {
+		q = q.Values(recordID, v)
+	}
+
+	if _, err := q.ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientLifestyleRecordResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "effective_date",
+		pre + "smoking_status",
+		pre + "pack_years",
+		pre + "alcohol_units",
+		pre + "activity_minutes",
+		pre + "diet",
+		pre + "entered_by",
+	}
+}
+
+func (s *PostgresStorage) scanPatientLifestyleRecord(row squirrel.RowScanner) (*model.LifestyleRecord, error) {
+	var r model.LifestyleRecord
+
+	if err := row.Scan(
+		&r.ID,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.PatientID,
+		&r.EffectiveDate,
+		&r.SmokingStatus,
+		&r.PackYears,
+		&r.AlcoholUnits,
+		&r.ActivityMinutes,
+		&r.Diet,
+		&r.EnteredBy,
+	); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
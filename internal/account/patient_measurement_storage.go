@@ -0,0 +1,275 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+func (s *PostgresStorage) AddPatientMeasurement(c context.Context, patientID interface{}, accountID int64, req *model.AddMeasurement) (*model.Measurement, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMeasurement}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientMeasurementByID, func(st *PostgresStorage, _ *model.Measurement) (int64, error) {
+		r, err := st.addPatientMeasurement(c, patientID, accountID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientMeasurement(c context.Context, patientID interface{}, accountID int64, req *model.AddMeasurement) (*model.Measurement, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientMeasurementsTableName).
+		Columns(
+			"profile_id",
+			"type",
+			"value",
+			"unit",
+			"measured_at",
+			"source",
+			"entered_by",
+		).
+		Values(
+			patientID,
+			req.Type,
+			req.Value,
+			req.Unit,
+			req.MeasuredAt,
+			req.Source,
+			accountID,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := s.updatePatientLatestMeasurement(c, patientID, accountID, req.Type); err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientMeasurementByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientMeasurements(c context.Context, patientID interface{}, req *model.ListMeasurementsRequest) ([]*model.Measurement, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(s.patientMeasurementResponseColumns()...).
+		From(patientMeasurementsTableName).
+		Where("profile_id = ?", patientID)
+
+	if req.Type != nil {
+		q = q.Where("type = ?", *req.Type)
+	}
+
+	if req.From != nil {
+		q = q.Where("measured_at >= ?", *req.From)
+	}
+
+	if req.To != nil {
+		q = q.Where("measured_at <= ?", *req.To)
+	}
+
+	rows, err := q.OrderBy("measured_at DESC", "id DESC").QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ms []*model.Measurement
+	for rows.Next() {
+		m, err := s.scanPatientMeasurement(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ms = append(ms, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ms, nil
+}
+
+func (s *PostgresStorage) GetPatientMeasurementByID(c context.Context, id interface{}) (*model.Measurement, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientMeasurementResponseColumns()...).
+		From(patientMeasurementsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	m, err := s.scanPatientMeasurement(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return m, nil
+}
+
+func (s *PostgresStorage) GetPatientMeasurementChart(c context.Context, patientID interface{}, req *model.MeasurementChartRequest) ([]*model.MeasurementChartPoint, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(
+		"DATE_TRUNC('"+req.Interval+"', measured_at) AS period",
+		"COUNT(*)",
+		"MIN(value)",
+		"MAX(value)",
+		"ROUND(AVG(value), 3)",
+		"(ARRAY_AGG(value ORDER BY measured_at DESC, id DESC))[1]",
+	).
+		From(patientMeasurementsTableName).
+		Where("profile_id = ? AND type = ?", patientID, req.Type)
+
+	if req.From != nil {
+		q = q.Where("measured_at >= ?", *req.From)
+	}
+
+	if req.To != nil {
+		q = q.Where("measured_at <= ?", *req.To)
+	}
+
+	rows, err := q.GroupBy("period").
+		OrderBy("period").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ps []*model.MeasurementChartPoint
+	for rows.Next() {
+		var p model.MeasurementChartPoint
+
+		if err := rows.Scan(
+			&p.Period,
+			&p.Count,
+			&p.Min,
+			&p.Max,
+			&p.Avg,
+			&p.Last,
+		); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ps = append(ps, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ps, nil
+}
+
+func (s *PostgresStorage) DeletePatientMeasurement(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMeasurement}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientMeasurementByID, func(st *PostgresStorage, r *model.Measurement) (int64, error) {
+		return r.ID, st.deletePatientMeasurement(c, id, patientID, accountID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientMeasurement(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	var t string
+	err := psql.Delete(patientMeasurementsTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		Suffix("RETURNING \"type\"").
+		QueryRowContext(c).
+		Scan(&t)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return s.updatePatientLatestMeasurement(c, patientID, accountID, t)
+}
+
+// updatePatientLatestMeasurement copies the most recent measurement of the type to the profile column of the same name,
+// the profile change is recorded in the history on behalf of the account that added or deleted the measurement
+func (s *PostgresStorage) updatePatientLatestMeasurement(c context.Context, patientID interface{}, accountID int64, measurementType string) error {
+	switch measurementType {
+	case model.MeasurementTypeHeight, model.MeasurementTypeWeight, model.MeasurementTypeLeftEye, model.MeasurementTypeRightEye:
+	default:
+		return storage.ErrNotFound
+	}
+
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceProfile}
+
+	_, err := changePatientResource(c, s, e, patientID, (*PostgresStorage).getPatientProfileRecord, func(st *PostgresStorage, r *model.PatientProfileRecord) (int64, error) {
+		return r.ID, st.setPatientLatestMeasurement(c, patientID, measurementType)
+	})
+	return err
+}
+
+func (s *PostgresStorage) setPatientLatestMeasurement(c context.Context, patientID interface{}, measurementType string) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	latest := squirrel.Expr(
+		"(SELECT value FROM "+patientMeasurementsTableName+" WHERE profile_id = ? AND type = ? ORDER BY measured_at DESC, id DESC LIMIT 1)",
+		patientID,
+		measurementType,
+	)
+
+	res, err := psql.Update(patientProfilesTableName).
+		Set(measurementType, latest).
+		Where("id = ?", patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientMeasurementResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "profile_id",
+		pre + "type",
+		pre + "value",
+		pre + "unit",
+		pre + "measured_at",
+		pre + "source",
+		pre + "entered_by",
+	}
+}
+
+func (s *PostgresStorage) scanPatientMeasurement(row squirrel.RowScanner) (*model.Measurement, error) {
+	var m model.Measurement
+
+	if err := row.Scan(
+		&m.ID,
+		&m.CreatedAt,
+		&m.PatientID,
+		&m.Type,
+		&m.Value,
+		&m.Unit,
+		&m.MeasuredAt,
+		&m.Source,
+		&m.EnteredBy,
+	); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
@@ -0,0 +1,260 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientMedication(c context.Context, patientID interface{}, accountID int64, req *model.AddMedication) (*model.Medication, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMedication}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientMedicationByID, func(st *PostgresStorage, _ *model.Medication) (int64, error) {
+		r, err := st.addPatientMedication(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientMedication(c context.Context, patientID interface{}, req *model.AddMedication) (*model.Medication, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientMedicationsTableName).
+		Columns(
+			"profile_id",
+			"name",
+			"active_substance",
+			"dose",
+			"frequency",
+			"start",
+			"finish",
+			"prescribed_by",
+		).
+		Values(
+			patientID,
+			req.Name,
+			storage.NullString(req.ActiveSubstance),
+			req.Dose,
+			req.Frequency,
+			req.Start,
+			storage.NullDatePGX(req.Finish),
+			storage.NullInt64(req.PrescribedBy),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetPatientMedicationByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientMedications(c context.Context, patientID interface{}, req *model.ListMedicationsRequest) ([]*model.Medication, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(s.patientMedicationResponseColumns()...).
+		From(patientMedicationsTableName).
+		Where("profile_id = ?", patientID).
+		OrderBy("start DESC", "id DESC")
+
+	if req.Status != nil {
+		switch *req.Status {
+		case model.MedicationStatusCurrent:
+			q = q.Where("(finish IS NULL OR finish > CURRENT_DATE)")
+		case model.MedicationStatusStopped:
+			q = q.Where("finish <= CURRENT_DATE")
+		}
+	}
+
+	rows, err := q.QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ms []*model.Medication
+	for rows.Next() {
+		m, err := s.scanPatientMedication(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ms = append(ms, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ms, nil
+}
+
+func (s *PostgresStorage) GetPatientMedicationByID(c context.Context, id interface{}) (*model.Medication, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientMedicationResponseColumns()...).
+		From(patientMedicationsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	m, err := s.scanPatientMedication(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return m, nil
+}
+
+func (s *PostgresStorage) UpdatePatientMedication(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateMedication) (*model.Medication, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMedication}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientMedicationByID, func(st *PostgresStorage, _ *model.Medication) (int64, error) {
+		r, err := st.updatePatientMedication(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientMedication(c context.Context, id interface{}, patientID interface{}, req *model.UpdateMedication) (*model.Medication, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientMedicationsTableName).
+		Set("updated_at", time.Now()).
+		Set("name", req.Name).
+		Set("active_substance", storage.NullString(req.ActiveSubstance)).
+		Set("dose", req.Dose).
+		Set("frequency", req.Frequency).
+		Set("start", req.Start).
+		Set("finish", storage.NullDatePGX(req.Finish)).
+		Set("prescribed_by", storage.NullInt64(req.PrescribedBy)).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientMedicationByID(c, id)
+}
+
+func (s *PostgresStorage) StopPatientMedication(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.StopMedication) (*model.Medication, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMedication}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientMedicationByID, func(st *PostgresStorage, _ *model.Medication) (int64, error) {
+		r, err := st.stopPatientMedication(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) stopPatientMedication(c context.Context, id interface{}, patientID interface{}, req *model.StopMedication) (*model.Medication, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientMedicationsTableName).
+		Set("updated_at", time.Now()).
+		Set("finish", storage.NullDatePGX(req.Finish)).
+		Set("stop_reason", storage.NullString(req.Reason)).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientMedicationByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientMedication(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMedication}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientMedicationByID, func(st *PostgresStorage, r *model.Medication) (int64, error) {
+		return r.ID, st.deletePatientMedication(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientMedication(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientMedicationsTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientMedicationResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "name",
+		pre + "active_substance",
+		pre + "dose",
+		pre + "frequency",
+		pre + "start",
+		pre + "finish",
+		pre + "prescribed_by",
+		pre + "stop_reason",
+	}
+}
+
+func (s *PostgresStorage) scanPatientMedication(row squirrel.RowScanner) (*model.Medication, error) {
+	var m model.Medication
+
+	if err := row.Scan(
+		&m.ID,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&m.PatientID,
+		&m.Name,
+		&m.ActiveSubstance,
+		&m.Dose,
+		&m.Frequency,
+		&m.Start,
+		&m.Finish,
+		&m.PrescribedBy,
+		&m.StopReason,
+	); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
@@ -6,23 +6,48 @@ import (
 	"github.com/Hvaekar/med-account/pkg/storage"
 	"github.com/Hvaekar/med-account/pkg/storage/postgres"
 	"github.com/Masterminds/squirrel"
+	"time"
 )
 
-func (s *PostgresStorage) AddMetalComponent(c context.Context, patientID interface{}, req *model.AddMetalComponent) (*model.MetalComponent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+func (s *PostgresStorage) AddMetalComponent(c context.Context, patientID interface{}, accountID int64, req *model.AddMetalComponent) (*model.MetalComponent, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMetalComponent}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetMetalComponentByID, func(st *PostgresStorage, _ *model.MetalComponent) (int64, error) {
+		r, err := st.addMetalComponent(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addMetalComponent(c context.Context, patientID interface{}, req *model.AddMetalComponent) (*model.MetalComponent, error) {
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(patientMetalComponentsTableName).
 		Columns(
 			"profile_id",
+			"type",
 			"metal",
 			"organ_id",
 			"description",
+			"manufacturer",
+			"model",
+			"implanted",
+			"mri_safety",
+			"mri_conditions",
 		).
 		Values(
 			patientID,
+			req.Type,
 			storage.NullString(req.Metal),
 			req.OrganID,
 			storage.NullString(req.Description),
+			storage.NullString(req.Manufacturer),
+			storage.NullString(req.Model),
+			storage.NullDatePGX(req.Implanted),
+			storage.NullString(req.MRISafety),
+			storage.NullString(req.MRIConditions),
 		).
 		Suffix("RETURNING \"id\"")
 
@@ -31,15 +56,20 @@ func (s *PostgresStorage) AddMetalComponent(c context.Context, patientID interfa
 		return nil, postgres.ConvertError(err)
 	}
 
+	if err := s.updateMetalComponentFiles(c, id, req.Files); err != nil {
+		return nil, err
+	}
+
 	return s.GetMetalComponentByID(c, id)
 }
 
 func (s *PostgresStorage) GetMetalComponents(c context.Context, patientID interface{}) ([]*model.MetalComponent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.patientMetalComponentsResponseColumns()...).
 		From(patientMetalComponentsTableName).
 		Where("profile_id = ?", patientID).
+		OrderBy("id").
 		QueryContext(c)
 	if err != nil {
 		return nil, postgres.ConvertError(err)
@@ -59,11 +89,18 @@ func (s *PostgresStorage) GetMetalComponents(c context.Context, patientID interf
 		return nil, postgres.ConvertError(err)
 	}
 
+	for _, v := range mcs {
+		v.Files, err = s.GetMetalComponentFiles(c, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return mcs, nil
 }
 
 func (s *PostgresStorage) GetMetalComponentByID(c context.Context, id interface{}) (*model.MetalComponent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.patientMetalComponentsResponseColumns()...).
 		From(patientMetalComponentsTableName).
@@ -75,17 +112,40 @@ func (s *PostgresStorage) GetMetalComponentByID(c context.Context, id interface{
 		return nil, postgres.ConvertError(err)
 	}
 
+	mc.Files, err = s.GetMetalComponentFiles(c, mc.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return mc, nil
 }
 
-func (s *PostgresStorage) UpdateMetalComponent(c context.Context, id interface{}, patientID interface{}, req *model.UpdateMetalComponent) (*model.MetalComponent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+func (s *PostgresStorage) UpdateMetalComponent(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateMetalComponent) (*model.MetalComponent, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMetalComponent}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetMetalComponentByID, func(st *PostgresStorage, _ *model.MetalComponent) (int64, error) {
+		r, err := st.updateMetalComponent(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updateMetalComponent(c context.Context, id interface{}, patientID interface{}, req *model.UpdateMetalComponent) (*model.MetalComponent, error) {
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(patientMetalComponentsTableName).
-		//Set("updated_at", time.Now()).
+		Set("updated_at", time.Now()).
+		Set("type", req.Type).
 		Set("metal", storage.NullString(req.Metal)).
 		Set("organ_id", req.OrganID).
 		Set("description", storage.NullString(req.Description)).
+		Set("manufacturer", storage.NullString(req.Manufacturer)).
+		Set("model", storage.NullString(req.Model)).
+		Set("implanted", storage.NullDatePGX(req.Implanted)).
+		Set("mri_safety", storage.NullString(req.MRISafety)).
+		Set("mri_conditions", storage.NullString(req.MRIConditions)).
 		Where("id = ? AND profile_id = ?", id, patientID).
 		ExecContext(c)
 	if err != nil {
@@ -100,12 +160,16 @@ func (s *PostgresStorage) UpdateMetalComponent(c context.Context, id interface{}
 		return nil, storage.ErrNotFound
 	}
 
+	if err := s.updateMetalComponentFiles(c, id, req.Files); err != nil {
+		return nil, err
+	}
+
 	return s.GetMetalComponentByID(c, id)
 }
 
 func (s *PostgresStorage) UpdateMetalComponentFields(c context.Context, id interface{}, patientID interface{}, req model.UpdateMetalComponentFields) (*model.MetalComponent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
-	//req["updated_at"] = time.Now()
+	psql := s.SetFormat().RunWith(s.runner())
+	req["updated_at"] = time.Now()
 
 	res, err := psql.Update(patientMetalComponentsTableName).
 		SetMap(req).
@@ -126,8 +190,17 @@ func (s *PostgresStorage) UpdateMetalComponentFields(c context.Context, id inter
 	return s.GetMetalComponentByID(c, id)
 }
 
-func (s *PostgresStorage) DeleteMetalComponent(c context.Context, id interface{}, patientID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+func (s *PostgresStorage) DeleteMetalComponent(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceMetalComponent}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetMetalComponentByID, func(st *PostgresStorage, r *model.MetalComponent) (int64, error) {
+		return r.ID, st.deleteMetalComponent(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deleteMetalComponent(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(patientMetalComponentsTableName).
 		Where("id = ? AND profile_id = ?", id, patientID).
@@ -147,15 +220,75 @@ func (s *PostgresStorage) DeleteMetalComponent(c context.Context, id interface{}
 	return nil
 }
 
+func (s *PostgresStorage) GetMetalComponentFiles(c context.Context, componentID interface{}) ([]*model.File, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.fileResponseColumns(accountFilesTableName)...).
+		From(patientMetalComponentFilesTableName).
+		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+patientMetalComponentFilesTableName+".file_id").
+		Where(patientMetalComponentFilesTableName+".component_id = ?", componentID).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var files []*model.File
+	for rows.Next() {
+		file, err := s.scanFile(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return files, nil
+}
+
+func (s *PostgresStorage) updateMetalComponentFiles(c context.Context, componentID interface{}, files []int64) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	if _, err := psql.Delete(patientMetalComponentFilesTableName).Where("component_id = ?", componentID).ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	fq := psql.Insert(patientMetalComponentFilesTableName).Columns("component_id", "file_id")
+	for _, v := range files {
+		fq = fq.Values(componentID, v)
+	}
+
+	if _, err := fq.ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
 func (s *PostgresStorage) patientMetalComponentsResponseColumns(prefixes ...string) []string {
 	pre := joinPrefixes(prefixes, ".")
 
 	return []string{
 		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
 		pre + "profile_id",
+		pre + "type",
 		pre + "metal",
 		pre + "organ_id",
 		pre + "description",
+		pre + "manufacturer",
+		pre + "model",
+		pre + "implanted",
+		pre + "mri_safety",
+		pre + "mri_conditions",
 	}
 }
 
@@ -164,10 +297,18 @@ func (s *PostgresStorage) scanMetalComponent(row squirrel.RowScanner) (*model.Me
 
 	if err := row.Scan(
 		&mc.ID,
+		&mc.CreatedAt,
+		&mc.UpdatedAt,
 		&mc.PatientID,
+		&mc.Type,
 		&mc.Metal,
 		&mc.OrganID,
 		&mc.Description,
+		&mc.Manufacturer,
+		&mc.Model,
+		&mc.Implanted,
+		&mc.MRISafety,
+		&mc.MRIConditions,
 	); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,209 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientSpecialist(c context.Context, patientID interface{}, accountID int64, req *model.AddPatientSpecialist) (*model.PatientSpecialist, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceSpecialist}
+
+	return changePatientResource(c, s, e, nil, patientSpecialistGetter(patientID), func(st *PostgresStorage, _ *model.PatientSpecialist) (int64, error) {
+		r, err := st.addPatientSpecialist(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientSpecialist(c context.Context, patientID interface{}, req *model.AddPatientSpecialist) (*model.PatientSpecialist, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Insert(patientSpecialistsTableName).
+		Columns(
+			"profile_id",
+			"specialist_id",
+		).
+		Values(
+			patientID,
+			req.SpecialistID,
+		).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientSpecialistByID(c, patientID, req.SpecialistID)
+}
+
+func (s *PostgresStorage) GetPatientSpecialists(c context.Context, patientID interface{}) ([]*model.PatientSpecialist, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientSpecialistResponseColumns()...).
+		From(patientSpecialistsTableName).
+		LeftJoin(specialistProfilesTableName+" ON "+specialistProfilesTableName+".id = "+patientSpecialistsTableName+".specialist_id").
+		LeftJoin(accountsTableName+" ON "+accountsTableName+".id = "+specialistProfilesTableName+".account_id").
+		Where(patientSpecialistsTableName+".profile_id = ?", patientID).
+		OrderBy(patientSpecialistsTableName + ".created_at").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ss []*model.PatientSpecialist
+	for rows.Next() {
+		sp, err := s.scanPatientSpecialist(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ss = append(ss, sp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ss, nil
+}
+
+func (s *PostgresStorage) GetPatientSpecialistByID(c context.Context, patientID interface{}, specialistID interface{}) (*model.PatientSpecialist, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientSpecialistResponseColumns()...).
+		From(patientSpecialistsTableName).
+		LeftJoin(specialistProfilesTableName+" ON "+specialistProfilesTableName+".id = "+patientSpecialistsTableName+".specialist_id").
+		LeftJoin(accountsTableName+" ON "+accountsTableName+".id = "+specialistProfilesTableName+".account_id").
+		Where(patientSpecialistsTableName+".profile_id = ? AND "+patientSpecialistsTableName+".specialist_id = ?", patientID, specialistID).
+		QueryRowContext(c)
+
+	sp, err := s.scanPatientSpecialist(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return sp, nil
+}
+
+// patientSpecialistGetter reads the specialist of the patient by the specialist id it is recorded under in the history
+func patientSpecialistGetter(patientID interface{}) func(st *PostgresStorage, c context.Context, id interface{}) (*model.PatientSpecialist, error) {
+	return func(st *PostgresStorage, c context.Context, id interface{}) (*model.PatientSpecialist, error) {
+		return st.GetPatientSpecialistByID(c, patientID, id)
+	}
+}
+
+// ConfirmPatientSpecialist is called by the specialist, a confirmed link stays confirmed
+func (s *PostgresStorage) ConfirmPatientSpecialist(c context.Context, patientID interface{}, accountID int64, specialistID interface{}) (*model.PatientSpecialist, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceSpecialist}
+
+	return changePatientResource(c, s, e, specialistID, patientSpecialistGetter(patientID), func(st *PostgresStorage, _ *model.PatientSpecialist) (int64, error) {
+		r, err := st.confirmPatientSpecialist(c, patientID, specialistID)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) confirmPatientSpecialist(c context.Context, patientID interface{}, specialistID interface{}) (*model.PatientSpecialist, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientSpecialistsTableName).
+		Set("confirmed_at", squirrel.Expr("COALESCE(confirmed_at, ?)", time.Now())).
+		Where("profile_id = ? AND specialist_id = ?", patientID, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetPatientSpecialistByID(c, patientID, specialistID)
+}
+
+func (s *PostgresStorage) DeletePatientSpecialist(c context.Context, patientID interface{}, accountID int64, specialistID interface{}) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceSpecialist}
+
+	_, err := changePatientResource(c, s, e, specialistID, patientSpecialistGetter(patientID), func(st *PostgresStorage, r *model.PatientSpecialist) (int64, error) {
+		return r.ID, st.deletePatientSpecialist(c, patientID, specialistID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientSpecialist(c context.Context, patientID interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientSpecialistsTableName).
+		Where("profile_id = ? AND specialist_id = ?", patientID, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientSpecialistResponseColumns() []string {
+	fields := make([]string, 0)
+
+	fields = append(s.patientSpecialistsResponseColumns(patientSpecialistsTableName), s.accountPatientProfileResponseColumns(accountsTableName)...)
+
+	return fields
+}
+
+func (s *PostgresStorage) patientSpecialistsResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "specialist_id",
+		pre + "profile_id",
+		pre + "created_at",
+		pre + "confirmed_at",
+	}
+}
+
+func (s *PostgresStorage) scanPatientSpecialist(row squirrel.RowScanner) (*model.PatientSpecialist, error) {
+	var sp model.PatientSpecialist
+
+	if err := row.Scan(
+		&sp.ID,
+		&sp.ProfileID,
+		&sp.CreatedAt,
+		&sp.ConfirmedAt,
+		&sp.FirstName,
+		&sp.FatherName,
+		&sp.LastName,
+		&sp.Photo,
+	); err != nil {
+		return nil, err
+	}
+
+	return &sp, nil
+}
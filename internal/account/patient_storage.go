@@ -11,14 +11,13 @@ import (
 )
 
 func (s *PostgresStorage) GetPatientByID(c context.Context, id interface{}) (*model.Patient, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.patientResponseColumns()...).
 		From(patientProfilesTableName).
 		LeftJoin(accountsTableName + " ON " + accountsTableName + ".id = " + patientProfilesTableName + ".account_id").
 		LeftJoin(accountPhonesTableName + " ON " + accountPhonesTableName + ".id = " + patientProfilesTableName + ".phone_id AND " + patientProfilesTableName + ".phone_id IS NOT NULL").
 		LeftJoin(accountEmailsTableName + " ON " + accountEmailsTableName + ".id = " + patientProfilesTableName + ".email_id AND " + patientProfilesTableName + ".email_id IS NOT NULL").
-		LeftJoin(patientMetalComponentsTableName + " ON " + patientMetalComponentsTableName + ".profile_id = " + patientProfilesTableName + ".id").
 		LeftJoin(patientDisabilityFilesTableName + " ON " + patientDisabilityFilesTableName + ".profile_id = " + patientProfilesTableName + ".id").
 		LeftJoin(accountFilesTableName + " ON " + accountFilesTableName + ".id = " + patientDisabilityFilesTableName + ".file_id").
 		Where(squirrel.Eq{patientProfilesTableName + ".id": id, accountsTableName + ".deleted_at": nil}).
@@ -37,16 +36,42 @@ func (s *PostgresStorage) GetPatientByID(c context.Context, id interface{}) (*mo
 		return nil, storage.ErrNotFound
 	}
 
+	p.MetalComponents, err = s.GetMetalComponents(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	p.EmergencyContacts, err = s.GetPatientEmergencyContacts(c, id)
+	if err != nil {
+		return nil, err
+	}
+
 	p.Admins, err = s.GetPatientProfileAdmins(c, id)
 	if err != nil {
 		return nil, err
 	}
 
+	p.Allergies, err = s.GetPatientAllergies(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	p.FamilyHistory, err = s.GetPatientFamilyHistory(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lrs, err := s.GetPatientLifestyleRecords(c, id)
+	if err != nil {
+		return nil, err
+	}
+	p.CurrentLifestyle = model.NewLifestyleSummary(lrs)
+
 	return p, nil
 }
 
 func (s *PostgresStorage) GetPatients(c context.Context, req *model.ListPatientsRequest) ([]*model.Patient, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Select(s.patientsResponseColumns()...).
 		From(patientProfilesTableName).
@@ -75,8 +100,31 @@ func (s *PostgresStorage) GetPatients(c context.Context, req *model.ListPatients
 	return ps, nil
 }
 
-func (s *PostgresStorage) UpdatePatientProfile(c context.Context, id interface{}, req model.UpdatePatientProfile) (*model.Patient, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+// getPatientProfileRecord reads the profile the way it is versioned in the history
+func (s *PostgresStorage) getPatientProfileRecord(c context.Context, id interface{}) (*model.PatientProfileRecord, error) {
+	p, err := s.GetPatientByID(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewPatientProfileRecord(p), nil
+}
+
+func (s *PostgresStorage) UpdatePatientProfile(c context.Context, id interface{}, accountID int64, req model.UpdatePatientProfile) (*model.Patient, error) {
+	e := patientHistoryEntry{patientID: id, accountID: accountID, resource: model.PatientHistoryResourceProfile}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).getPatientProfileRecord, func(st *PostgresStorage, r *model.PatientProfileRecord) (int64, error) {
+		return r.ID, st.updatePatientProfile(c, id, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientByID(c, id)
+}
+
+func (s *PostgresStorage) updatePatientProfile(c context.Context, id interface{}, req model.UpdatePatientProfile) error {
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(patientProfilesTableName).
 		Set("updated_at", time.Now()).
@@ -98,26 +146,22 @@ func (s *PostgresStorage) UpdatePatientProfile(c context.Context, id interface{}
 		Where("id = ?", id).
 		ExecContext(c)
 	if err != nil {
-		return nil, postgres.ConvertError(err)
+		return postgres.ConvertError(err)
 	}
 
 	ra, err := res.RowsAffected()
 	if err != nil {
-		return nil, postgres.ConvertError(err)
+		return postgres.ConvertError(err)
 	}
 	if ra == 0 {
-		return nil, storage.ErrNotFound
+		return storage.ErrNotFound
 	}
 
-	if err := s.UpdatePatientProfileDisabilityFiles(id, req.DisabilityFiles); err != nil {
-		return nil, err
-	}
-
-	return s.GetPatientByID(c, id)
+	return s.UpdatePatientProfileDisabilityFiles(id, req.DisabilityFiles)
 }
 
 func (s *PostgresStorage) UpdatePatientProfileDisabilityFiles(patientID interface{}, req []*model.File) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	_, err := psql.Delete(patientDisabilityFilesTableName).Where("profile_id = ?", patientID).Exec()
 	if err != nil {
@@ -149,7 +193,7 @@ func (s *PostgresStorage) UpdatePatientProfileDisabilityFiles(patientID interfac
 }
 
 func (s *PostgresStorage) UpdatePatientProfileFields(c context.Context, id interface{}, req model.UpdatePatientProfileFields) (*model.Patient, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	req["updated_at"] = time.Now()
 
 	res, err := psql.Update(patientProfilesTableName).
@@ -174,10 +218,9 @@ func (s *PostgresStorage) UpdatePatientProfileFields(c context.Context, id inter
 func (s *PostgresStorage) patientResponseColumns() []string {
 	fields := make([]string, 0)
 
-	fields = append(s.patientResponseColumnsMain(patientProfilesTableName), s.patientAccountResponseColumns(accountsTableName)...)
+	fields = append(s.patientResponseColumnsMain(patientProfilesTableName), s.patientAccountResponseColumns(patientProfilesTableName, accountsTableName)...)
 	fields = append(fields, s.patientPhoneResponseColumns(accountPhonesTableName)...)
 	fields = append(fields, s.patientEmailResponseColumns(accountEmailsTableName)...)
-	fields = append(fields, s.patientMetalComponentsResponseColumns(patientMetalComponentsTableName)...)
 	fields = append(fields, s.fileResponseColumns(accountFilesTableName)...)
 
 	return fields
@@ -186,7 +229,7 @@ func (s *PostgresStorage) patientResponseColumns() []string {
 func (s *PostgresStorage) patientsResponseColumns() []string {
 	fields := make([]string, 0)
 
-	fields = append(s.patientsResponseColumnsMain(patientProfilesTableName), s.patientAccountResponseColumns(accountsTableName)...)
+	fields = append(s.patientsResponseColumnsMain(patientProfilesTableName), s.patientAccountResponseColumns(patientProfilesTableName, accountsTableName)...)
 	fields = append(fields, s.patientPhoneResponseColumns(accountPhonesTableName)...)
 	fields = append(fields, s.patientEmailResponseColumns(accountEmailsTableName)...)
 
@@ -199,6 +242,7 @@ func (s *PostgresStorage) patientResponseColumnsMain(prefixes ...string) []strin
 	return []string{
 		pre + "id",
 		pre + "account_id",
+		pre + "owner_id",
 		//pre + "phone_id",
 		//pre + "email_id",
 		pre + "height",
@@ -222,19 +266,20 @@ func (s *PostgresStorage) patientsResponseColumnsMain(prefixes ...string) []stri
 
 	return []string{
 		pre + "id",
+		pre + "account_id",
+		pre + "owner_id",
 	}
 }
 
-func (s *PostgresStorage) patientAccountResponseColumns(prefixes ...string) []string {
-	pre := joinPrefixes(prefixes, ".")
-
+// patientAccountResponseColumns takes personal data from the dependent profile until it is handed over to an account.
+func (s *PostgresStorage) patientAccountResponseColumns(profile string, account string) []string {
 	return []string{
-		pre + "first_name",
-		pre + "father_name",
-		pre + "last_name",
-		pre + "sex",
-		pre + "photo",
-		pre + "birthday",
+		"COALESCE(" + profile + ".first_name, " + account + ".first_name)",
+		"COALESCE(" + profile + ".father_name, " + account + ".father_name)",
+		"COALESCE(" + profile + ".last_name, " + account + ".last_name)",
+		"COALESCE(" + profile + ".sex, " + account + ".sex)",
+		account + ".photo",
+		"COALESCE(" + profile + ".birthday, " + account + ".birthday)",
 	}
 }
 
@@ -257,18 +302,18 @@ func (s *PostgresStorage) patientEmailResponseColumns(prefixes ...string) []stri
 
 func (s *PostgresStorage) scanPatient(rows *sql.Rows) (*model.Patient, error) {
 	var p model.Patient
-	mcs := make(map[int64]*model.MetalComponent)
+	var accountID, ownerID *int64
 	files := make(map[int64]*model.File)
 
 	for rows.Next() {
 		var phone model.PhoneJoin
 		var email model.EmailJoin
-		var mc model.MetalComponentJoin
 		var df model.FileJoin
 
 		if err := rows.Scan(
 			&p.ID,
-			&p.AccountID,
+			&accountID,
+			&ownerID,
 			//&p.Phone,
 			//&p.Email,
 			&p.Height,
@@ -297,12 +342,6 @@ func (s *PostgresStorage) scanPatient(rows *sql.Rows) (*model.Patient, error) {
 
 			&email.Email,
 
-			&mc.ID,
-			&mc.PatientID,
-			&mc.Metal,
-			&mc.OrganID,
-			&mc.Description,
-
 			&df.ID,
 			&df.CreatedAt,
 			&df.UpdatedAt,
@@ -323,14 +362,6 @@ func (s *PostgresStorage) scanPatient(rows *sql.Rows) (*model.Patient, error) {
 			p.Email = &v
 		}
 
-		if mc.ID != nil {
-			if _, ok := mcs[*mc.ID]; !ok {
-				metalComponent := mc.ConvertToMetalComponent()
-				mcs[*mc.ID] = &metalComponent
-				p.MetalComponents = append(p.MetalComponents, &metalComponent)
-			}
-		}
-
 		if df.ID != nil {
 			if _, ok := files[*df.ID]; !ok {
 				disabilityFile := df.ConvertToFile()
@@ -344,6 +375,14 @@ func (s *PostgresStorage) scanPatient(rows *sql.Rows) (*model.Patient, error) {
 		return nil, err
 	}
 
+	if accountID != nil {
+		p.AccountID = *accountID
+	}
+
+	if ownerID != nil {
+		p.OwnerID = *ownerID
+	}
+
 	return &p, nil
 }
 
@@ -352,11 +391,14 @@ func (s *PostgresStorage) scanPatients(rows *sql.Rows) ([]*model.Patient, error)
 
 	for rows.Next() {
 		var p model.Patient
+		var accountID, ownerID *int64
 		var phone model.PhoneJoin
 		var email model.EmailJoin
 
 		if err := rows.Scan(
 			&p.ID,
+			&accountID,
+			&ownerID,
 
 			&p.FirstName,
 			&p.FatherName,
@@ -383,6 +425,14 @@ func (s *PostgresStorage) scanPatients(rows *sql.Rows) ([]*model.Patient, error)
 			p.Email = &v
 		}
 
+		if accountID != nil {
+			p.AccountID = *accountID
+		}
+
+		if ownerID != nil {
+			p.OwnerID = *ownerID
+		}
+
 		ps = append(ps, &p)
 	}
 
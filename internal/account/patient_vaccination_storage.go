@@ -0,0 +1,279 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddPatientVaccination(c context.Context, patientID interface{}, accountID int64, req *model.AddVaccination) (*model.Vaccination, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceVaccination}
+
+	return changePatientResource(c, s, e, nil, (*PostgresStorage).GetPatientVaccinationByID, func(st *PostgresStorage, _ *model.Vaccination) (int64, error) {
+		r, err := st.addPatientVaccination(c, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) addPatientVaccination(c context.Context, patientID interface{}, req *model.AddVaccination) (*model.Vaccination, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(patientVaccinationsTableName).
+		Columns(
+			"profile_id",
+			"vaccine",
+			"name",
+			"dose_number",
+			"vaccinated_at",
+			"lot",
+			"clinic",
+		).
+		Values(
+			patientID,
+			req.Vaccine,
+			storage.NullString(req.Name),
+			req.DoseNumber,
+			req.VaccinatedAt,
+			storage.NullString(req.Lot),
+			storage.NullString(req.Clinic),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := s.updatePatientVaccinationFiles(c, id, req.Files); err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientVaccinationByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientVaccinations(c context.Context, patientID interface{}) ([]*model.Vaccination, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.patientVaccinationResponseColumns()...).
+		From(patientVaccinationsTableName).
+		Where("profile_id = ?", patientID).
+		OrderBy("vaccinated_at DESC", "id DESC").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var vs []*model.Vaccination
+	for rows.Next() {
+		v, err := s.scanPatientVaccination(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		vs = append(vs, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	for _, v := range vs {
+		v.Files, err = s.GetPatientVaccinationFiles(c, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vs, nil
+}
+
+func (s *PostgresStorage) GetPatientVaccinationByID(c context.Context, id interface{}) (*model.Vaccination, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.patientVaccinationResponseColumns()...).
+		From(patientVaccinationsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	v, err := s.scanPatientVaccination(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	v.Files, err = s.GetPatientVaccinationFiles(c, v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (s *PostgresStorage) UpdatePatientVaccination(c context.Context, id interface{}, patientID interface{}, accountID int64, req *model.UpdateVaccination) (*model.Vaccination, error) {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceVaccination}
+
+	return changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientVaccinationByID, func(st *PostgresStorage, _ *model.Vaccination) (int64, error) {
+		r, err := st.updatePatientVaccination(c, id, patientID, req)
+		if err != nil {
+			return 0, err
+		}
+		return r.ID, nil
+	})
+}
+
+func (s *PostgresStorage) updatePatientVaccination(c context.Context, id interface{}, patientID interface{}, req *model.UpdateVaccination) (*model.Vaccination, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(patientVaccinationsTableName).
+		Set("updated_at", time.Now()).
+		Set("vaccine", req.Vaccine).
+		Set("name", storage.NullString(req.Name)).
+		Set("dose_number", req.DoseNumber).
+		Set("vaccinated_at", req.VaccinatedAt).
+		Set("lot", storage.NullString(req.Lot)).
+		Set("clinic", storage.NullString(req.Clinic)).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := s.updatePatientVaccinationFiles(c, id, req.Files); err != nil {
+		return nil, err
+	}
+
+	return s.GetPatientVaccinationByID(c, id)
+}
+
+func (s *PostgresStorage) DeletePatientVaccination(c context.Context, id interface{}, patientID interface{}, accountID int64) error {
+	e := patientHistoryEntry{patientID: patientID, accountID: accountID, resource: model.PatientHistoryResourceVaccination}
+
+	_, err := changePatientResource(c, s, e, id, (*PostgresStorage).GetPatientVaccinationByID, func(st *PostgresStorage, r *model.Vaccination) (int64, error) {
+		return r.ID, st.deletePatientVaccination(c, id, patientID)
+	})
+	return err
+}
+
+func (s *PostgresStorage) deletePatientVaccination(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(patientVaccinationsTableName).
+		Where("id = ? AND profile_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetPatientVaccinationFiles(c context.Context, vaccinationID interface{}) ([]*model.File, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.fileResponseColumns(accountFilesTableName)...).
+		From(patientVaccinationFilesTableName).
+		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+patientVaccinationFilesTableName+".file_id").
+		Where(patientVaccinationFilesTableName+".vaccination_id = ?", vaccinationID).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var files []*model.File
+	for rows.Next() {
+		file, err := s.scanFile(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return files, nil
+}
+
+func (s *PostgresStorage) updatePatientVaccinationFiles(c context.Context, vaccinationID interface{}, files []int64) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	if _, err := psql.Delete(patientVaccinationFilesTableName).Where("vaccination_id = ?", vaccinationID).ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	fq := psql.Insert(patientVaccinationFilesTableName).Columns("vaccination_id", "file_id")
+	for _, v := range files {
+		fq = fq.Values(vaccinationID, v)
+	}
+
+	if _, err := fq.ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) patientVaccinationResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "vaccine",
+		pre + "name",
+		pre + "dose_number",
+		pre + "vaccinated_at",
+		pre + "lot",
+		pre + "clinic",
+	}
+}
+
+func (s *PostgresStorage) scanPatientVaccination(row squirrel.RowScanner) (*model.Vaccination, error) {
+	var v model.Vaccination
+
+	if err := row.Scan(
+		&v.ID,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+		&v.PatientID,
+		&v.Vaccine,
+		&v.Name,
+		&v.DoseNumber,
+		&v.VaccinatedAt,
+		&v.Lot,
+		&v.Clinic,
+	); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
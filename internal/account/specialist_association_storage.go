@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddSpecialistProfileAssociation(c context.Context, specialistID interface{}, req *model.AddAssociation) (*model.Association, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(specialistAssociationsTableName).
 		Columns(
@@ -35,7 +35,7 @@ func (s *PostgresStorage) AddSpecialistProfileAssociation(c context.Context, spe
 }
 
 func (s *PostgresStorage) GetSpecialistProfileAssociations(c context.Context, specialistID interface{}) ([]*model.Association, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.associationResponseColumns()...).
 		From(specialistAssociationsTableName).
@@ -63,7 +63,7 @@ func (s *PostgresStorage) GetSpecialistProfileAssociations(c context.Context, sp
 }
 
 func (s *PostgresStorage) GetSpecialistProfileAssociationByID(c context.Context, id interface{}) (*model.Association, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.associationResponseColumns()...).
 		From(specialistAssociationsTableName).
@@ -79,12 +79,13 @@ func (s *PostgresStorage) GetSpecialistProfileAssociationByID(c context.Context,
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileAssociation(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateAssociation) (*model.Association, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(specialistAssociationsTableName).
 		Set("association_id", storage.NullInt64(req.AssociationID)).
 		Set("name", req.Name).
 		Set("job_title", storage.NullString(req.JobTitle)).
+		Set("verified", false).
 		Where("id = ? AND profile_id = ?", id, specialistID).
 		ExecContext(c)
 	if err != nil {
@@ -103,8 +104,9 @@ func (s *PostgresStorage) UpdateSpecialistProfileAssociation(c context.Context,
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileAssociationFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateAssociationFields) (*model.Association, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
+	req["verified"] = false
 
 	res, err := psql.Update(specialistAssociationsTableName).
 		SetMap(req).
@@ -126,7 +128,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileAssociationFields(c context.Con
 }
 
 func (s *PostgresStorage) DeleteSpecialistProfileAssociation(c context.Context, id interface{}, specialistID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(specialistAssociationsTableName).
 		Where("id = ? AND profile_id = ?", id, specialistID).
@@ -155,6 +157,7 @@ func (s *PostgresStorage) associationResponseColumns(prefixes ...string) []strin
 		pre + "association_id",
 		pre + "name",
 		pre + "job_title",
+		pre + "verified",
 	}
 }
 
@@ -167,6 +170,7 @@ func (s *PostgresStorage) scanAssociation(row squirrel.RowScanner) (*model.Assoc
 		&a.AssociationID,
 		&a.Name,
 		&a.JobTitle,
+		&a.Verified,
 	); err != nil {
 		return nil, err
 	}
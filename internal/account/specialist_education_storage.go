@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddSpecialistProfileEducation(c context.Context, specialistID interface{}, req *model.AddEducation) (*model.Education, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(specialistEducationsTableName).
 		Columns(
@@ -45,7 +45,7 @@ func (s *PostgresStorage) AddSpecialistProfileEducation(c context.Context, speci
 }
 
 func (s *PostgresStorage) GetSpecialistProfileEducations(c context.Context, specialistID interface{}) ([]*model.Education, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.educationResponseColumns()...).
 		From(specialistEducationsTableName).
@@ -66,7 +66,7 @@ func (s *PostgresStorage) GetSpecialistProfileEducations(c context.Context, spec
 }
 
 func (s *PostgresStorage) GetSpecialistProfileEducationByID(c context.Context, id interface{}) (*model.Education, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.educationResponseColumns()...).
 		From(specialistEducationsTableName).
@@ -74,17 +74,25 @@ func (s *PostgresStorage) GetSpecialistProfileEducationByID(c context.Context, i
 		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+specialistEducationFilesTableName+".file_id").
 		Where(specialistEducationsTableName+".id = ?", id).
 		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
 
 	e, err := s.scanEducations(rows)
 	if err != nil {
 		return nil, postgres.ConvertError(err)
 	}
 
+	if len(e) == 0 {
+		return nil, storage.ErrNotFound
+	}
+
 	return e[0], nil
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileEducation(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateEducation) (*model.Education, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(specialistEducationsTableName).
 		Set("institution_id", req.InstitutionID).
@@ -93,6 +101,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileEducation(c context.Context, id
 		Set("form_id", storage.NullInt64(req.FormID)).
 		Set("degree_id", storage.NullInt64(req.DegreeID)).
 		Set("graduation", req.Graduation).
+		Set("verified", false).
 		Where("id = ? AND profile_id = ?", id, specialistID).
 		ExecContext(c)
 	if err != nil {
@@ -115,7 +124,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileEducation(c context.Context, id
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileEducationFiles(educationID interface{}, req []*model.File) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	_, err := psql.Delete(specialistEducationFilesTableName).Where("education_id = ?", educationID).Exec()
 	if err != nil {
@@ -147,8 +156,9 @@ func (s *PostgresStorage) UpdateSpecialistProfileEducationFiles(educationID inte
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileEducationFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateEducationFields) (*model.Education, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
+	req["verified"] = false
 
 	res, err := psql.Update(specialistEducationsTableName).
 		SetMap(req).
@@ -170,7 +180,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileEducationFields(c context.Conte
 }
 
 func (s *PostgresStorage) DeleteSpecialistProfileEducation(c context.Context, id interface{}, specialistID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(specialistEducationsTableName).
 		Where("id = ? AND profile_id = ?", id, specialistID).
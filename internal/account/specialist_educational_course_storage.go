@@ -0,0 +1,329 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+)
+
+func (s *PostgresStorage) AddSpecialistProfileEducationalCourse(c context.Context, specialistID interface{}, req *model.AddEducationalCourse) (*model.EducationalCourse, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistEducationalCoursesTableName).
+		Columns(
+			"profile_id",
+			"course_id",
+			"name",
+			"institution_id",
+			"institution_name",
+			"form_id",
+			"graduation",
+			"points",
+		).
+		Values(
+			specialistID,
+			storage.NullInt64(req.CourseID),
+			req.Name,
+			storage.NullInt64(req.InstitutionID),
+			storage.NullString(req.InstitutionName),
+			storage.NullInt64(req.FormID),
+			req.Graduation,
+			req.Points,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := s.UpdateSpecialistProfileEducationalCourseFiles(id, req.Files); err != nil {
+		return nil, err
+	}
+
+	return s.GetSpecialistProfileEducationalCourseByID(c, id)
+}
+
+func (s *PostgresStorage) GetSpecialistProfileEducationalCourses(c context.Context, specialistID interface{}) ([]*model.EducationalCourse, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.educationalCourseResponseColumns()...).
+		From(specialistEducationalCoursesTableName).
+		LeftJoin(specialistEducationalCourseFilesTableName+" ON "+specialistEducationalCourseFilesTableName+".educational_course_id = "+specialistEducationalCoursesTableName+".id").
+		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+specialistEducationalCourseFilesTableName+".file_id").
+		Where(specialistEducationalCoursesTableName+".profile_id = ?", specialistID).
+		OrderBy(specialistEducationalCoursesTableName+".graduation DESC", specialistEducationalCoursesTableName+".id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	courses, err := s.scanEducationalCourses(rows)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return courses, nil
+}
+
+func (s *PostgresStorage) GetSpecialistProfileEducationalCourseByID(c context.Context, id interface{}) (*model.EducationalCourse, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.educationalCourseResponseColumns()...).
+		From(specialistEducationalCoursesTableName).
+		LeftJoin(specialistEducationalCourseFilesTableName+" ON "+specialistEducationalCourseFilesTableName+".educational_course_id = "+specialistEducationalCoursesTableName+".id").
+		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+specialistEducationalCourseFilesTableName+".file_id").
+		Where(specialistEducationalCoursesTableName+".id = ?", id).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	courses, err := s.scanEducationalCourses(rows)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if len(courses) == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return courses[0], nil
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileEducationalCourse(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateEducationalCourse) (*model.EducationalCourse, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistEducationalCoursesTableName).
+		Set("course_id", storage.NullInt64(req.CourseID)).
+		Set("name", req.Name).
+		Set("institution_id", storage.NullInt64(req.InstitutionID)).
+		Set("institution_name", storage.NullString(req.InstitutionName)).
+		Set("form_id", storage.NullInt64(req.FormID)).
+		Set("graduation", req.Graduation).
+		Set("points", req.Points).
+		Set("verified", false).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := s.UpdateSpecialistProfileEducationalCourseFiles(id, req.Files); err != nil {
+		return nil, err
+	}
+
+	return s.GetSpecialistProfileEducationalCourseByID(c, id)
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileEducationalCourseFiles(courseID interface{}, req []*model.File) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	_, err := psql.Delete(specialistEducationalCourseFilesTableName).Where("educational_course_id = ?", courseID).Exec()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	if len(req) > 0 {
+		aq := psql.Insert(specialistEducationalCourseFilesTableName).Columns("educational_course_id", "file_id")
+
+		for _, v := range req {
+			aq = aq.Values(courseID, v.ID)
+		}
+
+		res, err := aq.Exec()
+		if err != nil {
+			return postgres.ConvertError(err)
+		}
+
+		ra, err := res.RowsAffected()
+		if err != nil {
+			return postgres.ConvertError(err)
+		}
+		if ra == 0 {
+			return storage.ErrNotFound
+		}
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileEducationalCourseFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateEducationalCourseFields) (*model.EducationalCourse, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+	req["verified"] = false
+
+	res, err := psql.Update(specialistEducationalCoursesTableName).
+		SetMap(req).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileEducationalCourseByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteSpecialistProfileEducationalCourse(c context.Context, id interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistEducationalCoursesTableName).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetSpecialistProfileCoursePoints(c context.Context, specialistID interface{}, req *model.CoursePointsRequest) ([]*model.CoursePoints, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(
+		"EXTRACT(YEAR FROM graduation)::INT AS year",
+		"COUNT(*)",
+		"COALESCE(SUM(points), 0)",
+		"COALESCE(SUM(points) FILTER (WHERE verified), 0)",
+	).
+		From(specialistEducationalCoursesTableName).
+		Where("profile_id = ?", specialistID)
+
+	if req.From != nil {
+		q = q.Where("graduation >= ?", storage.NullDatePGX(req.From))
+	}
+
+	if req.To != nil {
+		q = q.Where("graduation <= ?", storage.NullDatePGX(req.To))
+	}
+
+	rows, err := q.GroupBy("year").
+		OrderBy("year").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ps []*model.CoursePoints
+	for rows.Next() {
+		var p model.CoursePoints
+
+		if err := rows.Scan(
+			&p.Year,
+			&p.Courses,
+			&p.Points,
+			&p.VerifiedPoints,
+		); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ps = append(ps, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ps, nil
+}
+
+func (s *PostgresStorage) educationalCourseResponseColumns() []string {
+	fields := make([]string, 0)
+
+	fields = append(s.educationalCourseResponseColumnsMain(specialistEducationalCoursesTableName), s.fileResponseColumns(accountFilesTableName)...)
+
+	return fields
+}
+
+func (s *PostgresStorage) educationalCourseResponseColumnsMain(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "profile_id",
+		pre + "course_id",
+		pre + "name",
+		pre + "institution_id",
+		pre + "institution_name",
+		pre + "form_id",
+		pre + "graduation",
+		pre + "points",
+		pre + "verified",
+	}
+}
+
+func (s *PostgresStorage) scanEducationalCourses(rows *sql.Rows) ([]*model.EducationalCourse, error) {
+	var cs []*model.EducationalCourse
+	courses := make(map[int64]*model.EducationalCourse)
+
+	for rows.Next() {
+		var course model.EducationalCourse
+		var cf model.FileJoin
+
+		if err := rows.Scan(
+			&course.ID,
+			&course.ProfileID,
+			&course.CourseID,
+			&course.Name,
+			&course.InstitutionID,
+			&course.InstitutionName,
+			&course.FormID,
+			&course.Graduation,
+			&course.Points,
+			&course.Verified,
+
+			&cf.ID,
+			&cf.CreatedAt,
+			&cf.UpdatedAt,
+			&cf.AccountID,
+			&cf.Name,
+			&cf.Description,
+		); err != nil {
+			return nil, err
+		}
+
+		if _, ok := courses[course.ID]; !ok {
+			courses[course.ID] = &course
+			cs = append(cs, &course)
+		}
+
+		if cf.ID != nil {
+			courseFile := cf.ConvertToFile()
+			courses[course.ID].Files = append(courses[course.ID].Files, &courseFile)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cs, nil
+}
@@ -9,12 +9,13 @@ import (
 )
 
 func (s *PostgresStorage) AddSpecialistProfileExperience(c context.Context, specialistID interface{}, req *model.AddExperience) (*model.Experience, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(specialistExperiencesTableName).
 		Columns(
 			"profile_id",
 			"company_id",
+			"organization_id",
 			"company",
 			"start",
 			"finish",
@@ -22,6 +23,7 @@ func (s *PostgresStorage) AddSpecialistProfileExperience(c context.Context, spec
 		Values(
 			specialistID,
 			storage.NullInt64(req.CompanyID),
+			storage.NullInt64(req.OrganizationID),
 			req.Company,
 			req.Start,
 			storage.NullDatePGX(req.Finish),
@@ -41,7 +43,7 @@ func (s *PostgresStorage) AddSpecialistProfileExperience(c context.Context, spec
 }
 
 func (s *PostgresStorage) GetSpecialistProfileExperiences(c context.Context, specialistID interface{}) ([]*model.Experience, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.experienceResponseColumns()...).
 		From(specialistExperiencesTableName).
@@ -61,30 +63,40 @@ func (s *PostgresStorage) GetSpecialistProfileExperiences(c context.Context, spe
 }
 
 func (s *PostgresStorage) GetSpecialistProfileExperienceByID(c context.Context, id interface{}) (*model.Experience, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.experienceResponseColumns()...).
 		From(specialistExperiencesTableName).
 		LeftJoin(specialistExperienceSpecializationsTableName+" ON "+specialistExperienceSpecializationsTableName+".experience_id = "+specialistExperiencesTableName+".id").
 		Where(specialistExperiencesTableName+".id = ?", id).
 		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
 
 	e, err := s.scanExperiences(rows)
 	if err != nil {
 		return nil, postgres.ConvertError(err)
 	}
 
+	if len(e) == 0 {
+		return nil, storage.ErrNotFound
+	}
+
 	return e[0], nil
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileExperience(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateExperience) (*model.Experience, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(specialistExperiencesTableName).
 		Set("company_id", storage.NullInt64(req.CompanyID)).
+		Set("organization_id", storage.NullInt64(req.OrganizationID)).
 		Set("company", req.Company).
 		Set("start", req.Start).
 		Set("finish", storage.NullDatePGX(req.Finish)).
+		Set("verified", false).
 		Where("id = ? AND profile_id = ?", id, specialistID).
 		ExecContext(c)
 	if err != nil {
@@ -107,7 +119,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileExperience(c context.Context, i
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileExperienceSpecializations(experienceID interface{}, req []int64) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	_, err := psql.Delete(specialistExperienceSpecializationsTableName).Where("experience_id = ?", experienceID).Exec()
 	if err != nil {
@@ -139,8 +151,9 @@ func (s *PostgresStorage) UpdateSpecialistProfileExperienceSpecializations(exper
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileExperienceFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateExperienceFields) (*model.Experience, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
+	req["verified"] = false
 
 	res, err := psql.Update(specialistExperiencesTableName).
 		SetMap(req).
@@ -162,7 +175,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileExperienceFields(c context.Cont
 }
 
 func (s *PostgresStorage) DeleteSpecialistProfileExperience(c context.Context, id interface{}, specialistID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(specialistExperiencesTableName).
 		Where("id = ? AND profile_id = ?", id, specialistID).
@@ -198,9 +211,11 @@ func (s *PostgresStorage) experienceResponseColumnsMain(prefixes ...string) []st
 		pre + "id",
 		pre + "profile_id",
 		pre + "company_id",
+		pre + "organization_id",
 		pre + "company",
 		pre + "start",
 		pre + "finish",
+		pre + "verified",
 	}
 }
 
@@ -224,9 +239,11 @@ func (s *PostgresStorage) scanExperiences(rows *sql.Rows) ([]*model.Experience,
 			&e.ID,
 			&e.ProfileID,
 			&e.CompanyID,
+			&e.OrganizationID,
 			&e.Company,
 			&e.Start,
 			&e.Finish,
+			&e.Verified,
 
 			&sp,
 		); err != nil {
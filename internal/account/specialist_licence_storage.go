@@ -0,0 +1,375 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"strings"
+	"time"
+)
+
+func (s *PostgresStorage) AddSpecialistProfileLicence(c context.Context, specialistID interface{}, req *model.AddLicence) (*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistLicencesTableName).
+		Columns(
+			"profile_id",
+			"number",
+			"full_name",
+			"practice",
+			"issued",
+			"expires",
+		).
+		Values(
+			specialistID,
+			req.Number,
+			req.FullName,
+			req.Practice,
+			storage.NullDatePGX(req.Issued),
+			storage.NullDatePGX(req.Expires),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetSpecialistProfileLicenceByID(c, id)
+}
+
+func (s *PostgresStorage) GetSpecialistProfileLicences(c context.Context, specialistID interface{}) ([]*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.licenceResponseColumns()...).
+		From(specialistLicencesTableName).
+		Where("profile_id = ?", specialistID).
+		OrderBy("id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ls []*model.Licence
+	for rows.Next() {
+		l, err := s.scanLicence(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ls = append(ls, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ls, nil
+}
+
+func (s *PostgresStorage) GetSpecialistIDsByLicenceNumber(c context.Context, number interface{}) ([]int64, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select("DISTINCT profile_id").
+		From(specialistLicencesTableName).
+		Where(squirrel.Eq{"number": number, "status": model.LicenceStatusActive}).
+		OrderBy("profile_id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ids, nil
+}
+
+func (s *PostgresStorage) GetSpecialistProfileLicenceByID(c context.Context, id interface{}) (*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.licenceResponseColumns()...).
+		From(specialistLicencesTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	l, err := s.scanLicence(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return l, nil
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileLicence(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateLicence) (*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistLicencesTableName).
+		Set("updated_at", time.Now()).
+		Set("number", req.Number).
+		Set("full_name", req.FullName).
+		Set("practice", req.Practice).
+		Set("issued", storage.NullDatePGX(req.Issued)).
+		Set("expires", storage.NullDatePGX(req.Expires)).
+		Set("verified", false).
+		Set("checked_at", nil).
+		Set("reminded_at", nil).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileLicenceByID(c, id)
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileLicenceFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateLicenceFields) (*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+	req["updated_at"] = time.Now()
+	req["verified"] = false
+	req["checked_at"] = nil
+	req["reminded_at"] = nil
+
+	res, err := psql.Update(specialistLicencesTableName).
+		SetMap(req).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileLicenceByID(c, id)
+}
+
+// UpdateSpecialistProfileLicenceStatus resets the verification of a reactivated licence
+func (s *PostgresStorage) UpdateSpecialistProfileLicenceStatus(c context.Context, id interface{}, specialistID interface{}, status string) (*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Update(specialistLicencesTableName).
+		Set("updated_at", time.Now()).
+		Set("status", status)
+
+	if status == model.LicenceStatusActive {
+		q = q.Set("verified", false).
+			Set("checked_at", nil).
+			Set("reminded_at", nil)
+	}
+
+	res, err := q.Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileLicenceByID(c, id)
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileLicenceCheck(c context.Context, id interface{}, verified bool) (*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistLicencesTableName).
+		Set("verified", verified).
+		Set("checked_at", time.Now()).
+		Where("id = ?", id).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileLicenceByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteSpecialistProfileLicence(c context.Context, id interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistLicencesTableName).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) GetExpiringLicences(c context.Context, before time.Time) ([]*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.licenceResponseColumns()...).
+		From(specialistLicencesTableName).
+		Where(squirrel.Eq{"status": model.LicenceStatusActive, "reminded_at": nil}).
+		Where("expires IS NOT NULL AND expires <= ?", before).
+		OrderBy("expires").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ls []*model.Licence
+	for rows.Next() {
+		l, err := s.scanLicence(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ls = append(ls, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ls, nil
+}
+
+func (s *PostgresStorage) SetLicenceReminded(c context.Context, id interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistLicencesTableName).
+		Set("reminded_at", time.Now()).
+		Where("id = ?", id).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) ExpireLicences(c context.Context) ([]*model.Licence, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Update(specialistLicencesTableName).
+		Set("updated_at", time.Now()).
+		Set("status", model.LicenceStatusExpired).
+		Where(squirrel.Eq{"status": model.LicenceStatusActive}).
+		Where("expires IS NOT NULL AND expires < CURRENT_DATE").
+		Suffix("RETURNING " + strings.Join(s.licenceResponseColumns(), ", ")).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ls []*model.Licence
+	for rows.Next() {
+		l, err := s.scanLicence(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ls = append(ls, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ls, nil
+}
+
+func (s *PostgresStorage) licenceResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "number",
+		pre + "status",
+		pre + "full_name",
+		pre + "practice",
+		pre + "issued",
+		pre + "expires",
+		pre + "verified",
+		pre + "checked_at",
+	}
+}
+
+func (s *PostgresStorage) scanLicence(row squirrel.RowScanner) (*model.Licence, error) {
+	var l model.Licence
+
+	if err := row.Scan(
+		&l.ID,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+		&l.ProfileID,
+		&l.Number,
+		&l.Status,
+		&l.FullName,
+		&l.Practice,
+		&l.Issued,
+		&l.Expires,
+		&l.Verified,
+		&l.CheckedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddSpecialistProfilePatent(c context.Context, specialistID interface{}, req *model.AddPatent) (*model.Patent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(specialistPatentsTableName).
 		Columns(
@@ -35,7 +35,7 @@ func (s *PostgresStorage) AddSpecialistProfilePatent(c context.Context, speciali
 }
 
 func (s *PostgresStorage) GetSpecialistProfilePatents(c context.Context, specialistID interface{}) ([]*model.Patent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.patentResponseColumns()...).
 		From(specialistPatentsTableName).
@@ -63,7 +63,7 @@ func (s *PostgresStorage) GetSpecialistProfilePatents(c context.Context, special
 }
 
 func (s *PostgresStorage) GetSpecialistProfilePatentByID(c context.Context, id interface{}) (*model.Patent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.patentResponseColumns()...).
 		From(specialistPatentsTableName).
@@ -79,7 +79,7 @@ func (s *PostgresStorage) GetSpecialistProfilePatentByID(c context.Context, id i
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfilePatent(c context.Context, id interface{}, specialistID interface{}, req *model.UpdatePatent) (*model.Patent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(specialistPatentsTableName).
 		Set("number", req.Number).
@@ -103,7 +103,7 @@ func (s *PostgresStorage) UpdateSpecialistProfilePatent(c context.Context, id in
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfilePatentFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdatePatentFields) (*model.Patent, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
 
 	res, err := psql.Update(specialistPatentsTableName).
@@ -126,7 +126,7 @@ func (s *PostgresStorage) UpdateSpecialistProfilePatentFields(c context.Context,
 }
 
 func (s *PostgresStorage) DeleteSpecialistProfilePatent(c context.Context, id interface{}, specialistID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(specialistPatentsTableName).
 		Where("id = ? AND profile_id = ?", id, specialistID).
@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddSpecialistProfilePublicationLink(c context.Context, specialistID interface{}, req *model.AddPublicationLink) (*model.PublicationLink, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(specialistPublicationLinksTableName).
 		Columns(
@@ -33,7 +33,7 @@ func (s *PostgresStorage) AddSpecialistProfilePublicationLink(c context.Context,
 }
 
 func (s *PostgresStorage) GetSpecialistProfilePublicationLinks(c context.Context, specialistID interface{}) ([]*model.PublicationLink, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.publicationLinkResponseColumns()...).
 		From(specialistPublicationLinksTableName).
@@ -61,7 +61,7 @@ func (s *PostgresStorage) GetSpecialistProfilePublicationLinks(c context.Context
 }
 
 func (s *PostgresStorage) GetSpecialistProfilePublicationLinkByID(c context.Context, id interface{}) (*model.PublicationLink, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.publicationLinkResponseColumns()...).
 		From(specialistPublicationLinksTableName).
@@ -77,7 +77,7 @@ func (s *PostgresStorage) GetSpecialistProfilePublicationLinkByID(c context.Cont
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfilePublicationLink(c context.Context, id interface{}, specialistID interface{}, req *model.UpdatePublicationLink) (*model.PublicationLink, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(specialistPublicationLinksTableName).
 		Set("title", req.Title).
@@ -100,7 +100,7 @@ func (s *PostgresStorage) UpdateSpecialistProfilePublicationLink(c context.Conte
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfilePublicationLinkFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdatePublicationLinkFields) (*model.PublicationLink, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
 
 	res, err := psql.Update(specialistPublicationLinksTableName).
@@ -123,7 +123,7 @@ func (s *PostgresStorage) UpdateSpecialistProfilePublicationLinkFields(c context
 }
 
 func (s *PostgresStorage) DeleteSpecialistProfilePublicationLink(c context.Context, id interface{}, specialistID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(specialistPublicationLinksTableName).
 		Where("id = ? AND profile_id = ?", id, specialistID).
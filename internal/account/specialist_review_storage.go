@@ -0,0 +1,348 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+func (s *PostgresStorage) AddReview(c context.Context, patientID interface{}, req *model.AddReview) (*model.Review, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistReviewsTableName).
+		Columns(
+			"specialist_id",
+			"patient_id",
+			"professionalism",
+			"attentiveness",
+			"communication",
+			"punctuality",
+			"text",
+			"anonymous",
+		).
+		Values(
+			req.SpecialistID,
+			patientID,
+			req.Ratings.Professionalism,
+			req.Ratings.Attentiveness,
+			req.Ratings.Communication,
+			req.Ratings.Punctuality,
+			storage.NullString(req.Text),
+			req.Anonymous,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetReviewByID(c, id)
+}
+
+func (s *PostgresStorage) GetPatientReviews(c context.Context, patientID interface{}) ([]*model.Review, error) {
+	rows, err := s.reviewQuery().
+		Where("patient_id = ?", patientID).
+		OrderBy("created_at DESC").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	rs := make([]*model.Review, 0)
+	for rows.Next() {
+		r, err := s.scanReview(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		rs = append(rs, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return rs, nil
+}
+
+func (s *PostgresStorage) GetReviews(c context.Context, req *model.ListReviewsRequest) ([]*model.Review, error) {
+	q := s.reviewQuery().
+		Where("status = ?", req.Status)
+
+	if req.SpecialistID != 0 {
+		q = q.Where("specialist_id = ?", req.SpecialistID)
+	}
+
+	rows, err := q.OrderBy(req.OrderBy).
+		Limit(req.Limit).
+		Offset(req.Offset()).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	rs := make([]*model.Review, 0)
+	for rows.Next() {
+		r, err := s.scanReview(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		rs = append(rs, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return rs, nil
+}
+
+func (s *PostgresStorage) GetReviewByID(c context.Context, id interface{}) (*model.Review, error) {
+	row := s.reviewQuery().
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	r, err := s.scanReview(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return r, nil
+}
+
+// UpdateReview returns the changed review to moderation, the reply of the specialist is kept
+func (s *PostgresStorage) UpdateReview(c context.Context, id interface{}, patientID interface{}, req *model.UpdateReview) (*model.Review, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistReviewsTableName).
+		Set("updated_at", time.Now()).
+		Set("professionalism", req.Ratings.Professionalism).
+		Set("attentiveness", req.Ratings.Attentiveness).
+		Set("communication", req.Ratings.Communication).
+		Set("punctuality", req.Ratings.Punctuality).
+		Set("text", storage.NullString(req.Text)).
+		Set("anonymous", req.Anonymous).
+		Set("status", model.ReviewStatusPending).
+		Set("reviewer_id", nil).
+		Set("reviewer_comment", nil).
+		Set("reviewed_at", nil).
+		Where("id = ? AND patient_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetReviewByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteReview(c context.Context, id interface{}, patientID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistReviewsTableName).
+		Where("id = ? AND patient_id = ?", id, patientID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) ModerateReview(c context.Context, id interface{}, reviewerID interface{}, status string, req *model.ModerateReview) (*model.Review, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	now := time.Now()
+	res, err := psql.Update(specialistReviewsTableName).
+		Set("updated_at", now).
+		Set("status", status).
+		Set("reviewer_id", reviewerID).
+		Set("reviewer_comment", storage.NullString(req.Comment)).
+		Set("reviewed_at", now).
+		Where("id = ? AND status = ?", id, model.ReviewStatusPending).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetReviewByID(c, id)
+}
+
+// ReplyReview sets the reply of the specialist to an approved review, nil removes it
+func (s *PostgresStorage) ReplyReview(c context.Context, id interface{}, specialistID interface{}, reply *string) (*model.Review, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	var repliedAt *time.Time
+	if reply != nil {
+		now := time.Now()
+		repliedAt = &now
+	}
+
+	res, err := psql.Update(specialistReviewsTableName).
+		Set("reply", storage.NullString(reply)).
+		Set("replied_at", repliedAt).
+		Where("id = ? AND specialist_id = ? AND status = ?", id, specialistID, model.ReviewStatusApproved).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetReviewByID(c, id)
+}
+
+// GetSpecialistRatings counts the approved reviews of the specialists, the specialists without them are left out
+func (s *PostgresStorage) GetSpecialistRatings(c context.Context, specialistIDs []int64) (map[int64]*model.SpecialistRating, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rs := make(map[int64]*model.SpecialistRating)
+	if len(specialistIDs) == 0 {
+		return rs, nil
+	}
+
+	rows, err := psql.Select(
+		"specialist_id",
+		"COUNT(*)",
+		"ROUND(AVG(rating), 2)",
+		"ROUND(AVG(professionalism), 2)",
+		"ROUND(AVG(attentiveness), 2)",
+		"ROUND(AVG(communication), 2)",
+		"ROUND(AVG(punctuality), 2)",
+	).
+		From(specialistReviewsTableName).
+		Where(squirrel.Eq{"specialist_id": specialistIDs, "status": model.ReviewStatusApproved}).
+		GroupBy("specialist_id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var r model.SpecialistRating
+		if err := rows.Scan(
+			&id,
+			&r.Count,
+			&r.Average,
+			&r.Professionalism,
+			&r.Attentiveness,
+			&r.Communication,
+			&r.Punctuality,
+		); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		rs[id] = &r
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return rs, nil
+}
+
+// reviewQuery selects the author with subqueries, so the columns of the reviews can be ordered by without a prefix.
+// The name is taken from the account or from the profile of a dependent.
+func (s *PostgresStorage) reviewQuery() squirrel.SelectBuilder {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	return psql.Select(s.reviewResponseColumns()...).
+		From(specialistReviewsTableName)
+}
+
+func (s *PostgresStorage) reviewResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "specialist_id",
+		pre + "patient_id",
+		"(SELECT COALESCE(account_id, owner_id) FROM " + patientProfilesTableName + " WHERE " + patientProfilesTableName + ".id = " + specialistReviewsTableName + ".patient_id)",
+		"(SELECT COALESCE(" + accountsTableName + ".first_name, " + patientProfilesTableName + ".first_name) FROM " + patientProfilesTableName +
+			" LEFT JOIN " + accountsTableName + " ON " + accountsTableName + ".id = " + patientProfilesTableName + ".account_id" +
+			" WHERE " + patientProfilesTableName + ".id = " + specialistReviewsTableName + ".patient_id)",
+		pre + "professionalism",
+		pre + "attentiveness",
+		pre + "communication",
+		pre + "punctuality",
+		pre + "rating",
+		pre + "text",
+		pre + "anonymous",
+		pre + "status",
+		pre + "reviewer_id",
+		pre + "reviewer_comment",
+		pre + "reviewed_at",
+		pre + "reply",
+		pre + "replied_at",
+	}
+}
+
+func (s *PostgresStorage) scanReview(row squirrel.RowScanner) (*model.Review, error) {
+	var r model.Review
+
+	if err := row.Scan(
+		&r.ID,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.SpecialistID,
+		&r.PatientID,
+		&r.AuthorAccountID,
+		&r.AuthorName,
+		&r.Ratings.Professionalism,
+		&r.Ratings.Attentiveness,
+		&r.Ratings.Communication,
+		&r.Ratings.Punctuality,
+		&r.Rating,
+		&r.Text,
+		&r.Anonymous,
+		&r.Status,
+		&r.ReviewerID,
+		&r.ReviewerComment,
+		&r.ReviewedAt,
+		&r.Reply,
+		&r.RepliedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,424 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+func (s *PostgresStorage) AddSpecialistSchedule(c context.Context, specialistID interface{}, req *model.AddSchedule) (*model.Schedule, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistSchedulesTableName).
+		Columns(
+			"profile_id",
+			"organization_id",
+			"address_id",
+			"timezone",
+			"slot_duration",
+		).
+		Values(
+			specialistID,
+			storage.NullInt64(req.OrganizationID),
+			storage.NullInt64(req.AddressID),
+			req.Timezone,
+			req.SlotDuration,
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := s.updateSpecialistScheduleHours(c, id, req.Hours); err != nil {
+		return nil, err
+	}
+
+	return s.GetSpecialistScheduleByID(c, id)
+}
+
+func (s *PostgresStorage) GetSpecialistSchedules(c context.Context, specialistID interface{}) ([]*model.Schedule, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.specialistScheduleResponseColumns()...).
+		From(specialistSchedulesTableName).
+		Where("profile_id = ?", specialistID).
+		OrderBy("id").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	ss := make([]*model.Schedule, 0)
+	for rows.Next() {
+		sc, err := s.scanSpecialistSchedule(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		ss = append(ss, sc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	for _, v := range ss {
+		v.Hours, err = s.getSpecialistScheduleHours(c, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ss, nil
+}
+
+func (s *PostgresStorage) GetSpecialistScheduleByID(c context.Context, id interface{}) (*model.Schedule, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.specialistScheduleResponseColumns()...).
+		From(specialistSchedulesTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	sc, err := s.scanSpecialistSchedule(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	sc.Hours, err = s.getSpecialistScheduleHours(c, sc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+func (s *PostgresStorage) UpdateSpecialistSchedule(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateSchedule) (*model.Schedule, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistSchedulesTableName).
+		Set("updated_at", time.Now()).
+		Set("organization_id", storage.NullInt64(req.OrganizationID)).
+		Set("address_id", storage.NullInt64(req.AddressID)).
+		Set("timezone", req.Timezone).
+		Set("slot_duration", req.SlotDuration).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	if err := s.updateSpecialistScheduleHours(c, id, req.Hours); err != nil {
+		return nil, err
+	}
+
+	return s.GetSpecialistScheduleByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteSpecialistSchedule(c context.Context, id interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistSchedulesTableName).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) getSpecialistScheduleHours(c context.Context, scheduleID interface{}) ([]*model.WorkingHours, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select("weekday", "start_minute", "end_minute").
+		From(specialistScheduleHoursTableName).
+		Where("schedule_id = ?", scheduleID).
+		OrderBy("weekday", "start_minute").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	hs := make([]*model.WorkingHours, 0)
+	for rows.Next() {
+		var h model.WorkingHours
+		if err := rows.Scan(&h.Weekday, &h.Start, &h.End); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		hs = append(hs, &h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return hs, nil
+}
+
+func (s *PostgresStorage) updateSpecialistScheduleHours(c context.Context, scheduleID interface{}, hours []*model.WorkingHours) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	if _, err := psql.Delete(specialistScheduleHoursTableName).Where("schedule_id = ?", scheduleID).ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	if len(hours) == 0 {
+		return nil
+	}
+
+	q := psql.Insert(specialistScheduleHoursTableName).Columns("schedule_id", "weekday", "start_minute", "end_minute")
+	for _, v := range hours {
+		q = q.Values(scheduleID, v.Weekday, int64(v.Start), int64(v.End))
+	}
+
+	if _, err := q.ExecContext(c); err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) specialistScheduleResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "organization_id",
+		pre + "address_id",
+		pre + "timezone",
+		pre + "slot_duration",
+	}
+}
+
+func (s *PostgresStorage) scanSpecialistSchedule(row squirrel.RowScanner) (*model.Schedule, error) {
+	var sc model.Schedule
+
+	if err := row.Scan(
+		&sc.ID,
+		&sc.CreatedAt,
+		&sc.UpdatedAt,
+		&sc.ProfileID,
+		&sc.OrganizationID,
+		&sc.AddressID,
+		&sc.Timezone,
+		&sc.SlotDuration,
+	); err != nil {
+		return nil, err
+	}
+
+	return &sc, nil
+}
+
+func (s *PostgresStorage) AddSpecialistScheduleException(c context.Context, specialistID interface{}, req *model.AddScheduleException) (*model.ScheduleException, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistScheduleExceptionsTableName).
+		Columns(
+			"profile_id",
+			"schedule_id",
+			"type",
+			"start_date",
+			"end_date",
+			"start_minute",
+			"end_minute",
+			"comment",
+		).
+		Values(
+			specialistID,
+			storage.NullInt64(req.ScheduleID),
+			req.Type,
+			req.StartDate,
+			storage.NullDatePGX(req.EndDate),
+			nullClock(req.Start),
+			nullClock(req.End),
+			storage.NullString(req.Comment),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetSpecialistScheduleExceptionByID(c, id)
+}
+
+// GetSpecialistScheduleExceptions returns the exceptions overlapping the dates, the range is open on a side without the date
+func (s *PostgresStorage) GetSpecialistScheduleExceptions(c context.Context, specialistID interface{}, from *pgtype.Date, to *pgtype.Date) ([]*model.ScheduleException, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(s.specialistScheduleExceptionResponseColumns()...).
+		From(specialistScheduleExceptionsTableName).
+		Where("profile_id = ?", specialistID)
+
+	if from != nil {
+		q = q.Where("end_date >= ?", *from)
+	}
+
+	if to != nil {
+		q = q.Where("start_date <= ?", *to)
+	}
+
+	rows, err := q.OrderBy("start_date", "id").QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	es := make([]*model.ScheduleException, 0)
+	for rows.Next() {
+		e, err := s.scanSpecialistScheduleException(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		es = append(es, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return es, nil
+}
+
+func (s *PostgresStorage) GetSpecialistScheduleExceptionByID(c context.Context, id interface{}) (*model.ScheduleException, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.specialistScheduleExceptionResponseColumns()...).
+		From(specialistScheduleExceptionsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	e, err := s.scanSpecialistScheduleException(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return e, nil
+}
+
+func (s *PostgresStorage) UpdateSpecialistScheduleException(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateScheduleException) (*model.ScheduleException, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistScheduleExceptionsTableName).
+		Set("updated_at", time.Now()).
+		Set("schedule_id", storage.NullInt64(req.ScheduleID)).
+		Set("type", req.Type).
+		Set("start_date", req.StartDate).
+		Set("end_date", storage.NullDatePGX(req.EndDate)).
+		Set("start_minute", nullClock(req.Start)).
+		Set("end_minute", nullClock(req.End)).
+		Set("comment", storage.NullString(req.Comment)).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistScheduleExceptionByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteSpecialistScheduleException(c context.Context, id interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistScheduleExceptionsTableName).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) specialistScheduleExceptionResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "schedule_id",
+		pre + "type",
+		pre + "start_date",
+		pre + "end_date",
+		pre + "start_minute",
+		pre + "end_minute",
+		pre + "comment",
+	}
+}
+
+func (s *PostgresStorage) scanSpecialistScheduleException(row squirrel.RowScanner) (*model.ScheduleException, error) {
+	var e model.ScheduleException
+
+	if err := row.Scan(
+		&e.ID,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&e.ProfileID,
+		&e.ScheduleID,
+		&e.Type,
+		&e.StartDate,
+		&e.EndDate,
+		&e.Start,
+		&e.End,
+		&e.Comment,
+	); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+func nullClock(t *model.Clock) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(*t), Valid: true}
+}
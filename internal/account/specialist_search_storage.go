@@ -0,0 +1,240 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+// accountNameSearchVector must stay the same as the expression of idx_accounts_name_search
+const accountNameSearchVector = "to_tsvector('simple', normalize_name(coalesce(" + accountsTableName + ".first_name, '') || ' ' || coalesce(" + accountsTableName + ".father_name, '') || ' ' || coalesce(" + accountsTableName + ".last_name, '')))"
+
+func (s *PostgresStorage) GetSpecialistFacets(c context.Context, req *model.ListSpecialistsRequest) (*model.SpecialistFacets, error) {
+	var f model.SpecialistFacets
+	var err error
+
+	if f.Total, err = s.countSpecialists(c, req, "", nil); err != nil {
+		return nil, err
+	}
+
+	if f.TreatsAdults, err = s.countSpecialists(c, req, "treats_adults", squirrel.Eq{specialistProfilesTableName + ".treats_adults": true}); err != nil {
+		return nil, err
+	}
+
+	if f.TreatsChildren, err = s.countSpecialists(c, req, "treats_children", squirrel.Eq{specialistProfilesTableName + ".treats_children": true}); err != nil {
+		return nil, err
+	}
+
+	f.Specializations, err = s.getSpecialistIDFacet(c, s.specialistFacetQuery(req, "specializations",
+		specialistSpecializationsTableName+".specialization_id",
+		specialistSpecializationsTableName+" ON "+specialistSpecializationsTableName+".profile_id = "+specialistProfilesTableName+".id",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	f.CuresDiseases, err = s.getSpecialistIDFacet(c, s.specialistFacetQuery(req, "cures_diseases",
+		specialistCuresDiseasesTableName+".disease_id",
+		specialistCuresDiseasesTableName+" ON "+specialistCuresDiseasesTableName+".profile_id = "+specialistProfilesTableName+".id",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	f.Services, err = s.getSpecialistIDFacet(c, s.specialistFacetQuery(req, "services",
+		specialistServicesTableName+".service_id",
+		specialistServicesTableName+" ON "+specialistServicesTableName+".profile_id = "+specialistProfilesTableName+".id",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	f.Cities, err = s.getSpecialistIDFacet(c, s.specialistFacetQuery(req, "cities",
+		accountAddressesTableName+".city_id",
+		accountAddressesTableName+" ON "+accountAddressesTableName+".account_id = "+accountsTableName+".id AND "+accountAddressesTableName+".open",
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	f.MedicalCategories, err = s.getSpecialistValueFacet(c, s.specialistFacetQuery(req, "medical_categories", specialistProfilesTableName+".medical_category", "").
+		Where(specialistProfilesTableName+".medical_category IS NOT NULL"))
+	if err != nil {
+		return nil, err
+	}
+
+	f.Languages, err = s.getSpecialistValueFacet(c, s.specialistFacetQuery(req, "languages",
+		accountLanguagesTableName+".language",
+		accountLanguagesTableName+" ON "+accountLanguagesTableName+".account_id = "+accountsTableName+".id",
+	).Where(s.accountPrivacyVisible("languages")))
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (s *PostgresStorage) countSpecialists(c context.Context, req *model.ListSpecialistsRequest, skip string, where squirrel.Sqlizer) (int64, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := s.specialistSearchQuery(psql, "COUNT(*)").Where(s.specialistSearchFilter(req, skip))
+	if where != nil {
+		q = q.Where(where)
+	}
+
+	var count int64
+	if err := q.QueryRowContext(c).Scan(&count); err != nil {
+		return 0, postgres.ConvertError(err)
+	}
+
+	return count, nil
+}
+
+// specialistFacetQuery counts the specialists for every value of column, join adds the table of the column when needed
+func (s *PostgresStorage) specialistFacetQuery(req *model.ListSpecialistsRequest, skip string, column string, join string) squirrel.SelectBuilder {
+	q := s.specialistSearchQuery(s.SetFormat().RunWith(s.runner()), column, "COUNT(DISTINCT "+specialistProfilesTableName+".id)")
+	if join != "" {
+		q = q.Join(join)
+	}
+
+	return q.Where(s.specialistSearchFilter(req, skip)).
+		GroupBy(column).
+		OrderBy("2 DESC", column)
+}
+
+func (s *PostgresStorage) getSpecialistIDFacet(c context.Context, q squirrel.SelectBuilder) ([]*model.FacetIDCount, error) {
+	rows, err := q.QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	fs := make([]*model.FacetIDCount, 0)
+	for rows.Next() {
+		var f model.FacetIDCount
+		if err := rows.Scan(&f.ID, &f.Count); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		fs = append(fs, &f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return fs, nil
+}
+
+func (s *PostgresStorage) getSpecialistValueFacet(c context.Context, q squirrel.SelectBuilder) ([]*model.FacetCount, error) {
+	rows, err := q.QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	fs := make([]*model.FacetCount, 0)
+	for rows.Next() {
+		var f model.FacetCount
+		if err := rows.Scan(&f.Value, &f.Count); err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		fs = append(fs, &f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return fs, nil
+}
+
+func (s *PostgresStorage) specialistSearchQuery(psql squirrel.StatementBuilderType, columns ...string) squirrel.SelectBuilder {
+	return psql.Select(columns...).
+		From(specialistProfilesTableName).
+		Join(accountsTableName + " ON " + accountsTableName + ".id = " + specialistProfilesTableName + ".account_id")
+}
+
+// specialistSearchFilter matches the search request leaving out the filter named by skip.
+// Filters with several values match any of them.
+func (s *PostgresStorage) specialistSearchFilter(req *model.ListSpecialistsRequest, skip string) squirrel.And {
+	// the subqueries keep question placeholders, they are numbered with the query they are put in
+	psql := squirrel.StatementBuilder
+
+	f := squirrel.And{squirrel.Eq{accountsTableName + ".deleted_at": nil}}
+
+	if len(req.IDList) > 0 {
+		f = append(f, squirrel.Eq{specialistProfilesTableName + ".id": req.IDList})
+	}
+
+	if q := req.NameQuery(); q != "" {
+		f = append(f,
+			squirrel.Expr(accountNameSearchVector+" @@ to_tsquery('simple', ?)", q),
+			s.accountPrivacyVisible("names"),
+		)
+	}
+
+	if len(req.Specializations) > 0 && skip != "specializations" {
+		f = append(f, squirrel.Expr("EXISTS(?)", psql.Select("1").
+			From(specialistSpecializationsTableName).
+			Where(specialistSpecializationsTableName+".profile_id = "+specialistProfilesTableName+".id").
+			Where(squirrel.Eq{specialistSpecializationsTableName + ".specialization_id": req.Specializations})))
+	}
+
+	if len(req.CuresDiseases) > 0 && skip != "cures_diseases" {
+		f = append(f, squirrel.Expr("EXISTS(?)", psql.Select("1").
+			From(specialistCuresDiseasesTableName).
+			Where(specialistCuresDiseasesTableName+".profile_id = "+specialistProfilesTableName+".id").
+			Where(squirrel.Eq{specialistCuresDiseasesTableName + ".disease_id": req.CuresDiseases})))
+	}
+
+	if len(req.Services) > 0 && skip != "services" {
+		f = append(f, squirrel.Expr("EXISTS(?)", psql.Select("1").
+			From(specialistServicesTableName).
+			Where(specialistServicesTableName+".profile_id = "+specialistProfilesTableName+".id").
+			Where(squirrel.Eq{specialistServicesTableName + ".service_id": req.Services})))
+	}
+
+	if len(req.MedicalCategories) > 0 && skip != "medical_categories" {
+		f = append(f, squirrel.Eq{specialistProfilesTableName + ".medical_category": req.MedicalCategories})
+	}
+
+	if req.TreatsAdults != nil && skip != "treats_adults" {
+		f = append(f, squirrel.Eq{specialistProfilesTableName + ".treats_adults": *req.TreatsAdults})
+	}
+
+	if req.TreatsChildren != nil && skip != "treats_children" {
+		f = append(f, squirrel.Eq{specialistProfilesTableName + ".treats_children": *req.TreatsChildren})
+	}
+
+	if len(req.Languages) > 0 && skip != "languages" {
+		f = append(f,
+			squirrel.Expr("EXISTS(?)", psql.Select("1").
+				From(accountLanguagesTableName).
+				Where(accountLanguagesTableName+".account_id = "+accountsTableName+".id").
+				Where(squirrel.Eq{accountLanguagesTableName + ".language": req.Languages})),
+			s.accountPrivacyVisible("languages"),
+		)
+	}
+
+	if len(req.Cities) > 0 && skip != "cities" {
+		f = append(f, squirrel.Expr("EXISTS(?)", psql.Select("1").
+			From(accountAddressesTableName).
+			Where(accountAddressesTableName+".account_id = "+accountsTableName+".id AND "+accountAddressesTableName+".open").
+			Where(squirrel.Eq{accountAddressesTableName + ".city_id": req.Cities})))
+	}
+
+	return f
+}
+
+// accountPrivacyVisible keeps the accounts showing the privacy setting column to every user,
+// searching by the data hidden from the searcher would reveal it otherwise.
+// Accounts without settings have the default, users for the names and languages.
+func (s *PostgresStorage) accountPrivacyVisible(column string) squirrel.Sqlizer {
+	return squirrel.Expr(
+		"COALESCE((SELECT "+column+"::TEXT FROM "+accountPrivacySettingsTableName+" WHERE account_id = "+accountsTableName+".id), ?) IN (?, ?)",
+		model.PrivacyUsers, model.PrivacyUsers, model.PrivacyPublic,
+	)
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func (s *PostgresStorage) AddSpecialistProfileSpecialization(c context.Context, specialistID interface{}, req *model.AddSpecialization) (*model.Specialization, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Insert(specialistSpecializationsTableName).
 		Columns("profile_id", "specialization_id", "start").
@@ -31,7 +31,7 @@ func (s *PostgresStorage) AddSpecialistProfileSpecialization(c context.Context,
 }
 
 func (s *PostgresStorage) GetSpecialistProfileSpecializations(c context.Context, specialistID interface{}) ([]*model.Specialization, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.specializationResponseColumns()...).
 		From(specialistSpecializationsTableName).
@@ -54,7 +54,7 @@ func (s *PostgresStorage) GetSpecialistProfileSpecializations(c context.Context,
 }
 
 func (s *PostgresStorage) GetSpecialistProfileSpecialization(c context.Context, id interface{}, specialistID interface{}) (*model.Specialization, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	row := psql.Select(s.specializationResponseColumns()...).
 		From(specialistSpecializationsTableName).
@@ -70,7 +70,7 @@ func (s *PostgresStorage) GetSpecialistProfileSpecialization(c context.Context,
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileSpecialization(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateSpecialization) (*model.Specialization, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(specialistSpecializationsTableName).
 		Set("start", req.Start).
@@ -92,7 +92,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileSpecialization(c context.Contex
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileSpecializationFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateSpecializationFields) (*model.Specialization, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	//req["updated_at"] = time.Now()
 
 	res, err := psql.Update(specialistSpecializationsTableName).
@@ -115,7 +115,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileSpecializationFields(c context.
 }
 
 func (s *PostgresStorage) DeleteSpecialistProfileSpecialization(c context.Context, id interface{}, specialistID interface{}) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Delete(specialistSpecializationsTableName).
 		Where("profile_id = ? AND specialization_id = ?", specialistID, id).
@@ -0,0 +1,185 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+func (s *PostgresStorage) AddSpecialistProfileSpeech(c context.Context, specialistID interface{}, req *model.AddSpeech) (*model.Speech, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistSpeechesTableName).
+		Columns(
+			"profile_id",
+			"event",
+			"topic",
+			"location",
+			"date",
+			"link",
+		).
+		Values(
+			specialistID,
+			req.Event,
+			req.Topic,
+			storage.NullString(req.Location),
+			req.Date,
+			storage.NullString(req.Link),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetSpecialistProfileSpeechByID(c, id)
+}
+
+func (s *PostgresStorage) GetSpecialistProfileSpeeches(c context.Context, specialistID interface{}) ([]*model.Speech, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.speechResponseColumns()...).
+		From(specialistSpeechesTableName).
+		Where("profile_id = ?", specialistID).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ss []*model.Speech
+	for rows.Next() {
+		sp, err := s.scanSpeech(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ss = append(ss, sp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ss, nil
+}
+
+func (s *PostgresStorage) GetSpecialistProfileSpeechByID(c context.Context, id interface{}) (*model.Speech, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.speechResponseColumns()...).
+		From(specialistSpeechesTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	sp, err := s.scanSpeech(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return sp, nil
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileSpeech(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateSpeech) (*model.Speech, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistSpeechesTableName).
+		Set("event", req.Event).
+		Set("topic", req.Topic).
+		Set("location", storage.NullString(req.Location)).
+		Set("date", req.Date).
+		Set("link", storage.NullString(req.Link)).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileSpeechByID(c, id)
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileSpeechFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateSpeechFields) (*model.Speech, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistSpeechesTableName).
+		SetMap(req).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileSpeechByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteSpecialistProfileSpeech(c context.Context, id interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistSpeechesTableName).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) speechResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "profile_id",
+		pre + "event",
+		pre + "topic",
+		pre + "location",
+		pre + "date",
+		pre + "link",
+	}
+}
+
+func (s *PostgresStorage) scanSpeech(row squirrel.RowScanner) (*model.Speech, error) {
+	var sp model.Speech
+
+	if err := row.Scan(
+		&sp.ID,
+		&sp.ProfileID,
+		&sp.Event,
+		&sp.Topic,
+		&sp.Location,
+		&sp.Date,
+		&sp.Link,
+	); err != nil {
+		return nil, err
+	}
+
+	return &sp, nil
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func (s *PostgresStorage) AddSpecialistProfile(c context.Context, accountID interface{}, req *model.AddSpecialistProfile) (*model.Specialist, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Insert(specialistProfilesTableName).
 		Columns(
@@ -53,7 +53,7 @@ func (s *PostgresStorage) AddSpecialistProfile(c context.Context, accountID inte
 }
 
 func (s *PostgresStorage) GetSpecialistByID(c context.Context, id interface{}) (*model.Specialist, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	rows, err := psql.Select(s.specialistResponseColumns()...).
 		From(specialistProfilesTableName).
@@ -71,6 +71,8 @@ func (s *PostgresStorage) GetSpecialistByID(c context.Context, id interface{}) (
 		LeftJoin(specialistAssociationsTableName + " ON " + specialistAssociationsTableName + ".profile_id = " + specialistProfilesTableName + ".id").
 		LeftJoin(specialistPatentsTableName + " ON " + specialistPatentsTableName + ".profile_id = " + specialistProfilesTableName + ".id").
 		LeftJoin(specialistPublicationLinksTableName + " ON " + specialistPublicationLinksTableName + ".profile_id = " + specialistProfilesTableName + ".id").
+		LeftJoin(specialistTeachingsTableName + " ON " + specialistTeachingsTableName + ".profile_id = " + specialistProfilesTableName + ".id").
+		LeftJoin(specialistSpeechesTableName + " ON " + specialistSpeechesTableName + ".profile_id = " + specialistProfilesTableName + ".id").
 		Where(squirrel.Eq{specialistProfilesTableName + ".id": id, accountsTableName + ".deleted_at": nil}).
 		QueryContext(c)
 	if err != nil {
@@ -87,11 +89,21 @@ func (s *PostgresStorage) GetSpecialistByID(c context.Context, id interface{}) (
 		return nil, storage.ErrNotFound
 	}
 
+	p.Licences, err = s.GetSpecialistProfileLicences(c, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.EducationalCourses, err = s.GetSpecialistProfileEducationalCourses(c, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return p, nil
 }
 
 func (s *PostgresStorage) GetSpecialists(c context.Context, req *model.ListSpecialistsRequest) ([]*model.Specialist, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	q := psql.Select(s.specialistsResponseColumns()...).
 		From(specialistProfilesTableName).
@@ -100,13 +112,15 @@ func (s *PostgresStorage) GetSpecialists(c context.Context, req *model.ListSpeci
 		LeftJoin(accountEmailsTableName + " ON " + accountEmailsTableName + ".id = " + specialistProfilesTableName + ".email_id AND " + specialistProfilesTableName + ".email_id IS NOT NULL").
 		LeftJoin(specialistSpecializationsTableName + " ON " + specialistSpecializationsTableName + ".profile_id = " + specialistProfilesTableName + ".id")
 
-	if len(req.IDList) > 0 {
-		q = q.Where(squirrel.Eq{specialistProfilesTableName + ".id": req.IDList})
-	}
-
-	rows, err := q.OrderBy(req.OrderBy).
+	// the page is taken over the profiles, the joined specializations would cut it otherwise
+	page := s.specialistSearchQuery(psql, specialistProfilesTableName+".*").
+		Where(s.specialistSearchFilter(req, "")).
+		OrderBy(req.OrderBy).
 		Limit(req.Limit).
-		Offset(req.Offset()).
+		Offset(req.Offset())
+
+	rows, err := q.Where(psql.Select("id").FromSelect(page, "page").Prefix(specialistProfilesTableName + ".id IN (").Suffix(")")).
+		OrderBy(req.OrderBy).
 		QueryContext(c)
 	if err != nil {
 		return nil, postgres.ConvertError(err)
@@ -122,7 +136,7 @@ func (s *PostgresStorage) GetSpecialists(c context.Context, req *model.ListSpeci
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileMain(c context.Context, specialistID interface{}, req *model.UpdateSpecialistProfile) (*model.Specialist, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	res, err := psql.Update(specialistProfilesTableName).
 		Set("updated_at", time.Now()).
@@ -157,7 +171,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileMain(c context.Context, special
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileCuresDiseases(specialistID interface{}, req []int64) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	_, err := psql.Delete(specialistCuresDiseasesTableName).Where("profile_id = ?", specialistID).Exec()
 	if err != nil {
@@ -189,7 +203,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileCuresDiseases(specialistID inte
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileServices(specialistID interface{}, req []int64) error {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 
 	_, err := psql.Delete(specialistServicesTableName).Where("profile_id = ?", specialistID).Exec()
 	if err != nil {
@@ -221,7 +235,7 @@ func (s *PostgresStorage) UpdateSpecialistProfileServices(specialistID interface
 }
 
 func (s *PostgresStorage) UpdateSpecialistProfileFields(c context.Context, id interface{}, req model.UpdateSpecialistProfileFields) (*model.Specialist, error) {
-	psql := s.SetFormat().RunWith(s.DB)
+	psql := s.SetFormat().RunWith(s.runner())
 	req["updated_at"] = time.Now()
 
 	res, err := psql.Update(specialistProfilesTableName).
@@ -259,6 +273,8 @@ func (s *PostgresStorage) specialistResponseColumns() []string {
 	fields = append(fields, s.associationResponseColumns(specialistAssociationsTableName)...)
 	fields = append(fields, s.patentResponseColumns(specialistPatentsTableName)...)
 	fields = append(fields, s.publicationLinkResponseColumns(specialistPublicationLinksTableName)...)
+	fields = append(fields, s.teachingResponseColumns(specialistTeachingsTableName)...)
+	fields = append(fields, s.speechResponseColumns(specialistSpeechesTableName)...)
 
 	return fields
 }
@@ -279,6 +295,7 @@ func (s *PostgresStorage) specialistResponseColumnsMain(prefixes ...string) []st
 
 	return []string{
 		pre + "id",
+		pre + "account_id",
 		pre + "updated_at",
 		pre + "about",
 		pre + "medical_category",
@@ -342,6 +359,8 @@ func (s *PostgresStorage) scanSpecialist(rows *sql.Rows) (*model.Specialist, err
 	associations := make(map[int64]*model.Association)
 	patents := make(map[int64]*model.Patent)
 	publicationLinks := make(map[int64]*model.PublicationLink)
+	teachings := make(map[int64]*model.Teaching)
+	speeches := make(map[int64]*model.Speech)
 
 	for rows.Next() {
 		var phone model.PhoneJoin
@@ -356,9 +375,12 @@ func (s *PostgresStorage) scanSpecialist(rows *sql.Rows) (*model.Specialist, err
 		var ass model.AssociationJoin
 		var pat model.PatentJoin
 		var pl model.PublicationLinkJoin
+		var tch model.TeachingJoin
+		var sph model.SpeechJoin
 
 		if err := rows.Scan(
 			&sp.ID,
+			&sp.AccountID,
 			&sp.UpdatedAt,
 			&sp.About,
 			&sp.MedicalCategory,
@@ -403,9 +425,11 @@ func (s *PostgresStorage) scanSpecialist(rows *sql.Rows) (*model.Specialist, err
 			&exp.ID,
 			&exp.ProfileID,
 			&exp.CompanyID,
+			&exp.OrganizationID,
 			&exp.Company,
 			&exp.Start,
 			&exp.Finish,
+			&exp.Verified,
 
 			&expSp,
 
@@ -414,6 +438,7 @@ func (s *PostgresStorage) scanSpecialist(rows *sql.Rows) (*model.Specialist, err
 			&ass.AssociationID,
 			&ass.Name,
 			&ass.JobTitle,
+			&ass.Verified,
 
 			&pat.ID,
 			&pat.ProfileID,
@@ -425,6 +450,23 @@ func (s *PostgresStorage) scanSpecialist(rows *sql.Rows) (*model.Specialist, err
 			&pl.ProfileID,
 			&pl.Title,
 			&pl.Link,
+
+			&tch.ID,
+			&tch.ProfileID,
+			&tch.InstitutionID,
+			&tch.Institution,
+			&tch.Position,
+			&tch.Subject,
+			&tch.Start,
+			&tch.Finish,
+
+			&sph.ID,
+			&sph.ProfileID,
+			&sph.Event,
+			&sph.Topic,
+			&sph.Location,
+			&sph.Date,
+			&sph.Link,
 		); err != nil {
 			return nil, err
 		}
@@ -505,6 +547,22 @@ func (s *PostgresStorage) scanSpecialist(rows *sql.Rows) (*model.Specialist, err
 				sp.PublicationLinks = append(sp.PublicationLinks, &val)
 			}
 		}
+
+		if tch.ID != nil {
+			if _, ok := teachings[*tch.ID]; !ok {
+				val := tch.ConvertToTeaching()
+				teachings[*tch.ID] = &val
+				sp.Teachings = append(sp.Teachings, &val)
+			}
+		}
+
+		if sph.ID != nil {
+			if _, ok := speeches[*sph.ID]; !ok {
+				val := sph.ConvertToSpeech()
+				speeches[*sph.ID] = &val
+				sp.Speeches = append(sp.Speeches, &val)
+			}
+		}
 	}
 
 	for _, v := range educations {
@@ -536,6 +594,7 @@ func (s *PostgresStorage) scanSpecialists(rows *sql.Rows) ([]*model.Specialist,
 
 		if err := rows.Scan(
 			&sp.ID,
+			&sp.AccountID,
 			&sp.UpdatedAt,
 			&sp.About,
 			&sp.MedicalCategory,
@@ -569,21 +628,15 @@ func (s *PostgresStorage) scanSpecialists(rows *sql.Rows) ([]*model.Specialist,
 			sp.Email = &v
 		}
 
+		if _, ok := ssM[sp.ID]; !ok {
+			ssM[sp.ID] = &sp
+			ss = append(ss, &sp)
+		}
+
 		if specialization.SpecializationID != nil {
 			val := specialization.ConvertToSpecialization()
-
-			if _, ok := ssM[sp.ID]; ok {
-				ssM[sp.ID].Specializations = append(ssM[sp.ID].Specializations, &val)
-			} else {
-				sp.Specializations = append(sp.Specializations, &val)
-				ssM[sp.ID] = &sp
-			}
+			ssM[sp.ID].Specializations = append(ssM[sp.ID].Specializations, &val)
 		}
-
-	}
-
-	for _, v := range ssM {
-		ss = append(ss, v)
 	}
 
 	if err := rows.Err(); err != nil {
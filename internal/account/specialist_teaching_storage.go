@@ -0,0 +1,190 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+)
+
+func (s *PostgresStorage) AddSpecialistProfileTeaching(c context.Context, specialistID interface{}, req *model.AddTeaching) (*model.Teaching, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistTeachingsTableName).
+		Columns(
+			"profile_id",
+			"institution_id",
+			"institution",
+			"position",
+			"subject",
+			"start",
+			"finish",
+		).
+		Values(
+			specialistID,
+			storage.NullInt64(req.InstitutionID),
+			req.Institution,
+			req.Position,
+			storage.NullString(req.Subject),
+			req.Start,
+			storage.NullDatePGX(req.Finish),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetSpecialistProfileTeachingByID(c, id)
+}
+
+func (s *PostgresStorage) GetSpecialistProfileTeachings(c context.Context, specialistID interface{}) ([]*model.Teaching, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.teachingResponseColumns()...).
+		From(specialistTeachingsTableName).
+		Where("profile_id = ?", specialistID).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var ts []*model.Teaching
+	for rows.Next() {
+		t, err := s.scanTeaching(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+
+		ts = append(ts, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return ts, nil
+}
+
+func (s *PostgresStorage) GetSpecialistProfileTeachingByID(c context.Context, id interface{}) (*model.Teaching, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.teachingResponseColumns()...).
+		From(specialistTeachingsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	t, err := s.scanTeaching(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return t, nil
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileTeaching(c context.Context, id interface{}, specialistID interface{}, req *model.UpdateTeaching) (*model.Teaching, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistTeachingsTableName).
+		Set("institution_id", storage.NullInt64(req.InstitutionID)).
+		Set("institution", req.Institution).
+		Set("position", req.Position).
+		Set("subject", storage.NullString(req.Subject)).
+		Set("start", req.Start).
+		Set("finish", storage.NullDatePGX(req.Finish)).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileTeachingByID(c, id)
+}
+
+func (s *PostgresStorage) UpdateSpecialistProfileTeachingFields(c context.Context, id interface{}, specialistID interface{}, req model.UpdateTeachingFields) (*model.Teaching, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Update(specialistTeachingsTableName).
+		SetMap(req).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return nil, storage.ErrNotFound
+	}
+
+	return s.GetSpecialistProfileTeachingByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteSpecialistProfileTeaching(c context.Context, id interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistTeachingsTableName).
+		Where("id = ? AND profile_id = ?", id, specialistID).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) teachingResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "profile_id",
+		pre + "institution_id",
+		pre + "institution",
+		pre + "position",
+		pre + "subject",
+		pre + "start",
+		pre + "finish",
+	}
+}
+
+func (s *PostgresStorage) scanTeaching(row squirrel.RowScanner) (*model.Teaching, error) {
+	var t model.Teaching
+
+	if err := row.Scan(
+		&t.ID,
+		&t.ProfileID,
+		&t.InstitutionID,
+		&t.Institution,
+		&t.Position,
+		&t.Subject,
+		&t.Start,
+		&t.Finish,
+	); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,283 @@
+package account
+
+import (
+	"context"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Masterminds/squirrel"
+	"time"
+)
+
+var verificationEntityTables = map[string]string{
+	model.VerificationEntityEducation:   specialistEducationsTableName,
+	model.VerificationEntityAssociation: specialistAssociationsTableName,
+	model.VerificationEntityExperience:  specialistExperiencesTableName,
+	model.VerificationEntityCourse:      specialistEducationalCoursesTableName,
+}
+
+func (s *PostgresStorage) AddVerification(c context.Context, specialistID interface{}, req *model.AddVerification) (*model.Verification, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Insert(specialistVerificationsTableName).
+		Columns(
+			"profile_id",
+			"entity",
+			"entity_id",
+			"comment",
+		).
+		Values(
+			specialistID,
+			req.Entity,
+			req.EntityID,
+			storage.NullString(req.Comment),
+		).
+		Suffix("RETURNING \"id\"")
+
+	var id int64
+	if err := q.QueryRowContext(c).Scan(&id); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	fq := psql.Insert(specialistVerificationFilesTableName).Columns("verification_id", "file_id")
+	for _, v := range req.Files {
+		fq = fq.Values(id, v)
+	}
+
+	if _, err := fq.ExecContext(c); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetVerificationByID(c, id)
+}
+
+func (s *PostgresStorage) GetSpecialistVerifications(c context.Context, specialistID interface{}) ([]*model.Verification, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.verificationResponseColumns()...).
+		From(specialistVerificationsTableName).
+		Where("profile_id = ?", specialistID).
+		OrderBy("created_at DESC").
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var vs []*model.Verification
+	for rows.Next() {
+		v, err := s.scanVerification(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		vs = append(vs, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	for _, v := range vs {
+		v.Files, err = s.GetVerificationFiles(c, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vs, nil
+}
+
+func (s *PostgresStorage) GetVerifications(c context.Context, req *model.ListVerificationsRequest) ([]*model.Verification, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	q := psql.Select(s.verificationResponseColumns()...).
+		From(specialistVerificationsTableName).
+		Where("status = ?", req.Status)
+
+	if req.Entity != "" {
+		q = q.Where("entity = ?", req.Entity)
+	}
+
+	rows, err := q.OrderBy(req.OrderBy).
+		Limit(req.Limit).
+		Offset(req.Offset()).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var vs []*model.Verification
+	for rows.Next() {
+		v, err := s.scanVerification(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		vs = append(vs, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	for _, v := range vs {
+		v.Files, err = s.GetVerificationFiles(c, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vs, nil
+}
+
+func (s *PostgresStorage) GetVerificationByID(c context.Context, id interface{}) (*model.Verification, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	row := psql.Select(s.verificationResponseColumns()...).
+		From(specialistVerificationsTableName).
+		Where("id = ?", id).
+		QueryRowContext(c)
+
+	v, err := s.scanVerification(row)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	v.Files, err = s.GetVerificationFiles(c, v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (s *PostgresStorage) GetVerificationFiles(c context.Context, verificationID interface{}) ([]*model.File, error) {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	rows, err := psql.Select(s.fileResponseColumns(accountFilesTableName)...).
+		From(specialistVerificationFilesTableName).
+		LeftJoin(accountFilesTableName+" ON "+accountFilesTableName+".id = "+specialistVerificationFilesTableName+".file_id").
+		Where(specialistVerificationFilesTableName+".verification_id = ?", verificationID).
+		QueryContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer rows.Close()
+
+	var files []*model.File
+	for rows.Next() {
+		file, err := s.scanFile(rows)
+		if err != nil {
+			return nil, postgres.ConvertError(err)
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return files, nil
+}
+
+// ReviewVerification sets the status of the verification and the verified flag of the entity together
+func (s *PostgresStorage) ReviewVerification(c context.Context, id interface{}, reviewerID interface{}, status string, req *model.ReviewVerification) (*model.Verification, error) {
+	tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+	defer tx.Rollback()
+
+	psql := s.SetFormat().RunWith(tx)
+
+	var entity string
+	var entityID, profileID int64
+	now := time.Now()
+	if err := psql.Update(specialistVerificationsTableName).
+		Set("updated_at", now).
+		Set("status", status).
+		Set("reviewer_id", reviewerID).
+		Set("reviewer_comment", storage.NullString(req.Comment)).
+		Set("reviewed_at", now).
+		Where("id = ? AND status = ?", id, model.VerificationStatusPending).
+		Suffix("RETURNING \"entity\", \"entity_id\", \"profile_id\"").
+		QueryRowContext(c).
+		Scan(&entity, &entityID, &profileID); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	_, err = psql.Update(verificationEntityTables[entity]).
+		Set("verified", status == model.VerificationStatusApproved).
+		Where("id = ? AND profile_id = ?", entityID, profileID).
+		ExecContext(c)
+	if err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.ConvertError(err)
+	}
+
+	return s.GetVerificationByID(c, id)
+}
+
+func (s *PostgresStorage) DeleteVerification(c context.Context, id interface{}, specialistID interface{}) error {
+	psql := s.SetFormat().RunWith(s.runner())
+
+	res, err := psql.Delete(specialistVerificationsTableName).
+		Where("id = ? AND profile_id = ? AND status = ?", id, specialistID, model.VerificationStatusPending).
+		ExecContext(c)
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return postgres.ConvertError(err)
+	}
+	if ra == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) verificationResponseColumns(prefixes ...string) []string {
+	pre := joinPrefixes(prefixes, ".")
+
+	return []string{
+		pre + "id",
+		pre + "created_at",
+		pre + "updated_at",
+		pre + "profile_id",
+		pre + "entity",
+		pre + "entity_id",
+		pre + "status",
+		pre + "comment",
+		pre + "reviewer_id",
+		pre + "reviewer_comment",
+		pre + "reviewed_at",
+	}
+}
+
+func (s *PostgresStorage) scanVerification(row squirrel.RowScanner) (*model.Verification, error) {
+	var v model.Verification
+
+	if err := row.Scan(
+		&v.ID,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+		&v.ProfileID,
+		&v.Entity,
+		&v.EntityID,
+		&v.Status,
+		&v.Comment,
+		&v.ReviewerID,
+		&v.ReviewerComment,
+		&v.ReviewedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
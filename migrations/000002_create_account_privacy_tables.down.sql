@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS patient_specialists;
+DROP TABLE IF EXISTS account_privacy_settings;
+DROP TYPE IF EXISTS PRIVACY_AUDIENCE;
@@ -0,0 +1,26 @@
+CREATE TYPE PRIVACY_AUDIENCE AS ENUM ('only_me', 'admins', 'specialists', 'users', 'public');
+
+CREATE TABLE IF NOT EXISTS account_privacy_settings
+(
+    account_id BIGINT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    names PRIVACY_AUDIENCE NOT NULL DEFAULT 'users',
+    birthday PRIVACY_AUDIENCE NOT NULL DEFAULT 'admins',
+    photo PRIVACY_AUDIENCE NOT NULL DEFAULT 'users',
+    contacts PRIVACY_AUDIENCE NOT NULL DEFAULT 'admins',
+    languages PRIVACY_AUDIENCE NOT NULL DEFAULT 'users',
+    specialist PRIVACY_AUDIENCE NOT NULL DEFAULT 'public',
+    PRIMARY KEY (account_id),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS patient_specialists
+(
+    profile_id BIGINT NOT NULL,
+    specialist_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (profile_id, specialist_id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (specialist_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_patient_specialists_specialist_id ON patient_specialists(specialist_id);
@@ -0,0 +1,7 @@
+ALTER TABLE specialist_experiences DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organization_licences;
+DROP TABLE IF EXISTS organizations;
+DROP TYPE IF EXISTS INVITATION_STATUS;
+DROP TYPE IF EXISTS ORGANIZATION_ROLE;
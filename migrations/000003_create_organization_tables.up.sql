@@ -0,0 +1,66 @@
+CREATE TYPE ORGANIZATION_ROLE AS ENUM ('owner', 'manager', 'specialist', 'receptionist');
+CREATE TYPE INVITATION_STATUS AS ENUM ('pending', 'accepted', 'declined');
+
+CREATE TABLE IF NOT EXISTS organizations
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    name VARCHAR(255) NOT NULL,
+    about TEXT,
+    city_id BIGINT,
+    address VARCHAR(255),
+    phone VARCHAR(40),
+    email VARCHAR(255),
+    website VARCHAR(255),
+    logo_file_id BIGINT,
+    PRIMARY KEY (id),
+    FOREIGN KEY (logo_file_id) REFERENCES account_files(id) ON DELETE SET NULL,
+    CHECK (city_id > 0)
+);
+
+CREATE TABLE IF NOT EXISTS organization_licences
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    organization_id BIGINT NOT NULL,
+    number VARCHAR(100) NOT NULL,
+    issued DATE NOT NULL,
+    expires DATE,
+    description VARCHAR(255),
+    PRIMARY KEY (id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    UNIQUE (organization_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS organization_members
+(
+    organization_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    role ORGANIZATION_ROLE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, account_id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_organization_members_account_id ON organization_members(account_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    organization_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    invited_by BIGINT,
+    role ORGANIZATION_ROLE NOT NULL,
+    status INVITATION_STATUS NOT NULL DEFAULT 'pending',
+    PRIMARY KEY (id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES accounts(id) ON DELETE SET NULL
+);
+CREATE UNIQUE INDEX idx_organization_invitations_pending ON organization_invitations(organization_id, account_id) WHERE status = 'pending';
+
+ALTER TABLE specialist_experiences ADD COLUMN organization_id BIGINT;
+ALTER TABLE specialist_experiences ADD FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE SET NULL;
+CREATE INDEX idx_specialist_experiences_organization_id ON specialist_experiences(organization_id);
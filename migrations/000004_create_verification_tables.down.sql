@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS specialist_verification_files;
+DROP TABLE IF EXISTS specialist_verifications;
+DROP TYPE IF EXISTS VERIFICATION_STATUS;
+DROP TYPE IF EXISTS VERIFICATION_ENTITY;
+ALTER TABLE specialist_associations DROP COLUMN IF EXISTS verified;
+ALTER TABLE specialist_experiences DROP COLUMN IF EXISTS verified;
+DROP TABLE IF EXISTS account_roles;
+DROP TYPE IF EXISTS ACCOUNT_ROLE;
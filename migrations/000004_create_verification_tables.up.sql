@@ -0,0 +1,44 @@
+CREATE TYPE ACCOUNT_ROLE AS ENUM ('moderator', 'admin');
+CREATE TABLE IF NOT EXISTS account_roles
+(
+    account_id BIGINT NOT NULL,
+    role ACCOUNT_ROLE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, role),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+ALTER TABLE specialist_experiences ADD COLUMN verified BOOLEAN DEFAULT 'false';
+ALTER TABLE specialist_associations ADD COLUMN verified BOOLEAN DEFAULT 'false';
+
+CREATE TYPE VERIFICATION_ENTITY AS ENUM ('education', 'association', 'experience');
+CREATE TYPE VERIFICATION_STATUS AS ENUM ('pending', 'approved', 'rejected');
+CREATE TABLE IF NOT EXISTS specialist_verifications
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    entity VERIFICATION_ENTITY NOT NULL,
+    entity_id BIGINT NOT NULL,
+    status VERIFICATION_STATUS NOT NULL DEFAULT 'pending',
+    comment TEXT,
+    reviewer_id BIGINT,
+    reviewer_comment TEXT,
+    reviewed_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES accounts(id) ON DELETE SET NULL,
+    CHECK (entity_id > 0)
+);
+CREATE UNIQUE INDEX idx_specialist_verifications_pending ON specialist_verifications(entity, entity_id) WHERE status = 'pending';
+CREATE INDEX idx_specialist_verifications_status ON specialist_verifications(status, created_at);
+
+CREATE TABLE IF NOT EXISTS specialist_verification_files
+(
+    verification_id BIGINT NOT NULL,
+    file_id BIGINT NOT NULL,
+    PRIMARY KEY (verification_id, file_id),
+    FOREIGN KEY (verification_id) REFERENCES specialist_verifications(id) ON DELETE CASCADE,
+    FOREIGN KEY (file_id) REFERENCES account_files(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS specialist_licences;
+DROP TYPE IF EXISTS LICENCE_STATUS;
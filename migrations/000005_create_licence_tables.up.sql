@@ -0,0 +1,23 @@
+CREATE TYPE LICENCE_STATUS AS ENUM ('active', 'suspended', 'closed', 'expired');
+
+CREATE TABLE IF NOT EXISTS specialist_licences
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    number VARCHAR(100) NOT NULL,
+    status LICENCE_STATUS NOT NULL DEFAULT 'active',
+    full_name VARCHAR(255) NOT NULL,
+    practice VARCHAR(255) NOT NULL,
+    issued DATE,
+    expires DATE,
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    checked_at TIMESTAMP,
+    reminded_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE,
+    UNIQUE (number)
+);
+CREATE INDEX idx_specialist_licences_profile_id ON specialist_licences(profile_id);
+CREATE INDEX idx_specialist_licences_expires ON specialist_licences(expires) WHERE status = 'active';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS specialist_educational_course_files;
+DROP TABLE IF EXISTS specialist_educational_courses;
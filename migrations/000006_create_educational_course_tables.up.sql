@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS specialist_educational_courses
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    profile_id BIGINT NOT NULL,
+    course_id BIGINT,
+    name VARCHAR(255) NOT NULL,
+    institution_id SMALLINT,
+    institution_name VARCHAR(100),
+    form_id SMALLINT,
+    graduation DATE NOT NULL,
+    points NUMERIC(6, 2) NOT NULL DEFAULT 0,
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE,
+    CHECK (course_id > 0),
+    CHECK (institution_id > 0),
+    CHECK (form_id > 0),
+    CHECK (points >= 0)
+);
+CREATE INDEX idx_specialist_educational_courses_profile_id ON specialist_educational_courses(profile_id, graduation);
+
+CREATE TABLE IF NOT EXISTS specialist_educational_course_files
+(
+    educational_course_id BIGINT NOT NULL,
+    file_id BIGINT NOT NULL,
+    PRIMARY KEY (educational_course_id, file_id),
+    FOREIGN KEY (educational_course_id) REFERENCES specialist_educational_courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (file_id) REFERENCES account_files(id) ON DELETE CASCADE
+);
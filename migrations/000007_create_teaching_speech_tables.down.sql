@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS specialist_speeches;
+DROP TABLE IF EXISTS specialist_teachings;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS specialist_teachings
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    profile_id BIGINT NOT NULL,
+    institution_id BIGINT,
+    institution VARCHAR(255) NOT NULL,
+    position VARCHAR(255) NOT NULL,
+    subject VARCHAR(255),
+    start DATE NOT NULL,
+    finish DATE,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE,
+    CHECK (institution_id > 0),
+    CHECK (finish IS NULL OR finish >= start)
+);
+CREATE INDEX idx_specialist_teachings_profile_id ON specialist_teachings(profile_id);
+
+CREATE TABLE IF NOT EXISTS specialist_speeches
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    profile_id BIGINT NOT NULL,
+    event VARCHAR(255) NOT NULL,
+    topic VARCHAR(255) NOT NULL,
+    location VARCHAR(255),
+    date DATE NOT NULL,
+    link VARCHAR(255),
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_specialist_speeches_profile_id ON specialist_speeches(profile_id);
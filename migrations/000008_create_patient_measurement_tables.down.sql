@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS patient_measurements;
+DROP TYPE IF EXISTS MEASUREMENT_SOURCE;
+DROP TYPE IF EXISTS MEASUREMENT_TYPE;
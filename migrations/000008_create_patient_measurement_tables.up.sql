@@ -0,0 +1,27 @@
+CREATE TYPE MEASUREMENT_TYPE AS ENUM ('height', 'weight', 'left_eye', 'right_eye');
+CREATE TYPE MEASUREMENT_SOURCE AS ENUM ('manual', 'device', 'clinic', 'profile');
+CREATE TABLE IF NOT EXISTS patient_measurements
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    type MEASUREMENT_TYPE NOT NULL,
+    value DECIMAL(7,3) NOT NULL,
+    unit VARCHAR(10) NOT NULL,
+    measured_at TIMESTAMP NOT NULL,
+    source MEASUREMENT_SOURCE NOT NULL DEFAULT 'manual',
+    entered_by BIGINT,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (entered_by) REFERENCES accounts(id) ON DELETE SET NULL
+);
+CREATE INDEX idx_patient_measurements_profile_id_type_measured_at ON patient_measurements(profile_id, type, measured_at);
+
+INSERT INTO patient_measurements (profile_id, type, value, unit, measured_at, source, entered_by)
+SELECT id, 'height', height, 'cm', COALESCE(updated_at, CURRENT_TIMESTAMP), 'profile', account_id FROM patient_profiles WHERE height IS NOT NULL
+UNION ALL
+SELECT id, 'weight', weight, 'kg', COALESCE(updated_at, CURRENT_TIMESTAMP), 'profile', account_id FROM patient_profiles WHERE weight IS NOT NULL
+UNION ALL
+SELECT id, 'left_eye', left_eye, 'D', COALESCE(updated_at, CURRENT_TIMESTAMP), 'profile', account_id FROM patient_profiles WHERE left_eye IS NOT NULL
+UNION ALL
+SELECT id, 'right_eye', right_eye, 'D', COALESCE(updated_at, CURRENT_TIMESTAMP), 'profile', account_id FROM patient_profiles WHERE right_eye IS NOT NULL;
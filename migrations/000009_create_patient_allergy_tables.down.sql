@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS patient_allergies;
+DROP TYPE IF EXISTS ALLERGY_SEVERITY;
+DROP TYPE IF EXISTS ALLERGY_REACTION_TYPE;
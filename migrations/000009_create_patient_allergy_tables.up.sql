@@ -0,0 +1,23 @@
+CREATE TYPE ALLERGY_REACTION_TYPE AS ENUM ('allergy', 'intolerance', 'anaphylaxis', 'skin', 'respiratory', 'gastrointestinal', 'other');
+CREATE TYPE ALLERGY_SEVERITY AS ENUM ('mild', 'moderate', 'severe', 'life_threatening');
+CREATE TABLE IF NOT EXISTS patient_allergies
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    allergen_id BIGINT,
+    allergen VARCHAR(255),
+    reaction_type ALLERGY_REACTION_TYPE NOT NULL,
+    severity ALLERGY_SEVERITY NOT NULL,
+    description VARCHAR(255),
+    confirmed BOOLEAN NOT NULL DEFAULT 'false',
+    confirmed_by BIGINT,
+    confirmed_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (confirmed_by) REFERENCES specialist_profiles(id) ON DELETE SET NULL,
+    CHECK (allergen_id > 0),
+    CHECK (allergen_id IS NOT NULL OR allergen IS NOT NULL)
+);
+CREATE INDEX idx_patient_allergies_profile_id ON patient_allergies(profile_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS patient_conditions;
+DROP TYPE IF EXISTS CONDITION_STATUS;
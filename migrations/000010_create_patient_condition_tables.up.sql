@@ -0,0 +1,21 @@
+CREATE TYPE CONDITION_STATUS AS ENUM ('active', 'resolved');
+CREATE TABLE IF NOT EXISTS patient_conditions
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    icd10 VARCHAR(8) NOT NULL,
+    disease_id SMALLINT,
+    name VARCHAR(255),
+    onset DATE,
+    status CONDITION_STATUS NOT NULL DEFAULT 'active',
+    confirmed_by BIGINT,
+    confirmed_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (confirmed_by) REFERENCES specialist_profiles(id) ON DELETE SET NULL,
+    CHECK (disease_id > 0)
+);
+CREATE INDEX idx_patient_conditions_profile_id ON patient_conditions(profile_id);
+CREATE INDEX idx_patient_conditions_disease_id ON patient_conditions(disease_id);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS patient_medications
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    active_substance VARCHAR(255),
+    dose VARCHAR(100) NOT NULL,
+    frequency VARCHAR(100) NOT NULL,
+    start DATE NOT NULL,
+    finish DATE,
+    prescribed_by BIGINT,
+    stop_reason VARCHAR(255),
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (prescribed_by) REFERENCES specialist_profiles(id) ON DELETE SET NULL,
+    CHECK (finish IS NULL OR finish >= start)
+);
+CREATE INDEX idx_patient_medications_profile_id ON patient_medications(profile_id);
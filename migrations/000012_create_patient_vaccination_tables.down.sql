@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS patient_vaccination_files;
+DROP TABLE IF EXISTS patient_vaccinations;
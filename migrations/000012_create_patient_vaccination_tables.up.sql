@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS patient_vaccinations
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    vaccine VARCHAR(50) NOT NULL,
+    name VARCHAR(255),
+    dose_number SMALLINT NOT NULL,
+    vaccinated_at DATE NOT NULL,
+    lot VARCHAR(50),
+    clinic VARCHAR(255),
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_patient_vaccinations_profile_id ON patient_vaccinations(profile_id);
+
+CREATE TABLE IF NOT EXISTS patient_vaccination_files
+(
+    vaccination_id BIGINT NOT NULL,
+    file_id BIGINT NOT NULL,
+    PRIMARY KEY (vaccination_id, file_id),
+    FOREIGN KEY (vaccination_id) REFERENCES patient_vaccinations(id) ON DELETE CASCADE,
+    FOREIGN KEY (file_id) REFERENCES account_files(id) ON DELETE CASCADE
+);
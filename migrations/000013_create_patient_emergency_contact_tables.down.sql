@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS patient_emergency_contacts;
+DROP TYPE IF EXISTS EMERGENCY_CONTACT_RELATION;
@@ -0,0 +1,17 @@
+CREATE TYPE EMERGENCY_CONTACT_RELATION AS ENUM ('parent', 'child', 'spouse', 'sibling', 'relative', 'guardian', 'friend', 'other');
+CREATE TABLE IF NOT EXISTS patient_emergency_contacts
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    relation EMERGENCY_CONTACT_RELATION NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    account_id BIGINT,
+    priority SMALLINT NOT NULL DEFAULT 1,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE SET NULL
+);
+CREATE INDEX idx_patient_emergency_contacts_profile_id ON patient_emergency_contacts(profile_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS patient_insurance_policy_files;
+DROP TABLE IF EXISTS patient_insurance_policies;
+DROP TYPE IF EXISTS INSURANCE_COVERAGE_TYPE;
+
+DELETE FROM account_roles WHERE role = 'service';
+ALTER TYPE ACCOUNT_ROLE RENAME TO ACCOUNT_ROLE_OLD;
+CREATE TYPE ACCOUNT_ROLE AS ENUM ('moderator', 'admin');
+ALTER TABLE account_roles ALTER COLUMN role TYPE ACCOUNT_ROLE USING role::text::ACCOUNT_ROLE;
+DROP TYPE IF EXISTS ACCOUNT_ROLE_OLD;
@@ -0,0 +1,32 @@
+ALTER TYPE ACCOUNT_ROLE ADD VALUE IF NOT EXISTS 'service';
+
+CREATE TYPE INSURANCE_COVERAGE_TYPE AS ENUM ('compulsory', 'voluntary', 'travel', 'dental', 'other');
+CREATE TABLE IF NOT EXISTS patient_insurance_policies
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    insurer VARCHAR(255) NOT NULL,
+    policy_number VARCHAR(100) NOT NULL,
+    coverage_type INSURANCE_COVERAGE_TYPE NOT NULL,
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    reminded_at TIMESTAMP,
+    expired_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    UNIQUE (profile_id, insurer, policy_number),
+    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+CREATE INDEX idx_patient_insurance_policies_profile_id ON patient_insurance_policies(profile_id);
+CREATE INDEX idx_patient_insurance_policies_valid_to ON patient_insurance_policies(valid_to) WHERE expired_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS patient_insurance_policy_files
+(
+    policy_id BIGINT NOT NULL,
+    file_id BIGINT NOT NULL,
+    PRIMARY KEY (policy_id, file_id),
+    FOREIGN KEY (policy_id) REFERENCES patient_insurance_policies(id) ON DELETE CASCADE,
+    FOREIGN KEY (file_id) REFERENCES account_files(id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+DELETE FROM patient_profiles WHERE account_id IS NULL;
+DROP INDEX IF EXISTS idx_patient_profiles_owner_id;
+ALTER TABLE patient_profiles
+    DROP COLUMN IF EXISTS handover_expires_at,
+    DROP COLUMN IF EXISTS handover_code,
+    DROP COLUMN IF EXISTS birthday,
+    DROP COLUMN IF EXISTS sex,
+    DROP COLUMN IF EXISTS last_name,
+    DROP COLUMN IF EXISTS father_name,
+    DROP COLUMN IF EXISTS first_name,
+    DROP COLUMN IF EXISTS owner_id,
+    ALTER COLUMN account_id SET NOT NULL;
@@ -0,0 +1,14 @@
+ALTER TABLE patient_profiles
+    ALTER COLUMN account_id DROP NOT NULL,
+    ADD COLUMN owner_id BIGINT,
+    ADD COLUMN first_name VARCHAR(100),
+    ADD COLUMN father_name VARCHAR(100),
+    ADD COLUMN last_name VARCHAR(100),
+    ADD COLUMN sex SEX_TYPE,
+    ADD COLUMN birthday DATE,
+    ADD COLUMN handover_code VARCHAR(64), -- sha256 of the code given to the dependent
+    ADD COLUMN handover_expires_at TIMESTAMP,
+    ADD FOREIGN KEY (owner_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    ADD UNIQUE (handover_code),
+    ADD CHECK (account_id IS NOT NULL OR owner_id IS NOT NULL);
+CREATE INDEX idx_patient_profiles_owner_id ON patient_profiles(owner_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS account_exports;
+DROP TYPE IF EXISTS EXPORT_STATUS;
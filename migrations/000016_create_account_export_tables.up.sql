@@ -0,0 +1,18 @@
+CREATE TYPE EXPORT_STATUS AS ENUM ('pending', 'processing', 'ready', 'failed', 'expired');
+CREATE TABLE IF NOT EXISTS account_exports
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    account_id BIGINT NOT NULL,
+    status EXPORT_STATUS NOT NULL DEFAULT 'pending',
+    file_key VARCHAR(255),
+    size BIGINT,
+    error TEXT,
+    ready_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_account_exports_account_id ON account_exports(account_id);
+CREATE UNIQUE INDEX idx_account_exports_account_id_active ON account_exports(account_id) WHERE status IN ('pending', 'processing');
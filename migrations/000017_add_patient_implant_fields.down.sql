@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS patient_metal_component_files;
+DROP INDEX IF EXISTS idx_patient_metal_components_profile_id;
+ALTER TABLE patient_metal_components
+    DROP COLUMN IF EXISTS mri_conditions,
+    DROP COLUMN IF EXISTS mri_safety,
+    DROP COLUMN IF EXISTS implanted,
+    DROP COLUMN IF EXISTS model,
+    DROP COLUMN IF EXISTS manufacturer,
+    DROP COLUMN IF EXISTS type,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
+DROP TYPE IF EXISTS MRI_SAFETY_TYPE;
+DROP TYPE IF EXISTS IMPLANT_TYPE;
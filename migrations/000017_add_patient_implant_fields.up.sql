@@ -0,0 +1,22 @@
+CREATE TYPE IMPLANT_TYPE AS ENUM ('pacemaker', 'defibrillator', 'cochlear_implant', 'neurostimulator', 'infusion_pump', 'stent', 'heart_valve', 'joint_replacement', 'osteosynthesis', 'aneurysm_clip', 'dental_implant', 'foreign_body', 'other');
+CREATE TYPE MRI_SAFETY_TYPE AS ENUM ('safe', 'conditional', 'unsafe');
+ALTER TABLE patient_metal_components
+    ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN type IMPLANT_TYPE NOT NULL DEFAULT 'other',
+    ADD COLUMN manufacturer VARCHAR(255),
+    ADD COLUMN model VARCHAR(255),
+    ADD COLUMN implanted DATE,
+    ADD COLUMN mri_safety MRI_SAFETY_TYPE,
+    ADD COLUMN mri_conditions TEXT,
+    ADD CHECK (mri_safety = 'conditional' OR mri_conditions IS NULL);
+CREATE INDEX idx_patient_metal_components_profile_id ON patient_metal_components(profile_id);
+
+CREATE TABLE IF NOT EXISTS patient_metal_component_files
+(
+    component_id BIGINT NOT NULL,
+    file_id BIGINT NOT NULL,
+    PRIMARY KEY (component_id, file_id),
+    FOREIGN KEY (component_id) REFERENCES patient_metal_components(id) ON DELETE CASCADE,
+    FOREIGN KEY (file_id) REFERENCES account_files(id) ON DELETE CASCADE
+);
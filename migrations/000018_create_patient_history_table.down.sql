@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS patient_history;
+DROP TYPE IF EXISTS HISTORY_ACTION_TYPE;
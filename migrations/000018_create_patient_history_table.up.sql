@@ -0,0 +1,18 @@
+CREATE TYPE HISTORY_ACTION_TYPE AS ENUM ('create', 'update', 'delete');
+CREATE TABLE IF NOT EXISTS patient_history
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    account_id BIGINT,
+    resource VARCHAR(50) NOT NULL,
+    resource_id BIGINT NOT NULL,
+    action HISTORY_ACTION_TYPE NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    snapshot JSONB NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE SET NULL
+);
+CREATE INDEX idx_patient_history_profile_id_created_at ON patient_history(profile_id, created_at);
+CREATE INDEX idx_patient_history_resource ON patient_history(profile_id, resource, resource_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS patient_family_history;
+DROP TYPE IF EXISTS FAMILY_RELATIVE_TYPE;
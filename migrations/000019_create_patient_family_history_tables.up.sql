@@ -0,0 +1,21 @@
+CREATE TYPE FAMILY_RELATIVE_TYPE AS ENUM ('mother', 'father', 'sibling', 'child', 'grandparent', 'grandchild', 'aunt_uncle', 'cousin', 'other');
+CREATE TABLE IF NOT EXISTS patient_family_history
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    relative FAMILY_RELATIVE_TYPE NOT NULL,
+    relative_profile_id BIGINT,
+    icd10 VARCHAR(8) NOT NULL,
+    name VARCHAR(255),
+    onset_age SMALLINT,
+    deceased BOOLEAN NOT NULL DEFAULT 'false',
+    description VARCHAR(255),
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (relative_profile_id) REFERENCES patient_profiles(id) ON DELETE SET NULL,
+    CHECK (onset_age >= 0),
+    CHECK (relative_profile_id <> profile_id)
+);
+CREATE INDEX idx_patient_family_history_profile_id ON patient_family_history(profile_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS patient_lifestyle_hazards;
+DROP TABLE IF EXISTS patient_lifestyle_records;
+DROP TYPE IF EXISTS OCCUPATIONAL_HAZARD;
+DROP TYPE IF EXISTS DIET_TYPE;
+DROP TYPE IF EXISTS SMOKING_STATUS;
@@ -0,0 +1,33 @@
+CREATE TYPE SMOKING_STATUS AS ENUM ('never', 'former', 'current');
+CREATE TYPE DIET_TYPE AS ENUM ('omnivore', 'vegetarian', 'vegan', 'pescatarian', 'mediterranean', 'low_carb', 'other');
+CREATE TYPE OCCUPATIONAL_HAZARD AS ENUM ('none', 'noise', 'dust', 'chemicals', 'radiation', 'vibration', 'biological', 'heat', 'cold', 'night_shifts', 'heavy_lifting', 'prolonged_sitting', 'screen_work', 'other');
+CREATE TABLE IF NOT EXISTS patient_lifestyle_records
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    effective_date DATE NOT NULL,
+    smoking_status SMOKING_STATUS,
+    pack_years DECIMAL(5,1),
+    alcohol_units SMALLINT,
+    activity_minutes SMALLINT,
+    diet DIET_TYPE,
+    entered_by BIGINT,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (entered_by) REFERENCES accounts(id) ON DELETE SET NULL,
+    CHECK (pack_years >= 0),
+    CHECK (pack_years IS NULL OR smoking_status IN ('former', 'current')),
+    CHECK (alcohol_units >= 0),
+    CHECK (activity_minutes >= 0)
+);
+CREATE INDEX idx_patient_lifestyle_records_profile_id_effective_date ON patient_lifestyle_records(profile_id, effective_date);
+
+CREATE TABLE IF NOT EXISTS patient_lifestyle_hazards
+(
+    record_id BIGINT NOT NULL,
+    hazard OCCUPATIONAL_HAZARD NOT NULL,
+    PRIMARY KEY (record_id, hazard),
+    FOREIGN KEY (record_id) REFERENCES patient_lifestyle_records(id) ON DELETE CASCADE
+);
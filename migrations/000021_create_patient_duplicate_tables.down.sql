@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS patient_duplicates;
+DROP TYPE IF EXISTS DUPLICATE_STATUS_TYPE;
@@ -0,0 +1,29 @@
+CREATE TYPE DUPLICATE_STATUS_TYPE AS ENUM ('pending', 'merged', 'dismissed');
+CREATE TABLE IF NOT EXISTS patient_duplicates
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT,
+    duplicate_profile_id BIGINT,
+    score DECIMAL(4,3) NOT NULL,
+    name_score DECIMAL(4,3) NOT NULL,
+    birthday_match BOOLEAN DEFAULT 'false',
+    sex_match BOOLEAN DEFAULT 'false',
+    phone_match BOOLEAN DEFAULT 'false',
+    email_match BOOLEAN DEFAULT 'false',
+    status DUPLICATE_STATUS_TYPE NOT NULL DEFAULT 'pending',
+    reviewer_id BIGINT,
+    reviewed_at TIMESTAMP,
+    survivor_id BIGINT,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES patient_profiles(id) ON DELETE SET NULL,
+    FOREIGN KEY (duplicate_profile_id) REFERENCES patient_profiles(id) ON DELETE SET NULL,
+    FOREIGN KEY (reviewer_id) REFERENCES accounts(id) ON DELETE SET NULL,
+    FOREIGN KEY (survivor_id) REFERENCES patient_profiles(id) ON DELETE SET NULL,
+    UNIQUE (profile_id, duplicate_profile_id),
+    CHECK (profile_id < duplicate_profile_id),
+    CHECK (score >= 0 AND score <= 1),
+    CHECK (name_score >= 0 AND name_score <= 1)
+);
+CREATE INDEX idx_patient_duplicates_status ON patient_duplicates(status);
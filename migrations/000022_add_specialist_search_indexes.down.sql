@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_account_addresses_city_id;
+DROP INDEX IF EXISTS idx_account_languages_language;
+DROP INDEX IF EXISTS idx_specialist_profiles_medical_category;
+DROP INDEX IF EXISTS idx_specialist_services_service_id;
+DROP INDEX IF EXISTS idx_specialist_cures_diseases_disease_id;
+DROP INDEX IF EXISTS idx_specialist_specializations_specialization_id;
+DROP INDEX IF EXISTS idx_accounts_name_search;
+DROP FUNCTION IF EXISTS normalize_name(TEXT);
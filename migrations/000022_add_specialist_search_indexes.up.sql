@@ -0,0 +1,24 @@
+-- normalize_name follows namematch.Normalize, words are kept apart to be indexed separately
+CREATE OR REPLACE FUNCTION normalize_name(name TEXT) RETURNS TEXT AS
+$$
+SELECT regexp_replace(
+    replace(replace(replace(replace(replace(replace(
+        regexp_replace(
+            translate(
+                replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(replace(lower(name),
+                    'щ', 'shch'), 'ж', 'zh'), 'х', 'kh'), 'ц', 'ts'), 'ч', 'ch'), 'ш', 'sh'), 'є', 'ie'), 'ю', 'iu'), 'я', 'ia'),
+                    'č', 'ch'), 'š', 'sh'), 'ž', 'zh'),
+                'абвгґдезиіїйклмнопрстуфёыэáàâäąćęéèëíïłńóöśúüýźżьъ',
+                'abvhgdezyiiiklmnoprstufeyeaaaaaceeeeiilnoosuuyzz'),
+            '[^[:alpha:][:space:]]', '', 'g'),
+        'kh', 'h'), 'ph', 'f'), 'g', 'h'), 'w', 'v'), 'j', 'i'), 'y', 'i'),
+    '(.)\1+', '\1', 'g')
+$$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE;
+
+CREATE INDEX idx_accounts_name_search ON accounts USING GIN (to_tsvector('simple', normalize_name(coalesce(first_name, '') || ' ' || coalesce(father_name, '') || ' ' || coalesce(last_name, ''))));
+CREATE INDEX idx_specialist_specializations_specialization_id ON specialist_specializations(specialization_id);
+CREATE INDEX idx_specialist_cures_diseases_disease_id ON specialist_cures_diseases(disease_id);
+CREATE INDEX idx_specialist_services_service_id ON specialist_services(service_id);
+CREATE INDEX idx_specialist_profiles_medical_category ON specialist_profiles(medical_category);
+CREATE INDEX idx_account_languages_language ON account_languages(language);
+CREATE INDEX idx_account_addresses_city_id ON account_addresses(city_id) WHERE open;
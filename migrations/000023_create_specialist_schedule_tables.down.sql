@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS specialist_schedule_exceptions;
+DROP TYPE IF EXISTS SCHEDULE_EXCEPTION_TYPE;
+DROP TABLE IF EXISTS specialist_schedule_hours;
+DROP TABLE IF EXISTS specialist_schedules;
@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS specialist_schedules
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    organization_id BIGINT,
+    address_id BIGINT,
+    timezone VARCHAR(64) NOT NULL,
+    slot_duration SMALLINT NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (address_id) REFERENCES account_addresses(id) ON DELETE CASCADE,
+    UNIQUE (profile_id, organization_id),
+    UNIQUE (profile_id, address_id),
+    CHECK ((organization_id IS NULL) <> (address_id IS NULL)),
+    CHECK (slot_duration >= 5 AND slot_duration <= 480)
+);
+CREATE INDEX idx_specialist_schedules_profile_id ON specialist_schedules(profile_id);
+
+-- the minutes are counted from the local midnight of the schedule timezone
+CREATE TABLE IF NOT EXISTS specialist_schedule_hours
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    schedule_id BIGINT NOT NULL,
+    weekday SMALLINT NOT NULL, -- 1 is monday, 7 is sunday
+    start_minute SMALLINT NOT NULL,
+    end_minute SMALLINT NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (schedule_id) REFERENCES specialist_schedules(id) ON DELETE CASCADE,
+    CHECK (weekday >= 1 AND weekday <= 7),
+    CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+CREATE INDEX idx_specialist_schedule_hours_schedule_id ON specialist_schedule_hours(schedule_id);
+
+CREATE TYPE SCHEDULE_EXCEPTION_TYPE AS ENUM ('vacation', 'sick_leave', 'day_off', 'other');
+CREATE TABLE IF NOT EXISTS specialist_schedule_exceptions
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    profile_id BIGINT NOT NULL,
+    schedule_id BIGINT, -- all schedules when not set
+    type SCHEDULE_EXCEPTION_TYPE NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    start_minute SMALLINT, -- whole days when not set
+    end_minute SMALLINT,
+    comment VARCHAR(255),
+    PRIMARY KEY (id),
+    FOREIGN KEY (profile_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (schedule_id) REFERENCES specialist_schedules(id) ON DELETE CASCADE,
+    CHECK (start_date <= end_date),
+    CHECK ((start_minute IS NULL) = (end_minute IS NULL)),
+    CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+CREATE INDEX idx_specialist_schedule_exceptions_profile_id_dates ON specialist_schedule_exceptions(profile_id, start_date, end_date);
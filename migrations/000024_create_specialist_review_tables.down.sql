@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS specialist_reviews;
+DROP TYPE IF EXISTS REVIEW_STATUS;
@@ -0,0 +1,34 @@
+CREATE TYPE REVIEW_STATUS AS ENUM ('pending', 'approved', 'rejected');
+CREATE TABLE IF NOT EXISTS specialist_reviews
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    specialist_id BIGINT NOT NULL,
+    patient_id BIGINT NOT NULL,
+    professionalism SMALLINT NOT NULL,
+    attentiveness SMALLINT NOT NULL,
+    communication SMALLINT NOT NULL,
+    punctuality SMALLINT NOT NULL,
+    rating DECIMAL(3,2) GENERATED ALWAYS AS ((professionalism + attentiveness + communication + punctuality) / 4.0) STORED,
+    text TEXT,
+    anonymous BOOLEAN DEFAULT 'false',
+    status REVIEW_STATUS NOT NULL DEFAULT 'pending',
+    reviewer_id BIGINT,
+    reviewer_comment TEXT,
+    reviewed_at TIMESTAMP,
+    reply TEXT,
+    replied_at TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (specialist_id) REFERENCES specialist_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (patient_id) REFERENCES patient_profiles(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES accounts(id) ON DELETE SET NULL,
+    UNIQUE (specialist_id, patient_id),
+    CHECK (professionalism >= 1 AND professionalism <= 5),
+    CHECK (attentiveness >= 1 AND attentiveness <= 5),
+    CHECK (communication >= 1 AND communication <= 5),
+    CHECK (punctuality >= 1 AND punctuality <= 5)
+);
+CREATE INDEX idx_specialist_reviews_specialist_id_status ON specialist_reviews(specialist_id, status);
+CREATE INDEX idx_specialist_reviews_patient_id ON specialist_reviews(patient_id);
+CREATE INDEX idx_specialist_reviews_status ON specialist_reviews(status, created_at);
@@ -0,0 +1,7 @@
+DELETE FROM specialist_verifications WHERE entity = 'educational_course';
+DROP INDEX IF EXISTS idx_specialist_verifications_pending;
+ALTER TYPE VERIFICATION_ENTITY RENAME TO VERIFICATION_ENTITY_OLD;
+CREATE TYPE VERIFICATION_ENTITY AS ENUM ('education', 'association', 'experience');
+ALTER TABLE specialist_verifications ALTER COLUMN entity TYPE VERIFICATION_ENTITY USING entity::text::VERIFICATION_ENTITY;
+DROP TYPE IF EXISTS VERIFICATION_ENTITY_OLD;
+CREATE UNIQUE INDEX idx_specialist_verifications_pending ON specialist_verifications(entity, entity_id) WHERE status = 'pending';
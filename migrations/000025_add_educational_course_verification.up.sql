@@ -0,0 +1 @@
+ALTER TYPE VERIFICATION_ENTITY ADD VALUE IF NOT EXISTS 'educational_course';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS disease_icd10_codes;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS disease_icd10_codes
+(
+    disease_id SMALLINT NOT NULL,
+    icd10 VARCHAR(8) NOT NULL, -- category (E11) or subcategory (E11.9)
+    PRIMARY KEY (disease_id, icd10),
+    CHECK (disease_id > 0)
+);
+CREATE INDEX idx_disease_icd10_codes_icd10 ON disease_icd10_codes(icd10);
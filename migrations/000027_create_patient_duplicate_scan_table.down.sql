@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_accounts_updated_at;
+DROP INDEX IF EXISTS idx_patient_profiles_updated_at;
+DROP TABLE IF EXISTS patient_duplicate_scans;
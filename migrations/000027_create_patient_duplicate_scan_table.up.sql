@@ -0,0 +1,12 @@
+-- every detection run is recorded, the next one compares only the profiles changed since it started
+CREATE TABLE IF NOT EXISTS patient_duplicate_scans
+(
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY (INCREMENT BY 1 START 100), -- GENERATED ALWAYS AS IDENTITY
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_patient_duplicate_scans_started_at ON patient_duplicate_scans(started_at);
+
+CREATE INDEX idx_patient_profiles_updated_at ON patient_profiles(updated_at);
+CREATE INDEX idx_accounts_updated_at ON accounts(updated_at);
@@ -0,0 +1 @@
+ALTER TABLE patient_specialists DROP COLUMN IF EXISTS confirmed_at;
@@ -0,0 +1,2 @@
+-- the patient adds the specialist alone, the specialist confirms treating the patient
+ALTER TABLE patient_specialists ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMP;
@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
@@ -71,6 +72,22 @@ func (s *S3) UploadObject(c context.Context, file *multipart.FileHeader) (*strin
 	return &s3name, nil
 }
 
+func (s *S3) PutObject(c context.Context, item string, body io.Reader) error {
+	_, err := s.Uploader.UploadWithContext(
+		c,
+		&s3manager.UploadInput{
+			Bucket: aws.String(s.cfg.AccountBucketName),
+			Key:    aws.String(item),
+			Body:   body,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *S3) DownloadObject(c context.Context, item string, fileName string) error {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -37,14 +37,25 @@ const (
 	LanguageGetKey    = "language_get"
 	LanguageUpdateKey = "language_update"
 
+	PrivacyUpdateKey = "privacy_update"
+
+	ExportAddKey    = "export_add"
+	ExportReadyKey  = "export_ready"
+	ExportFailedKey = "export_failed"
+
 	ProfileGetKey = "account_profile_get"
 
+	PatientAddKey    = "patient_add"
 	PatientDeleteKey = "patient_delete"
 	PatientGetKey    = "patient_get"
 	PatientUpdateKey = "patient_update"
 
 	PatientVerifiedKey = "patient_verified"
 	PatientSelectedKey = "patient_selected"
+	PatientHandoverKey = "patient_handover"
+
+	PatientDuplicateKey = "patient_duplicate"
+	PatientMergedKey    = "patient_merged"
 
 	PatientAdminAddKey    = "patient_admin_add"
 	PatientAdminDeleteKey = "patient_admin_delete"
@@ -56,6 +67,47 @@ const (
 	MetalComponentGetKey    = "metal_component_get"
 	MetalComponentUpdateKey = "metal_component_update"
 
+	AllergyAddKey       = "allergy_add"
+	AllergyDeleteKey    = "allergy_delete"
+	AllergyUpdateKey    = "allergy_update"
+	AllergyConfirmedKey = "allergy_confirmed"
+
+	ConditionAddKey       = "condition_add"
+	ConditionDeleteKey    = "condition_delete"
+	ConditionUpdateKey    = "condition_update"
+	ConditionConfirmedKey = "condition_confirmed"
+
+	MedicationAddKey    = "medication_add"
+	MedicationDeleteKey = "medication_delete"
+	MedicationUpdateKey = "medication_update"
+	MedicationStopKey   = "medication_stop"
+
+	VaccinationAddKey    = "vaccination_add"
+	VaccinationDeleteKey = "vaccination_delete"
+	VaccinationUpdateKey = "vaccination_update"
+
+	EmergencyContactAddKey    = "emergency_contact_add"
+	EmergencyContactDeleteKey = "emergency_contact_delete"
+	EmergencyContactUpdateKey = "emergency_contact_update"
+
+	InsurancePolicyAddKey      = "insurance_policy_add"
+	InsurancePolicyDeleteKey   = "insurance_policy_delete"
+	InsurancePolicyUpdateKey   = "insurance_policy_update"
+	InsurancePolicyExpiringKey = "insurance_policy_expiring"
+	InsurancePolicyExpiredKey  = "insurance_policy_expired"
+
+	FamilyHistoryAddKey    = "family_history_add"
+	FamilyHistoryDeleteKey = "family_history_delete"
+	FamilyHistoryUpdateKey = "family_history_update"
+
+	LifestyleRecordAddKey    = "lifestyle_record_add"
+	LifestyleRecordDeleteKey = "lifestyle_record_delete"
+	LifestyleRecordUpdateKey = "lifestyle_record_update"
+
+	PatientSpecialistAddKey     = "patient_specialist_add"
+	PatientSpecialistConfirmKey = "patient_specialist_confirm"
+	PatientSpecialistDeleteKey  = "patient_specialist_delete"
+
 	SpecialistAddKey    = "specialist_add"
 	SpecialistGetKey    = "specialist_get"
 	SpecialistUpdateKey = "specialist_update"
@@ -89,4 +141,53 @@ const (
 	PublicationLinkDeleteKey = "publication_link_delete"
 	PublicationLinkGetKey    = "publication_link_get"
 	PublicationLinkUpdateKey = "publication_link_update"
+
+	TeachingAddKey    = "teaching_add"
+	TeachingDeleteKey = "teaching_delete"
+	TeachingUpdateKey = "teaching_update"
+
+	SpeechAddKey    = "speech_add"
+	SpeechDeleteKey = "speech_delete"
+	SpeechUpdateKey = "speech_update"
+
+	LicenceAddKey      = "licence_add"
+	LicenceDeleteKey   = "licence_delete"
+	LicenceUpdateKey   = "licence_update"
+	LicenceStatusKey   = "licence_status"
+	LicenceExpiringKey = "licence_expiring"
+	LicenceExpiredKey  = "licence_expired"
+
+	ScheduleAddKey    = "schedule_add"
+	ScheduleDeleteKey = "schedule_delete"
+	ScheduleUpdateKey = "schedule_update"
+
+	ScheduleExceptionAddKey    = "schedule_exception_add"
+	ScheduleExceptionDeleteKey = "schedule_exception_delete"
+	ScheduleExceptionUpdateKey = "schedule_exception_update"
+
+	VerificationAddKey      = "verification_add"
+	VerificationApprovedKey = "verification_approved"
+	VerificationRejectedKey = "verification_rejected"
+
+	ReviewAddKey      = "review_add"
+	ReviewDeleteKey   = "review_delete"
+	ReviewUpdateKey   = "review_update"
+	ReviewApprovedKey = "review_approved"
+	ReviewRejectedKey = "review_rejected"
+	ReviewReplyKey    = "review_reply"
+
+	AccountRoleAddKey    = "account_role_add"
+	AccountRoleDeleteKey = "account_role_delete"
+
+	OrganizationAddKey    = "organization_add"
+	OrganizationDeleteKey = "organization_delete"
+	OrganizationUpdateKey = "organization_update"
+
+	OrganizationMemberAddKey    = "organization_member_add"
+	OrganizationMemberDeleteKey = "organization_member_delete"
+	OrganizationMemberUpdateKey = "organization_member_update"
+
+	OrganizationInvitationAddKey      = "organization_invitation_add"
+	OrganizationInvitationAcceptedKey = "organization_invitation_accepted"
+	OrganizationInvitationDeclinedKey = "organization_invitation_declined"
 )
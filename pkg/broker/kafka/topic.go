@@ -39,14 +39,25 @@ var topics = map[string]string{
 	broker.LanguageGetKey:    "account_language.get",
 	broker.LanguageUpdateKey: "account_language.update",
 
+	broker.PrivacyUpdateKey: "account_privacy.update",
+
+	broker.ExportAddKey:    "account_export.add",
+	broker.ExportReadyKey:  "account_export.ready",
+	broker.ExportFailedKey: "account_export.failed",
+
 	broker.ProfileGetKey: "account_profile.get",
 
+	broker.PatientAddKey:    "patient.add",
 	broker.PatientDeleteKey: "patient.delete",
 	broker.PatientGetKey:    "patient.get",
 	broker.PatientUpdateKey: "patient.update",
 
 	broker.PatientVerifiedKey: "patient.verified",
 	broker.PatientSelectedKey: "patient.selected",
+	broker.PatientHandoverKey: "patient.handover",
+
+	broker.PatientDuplicateKey: "patient.duplicate",
+	broker.PatientMergedKey:    "patient.merged",
 
 	broker.PatientAdminAddKey:    "patient_admin.add",
 	broker.PatientAdminDeleteKey: "patient_admin.delete",
@@ -58,6 +69,47 @@ var topics = map[string]string{
 	broker.MetalComponentGetKey:    "patient_metal_component.get",
 	broker.MetalComponentUpdateKey: "patient_metal_component.update",
 
+	broker.AllergyAddKey:       "patient_allergy.add",
+	broker.AllergyDeleteKey:    "patient_allergy.delete",
+	broker.AllergyUpdateKey:    "patient_allergy.update",
+	broker.AllergyConfirmedKey: "patient_allergy.confirmed",
+
+	broker.ConditionAddKey:       "patient_condition.add",
+	broker.ConditionDeleteKey:    "patient_condition.delete",
+	broker.ConditionUpdateKey:    "patient_condition.update",
+	broker.ConditionConfirmedKey: "patient_condition.confirmed",
+
+	broker.MedicationAddKey:    "patient_medication.add",
+	broker.MedicationDeleteKey: "patient_medication.delete",
+	broker.MedicationUpdateKey: "patient_medication.update",
+	broker.MedicationStopKey:   "patient_medication.stop",
+
+	broker.VaccinationAddKey:    "patient_vaccination.add",
+	broker.VaccinationDeleteKey: "patient_vaccination.delete",
+	broker.VaccinationUpdateKey: "patient_vaccination.update",
+
+	broker.EmergencyContactAddKey:    "patient_emergency_contact.add",
+	broker.EmergencyContactDeleteKey: "patient_emergency_contact.delete",
+	broker.EmergencyContactUpdateKey: "patient_emergency_contact.update",
+
+	broker.InsurancePolicyAddKey:      "patient_insurance_policy.add",
+	broker.InsurancePolicyDeleteKey:   "patient_insurance_policy.delete",
+	broker.InsurancePolicyUpdateKey:   "patient_insurance_policy.update",
+	broker.InsurancePolicyExpiringKey: "patient_insurance_policy.expiring",
+	broker.InsurancePolicyExpiredKey:  "patient_insurance_policy.expired",
+
+	broker.FamilyHistoryAddKey:    "patient_family_history.add",
+	broker.FamilyHistoryDeleteKey: "patient_family_history.delete",
+	broker.FamilyHistoryUpdateKey: "patient_family_history.update",
+
+	broker.LifestyleRecordAddKey:    "patient_lifestyle_record.add",
+	broker.LifestyleRecordDeleteKey: "patient_lifestyle_record.delete",
+	broker.LifestyleRecordUpdateKey: "patient_lifestyle_record.update",
+
+	broker.PatientSpecialistAddKey:     "patient_specialist.add",
+	broker.PatientSpecialistConfirmKey: "patient_specialist.confirm",
+	broker.PatientSpecialistDeleteKey:  "patient_specialist.delete",
+
 	broker.SpecialistAddKey:    "specialist.add",
 	broker.SpecialistGetKey:    "specialist.get",
 	broker.SpecialistUpdateKey: "specialist.update",
@@ -91,6 +143,55 @@ var topics = map[string]string{
 	broker.PublicationLinkDeleteKey: "specialist_publication_link.delete",
 	broker.PublicationLinkGetKey:    "specialist_publication_link.get",
 	broker.PublicationLinkUpdateKey: "specialist_publication_link.update",
+
+	broker.TeachingAddKey:    "specialist_teaching.add",
+	broker.TeachingDeleteKey: "specialist_teaching.delete",
+	broker.TeachingUpdateKey: "specialist_teaching.update",
+
+	broker.SpeechAddKey:    "specialist_speech.add",
+	broker.SpeechDeleteKey: "specialist_speech.delete",
+	broker.SpeechUpdateKey: "specialist_speech.update",
+
+	broker.LicenceAddKey:      "specialist_licence.add",
+	broker.LicenceDeleteKey:   "specialist_licence.delete",
+	broker.LicenceUpdateKey:   "specialist_licence.update",
+	broker.LicenceStatusKey:   "specialist_licence.status",
+	broker.LicenceExpiringKey: "specialist_licence.expiring",
+	broker.LicenceExpiredKey:  "specialist_licence.expired",
+
+	broker.ScheduleAddKey:    "specialist_schedule.add",
+	broker.ScheduleDeleteKey: "specialist_schedule.delete",
+	broker.ScheduleUpdateKey: "specialist_schedule.update",
+
+	broker.ScheduleExceptionAddKey:    "specialist_schedule_exception.add",
+	broker.ScheduleExceptionDeleteKey: "specialist_schedule_exception.delete",
+	broker.ScheduleExceptionUpdateKey: "specialist_schedule_exception.update",
+
+	broker.VerificationAddKey:      "specialist_verification.add",
+	broker.VerificationApprovedKey: "specialist_verification.approved",
+	broker.VerificationRejectedKey: "specialist_verification.rejected",
+
+	broker.ReviewAddKey:      "specialist_review.add",
+	broker.ReviewDeleteKey:   "specialist_review.delete",
+	broker.ReviewUpdateKey:   "specialist_review.update",
+	broker.ReviewApprovedKey: "specialist_review.approved",
+	broker.ReviewRejectedKey: "specialist_review.rejected",
+	broker.ReviewReplyKey:    "specialist_review.reply",
+
+	broker.AccountRoleAddKey:    "account_role.add",
+	broker.AccountRoleDeleteKey: "account_role.delete",
+
+	broker.OrganizationAddKey:    "organization.add",
+	broker.OrganizationDeleteKey: "organization.delete",
+	broker.OrganizationUpdateKey: "organization.update",
+
+	broker.OrganizationMemberAddKey:    "organization_member.add",
+	broker.OrganizationMemberDeleteKey: "organization_member.delete",
+	broker.OrganizationMemberUpdateKey: "organization_member.update",
+
+	broker.OrganizationInvitationAddKey:      "organization_invitation.add",
+	broker.OrganizationInvitationAcceptedKey: "organization_invitation.accepted",
+	broker.OrganizationInvitationDeclinedKey: "organization_invitation.declined",
 }
 
 func (b *MessageBroker) GetTopic(name string) string {
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddExport(c context.Context, token string) (*model.Export, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/account/export", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var e model.Export
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &e, nil
+}
+
+func (h *HTTPClient) GetExports(c context.Context, token string) (*model.ListExports, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/account/export", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var es model.ListExports
+	if err := json.NewDecoder(resp.Body).Decode(&es); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &es, nil
+}
+
+func (h *HTTPClient) GetExport(c context.Context, token string, id int64) (*model.Export, error) {
+	exportID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/account/export/"+exportID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var e model.Export
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &e, nil
+}
+
+func (h *HTTPClient) DownloadExport(c context.Context, token string, id int64) ([]byte, error) {
+	exportID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/account/export/"+exportID+"/download", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return io.ReadAll(resp.Body)
+}
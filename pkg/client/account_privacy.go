@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+)
+
+func (h *HTTPClient) GetPrivacySettings(c context.Context, token string) (*model.PrivacySettings, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/account/privacy", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var settings model.PrivacySettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &settings, nil
+}
+
+func (h *HTTPClient) UpdatePrivacySettings(c context.Context, token string, r *model.UpdatePrivacySettings) (*model.PrivacySettings, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/account/privacy", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var settings model.PrivacySettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &settings, nil
+}
@@ -155,3 +155,94 @@ func (h *HTTPClient) AddSpecialistProfile(c context.Context, token string, r *mo
 
 	return &newToken, cookie, nil
 }
+
+func (h *HTTPClient) AddDependentPatientProfile(c context.Context, token string, r *model.AddDependentPatient) (*model.Patient, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/account/profiles/patient", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var patient model.Patient
+	if err := json.NewDecoder(resp.Body).Decode(&patient); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &patient, nil
+}
+
+func (h *HTTPClient) UpdateDependentPatientProfile(c context.Context, token string, id int64, r *model.UpdateDependentPatient) (*model.Patient, error) {
+	profileID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/account/profiles/patient/"+profileID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var patient model.Patient
+	if err := json.NewDecoder(resp.Body).Decode(&patient); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &patient, nil
+}
+
+func (h *HTTPClient) HandOverPatientProfile(c context.Context, token string, id int64) (*model.PatientHandover, error) {
+	profileID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/account/profiles/patient/"+profileID+"/handover", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ho model.PatientHandover
+	if err := json.NewDecoder(resp.Body).Decode(&ho); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ho, nil
+}
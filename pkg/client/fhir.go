@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Hvaekar/med-account/pkg/fhir"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GetFHIR returns the raw resource so it can be checked against the FHIR JSON structure.
+func (h *HTTPClient) GetFHIR(c context.Context, token string, path string, params url.Values) (map[string]interface{}, error) {
+	u := h.baseURL + "/fhir/" + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var oo fhir.OperationOutcome
+		if err := json.NewDecoder(resp.Body).Decode(&oo); err != nil || len(oo.Issue) == 0 {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		return nil, fmt.Errorf("unexpected status code: %d, error: %s", resp.StatusCode, oo.Issue[0].Diagnostics)
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/fhir+json") {
+		return nil, fmt.Errorf("unexpected content type: %s", resp.Header.Get("Content-Type"))
+	}
+
+	var resource map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return resource, nil
+}
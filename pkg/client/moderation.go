@@ -0,0 +1,242 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) GetModerationVerifications(c context.Context, token string, r *model.ListVerificationsRequest) (*model.ListVerifications, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/moderation/verifications", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var verifications model.ListVerifications
+	if err := json.NewDecoder(resp.Body).Decode(&verifications); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &verifications, nil
+}
+
+func (h *HTTPClient) GetModerationVerification(c context.Context, token string, id int64) (*model.Verification, error) {
+	verificationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/moderation/verifications/"+verificationID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var verification model.Verification
+	if err := json.NewDecoder(resp.Body).Decode(&verification); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &verification, nil
+}
+
+func (h *HTTPClient) ApproveVerification(c context.Context, token string, id int64, r *model.ReviewVerification) (*model.Verification, error) {
+	verificationID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/moderation/verifications/"+verificationID+"/approve", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var verification model.Verification
+	if err := json.NewDecoder(resp.Body).Decode(&verification); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &verification, nil
+}
+
+func (h *HTTPClient) RejectVerification(c context.Context, token string, id int64, r *model.ReviewVerification) (*model.Verification, error) {
+	verificationID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/moderation/verifications/"+verificationID+"/reject", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var verification model.Verification
+	if err := json.NewDecoder(resp.Body).Decode(&verification); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &verification, nil
+}
+
+func (h *HTTPClient) GetDiseaseICD10Codes(c context.Context, token string, id int64) (*model.DiseaseICD10Codes, error) {
+	diseaseID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/moderation/diseases/"+diseaseID+"/icd10", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var codes model.DiseaseICD10Codes
+	if err := json.NewDecoder(resp.Body).Decode(&codes); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &codes, nil
+}
+
+func (h *HTTPClient) UpdateDiseaseICD10Codes(c context.Context, token string, id int64, r *model.UpdateDiseaseICD10Codes) (*model.DiseaseICD10Codes, error) {
+	diseaseID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/moderation/diseases/"+diseaseID+"/icd10", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var codes model.DiseaseICD10Codes
+	if err := json.NewDecoder(resp.Body).Decode(&codes); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &codes, nil
+}
+
+func (h *HTTPClient) AddAccountRole(c context.Context, token string, r *model.AddAccountRole) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/moderation/roles", bytes.NewReader(body))
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) DeleteAccountRole(c context.Context, token string, id int64, role string) error {
+	accountID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/moderation/roles/"+accountID+"/"+role, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddOrganization(c context.Context, token string, r *model.AddOrganization) (*model.Organization, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/organizations", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var organization model.Organization
+	if err := json.NewDecoder(resp.Body).Decode(&organization); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &organization, nil
+}
+
+func (h *HTTPClient) GetOrganizations(c context.Context, token string) (*model.ListOrganizations, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var organizations model.ListOrganizations
+	if err := json.NewDecoder(resp.Body).Decode(&organizations); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &organizations, nil
+}
+
+func (h *HTTPClient) GetOrganization(c context.Context, token string, id int64) (*model.Organization, error) {
+	organizationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations/"+organizationID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var organization model.Organization
+	if err := json.NewDecoder(resp.Body).Decode(&organization); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &organization, nil
+}
+
+func (h *HTTPClient) UpdateOrganization(c context.Context, token string, id int64, r *model.UpdateOrganization) (*model.Organization, error) {
+	organizationID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/organizations/"+organizationID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var organization model.Organization
+	if err := json.NewDecoder(resp.Body).Decode(&organization); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &organization, nil
+}
+
+func (h *HTTPClient) DeleteOrganization(c context.Context, token string, id int64) error {
+	organizationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/organizations/"+organizationID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetOrganizationExperiences(c context.Context, token string, id int64) (*model.ListExperiences, error) {
+	organizationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations/"+organizationID+"/experiences", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var experiences model.ListExperiences
+	if err := json.NewDecoder(resp.Body).Decode(&experiences); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &experiences, nil
+}
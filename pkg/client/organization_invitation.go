@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddOrganizationInvitation(c context.Context, token string, orgID int64, r *model.AddOrganizationInvitation) (*model.OrganizationInvitation, error) {
+	organizationID := strconv.Itoa(int(orgID))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/organizations/"+organizationID+"/invitations", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var invitation model.OrganizationInvitation
+	if err := json.NewDecoder(resp.Body).Decode(&invitation); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &invitation, nil
+}
+
+func (h *HTTPClient) GetOrganizationInvitations(c context.Context, token string, orgID int64) (*model.ListOrganizationInvitations, error) {
+	organizationID := strconv.Itoa(int(orgID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations/"+organizationID+"/invitations", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var invitations model.ListOrganizationInvitations
+	if err := json.NewDecoder(resp.Body).Decode(&invitations); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &invitations, nil
+}
+
+func (h *HTTPClient) DeleteOrganizationInvitation(c context.Context, token string, orgID int64, id int64) error {
+	organizationID := strconv.Itoa(int(orgID))
+	invitationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/organizations/"+organizationID+"/invitations/"+invitationID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetAccountInvitations(c context.Context, token string) (*model.ListOrganizationInvitations, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/account/invitations", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var invitations model.ListOrganizationInvitations
+	if err := json.NewDecoder(resp.Body).Decode(&invitations); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &invitations, nil
+}
+
+func (h *HTTPClient) AcceptInvitation(c context.Context, token string, id int64) (*model.OrganizationMember, error) {
+	invitationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/account/invitations/"+invitationID+"/accept", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var member model.OrganizationMember
+	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &member, nil
+}
+
+func (h *HTTPClient) DeclineInvitation(c context.Context, token string, id int64) (*model.OrganizationInvitation, error) {
+	invitationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/account/invitations/"+invitationID+"/decline", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var invitation model.OrganizationInvitation
+	if err := json.NewDecoder(resp.Body).Decode(&invitation); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &invitation, nil
+}
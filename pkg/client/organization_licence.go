@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddOrganizationLicence(c context.Context, token string, orgID int64, r *model.AddOrganizationLicence) (*model.OrganizationLicence, error) {
+	organizationID := strconv.Itoa(int(orgID))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/organizations/"+organizationID+"/licences", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.OrganizationLicence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) GetOrganizationLicences(c context.Context, token string, orgID int64) (*model.ListOrganizationLicences, error) {
+	organizationID := strconv.Itoa(int(orgID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations/"+organizationID+"/licences", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licences model.ListOrganizationLicences
+	if err := json.NewDecoder(resp.Body).Decode(&licences); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licences, nil
+}
+
+func (h *HTTPClient) GetOrganizationLicence(c context.Context, token string, orgID int64, id int64) (*model.OrganizationLicence, error) {
+	organizationID := strconv.Itoa(int(orgID))
+	licenceID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations/"+organizationID+"/licences/"+licenceID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.OrganizationLicence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) UpdateOrganizationLicence(c context.Context, token string, orgID int64, id int64, r *model.UpdateOrganizationLicence) (*model.OrganizationLicence, error) {
+	organizationID := strconv.Itoa(int(orgID))
+	licenceID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/organizations/"+organizationID+"/licences/"+licenceID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.OrganizationLicence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) DeleteOrganizationLicence(c context.Context, token string, orgID int64, id int64) error {
+	organizationID := strconv.Itoa(int(orgID))
+	licenceID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/organizations/"+organizationID+"/licences/"+licenceID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
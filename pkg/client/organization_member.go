@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) GetOrganizationMembers(c context.Context, token string, orgID int64) (*model.ListOrganizationMembers, error) {
+	organizationID := strconv.Itoa(int(orgID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations/"+organizationID+"/members", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var members model.ListOrganizationMembers
+	if err := json.NewDecoder(resp.Body).Decode(&members); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &members, nil
+}
+
+func (h *HTTPClient) GetOrganizationMember(c context.Context, token string, orgID int64, id int64) (*model.OrganizationMember, error) {
+	organizationID := strconv.Itoa(int(orgID))
+	accountID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/organizations/"+organizationID+"/members/"+accountID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var member model.OrganizationMember
+	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &member, nil
+}
+
+func (h *HTTPClient) UpdateOrganizationMember(c context.Context, token string, orgID int64, id int64, r *model.UpdateOrganizationMember) (*model.OrganizationMember, error) {
+	organizationID := strconv.Itoa(int(orgID))
+	accountID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/organizations/"+organizationID+"/members/"+accountID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var member model.OrganizationMember
+	if err := json.NewDecoder(resp.Body).Decode(&member); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &member, nil
+}
+
+func (h *HTTPClient) DeleteOrganizationMember(c context.Context, token string, orgID int64, id int64) error {
+	organizationID := strconv.Itoa(int(orgID))
+	accountID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/organizations/"+organizationID+"/members/"+accountID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddAllergy(c context.Context, token string, r *model.AddAllergy) (*model.Allergy, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/allergies", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var a model.Allergy
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &a, nil
+}
+
+func (h *HTTPClient) GetAllergies(c context.Context, token string) (*model.ListAllergies, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/allergies", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var as model.ListAllergies
+	if err := json.NewDecoder(resp.Body).Decode(&as); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &as, nil
+}
+
+func (h *HTTPClient) GetAllergy(c context.Context, token string, id int64) (*model.Allergy, error) {
+	allergyID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/allergies/"+allergyID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var a model.Allergy
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &a, nil
+}
+
+func (h *HTTPClient) UpdateAllergy(c context.Context, token string, id int64, r *model.UpdateAllergy) (*model.Allergy, error) {
+	allergyID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/allergies/"+allergyID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var a model.Allergy
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &a, nil
+}
+
+func (h *HTTPClient) DeleteAllergy(c context.Context, token string, id int64) error {
+	allergyID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/allergies/"+allergyID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetPatientAllergies(c context.Context, token string, patientID int64) (*model.ListAllergies, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/allergies", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var as model.ListAllergies
+	if err := json.NewDecoder(resp.Body).Decode(&as); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &as, nil
+}
+
+func (h *HTTPClient) ConfirmAllergy(c context.Context, token string, patientID int64, allergyID int64) (*model.Allergy, error) {
+	pID := strconv.Itoa(int(patientID))
+	aID := strconv.Itoa(int(allergyID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/patients/"+pID+"/allergies/"+aID+"/confirm", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var a model.Allergy
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &a, nil
+}
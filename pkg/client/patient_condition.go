@@ -0,0 +1,228 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddCondition(c context.Context, token string, r *model.AddCondition) (*model.Condition, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/conditions", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var cd model.Condition
+	if err := json.NewDecoder(resp.Body).Decode(&cd); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &cd, nil
+}
+
+func (h *HTTPClient) GetConditions(c context.Context, token string) (*model.ListConditions, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/conditions", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var cds model.ListConditions
+	if err := json.NewDecoder(resp.Body).Decode(&cds); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &cds, nil
+}
+
+func (h *HTTPClient) GetConditionSpecialists(c context.Context, token string) (*model.ListConditionSpecialists, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/conditions/specialists", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ss model.ListConditionSpecialists
+	if err := json.NewDecoder(resp.Body).Decode(&ss); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ss, nil
+}
+
+func (h *HTTPClient) GetCondition(c context.Context, token string, id int64) (*model.Condition, error) {
+	conditionID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/conditions/"+conditionID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var cd model.Condition
+	if err := json.NewDecoder(resp.Body).Decode(&cd); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &cd, nil
+}
+
+func (h *HTTPClient) UpdateCondition(c context.Context, token string, id int64, r *model.UpdateCondition) (*model.Condition, error) {
+	conditionID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/conditions/"+conditionID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var cd model.Condition
+	if err := json.NewDecoder(resp.Body).Decode(&cd); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &cd, nil
+}
+
+func (h *HTTPClient) DeleteCondition(c context.Context, token string, id int64) error {
+	conditionID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/conditions/"+conditionID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetPatientConditions(c context.Context, token string, patientID int64) (*model.ListConditions, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/conditions", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var cds model.ListConditions
+	if err := json.NewDecoder(resp.Body).Decode(&cds); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &cds, nil
+}
+
+func (h *HTTPClient) ConfirmCondition(c context.Context, token string, patientID int64, conditionID int64) (*model.Condition, error) {
+	pID := strconv.Itoa(int(patientID))
+	cID := strconv.Itoa(int(conditionID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/patients/"+pID+"/conditions/"+cID+"/confirm", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var cd model.Condition
+	if err := json.NewDecoder(resp.Body).Decode(&cd); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &cd, nil
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) GetModerationDuplicates(c context.Context, token string, r *model.ListPatientDuplicatesRequest) (*model.ListPatientDuplicates, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/moderation/duplicates", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ds model.ListPatientDuplicates
+	if err := json.NewDecoder(resp.Body).Decode(&ds); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ds, nil
+}
+
+func (h *HTTPClient) GetModerationDuplicate(c context.Context, token string, id int64) (*model.PatientDuplicate, error) {
+	duplicateID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/moderation/duplicates/"+duplicateID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var duplicate model.PatientDuplicate
+	if err := json.NewDecoder(resp.Body).Decode(&duplicate); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &duplicate, nil
+}
+
+func (h *HTTPClient) MergeDuplicate(c context.Context, token string, id int64, r *model.MergePatientDuplicate) (*model.PatientDuplicate, error) {
+	duplicateID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/moderation/duplicates/"+duplicateID+"/merge", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var duplicate model.PatientDuplicate
+	if err := json.NewDecoder(resp.Body).Decode(&duplicate); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &duplicate, nil
+}
+
+func (h *HTTPClient) DismissDuplicate(c context.Context, token string, id int64) (*model.PatientDuplicate, error) {
+	duplicateID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/moderation/duplicates/"+duplicateID+"/dismiss", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var duplicate model.PatientDuplicate
+	if err := json.NewDecoder(resp.Body).Decode(&duplicate); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &duplicate, nil
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) GetEmergencySummary(c context.Context, token string) (*model.EmergencySummary, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/emergency", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var summary model.EmergencySummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &summary, nil
+}
+
+func (h *HTTPClient) AddEmergencyContact(c context.Context, token string, r *model.AddEmergencyContact) (*model.EmergencyContact, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/emergency-contacts", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var e model.EmergencyContact
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &e, nil
+}
+
+func (h *HTTPClient) GetEmergencyContacts(c context.Context, token string) (*model.ListEmergencyContacts, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/emergency-contacts", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var es model.ListEmergencyContacts
+	if err := json.NewDecoder(resp.Body).Decode(&es); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &es, nil
+}
+
+func (h *HTTPClient) GetEmergencyContact(c context.Context, token string, id int64) (*model.EmergencyContact, error) {
+	contactID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/emergency-contacts/"+contactID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var e model.EmergencyContact
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &e, nil
+}
+
+func (h *HTTPClient) UpdateEmergencyContact(c context.Context, token string, id int64, r *model.UpdateEmergencyContact) (*model.EmergencyContact, error) {
+	contactID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/emergency-contacts/"+contactID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var e model.EmergencyContact
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &e, nil
+}
+
+func (h *HTTPClient) DeleteEmergencyContact(c context.Context, token string, id int64) error {
+	contactID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/emergency-contacts/"+contactID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetPatientEmergencySummary(c context.Context, token string, patientID int64) (*model.EmergencySummary, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/emergency", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var summary model.EmergencySummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &summary, nil
+}
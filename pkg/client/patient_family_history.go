@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddFamilyHistory(c context.Context, token string, r *model.AddFamilyHistory) (*model.FamilyHistory, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/family_history", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var f model.FamilyHistory
+	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &f, nil
+}
+
+func (h *HTTPClient) GetFamilyHistory(c context.Context, token string) (*model.ListFamilyHistory, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/family_history", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var fs model.ListFamilyHistory
+	if err := json.NewDecoder(resp.Body).Decode(&fs); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &fs, nil
+}
+
+func (h *HTTPClient) GetFamilyHistoryEntry(c context.Context, token string, id int64) (*model.FamilyHistory, error) {
+	familyHistoryID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/family_history/"+familyHistoryID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var f model.FamilyHistory
+	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &f, nil
+}
+
+func (h *HTTPClient) UpdateFamilyHistory(c context.Context, token string, id int64, r *model.UpdateFamilyHistory) (*model.FamilyHistory, error) {
+	familyHistoryID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/family_history/"+familyHistoryID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var f model.FamilyHistory
+	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &f, nil
+}
+
+func (h *HTTPClient) DeleteFamilyHistory(c context.Context, token string, id int64) error {
+	familyHistoryID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/family_history/"+familyHistoryID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
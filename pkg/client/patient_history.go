@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+)
+
+func (h *HTTPClient) GetPatientHistory(c context.Context, token string, r *model.ListPatientHistoryRequest) (*model.ListPatientHistory, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/history", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var hs model.ListPatientHistory
+	if err := json.NewDecoder(resp.Body).Decode(&hs); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &hs, nil
+}
+
+func (h *HTTPClient) GetPatientSnapshot(c context.Context, token string, r *model.PatientSnapshotRequest) (*model.PatientSnapshot, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/history/snapshot", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ps model.PatientSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&ps); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ps, nil
+}
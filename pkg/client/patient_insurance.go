@@ -0,0 +1,175 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddInsurancePolicy(c context.Context, token string, r *model.AddInsurancePolicy) (*model.InsurancePolicy, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/insurance", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var in model.InsurancePolicy
+	if err := json.NewDecoder(resp.Body).Decode(&in); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &in, nil
+}
+
+func (h *HTTPClient) GetInsurancePolicies(c context.Context, token string) (*model.ListInsurancePolicies, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/insurance", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ins model.ListInsurancePolicies
+	if err := json.NewDecoder(resp.Body).Decode(&ins); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ins, nil
+}
+
+func (h *HTTPClient) GetInsurancePolicy(c context.Context, token string, id int64) (*model.InsurancePolicy, error) {
+	policyID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/insurance/"+policyID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var in model.InsurancePolicy
+	if err := json.NewDecoder(resp.Body).Decode(&in); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &in, nil
+}
+
+func (h *HTTPClient) UpdateInsurancePolicy(c context.Context, token string, id int64, r *model.UpdateInsurancePolicy) (*model.InsurancePolicy, error) {
+	policyID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/insurance/"+policyID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var in model.InsurancePolicy
+	if err := json.NewDecoder(resp.Body).Decode(&in); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &in, nil
+}
+
+func (h *HTTPClient) DeleteInsurancePolicy(c context.Context, token string, id int64) error {
+	policyID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/insurance/"+policyID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetActiveCoverage(c context.Context, token string, patientID int64) (*model.ListInsurancePolicies, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/service/patients/"+pID+"/insurance", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ins model.ListInsurancePolicies
+	if err := json.NewDecoder(resp.Body).Decode(&ins); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ins, nil
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddLifestyleRecord(c context.Context, token string, r *model.AddLifestyleRecord) (*model.LifestyleRecord, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/lifestyle", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var lr model.LifestyleRecord
+	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &lr, nil
+}
+
+func (h *HTTPClient) GetLifestyleRecords(c context.Context, token string) (*model.ListLifestyleRecords, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/lifestyle", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var rs model.ListLifestyleRecords
+	if err := json.NewDecoder(resp.Body).Decode(&rs); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &rs, nil
+}
+
+func (h *HTTPClient) GetCurrentLifestyle(c context.Context, token string) (*model.LifestyleSummary, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/lifestyle/current", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var s model.LifestyleSummary
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &s, nil
+}
+
+func (h *HTTPClient) GetLifestyleRecord(c context.Context, token string, id int64) (*model.LifestyleRecord, error) {
+	rID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/lifestyle/"+rID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var lr model.LifestyleRecord
+	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &lr, nil
+}
+
+func (h *HTTPClient) UpdateLifestyleRecord(c context.Context, token string, id int64, r *model.UpdateLifestyleRecord) (*model.LifestyleRecord, error) {
+	rID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/lifestyle/"+rID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var lr model.LifestyleRecord
+	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &lr, nil
+}
+
+func (h *HTTPClient) DeleteLifestyleRecord(c context.Context, token string, id int64) error {
+	rID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/lifestyle/"+rID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetPatientLifestyleRecords(c context.Context, token string, patientID int64) (*model.ListLifestyleRecords, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/lifestyle", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var rs model.ListLifestyleRecords
+	if err := json.NewDecoder(resp.Body).Decode(&rs); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &rs, nil
+}
+
+func (h *HTTPClient) GetPatientCurrentLifestyle(c context.Context, token string, patientID int64) (*model.LifestyleSummary, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/lifestyle/current", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var s model.LifestyleSummary
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddMeasurement(c context.Context, token string, r *model.AddMeasurement) (*model.Measurement, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/measurements", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var m model.Measurement
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &m, nil
+}
+
+func (h *HTTPClient) GetMeasurements(c context.Context, token string, r *model.ListMeasurementsRequest) (*model.ListMeasurements, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/measurements", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ms model.ListMeasurements
+	if err := json.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ms, nil
+}
+
+func (h *HTTPClient) GetMeasurementChart(c context.Context, token string, r *model.MeasurementChartRequest) (*model.MeasurementChart, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/measurements/chart", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var chart model.MeasurementChart
+	if err := json.NewDecoder(resp.Body).Decode(&chart); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &chart, nil
+}
+
+func (h *HTTPClient) GetMeasurement(c context.Context, token string, id int64) (*model.Measurement, error) {
+	measurementID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/measurements/"+measurementID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var m model.Measurement
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &m, nil
+}
+
+func (h *HTTPClient) DeleteMeasurement(c context.Context, token string, id int64) error {
+	measurementID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/measurements/"+measurementID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetPatientMeasurements(c context.Context, token string, id int64, r *model.ListMeasurementsRequest) (*model.ListMeasurements, error) {
+	patientID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+patientID+"/measurements", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ms model.ListMeasurements
+	if err := json.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ms, nil
+}
+
+func (h *HTTPClient) GetPatientMeasurementChart(c context.Context, token string, id int64, r *model.MeasurementChartRequest) (*model.MeasurementChart, error) {
+	patientID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+patientID+"/measurements/chart", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var chart model.MeasurementChart
+	if err := json.NewDecoder(resp.Body).Decode(&chart); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &chart, nil
+}
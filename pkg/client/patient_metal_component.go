@@ -146,3 +146,82 @@ func (h *HTTPClient) DeleteMetalComponent(c context.Context, token string, id in
 
 	return nil
 }
+
+func (h *HTTPClient) GetMRIScreening(c context.Context, token string) (*model.MRIScreening, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/metal_components/mri_screening", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ms model.MRIScreening
+	if err := json.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ms, nil
+}
+
+func (h *HTTPClient) GetPatientMetalComponents(c context.Context, token string, patientID int64) (*model.ListMetalComponents, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/metal_components", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var mcs model.ListMetalComponents
+	if err := json.NewDecoder(resp.Body).Decode(&mcs); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &mcs, nil
+}
+
+func (h *HTTPClient) GetPatientMRIScreening(c context.Context, token string, patientID int64) (*model.MRIScreening, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/metal_components/mri_screening", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var ms model.MRIScreening
+	if err := json.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &ms, nil
+}
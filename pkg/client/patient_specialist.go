@@ -0,0 +1,142 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddPatientSpecialist(c context.Context, token string, r *model.AddPatientSpecialist) (*model.PatientSpecialist, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/specialists", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var specialist model.PatientSpecialist
+	if err := json.NewDecoder(resp.Body).Decode(&specialist); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &specialist, nil
+}
+
+func (h *HTTPClient) GetPatientSpecialists(c context.Context, token string) (*model.ListPatientSpecialists, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/specialists", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var specialists model.ListPatientSpecialists
+	if err := json.NewDecoder(resp.Body).Decode(&specialists); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &specialists, nil
+}
+
+func (h *HTTPClient) GetPatientSpecialist(c context.Context, token string, id int64) (*model.PatientSpecialist, error) {
+	specialistID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/specialists/"+specialistID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var specialist model.PatientSpecialist
+	if err := json.NewDecoder(resp.Body).Decode(&specialist); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &specialist, nil
+}
+
+func (h *HTTPClient) DeletePatientSpecialist(c context.Context, token string, id int64) error {
+	specialistID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/specialists/"+specialistID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) ConfirmSpecialistPatient(c context.Context, token string, patientID int64) (*model.PatientSpecialist, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/patients/"+pID+"/confirm", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var specialist model.PatientSpecialist
+	if err := json.NewDecoder(resp.Body).Decode(&specialist); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &specialist, nil
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddVaccination(c context.Context, token string, r *model.AddVaccination) (*model.Vaccination, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/vaccinations", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var v model.Vaccination
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &v, nil
+}
+
+func (h *HTTPClient) GetVaccinations(c context.Context, token string) (*model.ListVaccinations, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/vaccinations", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var vs model.ListVaccinations
+	if err := json.NewDecoder(resp.Body).Decode(&vs); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &vs, nil
+}
+
+func (h *HTTPClient) GetVaccinationSchedule(c context.Context, token string) (*model.VaccinationSchedule, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/vaccinations/schedule", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var schedule model.VaccinationSchedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &schedule, nil
+}
+
+func (h *HTTPClient) GetOverdueVaccinations(c context.Context, token string) (*model.VaccinationSchedule, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/vaccinations/overdue", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var schedule model.VaccinationSchedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &schedule, nil
+}
+
+func (h *HTTPClient) GetVaccination(c context.Context, token string, id int64) (*model.Vaccination, error) {
+	vaccinationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/vaccinations/"+vaccinationID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var v model.Vaccination
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &v, nil
+}
+
+func (h *HTTPClient) UpdateVaccination(c context.Context, token string, id int64, r *model.UpdateVaccination) (*model.Vaccination, error) {
+	vaccinationID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/vaccinations/"+vaccinationID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var v model.Vaccination
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &v, nil
+}
+
+func (h *HTTPClient) DeleteVaccination(c context.Context, token string, id int64) error {
+	vaccinationID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/vaccinations/"+vaccinationID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetPatientVaccinations(c context.Context, token string, patientID int64) (*model.ListVaccinations, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/vaccinations", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var vs model.ListVaccinations
+	if err := json.NewDecoder(resp.Body).Decode(&vs); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &vs, nil
+}
+
+func (h *HTTPClient) GetPatientOverdueVaccinations(c context.Context, token string, patientID int64) (*model.VaccinationSchedule, error) {
+	pID := strconv.Itoa(int(patientID))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/patients/"+pID+"/vaccinations/overdue", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var schedule model.VaccinationSchedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &schedule, nil
+}
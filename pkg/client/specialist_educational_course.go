@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddEducationalCourse(c context.Context, token string, r *model.AddEducationalCourse) (*model.EducationalCourse, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/specialist/courses", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var course model.EducationalCourse
+	if err := json.NewDecoder(resp.Body).Decode(&course); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &course, nil
+}
+
+func (h *HTTPClient) GetEducationalCourses(c context.Context, token string) (*model.ListEducationalCourses, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/courses", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var courses model.ListEducationalCourses
+	if err := json.NewDecoder(resp.Body).Decode(&courses); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &courses, nil
+}
+
+func (h *HTTPClient) GetCoursePoints(c context.Context, token string, r *model.CoursePointsRequest) (*model.ListCoursePoints, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/courses/points", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var points model.ListCoursePoints
+	if err := json.NewDecoder(resp.Body).Decode(&points); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &points, nil
+}
+
+func (h *HTTPClient) GetEducationalCourse(c context.Context, token string, id int64) (*model.EducationalCourse, error) {
+	courseID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/courses/"+courseID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var course model.EducationalCourse
+	if err := json.NewDecoder(resp.Body).Decode(&course); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &course, nil
+}
+
+func (h *HTTPClient) UpdateEducationalCourse(c context.Context, token string, id int64, r *model.UpdateEducationalCourse) (*model.EducationalCourse, error) {
+	courseID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/courses/"+courseID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var course model.EducationalCourse
+	if err := json.NewDecoder(resp.Body).Decode(&course); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &course, nil
+}
+
+func (h *HTTPClient) DeleteEducationalCourse(c context.Context, token string, id int64) error {
+	courseID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/specialist/courses/"+courseID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
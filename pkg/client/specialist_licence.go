@@ -0,0 +1,208 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddLicence(c context.Context, token string, r *model.AddLicence) (*model.Licence, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/specialist/licences", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.Licence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) GetLicences(c context.Context, token string) (*model.ListLicences, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/licences", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licences model.ListLicences
+	if err := json.NewDecoder(resp.Body).Decode(&licences); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licences, nil
+}
+
+func (h *HTTPClient) GetLicence(c context.Context, token string, id int64) (*model.Licence, error) {
+	licenceID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/licences/"+licenceID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.Licence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) UpdateLicence(c context.Context, token string, id int64, r *model.UpdateLicence) (*model.Licence, error) {
+	licenceID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/licences/"+licenceID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.Licence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) UpdateLicenceStatus(c context.Context, token string, id int64, r *model.UpdateLicenceStatus) (*model.Licence, error) {
+	licenceID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/licences/"+licenceID+"/status", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.Licence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) CheckLicence(c context.Context, token string, id int64) (*model.Licence, error) {
+	licenceID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/specialist/licences/"+licenceID+"/check", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var licence model.Licence
+	if err := json.NewDecoder(resp.Body).Decode(&licence); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &licence, nil
+}
+
+func (h *HTTPClient) DeleteLicence(c context.Context, token string, id int64) error {
+	licenceID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/specialist/licences/"+licenceID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,396 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddReview(c context.Context, token string, r *model.AddReview) (*model.Review, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/patient/reviews", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
+
+func (h *HTTPClient) GetReviews(c context.Context, token string) (*model.ListReviews, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/reviews", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var list model.ListReviews
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &list, nil
+}
+
+func (h *HTTPClient) GetReview(c context.Context, token string, id int64) (*model.Review, error) {
+	reviewID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/patient/reviews/"+reviewID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
+
+func (h *HTTPClient) UpdateReview(c context.Context, token string, id int64, r *model.UpdateReview) (*model.Review, error) {
+	reviewID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/patient/reviews/"+reviewID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
+
+func (h *HTTPClient) DeleteReview(c context.Context, token string, id int64) error {
+	reviewID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/patient/reviews/"+reviewID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetSpecialistProfileReviews(c context.Context, token string, r *model.ListReviewsRequest) (*model.ListReviews, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/reviews", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var list model.ListReviews
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &list, nil
+}
+
+func (h *HTTPClient) ReplyReview(c context.Context, token string, id int64, r *model.ReplyReview) (*model.Review, error) {
+	reviewID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/reviews/"+reviewID+"/reply", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
+
+func (h *HTTPClient) DeleteReviewReply(c context.Context, token string, id int64) (*model.Review, error) {
+	reviewID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/specialist/reviews/"+reviewID+"/reply", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
+
+func (h *HTTPClient) GetSpecialistReviews(c context.Context, token string, id int64, r *model.ListReviewsRequest) (*model.ListReviews, error) {
+	specialistID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialists/"+specialistID+"/reviews", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var list model.ListReviews
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &list, nil
+}
+
+func (h *HTTPClient) GetModerationReviews(c context.Context, token string, r *model.ListReviewsRequest) (*model.ListReviews, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/moderation/reviews", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var list model.ListReviews
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &list, nil
+}
+
+func (h *HTTPClient) GetModerationReview(c context.Context, token string, id int64) (*model.Review, error) {
+	reviewID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/moderation/reviews/"+reviewID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
+
+func (h *HTTPClient) ApproveReview(c context.Context, token string, id int64, r *model.ModerateReview) (*model.Review, error) {
+	reviewID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/moderation/reviews/"+reviewID+"/approve", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
+
+func (h *HTTPClient) RejectReview(c context.Context, token string, id int64, r *model.ModerateReview) (*model.Review, error) {
+	reviewID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/moderation/reviews/"+reviewID+"/reject", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var review model.Review
+	if err := json.NewDecoder(resp.Body).Decode(&review); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &review, nil
+}
@@ -0,0 +1,346 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddSchedule(c context.Context, token string, r *model.AddSchedule) (*model.Schedule, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/specialist/schedules", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var schedule model.Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &schedule, nil
+}
+
+func (h *HTTPClient) GetSchedules(c context.Context, token string) (*model.ListSchedules, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/schedules", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var list model.ListSchedules
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &list, nil
+}
+
+func (h *HTTPClient) GetSchedule(c context.Context, token string, id int64) (*model.Schedule, error) {
+	scheduleID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/schedules/"+scheduleID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var schedule model.Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &schedule, nil
+}
+
+func (h *HTTPClient) UpdateSchedule(c context.Context, token string, id int64, r *model.UpdateSchedule) (*model.Schedule, error) {
+	scheduleID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/schedules/"+scheduleID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var schedule model.Schedule
+	if err := json.NewDecoder(resp.Body).Decode(&schedule); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &schedule, nil
+}
+
+func (h *HTTPClient) DeleteSchedule(c context.Context, token string, id int64) error {
+	scheduleID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/specialist/schedules/"+scheduleID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) AddScheduleException(c context.Context, token string, r *model.AddScheduleException) (*model.ScheduleException, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/specialist/schedule_exceptions", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var exception model.ScheduleException
+	if err := json.NewDecoder(resp.Body).Decode(&exception); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &exception, nil
+}
+
+func (h *HTTPClient) GetScheduleExceptions(c context.Context, token string) (*model.ListScheduleExceptions, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/schedule_exceptions", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var list model.ListScheduleExceptions
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &list, nil
+}
+
+func (h *HTTPClient) GetScheduleException(c context.Context, token string, id int64) (*model.ScheduleException, error) {
+	exceptionID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/schedule_exceptions/"+exceptionID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var exception model.ScheduleException
+	if err := json.NewDecoder(resp.Body).Decode(&exception); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &exception, nil
+}
+
+func (h *HTTPClient) UpdateScheduleException(c context.Context, token string, id int64, r *model.UpdateScheduleException) (*model.ScheduleException, error) {
+	exceptionID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/schedule_exceptions/"+exceptionID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var exception model.ScheduleException
+	if err := json.NewDecoder(resp.Body).Decode(&exception); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &exception, nil
+}
+
+func (h *HTTPClient) DeleteScheduleException(c context.Context, token string, id int64) error {
+	exceptionID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/specialist/schedule_exceptions/"+exceptionID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
+
+func (h *HTTPClient) GetSpecialistSchedules(c context.Context, token string, id int64) (*model.ListSchedules, error) {
+	specialistID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialists/"+specialistID+"/schedules", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var list model.ListSchedules
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &list, nil
+}
+
+func (h *HTTPClient) GetAvailability(c context.Context, token string, id int64, r *model.AvailabilityRequest) (*model.Availability, error) {
+	specialistID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialists/"+specialistID+"/availability", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var availability model.Availability
+	if err := json.NewDecoder(resp.Body).Decode(&availability); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &availability, nil
+}
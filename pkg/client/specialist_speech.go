@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddSpeech(c context.Context, token string, r *model.AddSpeech) (*model.Speech, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/specialist/speeches", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var speech model.Speech
+	if err := json.NewDecoder(resp.Body).Decode(&speech); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &speech, nil
+}
+
+func (h *HTTPClient) GetSpeeches(c context.Context, token string) (*model.ListSpeeches, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/speeches", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var speeches model.ListSpeeches
+	if err := json.NewDecoder(resp.Body).Decode(&speeches); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &speeches, nil
+}
+
+func (h *HTTPClient) GetSpeech(c context.Context, token string, id int64) (*model.Speech, error) {
+	speechID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/speeches/"+speechID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var speech model.Speech
+	if err := json.NewDecoder(resp.Body).Decode(&speech); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &speech, nil
+}
+
+func (h *HTTPClient) UpdateSpeech(c context.Context, token string, id int64, r *model.UpdateSpeech) (*model.Speech, error) {
+	speechID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/speeches/"+speechID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var speech model.Speech
+	if err := json.NewDecoder(resp.Body).Decode(&speech); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &speech, nil
+}
+
+func (h *HTTPClient) DeleteSpeech(c context.Context, token string, id int64) error {
+	speechID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/specialist/speeches/"+speechID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
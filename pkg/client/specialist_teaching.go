@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"net/http"
+	"strconv"
+)
+
+func (h *HTTPClient) AddTeaching(c context.Context, token string, r *model.AddTeaching) (*model.Teaching, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, h.baseURL+"/specialist/teaching", bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var teaching model.Teaching
+	if err := json.NewDecoder(resp.Body).Decode(&teaching); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &teaching, nil
+}
+
+func (h *HTTPClient) GetTeachings(c context.Context, token string) (*model.ListTeachings, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/teaching", nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var teachings model.ListTeachings
+	if err := json.NewDecoder(resp.Body).Decode(&teachings); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &teachings, nil
+}
+
+func (h *HTTPClient) GetTeaching(c context.Context, token string, id int64) (*model.Teaching, error) {
+	teachingID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, h.baseURL+"/specialist/teaching/"+teachingID, nil)
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var teaching model.Teaching
+	if err := json.NewDecoder(resp.Body).Decode(&teaching); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &teaching, nil
+}
+
+func (h *HTTPClient) UpdateTeaching(c context.Context, token string, id int64, r *model.UpdateTeaching) (*model.Teaching, error) {
+	teachingID := strconv.Itoa(int(id))
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, h.error(ErrMarshalRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPut, h.baseURL+"/specialist/teaching/"+teachingID, bytes.NewReader(body))
+	if err != nil {
+		return nil, h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	var teaching model.Teaching
+	if err := json.NewDecoder(resp.Body).Decode(&teaching); err != nil {
+		return nil, h.error(ErrDecodeResponseBody, err)
+	}
+
+	return &teaching, nil
+}
+
+func (h *HTTPClient) DeleteTeaching(c context.Context, token string, id int64) error {
+	teachingID := strconv.Itoa(int(id))
+
+	req, err := http.NewRequestWithContext(c, http.MethodDelete, h.baseURL+"/specialist/teaching/"+teachingID, nil)
+	if err != nil {
+		return h.error(ErrRegisterRequest, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return h.error(ErrDoRequest, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h.decodeErrorResponse(resp.StatusCode, resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"html/template"
+	"io"
+	"time"
+)
+
+const IndexName = "index.html"
+
+var indexTemplate = template.Must(template.New(IndexName).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Personal data export</title>
+</head>
+<body>
+<h1>Personal data export</h1>
+<p>Account: {{.Login}}</p>
+<p>Generated at: {{.GeneratedAt.Format "2006-01-02 15:04:05 MST"}}</p>
+<p>Every section is stored as a JSON file, the uploaded files are kept as they were uploaded.</p>
+<table>
+<tr><th>Section</th><th>File</th><th>Size, bytes</th></tr>
+{{- range .Entries}}
+<tr><td>{{.Title}}</td><td><a href="{{.Name}}">{{.Name}}</a></td><td>{{.Size}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+type Entry struct {
+	Name  string
+	Title string
+	Size  int64
+}
+
+// Archive writes a ZIP with machine-readable sections and an index page describing them.
+type Archive struct {
+	zw      *zip.Writer
+	login   string
+	entries []*Entry
+}
+
+func NewArchive(w io.Writer, login string) *Archive {
+	return &Archive{zw: zip.NewWriter(w), login: login}
+}
+
+func (a *Archive) AddJSON(name string, title string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return a.add(name, title, func(w io.Writer) (int64, error) {
+		n, err := w.Write(b)
+		return int64(n), err
+	})
+}
+
+func (a *Archive) AddFile(name string, title string, r io.Reader) error {
+	return a.add(name, title, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+}
+
+func (a *Archive) Entries() []*Entry {
+	return a.entries
+}
+
+// Close writes the index and finishes the archive, nothing can be added after it.
+func (a *Archive) Close(generatedAt time.Time) error {
+	w, err := a.zw.Create(IndexName)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Login       string
+		GeneratedAt time.Time
+		Entries     []*Entry
+	}{
+		Login:       a.login,
+		GeneratedAt: generatedAt,
+		Entries:     a.entries,
+	}
+
+	if err := indexTemplate.Execute(w, data); err != nil {
+		return err
+	}
+
+	return a.zw.Close()
+}
+
+func (a *Archive) add(name string, title string, write func(w io.Writer) (int64, error)) error {
+	w, err := a.zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	n, err := write(w)
+	if err != nil {
+		return err
+	}
+
+	a.entries = append(a.entries, &Entry{Name: name, Title: title, Size: n})
+
+	return nil
+}
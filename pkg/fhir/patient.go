@@ -0,0 +1,193 @@
+package fhir
+
+import "github.com/Hvaekar/med-account/pkg/model"
+
+type Patient struct {
+	ResourceType string           `json:"resourceType"`
+	ID           string           `json:"id"`
+	Identifier   []Identifier     `json:"identifier"`
+	Active       bool             `json:"active"`
+	Name         []HumanName      `json:"name,omitempty"`
+	Telecom      []ContactPoint   `json:"telecom,omitempty"`
+	Gender       string           `json:"gender,omitempty"`
+	BirthDate    string           `json:"birthDate,omitempty"`
+	Contact      []PatientContact `json:"contact,omitempty"`
+}
+
+func (p *Patient) ResourceName() string {
+	return p.ResourceType
+}
+
+func (p *Patient) ResourceID() string {
+	return p.ID
+}
+
+type PatientContact struct {
+	Relationship []CodeableConcept `json:"relationship"`
+	Name         *HumanName        `json:"name,omitempty"`
+	Telecom      []ContactPoint    `json:"telecom,omitempty"`
+}
+
+type Observation struct {
+	ResourceType         string            `json:"resourceType"`
+	ID                   string            `json:"id"`
+	Status               string            `json:"status"`
+	Category             []CodeableConcept `json:"category"`
+	Code                 CodeableConcept   `json:"code"`
+	Subject              Reference         `json:"subject"`
+	ValueQuantity        *Quantity         `json:"valueQuantity,omitempty"`
+	ValueCodeableConcept *CodeableConcept  `json:"valueCodeableConcept,omitempty"`
+}
+
+func (o *Observation) ResourceName() string {
+	return o.ResourceType
+}
+
+func (o *Observation) ResourceID() string {
+	return o.ID
+}
+
+type Device struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id"`
+	Status       string            `json:"status"`
+	Manufacturer string            `json:"manufacturer,omitempty"`
+	ModelNumber  string            `json:"modelNumber,omitempty"`
+	Type         *CodeableConcept  `json:"type,omitempty"`
+	Safety       []CodeableConcept `json:"safety,omitempty"`
+	Patient      Reference         `json:"patient"`
+	Note         []Annotation      `json:"note,omitempty"`
+}
+
+func (d *Device) ResourceName() string {
+	return d.ResourceType
+}
+
+func (d *Device) ResourceID() string {
+	return d.ID
+}
+
+func NewPatient(p *model.Patient) *Patient {
+	fp := Patient{
+		ResourceType: "Patient",
+		ID:           id(p.ID),
+		Identifier:   []Identifier{{System: SystemPatient, Value: id(p.ID)}},
+		Active:       true,
+		Name:         humanName(p.FirstName, p.FatherName, p.LastName),
+		Telecom:      telecom(p.Phone, p.Email),
+		Gender:       gender(p.Sex),
+		BirthDate:    date(p.Birthday),
+	}
+
+	for _, v := range p.EmergencyContacts {
+		fp.Contact = append(fp.Contact, PatientContact{
+			Relationship: []CodeableConcept{{
+				Coding: []Coding{{System: SystemContactRole, Code: "C", Display: "Emergency Contact"}},
+				Text:   v.Relation,
+			}},
+			Name:    &HumanName{Text: v.Name},
+			Telecom: []ContactPoint{{System: "phone", Value: v.Phone, Rank: int(v.Priority)}},
+		})
+	}
+
+	return &fp
+}
+
+// NewObservations turns the body, blood and vision data kept on the profile into observations,
+// the ids are stable so the same value always has the same address.
+func NewObservations(p *model.Patient) []*Observation {
+	var os []*Observation
+
+	subject := Reference{Reference: "Patient/" + id(p.ID)}
+
+	quantity := func(name string, category string, code Coding, value *float64, unit string, ucum string) {
+		if value == nil {
+			return
+		}
+
+		os = append(os, &Observation{
+			ResourceType:  "Observation",
+			ID:            id(p.ID) + "-" + name,
+			Status:        "final",
+			Category:      []CodeableConcept{{Coding: []Coding{{System: SystemObservationCategory, Code: category}}}},
+			Code:          CodeableConcept{Coding: []Coding{code}, Text: code.Display},
+			Subject:       subject,
+			ValueQuantity: &Quantity{Value: *value, Unit: unit, System: SystemUCUM, Code: ucum},
+		})
+	}
+
+	concept := func(name string, code Coding, value string) {
+		os = append(os, &Observation{
+			ResourceType:         "Observation",
+			ID:                   id(p.ID) + "-" + name,
+			Status:               "final",
+			Category:             []CodeableConcept{{Coding: []Coding{{System: SystemObservationCategory, Code: "laboratory"}}}},
+			Code:                 CodeableConcept{Coding: []Coding{code}, Text: code.Display},
+			Subject:              subject,
+			ValueCodeableConcept: &CodeableConcept{Text: value},
+		})
+	}
+
+	quantity("height", "vital-signs", Coding{System: SystemLOINC, Code: "8302-2", Display: "Body height"}, p.Height, "cm", "cm")
+	quantity("weight", "vital-signs", Coding{System: SystemLOINC, Code: "29463-7", Display: "Body weight"}, p.Weight, "kg", "kg")
+
+	if p.BloodType != nil {
+		concept("blood-type", Coding{System: SystemLOINC, Code: "883-9", Display: "ABO group [Type] in Blood"}, *p.BloodType)
+	}
+
+	if p.Rh != nil {
+		rh := "negative"
+		if *p.Rh {
+			rh = "positive"
+		}
+
+		concept("rh", Coding{System: SystemLOINC, Code: "10331-7", Display: "Rh [Type] in Blood"}, rh)
+	}
+
+	quantity("left-eye", "exam", Coding{System: SystemObservation, Code: "left-eye", Display: "Left eye refraction"}, p.LeftEye, "D", "[diop]")
+	quantity("right-eye", "exam", Coding{System: SystemObservation, Code: "right-eye", Display: "Right eye refraction"}, p.RightEye, "D", "[diop]")
+
+	return os
+}
+
+func NewDevices(p *model.Patient) []*Device {
+	var ds []*Device
+
+	for _, v := range p.MetalComponents {
+		d := Device{
+			ResourceType: "Device",
+			ID:           id(v.ID),
+			Status:       "active",
+			Type:         &CodeableConcept{Coding: []Coding{{System: SystemImplantType, Code: v.Type}}},
+			Patient:      Reference{Reference: "Patient/" + id(p.ID)},
+		}
+
+		if v.Metal != nil {
+			d.Type.Text = *v.Metal
+		}
+
+		if v.Manufacturer != nil {
+			d.Manufacturer = *v.Manufacturer
+		}
+
+		if v.Model != nil {
+			d.ModelNumber = *v.Model
+		}
+
+		if v.MRISafety != nil {
+			d.Safety = []CodeableConcept{{Coding: []Coding{{System: SystemMRISafety, Code: *v.MRISafety}}}}
+		}
+
+		if v.Description != nil {
+			d.Note = append(d.Note, Annotation{Text: *v.Description})
+		}
+
+		if v.MRIConditions != nil {
+			d.Note = append(d.Note, Annotation{Text: "MRI conditions: " + *v.MRIConditions})
+		}
+
+		ds = append(ds, &d)
+	}
+
+	return ds
+}
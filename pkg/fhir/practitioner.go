@@ -0,0 +1,106 @@
+package fhir
+
+import "github.com/Hvaekar/med-account/pkg/model"
+
+type Practitioner struct {
+	ResourceType  string          `json:"resourceType"`
+	ID            string          `json:"id"`
+	Identifier    []Identifier    `json:"identifier"`
+	Active        bool            `json:"active"`
+	Name          []HumanName     `json:"name,omitempty"`
+	Telecom       []ContactPoint  `json:"telecom,omitempty"`
+	Gender        string          `json:"gender,omitempty"`
+	Qualification []Qualification `json:"qualification,omitempty"`
+}
+
+func (p *Practitioner) ResourceName() string {
+	return p.ResourceType
+}
+
+func (p *Practitioner) ResourceID() string {
+	return p.ID
+}
+
+type Qualification struct {
+	Identifier []Identifier    `json:"identifier,omitempty"`
+	Code       CodeableConcept `json:"code"`
+	Period     *Period         `json:"period,omitempty"`
+}
+
+type PractitionerRole struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id"`
+	Active       bool              `json:"active"`
+	Practitioner Reference         `json:"practitioner"`
+	Specialty    []CodeableConcept `json:"specialty,omitempty"`
+	Period       *Period           `json:"period,omitempty"`
+}
+
+func (r *PractitionerRole) ResourceName() string {
+	return r.ResourceType
+}
+
+func (r *PractitionerRole) ResourceID() string {
+	return r.ID
+}
+
+// NewPractitioner lists only active licences, their numbers are also practitioner identifiers.
+func NewPractitioner(s *model.Specialist) *Practitioner {
+	p := Practitioner{
+		ResourceType: "Practitioner",
+		ID:           id(s.ID),
+		Identifier:   []Identifier{{System: SystemPractitioner, Value: id(s.ID)}},
+		Active:       true,
+		Name:         humanName(s.FirstName, s.FatherName, s.LastName),
+		Telecom:      telecom(s.Phone, s.Email),
+		Gender:       gender(s.Sex),
+	}
+
+	for _, v := range s.Licences {
+		if v.Status != model.LicenceStatusActive {
+			continue
+		}
+
+		p.Identifier = append(p.Identifier, Identifier{System: SystemLicence, Value: v.Number})
+
+		q := Qualification{
+			Identifier: []Identifier{{System: SystemLicence, Value: v.Number}},
+			Code:       CodeableConcept{Text: v.Practice},
+		}
+
+		if v.Issued != nil || v.Expires != nil {
+			q.Period = &Period{Start: date(v.Issued), End: date(v.Expires)}
+		}
+
+		p.Qualification = append(p.Qualification, q)
+	}
+
+	return &p
+}
+
+// NewPractitionerRole describes what the specialist practises, the role shares the practitioner id.
+func NewPractitionerRole(s *model.Specialist) *PractitionerRole {
+	r := PractitionerRole{
+		ResourceType: "PractitionerRole",
+		ID:           id(s.ID),
+		Active:       true,
+		Practitioner: Reference{Reference: "Practitioner/" + id(s.ID)},
+	}
+
+	var start string
+	for _, v := range s.Specializations {
+		r.Specialty = append(r.Specialty, CodeableConcept{
+			Coding: []Coding{{System: SystemSpecialization, Code: id(v.SpecializationID)}},
+		})
+
+		if d := date(&v.Start); d != "" && (start == "" || d < start) {
+			start = d
+		}
+	}
+
+	if start != "" {
+		r.Period = &Period{Start: start}
+	}
+
+	return &r
+}
@@ -0,0 +1,236 @@
+package fhir
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"strconv"
+	"strings"
+)
+
+// Only the parts of FHIR R4 (https://hl7.org/fhir/R4) that can be filled from our profiles are described here.
+
+const (
+	ContentType = "application/fhir+json; charset=utf-8"
+
+	SystemPatient        = "urn:med-account:patient"
+	SystemPractitioner   = "urn:med-account:practitioner"
+	SystemLicence        = "urn:med-account:licence"
+	SystemSpecialization = "urn:med-account:specialization"
+	SystemObservation    = "urn:med-account:observation"
+	SystemImplantType    = "urn:med-account:implant-type"
+	SystemMRISafety      = "urn:med-account:mri-safety"
+
+	SystemLOINC               = "http://loinc.org"
+	SystemUCUM                = "http://unitsofmeasure.org"
+	SystemObservationCategory = "http://terminology.hl7.org/CodeSystem/observation-category"
+	SystemContactRole         = "http://terminology.hl7.org/CodeSystem/v2-0131"
+
+	BundleTypeSearchSet = "searchset"
+	SearchModeMatch     = "match"
+
+	IssueSeverityError = "error"
+
+	IssueCodeInvalid      = "invalid"
+	IssueCodeNotFound     = "not-found"
+	IssueCodeNotSupported = "not-supported"
+	IssueCodeException    = "exception"
+)
+
+type Identifier struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value"`
+}
+
+type HumanName struct {
+	Use    string   `json:"use,omitempty"`
+	Text   string   `json:"text,omitempty"`
+	Family string   `json:"family,omitempty"`
+	Given  []string `json:"given,omitempty"`
+}
+
+type ContactPoint struct {
+	System string `json:"system"`
+	Value  string `json:"value"`
+	Rank   int    `json:"rank,omitempty"`
+}
+
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+type Reference struct {
+	Reference string `json:"reference"`
+	Display   string `json:"display,omitempty"`
+}
+
+type Quantity struct {
+	Value  float64 `json:"value"`
+	Unit   string  `json:"unit"`
+	System string  `json:"system"`
+	Code   string  `json:"code"`
+}
+
+type Period struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+type Annotation struct {
+	Text string `json:"text"`
+}
+
+type Bundle struct {
+	ResourceType string         `json:"resourceType"`
+	Type         string         `json:"type"`
+	Total        int            `json:"total"`
+	Entry        []*BundleEntry `json:"entry,omitempty"`
+}
+
+type BundleEntry struct {
+	FullURL  string        `json:"fullUrl"`
+	Resource interface{}   `json:"resource"`
+	Search   *BundleSearch `json:"search,omitempty"`
+}
+
+type BundleSearch struct {
+	Mode string `json:"mode"`
+}
+
+// NewSearchSet wraps the matched resources, baseURL is the absolute address of the /fhir endpoint.
+func NewSearchSet(baseURL string, resources ...Resource) *Bundle {
+	b := Bundle{
+		ResourceType: "Bundle",
+		Type:         BundleTypeSearchSet,
+		Total:        len(resources),
+	}
+
+	for _, v := range resources {
+		b.Entry = append(b.Entry, &BundleEntry{
+			FullURL:  baseURL + "/" + v.ResourceName() + "/" + v.ResourceID(),
+			Resource: v,
+			Search:   &BundleSearch{Mode: SearchModeMatch},
+		})
+	}
+
+	return &b
+}
+
+type Resource interface {
+	ResourceName() string
+	ResourceID() string
+}
+
+type OperationOutcome struct {
+	ResourceType string   `json:"resourceType"`
+	Issue        []*Issue `json:"issue"`
+}
+
+type Issue struct {
+	Severity    string `json:"severity"`
+	Code        string `json:"code"`
+	Diagnostics string `json:"diagnostics,omitempty"`
+}
+
+func NewOperationOutcome(code string, diagnostics string) *OperationOutcome {
+	return &OperationOutcome{
+		ResourceType: "OperationOutcome",
+		Issue:        []*Issue{{Severity: IssueSeverityError, Code: code, Diagnostics: diagnostics}},
+	}
+}
+
+// ParseToken splits a token search value, "system|value" or just "value" which matches any system.
+func ParseToken(token string) (string, string) {
+	if i := strings.Index(token, "|"); i >= 0 {
+		return token[:i], token[i+1:]
+	}
+
+	return "", token
+}
+
+// ParseReference accepts both "Type/id" and a bare id of the given resource type.
+func ParseReference(resourceType string, ref string) (int64, bool) {
+	ref = strings.TrimPrefix(ref, resourceType+"/")
+
+	id, err := strconv.ParseInt(ref, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
+func id(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func humanName(first *string, father *string, last *string) []HumanName {
+	var n HumanName
+	var parts []string
+
+	if first != nil {
+		n.Given = append(n.Given, *first)
+		parts = append(parts, *first)
+	}
+
+	if father != nil {
+		n.Given = append(n.Given, *father)
+		parts = append(parts, *father)
+	}
+
+	if last != nil {
+		n.Family = *last
+		parts = append(parts, *last)
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+
+	n.Use = "official"
+	n.Text = strings.Join(parts, " ")
+
+	return []HumanName{n}
+}
+
+func gender(sex *string) string {
+	if sex == nil {
+		return ""
+	}
+
+	switch *sex {
+	case "man":
+		return "male"
+	case "woman":
+		return "female"
+	default:
+		return "unknown"
+	}
+}
+
+func telecom(phone *string, email *string) []ContactPoint {
+	var cs []ContactPoint
+
+	if phone != nil {
+		cs = append(cs, ContactPoint{System: "phone", Value: *phone})
+	}
+
+	if email != nil {
+		cs = append(cs, ContactPoint{System: "email", Value: *email})
+	}
+
+	return cs
+}
+
+func date(d *pgtype.Date) string {
+	if d == nil || !d.Valid {
+		return ""
+	}
+
+	return d.Time.Format("2006-01-02")
+}
@@ -0,0 +1,154 @@
+package health
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// who holds WHO growth reference LMS parameters as tab separated "month l m s" rows: the monthly
+// WHO Child Growth Standards 2006 up to 60 months (length until 24 months, standing height after)
+// and yearly rows of the WHO Reference 2007 after. Values between rows are interpolated linearly.
+//
+//go:embed who/*.tsv
+var who embed.FS
+
+var ErrOutOfReference = errors.New("age is out of growth reference range")
+
+type lms struct {
+	month float64
+	l     float64
+	m     float64
+	s     float64
+}
+
+type reference []lms
+
+var references = map[string]reference{
+	"height_for_age_" + SexMale:   mustLoadReference("who/height_for_age_boys.tsv"),
+	"height_for_age_" + SexFemale: mustLoadReference("who/height_for_age_girls.tsv"),
+	"weight_for_age_" + SexMale:   mustLoadReference("who/weight_for_age_boys.tsv"),
+	"weight_for_age_" + SexFemale: mustLoadReference("who/weight_for_age_girls.tsv"),
+}
+
+func mustLoadReference(name string) reference {
+	content, err := who.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+
+	var ref reference
+	sc := bufio.NewScanner(bytes.NewReader(content))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 4 {
+			continue
+		}
+
+		values := make([]float64, 0, 4)
+		for _, f := range fields {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				break
+			}
+			values = append(values, v)
+		}
+		if len(values) != 4 {
+			continue // header
+		}
+
+		ref = append(ref, lms{month: values[0], l: values[1], m: values[2], s: values[3]})
+	}
+
+	sort.Slice(ref, func(i, j int) bool { return ref[i].month < ref[j].month })
+
+	return ref
+}
+
+func (r reference) at(month float64) (lms, error) {
+	if len(r) == 0 || month < r[0].month || month > r[len(r)-1].month {
+		return lms{}, ErrOutOfReference
+	}
+
+	i := sort.Search(len(r), func(i int) bool { return r[i].month >= month })
+	if r[i].month == month {
+		return r[i], nil
+	}
+
+	a, b := r[i-1], r[i]
+	k := (month - a.month) / (b.month - a.month)
+
+	return lms{
+		month: month,
+		l:     a.l + (b.l-a.l)*k,
+		m:     a.m + (b.m-a.m)*k,
+		s:     a.s + (b.s-a.s)*k,
+	}, nil
+}
+
+func (p lms) value(z float64) float64 {
+	if p.l == 0 {
+		return p.m * math.Exp(p.s*z)
+	}
+
+	return p.m * math.Pow(1+p.l*p.s*z, 1/p.l)
+}
+
+func (p lms) zScore(x float64) float64 {
+	if p.l == 0 {
+		return math.Log(x/p.m) / p.s
+	}
+
+	return (math.Pow(x/p.m, p.l) - 1) / (p.l * p.s)
+}
+
+// restrictedZScore applies the WHO adjustment for skewed indicators beyond +-3 SD.
+func (p lms) restrictedZScore(x float64) float64 {
+	z := p.zScore(x)
+
+	switch {
+	case z > 3:
+		sd3 := p.value(3)
+		return 3 + (x-sd3)/(sd3-p.value(2))
+	case z < -3:
+		sd3 := p.value(-3)
+		return -3 - (sd3-x)/(p.value(-2)-sd3)
+	default:
+		return z
+	}
+}
+
+type Growth struct {
+	ZScore     float64 `json:"z_score"`
+	Percentile float64 `json:"percentile"`
+}
+
+func HeightForAge(sex string, months float64, height float64) (*Growth, error) {
+	p, err := references["height_for_age_"+sex].at(months)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGrowth(p.zScore(height)), nil
+}
+
+func WeightForAge(sex string, months float64, weight float64) (*Growth, error) {
+	p, err := references["weight_for_age_"+sex].at(months)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGrowth(p.restrictedZScore(weight)), nil
+}
+
+func newGrowth(z float64) *Growth {
+	return &Growth{
+		ZScore:     round(z, 2),
+		Percentile: round(50*math.Erfc(-z/math.Sqrt2), 1),
+	}
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	SexMale   = "male"
+	SexFemale = "female"
+
+	BMIUnderweight = "underweight"
+	BMINormal      = "normal"
+	BMIOverweight  = "overweight"
+	BMIObese1      = "obese_1"
+	BMIObese2      = "obese_2"
+	BMIObese3      = "obese_3"
+
+	// adult BMI categories apply from 19 years, growth references end there
+	adultMonths = 228
+)
+
+type Indicators struct {
+	Age          *int     `json:"age,omitempty"`
+	AgeMonths    *int     `json:"age_months,omitempty"`
+	BMI          *float64 `json:"bmi,omitempty"`
+	BMICategory  *string  `json:"bmi_category,omitempty"`
+	HeightForAge *Growth  `json:"height_for_age,omitempty"`
+	WeightForAge *Growth  `json:"weight_for_age,omitempty"`
+}
+
+// NewIndicators computes everything the given data allows, nil if nothing can be derived.
+// Height is in centimetres, weight in kilograms.
+func NewIndicators(birthday *time.Time, sex string, height *float64, weight *float64, now time.Time) *Indicators {
+	var i Indicators
+
+	months := -1
+	if birthday != nil && !birthday.After(now) {
+		months = AgeMonths(*birthday, now)
+		years := months / 12
+
+		i.AgeMonths = &months
+		i.Age = &years
+	}
+
+	if height != nil && weight != nil && *height > 0 {
+		bmi := BMI(*height, *weight)
+		i.BMI = &bmi
+
+		if months < 0 || months >= adultMonths {
+			category := BMICategory(bmi)
+			i.BMICategory = &category
+		}
+	}
+
+	if months >= 0 && months < adultMonths && (sex == SexMale || sex == SexFemale) {
+		if height != nil {
+			i.HeightForAge, _ = HeightForAge(sex, float64(months), *height)
+		}
+
+		if weight != nil {
+			i.WeightForAge, _ = WeightForAge(sex, float64(months), *weight)
+		}
+	}
+
+	if i.Age == nil && i.BMI == nil {
+		return nil
+	}
+
+	return &i
+}
+
+// AgeMonths returns full months between birthday and now.
+func AgeMonths(birthday time.Time, now time.Time) int {
+	months := (now.Year()-birthday.Year())*12 + int(now.Month()-birthday.Month())
+	if now.Day() < birthday.Day() {
+		months--
+	}
+
+	return months
+}
+
+func BMI(height float64, weight float64) float64 {
+	m := height / 100
+
+	return round(weight/(m*m), 1)
+}
+
+// BMICategory uses WHO cut-off points for adults.
+func BMICategory(bmi float64) string {
+	switch {
+	case bmi < 18.5:
+		return BMIUnderweight
+	case bmi < 25:
+		return BMINormal
+	case bmi < 30:
+		return BMIOverweight
+	case bmi < 35:
+		return BMIObese1
+	case bmi < 40:
+		return BMIObese2
+	default:
+		return BMIObese3
+	}
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+
+	r := math.Round(v*p) / p
+	if r == 0 {
+		return 0 // drop negative zero
+	}
+
+	return r
+}
@@ -0,0 +1,120 @@
+package icd10
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// blocks.tsv lists the WHO ICD-10 blocks as "first-last<TAB>title" rows.
+//
+//go:embed blocks.tsv
+var blocksFile []byte
+
+// codes.tsv lists the WHO ICD-10 categories as "category<TAB>subcategories" rows,
+// where subcategories holds the fourth characters defined for the category.
+// Mortality-only categories (C97) and external causes (V01-Y98) are left out.
+//
+//go:embed codes.tsv
+var codesFile []byte
+
+var codeRegexp = regexp.MustCompile(`^[A-Z][0-9]{2}(\.[0-9])?$`)
+
+type Block struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Title string `json:"title"`
+}
+
+var (
+	blocks = mustLoadBlocks()
+	codes  = mustLoadCodes()
+)
+
+func mustLoadBlocks() []Block {
+	var bs []Block
+
+	sc := bufio.NewScanner(bytes.NewReader(blocksFile))
+	for sc.Scan() {
+		fields := strings.SplitN(sc.Text(), "\t", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		bounds := strings.Split(fields[0], "-")
+		if len(bounds) != 2 || len(bounds[0]) != 3 || len(bounds[1]) != 3 {
+			continue // header
+		}
+
+		bs = append(bs, Block{First: bounds[0], Last: bounds[1], Title: fields[1]})
+	}
+
+	if len(bs) == 0 {
+		panic("icd10: no blocks loaded")
+	}
+
+	sort.Slice(bs, func(i, j int) bool { return bs[i].First < bs[j].First })
+
+	return bs
+}
+
+func mustLoadCodes() map[string]string {
+	cs := make(map[string]string)
+
+	sc := bufio.NewScanner(bytes.NewReader(codesFile))
+	for sc.Scan() {
+		fields := strings.Split(sc.Text(), "\t")
+		if len(fields) != 2 || len(fields[0]) != 3 || fields[0] == "category" {
+			continue // header
+		}
+
+		cs[fields[0]] = fields[1]
+	}
+
+	if len(cs) == 0 {
+		panic("icd10: no codes loaded")
+	}
+
+	return cs
+}
+
+// Normalize upper-cases the code and adds the dot after the category if it is missing.
+func Normalize(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) > 3 && code[3] != '.' {
+		code = code[:3] + "." + code[3:]
+	}
+
+	return code
+}
+
+// Find returns the block of the code if the code is a category or a subcategory listed in codes.tsv.
+func Find(code string) (*Block, bool) {
+	code = Normalize(code)
+	if !codeRegexp.MatchString(code) {
+		return nil, false
+	}
+
+	category := code[:3]
+	subcategories, ok := codes[category]
+	if !ok || len(code) > 3 && !strings.Contains(subcategories, code[4:]) {
+		return nil, false
+	}
+
+	i := sort.Search(len(blocks), func(i int) bool { return blocks[i].Last >= category })
+	if i == len(blocks) || blocks[i].First > category {
+		return nil, false
+	}
+
+	b := blocks[i]
+
+	return &b, true
+}
+
+func Valid(code string) bool {
+	_, ok := Find(code)
+	return ok
+}
@@ -0,0 +1,86 @@
+package immunization
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calendar.tsv is the national immunization calendar for children as
+// "vaccine<TAB>dose<TAB>age in months<TAB>max age in months<TAB>title" rows,
+// the max age closes the catch-up window of the dose.
+//
+//go:embed calendar.tsv
+var calendarFile []byte
+
+type Dose struct {
+	Vaccine      string `json:"vaccine"`
+	Dose         int    `json:"dose"`
+	AgeMonths    int    `json:"age_months"`
+	MaxAgeMonths int    `json:"max_age_months"`
+	Title        string `json:"title"`
+}
+
+func (d Dose) Due(birthday time.Time) time.Time {
+	return birthday.AddDate(0, d.AgeMonths, 0)
+}
+
+// CatchUpUntil is the date the dose is no longer given to catch up on the calendar.
+func (d Dose) CatchUpUntil(birthday time.Time) time.Time {
+	return birthday.AddDate(0, d.MaxAgeMonths, 0)
+}
+
+var calendar = mustLoadCalendar()
+
+func mustLoadCalendar() []Dose {
+	var ds []Dose
+
+	sc := bufio.NewScanner(bytes.NewReader(calendarFile))
+	for sc.Scan() {
+		fields := strings.Split(sc.Text(), "\t")
+		if len(fields) != 5 {
+			continue
+		}
+
+		dose, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue // header
+		}
+
+		age, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+
+		maxAge, err := strconv.Atoi(fields[3])
+		if err != nil {
+			continue
+		}
+
+		ds = append(ds, Dose{Vaccine: fields[0], Dose: dose, AgeMonths: age, MaxAgeMonths: maxAge, Title: fields[4]})
+	}
+
+	if len(ds) == 0 {
+		panic("immunization: no calendar doses loaded")
+	}
+
+	sort.SliceStable(ds, func(i, j int) bool { return ds[i].AgeMonths < ds[j].AgeMonths })
+
+	return ds
+}
+
+func Calendar() []Dose {
+	ds := make([]Dose, len(calendar))
+	copy(ds, calendar)
+
+	return ds
+}
+
+// Normalize lower-cases the vaccine code, so "HepB" and "hepb" are matched against the same calendar entries.
+func Normalize(vaccine string) string {
+	return strings.ToLower(strings.TrimSpace(vaccine))
+}
@@ -0,0 +1,25 @@
+package licence
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound    = errors.New("licence is not found in registry")
+	ErrNoRegistry  = errors.New("licence registry is not configured")
+	ErrUnavailable = errors.New("licence registry is unavailable")
+)
+
+type Result struct {
+	Number   string     `json:"number"`
+	Status   string     `json:"status"`
+	FullName string     `json:"full_name"`
+	Practice string     `json:"practice"`
+	Expires  *time.Time `json:"expires,omitempty"`
+}
+
+type Checker interface {
+	Check(c context.Context, number string) (*Result, error)
+}
@@ -0,0 +1,51 @@
+package licence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Hvaekar/med-account/config"
+	"net/http"
+	"net/url"
+)
+
+type Registry struct {
+	cfg    *config.Licence
+	client *http.Client
+}
+
+func NewRegistry(cfg *config.Licence) *Registry {
+	return &Registry{cfg: cfg, client: &http.Client{Timeout: cfg.RegistryTimeout}}
+}
+
+func (r *Registry) Check(c context.Context, number string) (*Result, error) {
+	if r.cfg.RegistryURL == "" {
+		return nil, ErrNoRegistry
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, r.cfg.RegistryURL+"/licences/"+url.PathEscape(number), nil)
+	if err != nil {
+		return nil, fmt.Errorf("register request: %w", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, err.Error())
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	var res Result
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	return &res, nil
+}
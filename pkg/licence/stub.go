@@ -0,0 +1,53 @@
+package licence
+
+import (
+	"context"
+	"sync"
+)
+
+// Stub is an in-memory Checker used instead of the external registry in tests.
+type Stub struct {
+	mu       sync.RWMutex
+	licences map[string]*Result
+	err      error
+}
+
+func NewStub(licences ...*Result) *Stub {
+	s := &Stub{licences: make(map[string]*Result)}
+	for _, v := range licences {
+		s.Add(v)
+	}
+
+	return s
+}
+
+func (s *Stub) Add(r *Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.licences[r.Number] = r
+}
+
+// SetError makes every check fail with err, as an unreachable registry does, until it is reset with nil
+func (s *Stub) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+func (s *Stub) Check(_ context.Context, number string) (*Result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	r, ok := s.licences[number]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return r, nil
+}
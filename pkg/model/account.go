@@ -28,8 +28,9 @@ type Account struct {
 	ListAddresses
 	ListLanguages
 
-	Profiles ListProfiles `json:"profiles"`
-	//Companies      []Company           `json:"companies"`
+	Profiles      ListProfiles           `json:"profiles"`
+	Organizations []*AccountOrganization `json:"organizations,omitempty"`
+	Roles         []string               `json:"roles,omitempty"`
 }
 
 func (a *Account) ToResponse() IResponse {
@@ -41,6 +42,56 @@ func (a *Account) ToResponse() IResponse {
 	return a
 }
 
+func (a *Account) ApplyPrivacy(settings *PrivacySettings, relation PrivacyRelation) {
+	if relation.Self {
+		return
+	}
+
+	if !relation.Allows(settings.Names) {
+		a.FirstName = nil
+		a.FatherName = nil
+		a.LastName = nil
+	}
+
+	if !relation.Allows(settings.Birthday) {
+		a.Birthday = nil
+	}
+
+	if !relation.Allows(settings.Photo) {
+		a.Photo = nil
+	}
+
+	if relation.Allows(settings.Contacts) {
+		a.ListEmails.FilterOpen()
+		a.ListPhones.FilterOpen()
+		a.ListAddresses.FilterOpen()
+	} else {
+		a.Emails = nil
+		a.Phones = nil
+		a.Addresses = nil
+	}
+
+	if !relation.Allows(settings.Languages) {
+		a.Languages = nil
+	}
+
+	a.Profiles.Patients = nil
+	a.Organizations = nil
+	a.Roles = nil
+}
+
+func (a *Account) HasRole(roles ...string) bool {
+	for _, v := range a.Roles {
+		for _, r := range roles {
+			if v == r {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 type ListAccountsRequest struct {
 	OrderBy string `json:"order_by" form:"order_by" url:"order_by" binding:"omitempty,min=1"`
 	Limit   uint64 `json:"limit" form:"limit" url:"limit" binding:"omitempty,gt=0"`
@@ -59,6 +59,16 @@ func (l *ListAddresses) ToResponse() IResponse {
 	return l
 }
 
+func (l *ListAddresses) FilterOpen() {
+	open := make([]*Address, 0, len(l.Addresses))
+	for _, v := range l.Addresses {
+		if v.Open {
+			open = append(open, v)
+		}
+	}
+	l.Addresses = open
+}
+
 type UpdateAddressFields map[string]interface{}
 
 func (f UpdateAddressFields) DBColumns() map[string]interface{} {
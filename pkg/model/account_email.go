@@ -64,6 +64,16 @@ func (l *ListEmails) ToResponse() IResponse {
 	return l
 }
 
+func (l *ListEmails) FilterOpen() {
+	open := make([]*Email, 0, len(l.Emails))
+	for _, v := range l.Emails {
+		if v.Open {
+			open = append(open, v)
+		}
+	}
+	l.Emails = open
+}
+
 type UpdateEmailFields map[string]interface{}
 
 func (f UpdateEmailFields) DBColumns() map[string]interface{} {
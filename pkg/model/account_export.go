@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+const (
+	ExportStatusPending    = "pending"
+	ExportStatusProcessing = "processing"
+	ExportStatusReady      = "ready"
+	ExportStatusFailed     = "failed"
+	ExportStatusExpired    = "expired"
+
+	ExportRelationSelf  = "self"
+	ExportRelationOwner = "owner"
+	ExportRelationAdmin = "admin"
+)
+
+type Export struct {
+	ID        int64      `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	AccountID int64      `json:"-"`
+	Status    string     `json:"status"`
+	FileKey   *string    `json:"-"`
+	Size      *int64     `json:"size,omitempty"`
+	Error     *string    `json:"error,omitempty"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type ListExports struct {
+	Exports []*Export `json:"exports"`
+}
+
+type ExportMessage struct {
+	AccountID int64   `json:"account_id"`
+	Export    *Export `json:"export"`
+}
+
+// ExportPatient is a patient profile with all of its sections as it is written to the archive.
+type ExportPatient struct {
+	*Patient
+	Relation string `json:"relation"`
+	ListMeasurements
+	ListConditions
+	ListMedications
+	ListVaccinations
+	ListInsurancePolicies
+	ListPatientSpecialists
+	ListLifestyleRecords
+	ListReviews
+	ListPatientHistory
+}
+
+type ExportSpecialist struct {
+	*Specialist
+	ListVerifications
+	ListSchedules
+	ListScheduleExceptions
+}
@@ -66,6 +66,16 @@ func (l *ListPhones) ToResponse() IResponse {
 	return l
 }
 
+func (l *ListPhones) FilterOpen() {
+	open := make([]*Phone, 0, len(l.Phones))
+	for _, v := range l.Phones {
+		if v.Open {
+			open = append(open, v)
+		}
+	}
+	l.Phones = open
+}
+
 type UpdatePhoneFields map[string]interface{}
 
 func (f UpdatePhoneFields) DBColumns() map[string]interface{} {
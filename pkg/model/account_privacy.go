@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+const (
+	PrivacyOnlyMe      = "only_me"
+	PrivacyAdmins      = "admins"
+	PrivacySpecialists = "specialists"
+	PrivacyUsers       = "users"
+	PrivacyPublic      = "public"
+)
+
+type PrivacySettings struct {
+	AccountID  int64     `json:"-"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Names      string    `json:"names"`
+	Birthday   string    `json:"birthday"`
+	Photo      string    `json:"photo"`
+	Contacts   string    `json:"contacts"`
+	Languages  string    `json:"languages"`
+	Specialist string    `json:"specialist"`
+}
+
+func DefaultPrivacySettings() *PrivacySettings {
+	return &PrivacySettings{
+		Names:      PrivacyUsers,
+		Birthday:   PrivacyAdmins,
+		Photo:      PrivacyUsers,
+		Contacts:   PrivacyAdmins,
+		Languages:  PrivacyUsers,
+		Specialist: PrivacyPublic,
+	}
+}
+
+type UpdatePrivacySettings struct {
+	Names      string `json:"names" binding:"required,oneof=only_me admins specialists users public"`
+	Birthday   string `json:"birthday" binding:"required,oneof=only_me admins specialists users public"`
+	Photo      string `json:"photo" binding:"required,oneof=only_me admins specialists users public"`
+	Contacts   string `json:"contacts" binding:"required,oneof=only_me admins specialists users public"`
+	Languages  string `json:"languages" binding:"required,oneof=only_me admins specialists users public"`
+	Specialist string `json:"specialist" binding:"required,oneof=only_me admins specialists users public"`
+}
+
+// PrivacyRelation describes who the viewer is to the owner of the data:
+// admins are verified admins of the owner's patient profile, specialists are
+// specialist profiles the owner's patient profile is treated by.
+type PrivacyRelation struct {
+	Self       bool
+	Admin      bool
+	Specialist bool
+	User       bool
+}
+
+func (r PrivacyRelation) Allows(audience string) bool {
+	switch audience {
+	case PrivacyPublic:
+		return true
+	case PrivacyUsers:
+		return r.Self || r.User
+	case PrivacySpecialists:
+		return r.Self || r.Admin || r.Specialist
+	case PrivacyAdmins:
+		return r.Self || r.Admin
+	default:
+		return r.Self
+	}
+}
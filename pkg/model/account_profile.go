@@ -1,5 +1,10 @@
 package model
 
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
 type ListProfiles struct {
 	PatientProfileID    int64             `json:"patient_profile_id"`
 	SpecialistProfileID int64             `json:"specialist_profile_id"`
@@ -16,6 +21,22 @@ type AccountPatient struct {
 	Verified       bool    `json:"verified"`
 }
 
+type AddDependentPatient struct {
+	FirstName  string      `json:"first_name" binding:"required,max=100"`
+	FatherName *string     `json:"father_name,omitempty" binding:"omitempty,max=100"`
+	LastName   string      `json:"last_name" binding:"required,max=100"`
+	Sex        string      `json:"sex" binding:"required,oneof=man woman"`
+	Birthday   pgtype.Date `json:"birthday" binding:"required"`
+}
+
+type UpdateDependentPatient AddDependentPatient
+
+type PatientHandover struct {
+	PatientID int64     `json:"patient_id"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type UpdateAdminFields map[string]interface{}
 
 func (f UpdateAdminFields) DBColumns() map[string]interface{} {
@@ -0,0 +1,17 @@
+package model
+
+const (
+	AccountRoleModerator = "moderator"
+	AccountRoleAdmin     = "admin"
+	AccountRoleService   = "service"
+)
+
+type AddAccountRole struct {
+	AccountID int64  `json:"account_id" binding:"required,gt=0"`
+	Role      string `json:"role" binding:"required,oneof=moderator admin service"`
+}
+
+type AccountRoleMessage struct {
+	AccountID int64  `json:"account_id"`
+	Role      string `json:"role"`
+}
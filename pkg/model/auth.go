@@ -1,8 +1,9 @@
 package model
 
 type RegisterRequest struct {
-	Login    string `json:"login" binding:"required,alphanum,min=5,max=100"`
-	Password string `json:"password" binding:"required,min=8,max=100"`
+	Login        string  `json:"login" binding:"required,alphanum,min=5,max=100"`
+	Password     string  `json:"password" binding:"required,min=8,max=100"`
+	HandoverCode *string `json:"handover_code,omitempty" binding:"omitempty,len=64"`
 }
 
 type LoginRequest struct {
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"time"
+)
+
+const (
+	OrganizationRoleOwner        = "owner"
+	OrganizationRoleManager      = "manager"
+	OrganizationRoleSpecialist   = "specialist"
+	OrganizationRoleReceptionist = "receptionist"
+)
+
+type Organization struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name"`
+	About     *string   `json:"about,omitempty"`
+	CityID    *int64    `json:"city_id,omitempty"`
+	Address   *string   `json:"address,omitempty"`
+	Phone     *string   `json:"phone,omitempty"`
+	Email     *string   `json:"email,omitempty"`
+	Website   *string   `json:"website,omitempty"`
+	Logo      *File     `json:"logo,omitempty"`
+	ListOrganizationLicences
+}
+
+func (o *Organization) ToResponse() IResponse {
+	if o.Logo != nil {
+		o.Logo.ToResponse()
+	}
+	o.ListOrganizationLicences.ToResponse()
+	return o
+}
+
+type AddOrganization struct {
+	Name       string  `json:"name" binding:"required,max=255"`
+	About      *string `json:"about"`
+	CityID     *int64  `json:"city_id" binding:"omitempty,gt=0"`
+	Address    *string `json:"address" binding:"omitempty,max=255"`
+	Phone      *string `json:"phone" binding:"omitempty,max=40"`
+	Email      *string `json:"email" binding:"omitempty,email,max=255"`
+	Website    *string `json:"website" binding:"omitempty,http_url,max=255"`
+	LogoFileID *int64  `json:"logo_file_id" binding:"omitempty,gt=0"`
+}
+
+type UpdateOrganization AddOrganization
+
+type ListOrganizations struct {
+	Organizations []*Organization `json:"organizations"`
+}
+
+func (l *ListOrganizations) ToResponse() IResponse {
+	for _, v := range l.Organizations {
+		v.ToResponse()
+	}
+	return l
+}
+
+type AccountOrganization struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type UpdateOrganizationFields map[string]interface{}
+
+func (f UpdateOrganizationFields) DBColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"updated_at":   struct{}{},
+		"name":         struct{}{},
+		"about":        struct{}{},
+		"city_id":      struct{}{},
+		"address":      struct{}{},
+		"phone":        struct{}{},
+		"email":        struct{}{},
+		"website":      struct{}{},
+		"logo_file_id": struct{}{},
+	}
+}
+
+func (f UpdateOrganizationFields) Prepare() {
+	for k, v := range f {
+		columns := f.DBColumns()
+		if _, ok := columns[k]; !ok {
+			delete(f, k)
+			continue
+		}
+
+		switch k {
+		case "city_id", "logo_file_id":
+			if v == nil {
+				f[k] = storage.NullInt64(nil)
+				continue
+			}
+
+			val := int64(v.(float64))
+
+			f[k] = storage.NullInt64(&val)
+		case "about", "address", "phone", "email", "website":
+			if v == nil {
+				f[k] = storage.NullString(nil)
+				continue
+			}
+
+			val := v.(string)
+
+			f[k] = storage.NullString(&val)
+		default:
+			f[k] = v
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+)
+
+type OrganizationInvitation struct {
+	ID               int64     `json:"id"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	OrganizationID   int64     `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	AccountID        int64     `json:"account_id"`
+	InvitedBy        *int64    `json:"invited_by,omitempty"`
+	Role             string    `json:"role"`
+	Status           string    `json:"status"`
+}
+
+type AddOrganizationInvitation struct {
+	AccountID int64  `json:"account_id" binding:"required,gt=0"`
+	Role      string `json:"role" binding:"required,oneof=owner manager specialist receptionist"`
+}
+
+type ListOrganizationInvitations struct {
+	Invitations []*OrganizationInvitation `json:"invitations"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type OrganizationLicence struct {
+	ID             int64        `json:"id"`
+	OrganizationID int64        `json:"-"`
+	Number         string       `json:"number"`
+	Issued         pgtype.Date  `json:"issued"`
+	Expires        *pgtype.Date `json:"expires,omitempty"`
+	Description    *string      `json:"description,omitempty"`
+}
+
+func (l *OrganizationLicence) ToResponse() IResponse {
+	l.OrganizationID = 0
+	return l
+}
+
+type AddOrganizationLicence struct {
+	Number      string       `json:"number" binding:"required,max=100"`
+	Issued      pgtype.Date  `json:"issued" binding:"required"`
+	Expires     *pgtype.Date `json:"expires"`
+	Description *string      `json:"description" binding:"omitempty,max=255"`
+}
+
+type UpdateOrganizationLicence AddOrganizationLicence
+
+type ListOrganizationLicences struct {
+	Licences []*OrganizationLicence `json:"licences,omitempty"`
+}
+
+func (l *ListOrganizationLicences) ToResponse() IResponse {
+	for _, v := range l.Licences {
+		v.ToResponse()
+	}
+	return l
+}
+
+type UpdateOrganizationLicenceFields map[string]interface{}
+
+func (f UpdateOrganizationLicenceFields) DBColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"number":      struct{}{},
+		"issued":      struct{}{},
+		"expires":     struct{}{},
+		"description": struct{}{},
+	}
+}
+
+func (f UpdateOrganizationLicenceFields) Prepare() {
+	for k, v := range f {
+		columns := f.DBColumns()
+		if _, ok := columns[k]; !ok {
+			delete(f, k)
+			continue
+		}
+
+		switch k {
+		case "description":
+			if v == nil {
+				f[k] = storage.NullString(nil)
+				continue
+			}
+
+			val := v.(string)
+
+			f[k] = storage.NullString(&val)
+		default:
+			f[k] = v
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrLastOwner = errors.New("organization must have at least one owner")
+
+type OrganizationMember struct {
+	AccountID      int64     `json:"account_id"`
+	OrganizationID int64     `json:"-"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+	FirstName      *string   `json:"first_name,omitempty"`
+	FatherName     *string   `json:"father_name,omitempty"`
+	LastName       *string   `json:"last_name,omitempty"`
+	Photo          *string   `json:"photo,omitempty"`
+}
+
+func (m *OrganizationMember) ToResponse() IResponse {
+	m.OrganizationID = 0
+	return m
+}
+
+type UpdateOrganizationMember struct {
+	Role string `json:"role" binding:"required,oneof=owner manager specialist receptionist"`
+}
+
+type ListOrganizationMembers struct {
+	Members []*OrganizationMember `json:"members"`
+}
+
+func (l *ListOrganizationMembers) ToResponse() IResponse {
+	for _, v := range l.Members {
+		v.ToResponse()
+	}
+	return l
+}
+
+type OrganizationMemberMessage struct {
+	OrganizationID int64  `json:"organization_id"`
+	AccountID      int64  `json:"account_id"`
+	Role           string `json:"role,omitempty"`
+}
@@ -1,8 +1,10 @@
 package model
 
 import (
+	"github.com/Hvaekar/med-account/pkg/health"
 	"github.com/Hvaekar/med-account/pkg/storage"
 	"github.com/jackc/pgx/v5/pgtype"
+	"time"
 )
 
 const (
@@ -10,8 +12,10 @@ const (
 )
 
 type Patient struct {
-	ID         int64        `json:"id"`
-	AccountID  int64        `json:"-"`
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"-"`
+	OwnerID   int64 `json:"-"`
+	ListEmergencyContacts
 	FirstName  *string      `json:"first_name,omitempty"`
 	FatherName *string      `json:"father_name,omitempty"`
 	LastName   *string      `json:"last_name,omitempty"`
@@ -26,17 +30,69 @@ type Patient struct {
 	Disability
 	LifeStyle
 	ListMetalComponents
+	ListAllergies
+	ListFamilyHistory
 	ListAdmins
+	CurrentLifestyle *LifestyleSummary  `json:"lifestyle,omitempty"`
+	Indicators       *health.Indicators `json:"indicators,omitempty"`
 }
 
 func (p *Patient) ToResponse() IResponse {
+	p.ListEmergencyContacts.ToResponse()
 	p.ListMetalComponents.ToResponse()
+	p.ListAllergies.ToResponse()
+	p.ListFamilyHistory.ToResponse()
 	for _, v := range p.Disability.Files {
 		v.ToResponse()
 	}
 	return p
 }
 
+func (p *Patient) ApplyPrivacy(settings *PrivacySettings, relation PrivacyRelation) {
+	if relation.Self {
+		return
+	}
+
+	if !relation.Allows(settings.Names) {
+		p.FirstName = nil
+		p.FatherName = nil
+		p.LastName = nil
+	}
+
+	if !relation.Allows(settings.Birthday) {
+		p.Birthday = nil
+	}
+
+	if !relation.Allows(settings.Photo) {
+		p.Photo = nil
+	}
+
+	if !relation.Allows(settings.Contacts) {
+		p.Phone = nil
+		p.Email = nil
+	}
+}
+
+// SetIndicators derives health indicators from the values left visible on the patient.
+func (p *Patient) SetIndicators(now time.Time) {
+	var birthday *time.Time
+	if p.Birthday != nil && p.Birthday.Valid {
+		birthday = &p.Birthday.Time
+	}
+
+	var sex string
+	if p.Sex != nil {
+		switch *p.Sex {
+		case "man":
+			sex = health.SexMale
+		case "woman":
+			sex = health.SexFemale
+		}
+	}
+
+	p.Indicators = health.NewIndicators(birthday, sex, p.Height, p.Weight, now)
+}
+
 type Body struct {
 	Height   *float64 `json:"height,omitempty"`
 	Weight   *float64 `json:"weight,omitempty"`
@@ -60,6 +116,7 @@ type Disability struct {
 	Files       []*File `json:"disability_files,omitempty"`
 }
 
+// LifeStyle is the legacy free text, structured values are kept in LifestyleRecord
 type LifeStyle struct {
 	Activity  *string `json:"activity,omitempty"`
 	Nutrition *string `json:"nutrition,omitempty"`
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"time"
+)
+
+const (
+	AllergySeverityMild            = "mild"
+	AllergySeverityModerate        = "moderate"
+	AllergySeveritySevere          = "severe"
+	AllergySeverityLifeThreatening = "life_threatening"
+)
+
+type Allergy struct {
+	ID           int64      `json:"id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	PatientID    int64      `json:"-"`
+	AllergenID   *int64     `json:"allergen_id,omitempty"`
+	Allergen     *string    `json:"allergen,omitempty"`
+	ReactionType string     `json:"reaction_type"`
+	Severity     string     `json:"severity"`
+	Description  *string    `json:"description,omitempty"`
+	Confirmed    bool       `json:"confirmed"`
+	ConfirmedBy  *int64     `json:"confirmed_by,omitempty"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+}
+
+func (a *Allergy) ToResponse() IResponse {
+	a.PatientID = 0
+	return a
+}
+
+type AddAllergy struct {
+	AllergenID   *int64  `json:"allergen_id,omitempty" binding:"required_without=Allergen,omitempty,gt=0"`
+	Allergen     *string `json:"allergen,omitempty" binding:"required_without=AllergenID,omitempty,max=255"`
+	ReactionType string  `json:"reaction_type" binding:"required,oneof=allergy intolerance anaphylaxis skin respiratory gastrointestinal other"`
+	Severity     string  `json:"severity" binding:"required,oneof=mild moderate severe life_threatening"`
+	Description  *string `json:"description,omitempty" binding:"omitempty,max=255"`
+}
+
+type UpdateAllergy AddAllergy
+
+type ListAllergies struct {
+	Allergies []*Allergy `json:"allergies"`
+}
+
+func (l *ListAllergies) ToResponse() IResponse {
+	for _, v := range l.Allergies {
+		v.ToResponse()
+	}
+	return l
+}
+
+type AllergyMessage struct {
+	PatientID int64    `json:"patient_id"`
+	Allergy   *Allergy `json:"allergy"`
+}
+
+type UpdateAllergyFields map[string]interface{}
+
+func (f UpdateAllergyFields) DBColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"allergen_id":   struct{}{},
+		"allergen":      struct{}{},
+		"reaction_type": struct{}{},
+		"severity":      struct{}{},
+		"description":   struct{}{},
+	}
+}
+
+func (f UpdateAllergyFields) Prepare() {
+	for k, v := range f {
+		columns := f.DBColumns()
+		if _, ok := columns[k]; !ok {
+			delete(f, k)
+			continue
+		}
+
+		switch k {
+		case "allergen_id":
+			if v == nil {
+				f[k] = storage.NullInt64(nil)
+				continue
+			}
+
+			val := int64(v.(float64))
+
+			f[k] = storage.NullInt64(&val)
+		case "allergen", "description":
+			if v == nil {
+				f[k] = storage.NullString(nil)
+				continue
+			}
+
+			val := v.(string)
+
+			f[k] = storage.NullString(&val)
+		default:
+			f[k] = v
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/icd10"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+const (
+	ConditionStatusActive   = "active"
+	ConditionStatusResolved = "resolved"
+)
+
+type Condition struct {
+	ID          int64        `json:"id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	PatientID   int64        `json:"-"`
+	ICD10       string       `json:"icd10"`
+	DiseaseID   *int64       `json:"disease_id,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Onset       *pgtype.Date `json:"onset,omitempty"`
+	Status      string       `json:"status"`
+	ConfirmedBy *int64       `json:"confirmed_by,omitempty"`
+	ConfirmedAt *time.Time   `json:"confirmed_at,omitempty"`
+}
+
+func (c *Condition) ToResponse() IResponse {
+	c.PatientID = 0
+	return c
+}
+
+type AddCondition struct {
+	ICD10     string       `json:"icd10" binding:"required,max=8"`
+	DiseaseID *int64       `json:"disease_id,omitempty" binding:"omitempty,gt=0"`
+	Name      *string      `json:"name,omitempty" binding:"omitempty,max=255"`
+	Onset     *pgtype.Date `json:"onset,omitempty"`
+	Status    *string      `json:"status,omitempty" binding:"omitempty,oneof=active resolved"`
+}
+
+func (c *AddCondition) Prepare() {
+	c.ICD10 = icd10.Normalize(c.ICD10)
+	if c.Status == nil {
+		status := ConditionStatusActive
+		c.Status = &status
+	}
+}
+
+type UpdateCondition AddCondition
+
+func (c *UpdateCondition) Prepare() {
+	(*AddCondition)(c).Prepare()
+}
+
+type ListConditions struct {
+	Conditions []*Condition `json:"conditions"`
+}
+
+func (l *ListConditions) ToResponse() IResponse {
+	for _, v := range l.Conditions {
+		v.ToResponse()
+	}
+	return l
+}
+
+type ConditionMessage struct {
+	PatientID int64      `json:"patient_id"`
+	Condition *Condition `json:"condition"`
+}
+
+type ConditionSpecialist struct {
+	SpecialistID int64   `json:"specialist_id"`
+	DiseaseIDs   []int64 `json:"disease_ids"`
+}
+
+type ListConditionSpecialists struct {
+	Specialists []*ConditionSpecialist `json:"specialists"`
+}
+
+// DiseaseICD10Codes maps a disease of the directory to ICD-10 categories or subcategories,
+// conditions with a matching code are treated as the disease when looking for specialists.
+type DiseaseICD10Codes struct {
+	DiseaseID int64    `json:"disease_id"`
+	Codes     []string `json:"codes"`
+}
+
+type UpdateDiseaseICD10Codes struct {
+	Codes []string `json:"codes" binding:"required,min=1,dive,required,max=8"`
+}
+
+func (d *UpdateDiseaseICD10Codes) Prepare() {
+	for i := range d.Codes {
+		d.Codes[i] = icd10.Normalize(d.Codes[i])
+	}
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/namematch"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math"
+	"time"
+)
+
+const (
+	DuplicateStatusPending   = "pending"
+	DuplicateStatusMerged    = "merged"
+	DuplicateStatusDismissed = "dismissed"
+)
+
+type PatientDuplicate struct {
+	ID                 int64               `json:"id"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	ProfileID          *int64              `json:"profile_id,omitempty"`
+	DuplicateProfileID *int64              `json:"duplicate_profile_id,omitempty"`
+	Score              float64             `json:"score"`
+	NameScore          float64             `json:"name_score"`
+	BirthdayMatch      bool                `json:"birthday_match"`
+	SexMatch           bool                `json:"sex_match"`
+	PhoneMatch         bool                `json:"phone_match"`
+	EmailMatch         bool                `json:"email_match"`
+	Status             string              `json:"status"`
+	ReviewerID         *int64              `json:"reviewer_id,omitempty"`
+	ReviewedAt         *time.Time          `json:"reviewed_at,omitempty"`
+	SurvivorID         *int64              `json:"survivor_id,omitempty"`
+	Profiles           []*DuplicateProfile `json:"profiles"`
+}
+
+// DuplicateProfile is the personal data the profiles are compared by, contacts are set only when verified
+type DuplicateProfile struct {
+	ID         int64        `json:"id"`
+	AccountID  *int64       `json:"account_id,omitempty"`
+	FirstName  *string      `json:"first_name,omitempty"`
+	FatherName *string      `json:"father_name,omitempty"`
+	LastName   *string      `json:"last_name,omitempty"`
+	Sex        *string      `json:"sex,omitempty"`
+	Birthday   *pgtype.Date `json:"birthday,omitempty"`
+	Phone      *string      `json:"phone,omitempty"`
+	Email      *string      `json:"email,omitempty"`
+}
+
+type PatientDuplicateCandidate struct {
+	Profile   *DuplicateProfile
+	Duplicate *DuplicateProfile
+}
+
+type AddPatientDuplicate struct {
+	ProfileID          int64
+	DuplicateProfileID int64
+	Score              float64
+	NameScore          float64
+	BirthdayMatch      bool
+	SexMatch           bool
+	PhoneMatch         bool
+	EmailMatch         bool
+}
+
+// NewAddPatientDuplicate scores how likely the pair is the same person, nil is returned when the sex differs.
+func NewAddPatientDuplicate(cd *PatientDuplicateCandidate) *AddPatientDuplicate {
+	a, b := cd.Profile, cd.Duplicate
+
+	if a.Sex != nil && b.Sex != nil && *a.Sex != *b.Sex {
+		return nil
+	}
+
+	d := AddPatientDuplicate{
+		ProfileID:          a.ID,
+		DuplicateProfileID: b.ID,
+		SexMatch:           a.Sex != nil && b.Sex != nil,
+		BirthdayMatch:      a.Birthday != nil && b.Birthday != nil && a.Birthday.Valid && b.Birthday.Valid && a.Birthday.Time.Equal(b.Birthday.Time),
+		PhoneMatch:         a.Phone != nil && b.Phone != nil && *a.Phone == *b.Phone,
+		EmailMatch:         a.Email != nil && b.Email != nil && *a.Email == *b.Email,
+	}
+
+	last := namematch.Similarity(stringValue(a.LastName), stringValue(b.LastName))
+	first := namematch.Similarity(stringValue(a.FirstName), stringValue(b.FirstName))
+	if a.FatherName != nil && b.FatherName != nil {
+		d.NameScore = 0.45*last + 0.4*first + 0.15*namematch.Similarity(*a.FatherName, *b.FatherName)
+	} else {
+		d.NameScore = 0.5*last + 0.5*first
+	}
+
+	score := 0.5 * d.NameScore
+	if d.BirthdayMatch {
+		score += 0.3
+	}
+	if d.PhoneMatch || d.EmailMatch {
+		score += 0.2
+	}
+
+	d.NameScore = math.Round(d.NameScore*1000) / 1000
+	d.Score = math.Round(math.Min(score, 1)*1000) / 1000
+
+	return &d
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+type ListPatientDuplicatesRequest struct {
+	Status string `json:"status" form:"status" url:"status" binding:"omitempty,oneof=pending merged dismissed"`
+	Limit  uint64 `json:"limit" form:"limit" url:"limit" binding:"omitempty,gt=0"`
+	Page   uint64 `json:"page" form:"page" url:"page" binding:"omitempty,gt=0"`
+}
+
+func (l *ListPatientDuplicatesRequest) Prepare() {
+	if l.Status == "" {
+		l.Status = DuplicateStatusPending
+	}
+	if l.Limit == 0 {
+		l.Limit = defaultLimit
+	}
+	if l.Page == 0 {
+		l.Page = defaultPage
+	}
+}
+
+func (l *ListPatientDuplicatesRequest) Offset() uint64 {
+	return l.Limit * (l.Page - 1)
+}
+
+type ListPatientDuplicates struct {
+	Duplicates []*PatientDuplicate `json:"duplicates"`
+}
+
+// MergePatientDuplicate keeps the survivor, the other profile of the pair is removed after its data is moved
+type MergePatientDuplicate struct {
+	SurvivorID int64 `json:"survivor_id" binding:"required,gt=0"`
+}
+
+type PatientMergeMessage struct {
+	DuplicateID int64 `json:"duplicate_id"`
+	SurvivorID  int64 `json:"survivor_id"`
+	MergedID    int64 `json:"merged_id"`
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type EmergencyContact struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	PatientID int64     `json:"-"`
+	Name      string    `json:"name"`
+	Relation  string    `json:"relation"`
+	Phone     string    `json:"phone"`
+	AccountID *int64    `json:"account_id,omitempty"`
+	Priority  int64     `json:"priority"`
+}
+
+func (e *EmergencyContact) ToResponse() IResponse {
+	e.PatientID = 0
+	return e
+}
+
+type AddEmergencyContact struct {
+	Name      string `json:"name" binding:"required,max=255"`
+	Relation  string `json:"relation" binding:"required,oneof=parent child spouse sibling relative guardian friend other"`
+	Phone     string `json:"phone" binding:"required,max=20"`
+	AccountID *int64 `json:"account_id,omitempty" binding:"omitempty,gt=0"`
+	Priority  *int64 `json:"priority,omitempty" binding:"omitempty,gt=0,lte=100"`
+}
+
+type UpdateEmergencyContact AddEmergencyContact
+
+type ListEmergencyContacts struct {
+	EmergencyContacts []*EmergencyContact `json:"emergency_contacts,omitempty"`
+}
+
+func (l *ListEmergencyContacts) ToResponse() IResponse {
+	for _, v := range l.EmergencyContacts {
+		v.ToResponse()
+	}
+	return l
+}
+
+type EmergencyContactMessage struct {
+	PatientID        int64             `json:"patient_id"`
+	EmergencyContact *EmergencyContact `json:"emergency_contact"`
+}
+
+type EmergencySummary struct {
+	PatientID  int64        `json:"patient_id"`
+	FirstName  *string      `json:"first_name,omitempty"`
+	FatherName *string      `json:"father_name,omitempty"`
+	LastName   *string      `json:"last_name,omitempty"`
+	Sex        *string      `json:"sex,omitempty"`
+	Birthday   *pgtype.Date `json:"birthday,omitempty"`
+	ListEmergencyContacts
+	Blood
+	ListAllergies
+	Conditions  []*Condition  `json:"conditions"`
+	Medications []*Medication `json:"medications"`
+}
+
+func NewEmergencySummary(p *Patient, cs []*Condition, ms []*Medication) *EmergencySummary {
+	s := &EmergencySummary{
+		PatientID:             p.ID,
+		FirstName:             p.FirstName,
+		FatherName:            p.FatherName,
+		LastName:              p.LastName,
+		Sex:                   p.Sex,
+		Birthday:              p.Birthday,
+		ListEmergencyContacts: p.ListEmergencyContacts,
+		Blood:                 p.Blood,
+		ListAllergies:         p.ListAllergies,
+		Conditions:            make([]*Condition, 0),
+		Medications:           ms,
+	}
+
+	for _, v := range cs {
+		if v.Status == ConditionStatusActive {
+			s.Conditions = append(s.Conditions, v)
+		}
+	}
+
+	if s.Medications == nil {
+		s.Medications = make([]*Medication, 0)
+	}
+
+	return s
+}
+
+func (s *EmergencySummary) ToResponse() IResponse {
+	s.ListEmergencyContacts.ToResponse()
+	s.ListAllergies.ToResponse()
+	for _, v := range s.Conditions {
+		v.ToResponse()
+	}
+	for _, v := range s.Medications {
+		v.ToResponse()
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/icd10"
+	"time"
+)
+
+const (
+	FamilyRelativeMother      = "mother"
+	FamilyRelativeFather      = "father"
+	FamilyRelativeSibling     = "sibling"
+	FamilyRelativeChild       = "child"
+	FamilyRelativeGrandparent = "grandparent"
+	FamilyRelativeGrandchild  = "grandchild"
+	FamilyRelativeAuntUncle   = "aunt_uncle"
+	FamilyRelativeCousin      = "cousin"
+	FamilyRelativeOther       = "other"
+)
+
+type FamilyHistory struct {
+	ID                int64     `json:"id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	PatientID         int64     `json:"-"`
+	Relative          string    `json:"relative"`
+	RelativeProfileID *int64    `json:"relative_profile_id,omitempty"`
+	ICD10             string    `json:"icd10"`
+	Name              *string   `json:"name,omitempty"`
+	OnsetAge          *int64    `json:"onset_age,omitempty"`
+	Deceased          bool      `json:"deceased"`
+	Description       *string   `json:"description,omitempty"`
+}
+
+func (f *FamilyHistory) ToResponse() IResponse {
+	f.PatientID = 0
+	return f
+}
+
+type AddFamilyHistory struct {
+	Relative          string  `json:"relative" binding:"required,oneof=mother father sibling child grandparent grandchild aunt_uncle cousin other"`
+	RelativeProfileID *int64  `json:"relative_profile_id,omitempty" binding:"omitempty,gt=0"`
+	ICD10             string  `json:"icd10" binding:"required,max=8"`
+	Name              *string `json:"name,omitempty" binding:"omitempty,max=255"`
+	OnsetAge          *int64  `json:"onset_age,omitempty" binding:"omitempty,min=0,max=150"`
+	Deceased          bool    `json:"deceased"`
+	Description       *string `json:"description,omitempty" binding:"omitempty,max=255"`
+}
+
+func (f *AddFamilyHistory) Prepare() {
+	f.ICD10 = icd10.Normalize(f.ICD10)
+}
+
+type UpdateFamilyHistory AddFamilyHistory
+
+func (f *UpdateFamilyHistory) Prepare() {
+	(*AddFamilyHistory)(f).Prepare()
+}
+
+type ListFamilyHistory struct {
+	FamilyHistory []*FamilyHistory `json:"family_history"`
+}
+
+func (l *ListFamilyHistory) ToResponse() IResponse {
+	for _, v := range l.FamilyHistory {
+		v.ToResponse()
+	}
+	return l
+}
+
+type FamilyHistoryMessage struct {
+	PatientID     int64          `json:"patient_id"`
+	FamilyHistory *FamilyHistory `json:"family_history"`
+}
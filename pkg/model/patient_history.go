@@ -0,0 +1,295 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	PatientHistoryActionCreate = "create"
+	PatientHistoryActionUpdate = "update"
+	PatientHistoryActionDelete = "delete"
+
+	PatientHistoryResourceProfile          = "profile"
+	PatientHistoryResourceMetalComponent   = "metal_component"
+	PatientHistoryResourceMeasurement      = "measurement"
+	PatientHistoryResourceAllergy          = "allergy"
+	PatientHistoryResourceCondition        = "condition"
+	PatientHistoryResourceMedication       = "medication"
+	PatientHistoryResourceVaccination      = "vaccination"
+	PatientHistoryResourceEmergencyContact = "emergency_contact"
+	PatientHistoryResourceInsurancePolicy  = "insurance_policy"
+	PatientHistoryResourceFamilyHistory    = "family_history"
+	PatientHistoryResourceLifestyle        = "lifestyle"
+	PatientHistoryResourceSpecialist       = "specialist"
+	PatientHistoryResourceAdmin            = "admin"
+)
+
+// historyIgnoredFields change on every write, an update touching only them is not recorded
+var historyIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+type PatientHistory struct {
+	ID         int64                     `json:"id"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	PatientID  int64                     `json:"patient_id"`
+	AccountID  *int64                    `json:"account_id,omitempty"`
+	Resource   string                    `json:"resource"`
+	ResourceID int64                     `json:"resource_id"`
+	Action     string                    `json:"action"`
+	Changes    map[string]*HistoryChange `json:"changes"`
+	Snapshot   map[string]interface{}    `json:"snapshot"`
+}
+
+type HistoryChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+type ListPatientHistory struct {
+	History []*PatientHistory `json:"history"`
+}
+
+type ListPatientHistoryRequest struct {
+	Resource   *string    `json:"resource,omitempty" binding:"omitempty,oneof=profile metal_component measurement allergy condition medication vaccination emergency_contact insurance_policy family_history lifestyle specialist admin"`
+	ResourceID *int64     `json:"resource_id,omitempty" binding:"omitempty,gt=0"`
+	From       *time.Time `json:"from,omitempty"`
+	To         *time.Time `json:"to,omitempty"`
+}
+
+type AddPatientHistory struct {
+	AccountID  int64
+	Resource   string
+	ResourceID int64
+	Action     string
+	Changes    map[string]*HistoryChange
+	Snapshot   map[string]interface{}
+}
+
+// NewAddPatientHistory compares the resource before and after the change, a nil state means the resource
+// did not exist. Nil is returned when nothing worth recording changed.
+func NewAddPatientHistory(accountID int64, resource string, resourceID int64, before interface{}, after interface{}) (*AddPatientHistory, error) {
+	old, err := NewHistoryRecord(before)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := NewHistoryRecord(after)
+	if err != nil {
+		return nil, err
+	}
+
+	h := AddPatientHistory{
+		AccountID:  accountID,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Action:     PatientHistoryActionUpdate,
+		Changes:    map[string]*HistoryChange{},
+		Snapshot:   cur,
+	}
+
+	switch {
+	case old == nil && cur == nil:
+		return nil, nil
+	case old == nil:
+		h.Action = PatientHistoryActionCreate
+	case cur == nil:
+		h.Action = PatientHistoryActionDelete
+		h.Snapshot = old
+	}
+
+	for k, v := range old {
+		if historyIgnoredFields[k] {
+			continue
+		}
+
+		if n, ok := cur[k]; !ok || !reflect.DeepEqual(v, n) {
+			h.Changes[k] = &HistoryChange{Old: v, New: n}
+		}
+	}
+
+	for k, v := range cur {
+		if _, ok := old[k]; ok || historyIgnoredFields[k] {
+			continue
+		}
+
+		h.Changes[k] = &HistoryChange{New: v}
+	}
+
+	if len(h.Changes) == 0 {
+		return nil, nil
+	}
+
+	return &h, nil
+}
+
+// NewHistoryRecord keeps the resource in the same shape the api returns it
+func NewHistoryRecord(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var r map[string]interface{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// PatientProfileRecord is the versioned part of the profile, nested lists are versioned as separate resources
+type PatientProfileRecord struct {
+	ID         int64   `json:"id"`
+	AccountID  int64   `json:"account_id,omitempty"` // set once the dependent is handed over
+	OwnerID    int64   `json:"owner_id,omitempty"`
+	FirstName  *string `json:"first_name,omitempty"`
+	FatherName *string `json:"father_name,omitempty"`
+	LastName   *string `json:"last_name,omitempty"`
+	Sex        *string `json:"sex,omitempty"`
+	Photo      *string `json:"photo,omitempty"`
+	Birthday   *string `json:"birthday,omitempty"`
+	Phone      *string `json:"phone,omitempty"`
+	Email      *string `json:"email,omitempty"`
+	Body
+	Blood
+	Vision
+	Disability
+	LifeStyle
+}
+
+func NewPatientProfileRecord(p *Patient) *PatientProfileRecord {
+	r := PatientProfileRecord{
+		ID:         p.ID,
+		AccountID:  p.AccountID,
+		OwnerID:    p.OwnerID,
+		FirstName:  p.FirstName,
+		FatherName: p.FatherName,
+		LastName:   p.LastName,
+		Sex:        p.Sex,
+		Photo:      p.Photo,
+		Phone:      p.Phone,
+		Email:      p.Email,
+		Body:       p.Body,
+		Blood:      p.Blood,
+		Vision:     p.Vision,
+		Disability: p.Disability,
+		LifeStyle:  p.LifeStyle,
+	}
+
+	if p.Birthday != nil && p.Birthday.Valid {
+		b := p.Birthday.Time.Format(time.DateOnly)
+		r.Birthday = &b
+	}
+
+	return &r
+}
+
+type PatientSnapshotRequest struct {
+	At *time.Time `json:"at" binding:"required"`
+}
+
+type PatientSnapshot struct {
+	At        time.Time                           `json:"at"`
+	Profile   map[string]interface{}              `json:"profile"`
+	Resources map[string][]map[string]interface{} `json:"resources"`
+}
+
+// NewPatientSnapshot rolls the current state back to at by undoing the later changes, newest first.
+// Resources that have no history are considered unchanged since they were created.
+func NewPatientSnapshot(at time.Time, current map[string][]map[string]interface{}, later []*PatientHistory) *PatientSnapshot {
+	sort.SliceStable(later, func(i, j int) bool {
+		if later[i].CreatedAt.Equal(later[j].CreatedAt) {
+			return later[i].ID > later[j].ID
+		}
+		return later[i].CreatedAt.After(later[j].CreatedAt)
+	})
+
+	for _, v := range later {
+		records := current[v.Resource]
+		i := historyRecordIndex(records, v.ResourceID)
+
+		switch v.Action {
+		case PatientHistoryActionCreate:
+			if i >= 0 {
+				current[v.Resource] = append(records[:i], records[i+1:]...)
+			}
+		case PatientHistoryActionDelete:
+			if i < 0 {
+				current[v.Resource] = append(records, copyHistoryRecord(v.Snapshot))
+			}
+		case PatientHistoryActionUpdate:
+			if i < 0 {
+				continue
+			}
+
+			for k, c := range v.Changes {
+				if c.Old == nil {
+					delete(records[i], k)
+					continue
+				}
+				records[i][k] = c.Old
+			}
+		}
+	}
+
+	s := PatientSnapshot{At: at, Resources: map[string][]map[string]interface{}{}}
+	for k, v := range current {
+		sort.Slice(v, func(i, j int) bool {
+			return historyRecordID(v[i]) < historyRecordID(v[j])
+		})
+
+		if k == PatientHistoryResourceProfile {
+			if len(v) > 0 {
+				s.Profile = v[0]
+			}
+			continue
+		}
+
+		s.Resources[k] = v
+	}
+
+	return &s
+}
+
+func historyRecordIndex(records []map[string]interface{}, id int64) int {
+	for i, v := range records {
+		if historyRecordID(v) == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// historyRecordID reads the id back from a decoded record, json numbers are decoded as float64
+func historyRecordID(r map[string]interface{}) int64 {
+	id, _ := r["id"].(float64)
+	return int64(id)
+}
+
+func copyHistoryRecord(r map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(r))
+	for k, v := range r {
+		c[k] = v
+	}
+
+	return c
+}
+
+func NewHistoryRecords(v interface{}) ([]map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var rs []map[string]interface{}
+	if err := json.Unmarshal(b, &rs); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+const (
+	InsuranceCoverageCompulsory = "compulsory"
+	InsuranceCoverageVoluntary  = "voluntary"
+	InsuranceCoverageTravel     = "travel"
+	InsuranceCoverageDental     = "dental"
+	InsuranceCoverageOther      = "other"
+)
+
+type InsurancePolicy struct {
+	ID           int64        `json:"id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	PatientID    int64        `json:"patient_id"`
+	Insurer      string       `json:"insurer"`
+	PolicyNumber string       `json:"policy_number"`
+	CoverageType string       `json:"coverage_type"`
+	ValidFrom    pgtype.Date  `json:"valid_from"`
+	ValidTo      *pgtype.Date `json:"valid_to,omitempty"`
+	Files        []*File      `json:"files,omitempty"`
+}
+
+func (p *InsurancePolicy) ToResponse() IResponse {
+	for _, f := range p.Files {
+		f.ToResponse()
+	}
+	return p
+}
+
+type AddInsurancePolicy struct {
+	Insurer      string       `json:"insurer" binding:"required,max=255"`
+	PolicyNumber string       `json:"policy_number" binding:"required,max=100"`
+	CoverageType string       `json:"coverage_type" binding:"required,oneof=compulsory voluntary travel dental other"`
+	ValidFrom    pgtype.Date  `json:"valid_from" binding:"required"`
+	ValidTo      *pgtype.Date `json:"valid_to,omitempty"`
+	Files        []int64      `json:"files,omitempty" binding:"omitempty,dive,gt=0"`
+}
+
+func (p *AddInsurancePolicy) ValidDates() bool {
+	return p.ValidTo == nil || !p.ValidTo.Time.Before(p.ValidFrom.Time)
+}
+
+type UpdateInsurancePolicy AddInsurancePolicy
+
+func (p *UpdateInsurancePolicy) ValidDates() bool {
+	return (*AddInsurancePolicy)(p).ValidDates()
+}
+
+type ListInsurancePolicies struct {
+	Policies []*InsurancePolicy `json:"policies"`
+}
+
+func (l *ListInsurancePolicies) ToResponse() IResponse {
+	for _, v := range l.Policies {
+		v.ToResponse()
+	}
+	return l
+}
+
+type InsurancePolicyMessage struct {
+	PatientID int64            `json:"patient_id"`
+	Policy    *InsurancePolicy `json:"policy"`
+}
@@ -0,0 +1,192 @@
+package model
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+const (
+	SmokingStatusNever   = "never"
+	SmokingStatusFormer  = "former"
+	SmokingStatusCurrent = "current"
+
+	DietOmnivore      = "omnivore"
+	DietVegetarian    = "vegetarian"
+	DietVegan         = "vegan"
+	DietPescatarian   = "pescatarian"
+	DietMediterranean = "mediterranean"
+	DietLowCarb       = "low_carb"
+	DietOther         = "other"
+
+	OccupationalHazardNone             = "none"
+	OccupationalHazardNoise            = "noise"
+	OccupationalHazardDust             = "dust"
+	OccupationalHazardChemicals        = "chemicals"
+	OccupationalHazardRadiation        = "radiation"
+	OccupationalHazardVibration        = "vibration"
+	OccupationalHazardBiological       = "biological"
+	OccupationalHazardHeat             = "heat"
+	OccupationalHazardCold             = "cold"
+	OccupationalHazardNightShifts      = "night_shifts"
+	OccupationalHazardHeavyLifting     = "heavy_lifting"
+	OccupationalHazardProlongedSitting = "prolonged_sitting"
+	OccupationalHazardScreenWork       = "screen_work"
+	OccupationalHazardOther            = "other"
+)
+
+var (
+	ErrLifestyleEmpty     = errors.New("lifestyle record has no values")
+	ErrLifestyleDate      = errors.New("lifestyle effective date is in the future")
+	ErrLifestylePackYears = errors.New("pack-years require a former or current smoking status")
+	ErrLifestyleHazards   = errors.New("no occupational hazards can not be combined with other hazards")
+)
+
+type LifestyleRecord struct {
+	ID                  int64       `json:"id"`
+	CreatedAt           time.Time   `json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+	PatientID           int64       `json:"-"`
+	EffectiveDate       pgtype.Date `json:"effective_date"`
+	SmokingStatus       *string     `json:"smoking_status,omitempty"`
+	PackYears           *float64    `json:"pack_years,omitempty"`
+	AlcoholUnits        *int64      `json:"alcohol_units,omitempty"`    // per week
+	ActivityMinutes     *int64      `json:"activity_minutes,omitempty"` // per week
+	Diet                *string     `json:"diet,omitempty"`
+	OccupationalHazards []string    `json:"occupational_hazards,omitempty"`
+	EnteredBy           *int64      `json:"entered_by,omitempty"`
+}
+
+func (r *LifestyleRecord) ToResponse() IResponse {
+	r.PatientID = 0
+	return r
+}
+
+// AddLifestyleRecord sets only the factors that changed, the others keep their values from earlier records
+type AddLifestyleRecord struct {
+	EffectiveDate       *pgtype.Date `json:"effective_date,omitempty"`
+	SmokingStatus       *string      `json:"smoking_status,omitempty" binding:"omitempty,oneof=never former current"`
+	PackYears           *float64     `json:"pack_years,omitempty" binding:"omitempty,min=0,max=300"`
+	AlcoholUnits        *int64       `json:"alcohol_units,omitempty" binding:"omitempty,min=0,max=500"`
+	ActivityMinutes     *int64       `json:"activity_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
+	Diet                *string      `json:"diet,omitempty" binding:"omitempty,oneof=omnivore vegetarian vegan pescatarian mediterranean low_carb other"`
+	OccupationalHazards []string     `json:"occupational_hazards,omitempty" binding:"omitempty,unique,dive,oneof=none noise dust chemicals radiation vibration biological heat cold night_shifts heavy_lifting prolonged_sitting screen_work other"`
+}
+
+// Normalize checks the values that depend on each other and sets the effective date to today when missing.
+func (r *AddLifestyleRecord) Normalize(now time.Time) error {
+	if r.SmokingStatus == nil && r.AlcoholUnits == nil && r.ActivityMinutes == nil && r.Diet == nil && len(r.OccupationalHazards) == 0 {
+		return ErrLifestyleEmpty
+	}
+
+	if r.PackYears != nil && (r.SmokingStatus == nil || *r.SmokingStatus == SmokingStatusNever) {
+		return ErrLifestylePackYears
+	}
+
+	if len(r.OccupationalHazards) > 1 {
+		for _, v := range r.OccupationalHazards {
+			if v == OccupationalHazardNone {
+				return ErrLifestyleHazards
+			}
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if r.EffectiveDate == nil {
+		r.EffectiveDate = &pgtype.Date{Time: today, Valid: true}
+	}
+	if r.EffectiveDate.Time.After(today) {
+		return ErrLifestyleDate
+	}
+
+	return nil
+}
+
+type UpdateLifestyleRecord AddLifestyleRecord
+
+func (r *UpdateLifestyleRecord) Normalize(now time.Time) error {
+	return (*AddLifestyleRecord)(r).Normalize(now)
+}
+
+type ListLifestyleRecords struct {
+	Records []*LifestyleRecord `json:"records"`
+}
+
+func (l *ListLifestyleRecords) ToResponse() IResponse {
+	for _, v := range l.Records {
+		v.ToResponse()
+	}
+	return l
+}
+
+// LifestyleSummary holds the latest known value of every factor with the date it took effect
+type LifestyleSummary struct {
+	Smoking             *LifestyleSmoking `json:"smoking,omitempty"`
+	AlcoholUnits        *LifestyleValue   `json:"alcohol_units,omitempty"`
+	ActivityMinutes     *LifestyleValue   `json:"activity_minutes,omitempty"`
+	Diet                *LifestyleDiet    `json:"diet,omitempty"`
+	OccupationalHazards *LifestyleHazards `json:"occupational_hazards,omitempty"`
+}
+
+type LifestyleSmoking struct {
+	Status        string      `json:"status"`
+	PackYears     *float64    `json:"pack_years,omitempty"`
+	EffectiveDate pgtype.Date `json:"effective_date"`
+}
+
+type LifestyleValue struct {
+	Value         int64       `json:"value"`
+	EffectiveDate pgtype.Date `json:"effective_date"`
+}
+
+type LifestyleDiet struct {
+	Type          string      `json:"type"`
+	EffectiveDate pgtype.Date `json:"effective_date"`
+}
+
+type LifestyleHazards struct {
+	Hazards       []string    `json:"hazards"`
+	EffectiveDate pgtype.Date `json:"effective_date"`
+}
+
+// NewLifestyleSummary expects the records newest first, nil is returned when there are none.
+func NewLifestyleSummary(records []*LifestyleRecord) *LifestyleSummary {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var s LifestyleSummary
+	for _, v := range records {
+		if s.Smoking == nil && v.SmokingStatus != nil {
+			s.Smoking = &LifestyleSmoking{Status: *v.SmokingStatus, PackYears: v.PackYears, EffectiveDate: v.EffectiveDate}
+		}
+
+		if s.AlcoholUnits == nil && v.AlcoholUnits != nil {
+			s.AlcoholUnits = &LifestyleValue{Value: *v.AlcoholUnits, EffectiveDate: v.EffectiveDate}
+		}
+
+		if s.ActivityMinutes == nil && v.ActivityMinutes != nil {
+			s.ActivityMinutes = &LifestyleValue{Value: *v.ActivityMinutes, EffectiveDate: v.EffectiveDate}
+		}
+
+		if s.Diet == nil && v.Diet != nil {
+			s.Diet = &LifestyleDiet{Type: *v.Diet, EffectiveDate: v.EffectiveDate}
+		}
+
+		if s.OccupationalHazards == nil && len(v.OccupationalHazards) > 0 {
+			hazards := v.OccupationalHazards
+			if len(hazards) == 1 && hazards[0] == OccupationalHazardNone {
+				hazards = []string{}
+			}
+
+			s.OccupationalHazards = &LifestyleHazards{Hazards: hazards, EffectiveDate: v.EffectiveDate}
+		}
+	}
+
+	return &s
+}
+
+type LifestyleRecordMessage struct {
+	PatientID int64            `json:"patient_id"`
+	Record    *LifestyleRecord `json:"record"`
+}
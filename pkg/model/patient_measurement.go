@@ -0,0 +1,197 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	MeasurementTypeHeight   = "height"
+	MeasurementTypeWeight   = "weight"
+	MeasurementTypeLeftEye  = "left_eye"
+	MeasurementTypeRightEye = "right_eye"
+
+	MeasurementSourceManual  = "manual"
+	MeasurementSourceDevice  = "device"
+	MeasurementSourceClinic  = "clinic"
+	MeasurementSourceProfile = "profile"
+)
+
+var (
+	ErrMeasurementUnit  = errors.New("unit is not supported for this measurement type")
+	ErrMeasurementValue = errors.New("measurement value is out of range")
+	ErrMeasurementDate  = errors.New("measurement date is in the future")
+)
+
+type measurementSpec struct {
+	unit  string
+	min   float64
+	max   float64
+	units map[string]float64 // factor to convert into the base unit
+}
+
+// limits match the checks on patient_profiles so the latest value can always be copied there
+var measurementSpecs = map[string]measurementSpec{
+	MeasurementTypeHeight: {
+		unit:  "cm",
+		min:   10,
+		max:   300,
+		units: map[string]float64{"cm": 1, "m": 100, "in": 2.54},
+	},
+	MeasurementTypeWeight: {
+		unit:  "kg",
+		min:   0,
+		max:   600,
+		units: map[string]float64{"kg": 1, "g": 0.001, "lb": 0.45359237},
+	},
+	MeasurementTypeLeftEye: {
+		unit:  "D",
+		min:   -40,
+		max:   10,
+		units: map[string]float64{"D": 1},
+	},
+	MeasurementTypeRightEye: {
+		unit:  "D",
+		min:   -40,
+		max:   10,
+		units: map[string]float64{"D": 1},
+	},
+}
+
+func MeasurementUnit(measurementType string) string {
+	return measurementSpecs[measurementType].unit
+}
+
+type Measurement struct {
+	ID         int64     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	PatientID  int64     `json:"-"`
+	Type       string    `json:"type"`
+	Value      float64   `json:"value"`
+	Unit       string    `json:"unit"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Source     string    `json:"source"`
+	EnteredBy  *int64    `json:"entered_by,omitempty"`
+}
+
+func (m *Measurement) ToResponse() IResponse {
+	m.PatientID = 0
+	return m
+}
+
+type AddMeasurement struct {
+	Type       string     `json:"type" binding:"required,oneof=height weight left_eye right_eye"`
+	Value      float64    `json:"value"`
+	Unit       *string    `json:"unit,omitempty" binding:"omitempty,max=10"`
+	MeasuredAt *time.Time `json:"measured_at,omitempty"`
+	Source     *string    `json:"source,omitempty" binding:"omitempty,oneof=manual device clinic"`
+}
+
+// Normalize converts the value into the base unit of its type and fills defaults.
+func (m *AddMeasurement) Normalize(now time.Time) error {
+	spec, ok := measurementSpecs[m.Type]
+	if !ok {
+		return ErrMeasurementUnit
+	}
+
+	if m.Unit != nil && *m.Unit != spec.unit {
+		factor, ok := spec.units[*m.Unit]
+		if !ok {
+			return ErrMeasurementUnit
+		}
+
+		m.Value = math.Round(m.Value*factor*1000) / 1000
+	}
+	m.Unit = &spec.unit
+
+	if m.Value < spec.min || m.Value > spec.max || (m.Type == MeasurementTypeWeight && m.Value == 0) {
+		return ErrMeasurementValue
+	}
+
+	if m.MeasuredAt == nil {
+		m.MeasuredAt = &now
+	}
+	if m.MeasuredAt.After(now) {
+		return ErrMeasurementDate
+	}
+
+	if m.Source == nil {
+		source := MeasurementSourceManual
+		m.Source = &source
+	}
+
+	return nil
+}
+
+// NewProfileMeasurements returns measurements for the body and vision values changed by a profile update.
+func NewProfileMeasurements(p *Patient, req *UpdatePatientProfile) []*AddMeasurement {
+	values := []struct {
+		t   string
+		old *float64
+		new *float64
+	}{
+		{MeasurementTypeHeight, p.Height, req.Height},
+		{MeasurementTypeWeight, p.Weight, req.Weight},
+		{MeasurementTypeLeftEye, p.LeftEye, req.LeftEye},
+		{MeasurementTypeRightEye, p.RightEye, req.RightEye},
+	}
+
+	source := MeasurementSourceProfile
+	ms := make([]*AddMeasurement, 0)
+	for _, v := range values {
+		if v.new == nil || (v.old != nil && *v.old == *v.new) {
+			continue
+		}
+
+		ms = append(ms, &AddMeasurement{Type: v.t, Value: *v.new, Source: &source})
+	}
+
+	return ms
+}
+
+type ListMeasurementsRequest struct {
+	Type *string    `json:"type,omitempty" binding:"omitempty,oneof=height weight left_eye right_eye"`
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+type ListMeasurements struct {
+	Measurements []*Measurement `json:"measurements"`
+}
+
+func (l *ListMeasurements) ToResponse() IResponse {
+	for _, v := range l.Measurements {
+		v.ToResponse()
+	}
+	return l
+}
+
+type MeasurementChartRequest struct {
+	Type     string     `json:"type" binding:"required,oneof=height weight left_eye right_eye"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+	Interval string     `json:"interval" binding:"omitempty,oneof=day week month year"`
+}
+
+func (r *MeasurementChartRequest) Prepare() {
+	if r.Interval == "" {
+		r.Interval = "day"
+	}
+}
+
+type MeasurementChartPoint struct {
+	Period time.Time `json:"period"`
+	Count  int64     `json:"count"`
+	Min    float64   `json:"min"`
+	Max    float64   `json:"max"`
+	Avg    float64   `json:"avg"`
+	Last   float64   `json:"last"`
+}
+
+type MeasurementChart struct {
+	Type     string                   `json:"type"`
+	Unit     string                   `json:"unit"`
+	Interval string                   `json:"interval"`
+	Points   []*MeasurementChartPoint `json:"points"`
+}
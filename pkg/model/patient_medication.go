@@ -0,0 +1,82 @@
+package model
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+const (
+	MedicationStatusCurrent = "current"
+	MedicationStatusStopped = "stopped"
+)
+
+type Medication struct {
+	ID              int64        `json:"id"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	PatientID       int64        `json:"-"`
+	Name            string       `json:"name"`
+	ActiveSubstance *string      `json:"active_substance,omitempty"`
+	Dose            string       `json:"dose"`
+	Frequency       string       `json:"frequency"`
+	Start           pgtype.Date  `json:"start"`
+	Finish          *pgtype.Date `json:"finish,omitempty"`
+	PrescribedBy    *int64       `json:"prescribed_by,omitempty"`
+	StopReason      *string      `json:"stop_reason,omitempty"`
+}
+
+func (m *Medication) ToResponse() IResponse {
+	m.PatientID = 0
+	return m
+}
+
+type AddMedication struct {
+	Name            string       `json:"name" binding:"required,max=255"`
+	ActiveSubstance *string      `json:"active_substance,omitempty" binding:"omitempty,max=255"`
+	Dose            string       `json:"dose" binding:"required,max=100"`
+	Frequency       string       `json:"frequency" binding:"required,max=100"`
+	Start           pgtype.Date  `json:"start" binding:"required"`
+	Finish          *pgtype.Date `json:"finish,omitempty"`
+	PrescribedBy    *int64       `json:"prescribed_by,omitempty" binding:"omitempty,gt=0"`
+}
+
+func (m *AddMedication) ValidDates() bool {
+	return m.Finish == nil || !m.Finish.Time.Before(m.Start.Time)
+}
+
+type UpdateMedication AddMedication
+
+func (m *UpdateMedication) ValidDates() bool {
+	return (*AddMedication)(m).ValidDates()
+}
+
+type StopMedication struct {
+	Finish *pgtype.Date `json:"finish,omitempty"`
+	Reason *string      `json:"reason,omitempty" binding:"omitempty,max=255"`
+}
+
+func (m *StopMedication) Prepare(now time.Time) {
+	if m.Finish == nil {
+		m.Finish = &pgtype.Date{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+	}
+}
+
+type ListMedicationsRequest struct {
+	Status *string `json:"status,omitempty" binding:"omitempty,oneof=current stopped"`
+}
+
+type ListMedications struct {
+	Medications []*Medication `json:"medications"`
+}
+
+func (l *ListMedications) ToResponse() IResponse {
+	for _, v := range l.Medications {
+		v.ToResponse()
+	}
+	return l
+}
+
+type MedicationMessage struct {
+	PatientID  int64       `json:"patient_id"`
+	Medication *Medication `json:"medication"`
+}
@@ -1,48 +1,89 @@
 package model
 
-import "github.com/Hvaekar/med-account/pkg/storage"
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+const (
+	ImplantTypePacemaker        = "pacemaker"
+	ImplantTypeDefibrillator    = "defibrillator"
+	ImplantTypeCochlearImplant  = "cochlear_implant"
+	ImplantTypeNeurostimulator  = "neurostimulator"
+	ImplantTypeInfusionPump     = "infusion_pump"
+	ImplantTypeStent            = "stent"
+	ImplantTypeHeartValve       = "heart_valve"
+	ImplantTypeJointReplacement = "joint_replacement"
+	ImplantTypeOsteosynthesis   = "osteosynthesis"
+	ImplantTypeAneurysmClip     = "aneurysm_clip"
+	ImplantTypeDentalImplant    = "dental_implant"
+	ImplantTypeForeignBody      = "foreign_body"
+	ImplantTypeOther            = "other"
+
+	MRISafe        = "safe"
+	MRIConditional = "conditional"
+	MRIUnsafe      = "unsafe"
+
+	MRIScreeningClear           = "clear"
+	MRIScreeningConditional     = "conditional"
+	MRIScreeningUnknown         = "unknown"
+	MRIScreeningContraindicated = "contraindicated"
+)
 
 type MetalComponent struct {
-	ID          int64   `json:"id"`
-	PatientID   int64   `json:"-"`
-	Metal       *string `json:"metal,omitempty"`
-	OrganID     int64   `json:"organ_id,omitempty"`
-	Description *string `json:"description,omitempty"`
+	ID            int64        `json:"id"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	PatientID     int64        `json:"-"`
+	Type          string       `json:"type"`
+	Metal         *string      `json:"metal,omitempty"`
+	OrganID       int64        `json:"organ_id,omitempty"`
+	Description   *string      `json:"description,omitempty"`
+	Manufacturer  *string      `json:"manufacturer,omitempty"`
+	Model         *string      `json:"model,omitempty"`
+	Implanted     *pgtype.Date `json:"implanted,omitempty"`
+	MRISafety     *string      `json:"mri_safety,omitempty"`
+	MRIConditions *string      `json:"mri_conditions,omitempty"`
+	Files         []*File      `json:"files,omitempty"`
 }
 
 func (c *MetalComponent) ToResponse() IResponse {
 	c.PatientID = 0
+	for _, f := range c.Files {
+		f.ToResponse()
+	}
 	return c
 }
 
+// AddMetalComponent leaves the MRI safety empty when it is unknown, conditions are only kept for conditional devices.
 type AddMetalComponent struct {
-	Metal       *string `json:"metal,omitempty" binding:"omitempty,max=100"`
-	OrganID     int64   `json:"organ_id,omitempty" binding:"required,gt=0"`
-	Description *string `json:"description,omitempty" binding:"omitempty,max=255"`
+	Type          string       `json:"type,omitempty" binding:"omitempty,oneof=pacemaker defibrillator cochlear_implant neurostimulator infusion_pump stent heart_valve joint_replacement osteosynthesis aneurysm_clip dental_implant foreign_body other"`
+	Metal         *string      `json:"metal,omitempty" binding:"omitempty,max=100"`
+	OrganID       int64        `json:"organ_id,omitempty" binding:"required,gt=0"`
+	Description   *string      `json:"description,omitempty" binding:"omitempty,max=255"`
+	Manufacturer  *string      `json:"manufacturer,omitempty" binding:"omitempty,max=255"`
+	Model         *string      `json:"model,omitempty" binding:"omitempty,max=255"`
+	Implanted     *pgtype.Date `json:"implanted,omitempty"`
+	MRISafety     *string      `json:"mri_safety,omitempty" binding:"omitempty,oneof=safe conditional unsafe"`
+	MRIConditions *string      `json:"mri_conditions,omitempty" binding:"required_if=MRISafety conditional,omitempty,max=2000"`
+	Files         []int64      `json:"files,omitempty" binding:"omitempty,dive,gt=0"`
 }
 
-type MetalComponentJoin struct {
-	ID          *int64  `json:"id"`
-	PatientID   *int64  `json:"patient_id"`
-	Metal       *string `json:"metal,omitempty"`
-	OrganID     *int64  `json:"organ_id,omitempty"`
-	Description *string `json:"description,omitempty"`
-}
+func (c *AddMetalComponent) Prepare() {
+	if c.Type == "" {
+		c.Type = ImplantTypeOther
+	}
 
-func (m MetalComponentJoin) ConvertToMetalComponent() MetalComponent {
-	return MetalComponent{
-		ID:          *m.ID,
-		PatientID:   *m.PatientID,
-		Metal:       m.Metal,
-		OrganID:     *m.OrganID,
-		Description: m.Description,
+	if c.MRISafety == nil || *c.MRISafety != MRIConditional {
+		c.MRIConditions = nil
 	}
 }
 
-type UpdateMetalComponent struct {
-	Metal       *string `json:"metal,omitempty" binding:"omitempty,max=100"`
-	OrganID     int64   `json:"organ_id,omitempty" binding:"required,gt=0"`
-	Description *string `json:"description,omitempty" binding:"omitempty,max=255"`
+type UpdateMetalComponent AddMetalComponent
+
+func (c *UpdateMetalComponent) Prepare() {
+	(*AddMetalComponent)(c).Prepare()
 }
 
 type ListMetalComponents struct {
@@ -56,14 +97,69 @@ func (c *ListMetalComponents) ToResponse() IResponse {
 	return c
 }
 
+// MRIScreening groups the devices by MRI safety, a device with unknown safety needs the same checks as an unsafe one
+// until its implant card is verified.
+type MRIScreening struct {
+	Status      string            `json:"status"`
+	Unsafe      []*MetalComponent `json:"unsafe,omitempty"`
+	Unknown     []*MetalComponent `json:"unknown,omitempty"`
+	Conditional []*MetalComponent `json:"conditional,omitempty"`
+	Safe        []*MetalComponent `json:"safe,omitempty"`
+}
+
+func NewMRIScreening(mcs []*MetalComponent) *MRIScreening {
+	var s MRIScreening
+
+	for _, v := range mcs {
+		switch {
+		case v.MRISafety == nil:
+			s.Unknown = append(s.Unknown, v)
+		case *v.MRISafety == MRIUnsafe:
+			s.Unsafe = append(s.Unsafe, v)
+		case *v.MRISafety == MRIConditional:
+			s.Conditional = append(s.Conditional, v)
+		default:
+			s.Safe = append(s.Safe, v)
+		}
+	}
+
+	switch {
+	case len(s.Unsafe) > 0:
+		s.Status = MRIScreeningContraindicated
+	case len(s.Unknown) > 0:
+		s.Status = MRIScreeningUnknown
+	case len(s.Conditional) > 0:
+		s.Status = MRIScreeningConditional
+	default:
+		s.Status = MRIScreeningClear
+	}
+
+	return &s
+}
+
+func (s *MRIScreening) ToResponse() IResponse {
+	for _, l := range [][]*MetalComponent{s.Unsafe, s.Unknown, s.Conditional, s.Safe} {
+		for _, v := range l {
+			v.ToResponse()
+		}
+	}
+	return s
+}
+
 type UpdateMetalComponentFields map[string]interface{}
 
 func (f UpdateMetalComponentFields) DBColumns() map[string]interface{} {
 	return map[string]interface{}{
-		//"updated_at":              struct{}{},
-		"metal":       struct{}{},
-		"organ_id":    struct{}{},
-		"description": struct{}{},
+		"updated_at":     struct{}{},
+		"type":           struct{}{},
+		"metal":          struct{}{},
+		"organ_id":       struct{}{},
+		"description":    struct{}{},
+		"manufacturer":   struct{}{},
+		"model":          struct{}{},
+		"implanted":      struct{}{},
+		"mri_safety":     struct{}{},
+		"mri_conditions": struct{}{},
 	}
 }
 
@@ -76,7 +172,7 @@ func (f UpdateMetalComponentFields) Prepare() {
 		}
 
 		switch k {
-		case "metal", "description":
+		case "metal", "description", "manufacturer", "model", "mri_safety", "mri_conditions":
 			if v == nil {
 				f[k] = storage.NullString(nil)
 				continue
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type PatientSpecialist struct {
+	ID          int64      `json:"id"`
+	ProfileID   int64      `json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"` // set by the specialist, only then the patient can review them
+	FirstName   *string    `json:"first_name,omitempty"`
+	FatherName  *string    `json:"father_name,omitempty"`
+	LastName    *string    `json:"last_name,omitempty"`
+	Photo       *string    `json:"photo,omitempty"`
+}
+
+func (p *PatientSpecialist) ToResponse() IResponse {
+	p.ProfileID = 0
+	return p
+}
+
+type AddPatientSpecialist struct {
+	SpecialistID int64 `json:"specialist_id" binding:"required,gt=0"`
+}
+
+type ListPatientSpecialists struct {
+	Specialists []*PatientSpecialist `json:"specialists"`
+}
+
+func (l *ListPatientSpecialists) ToResponse() IResponse {
+	for _, v := range l.Specialists {
+		v.ToResponse()
+	}
+	return l
+}
+
+type PatientSpecialistMessage struct {
+	PatientID    int64 `json:"patient_id"`
+	SpecialistID int64 `json:"specialist_id"`
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/immunization"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+const (
+	VaccinationStatusDone     = "done"
+	VaccinationStatusOverdue  = "overdue"
+	VaccinationStatusUpcoming = "upcoming"
+	VaccinationStatusMissed   = "missed"
+)
+
+type Vaccination struct {
+	ID           int64       `json:"id"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	PatientID    int64       `json:"-"`
+	Vaccine      string      `json:"vaccine"`
+	Name         *string     `json:"name,omitempty"`
+	DoseNumber   int         `json:"dose_number"`
+	VaccinatedAt pgtype.Date `json:"vaccinated_at"`
+	Lot          *string     `json:"lot,omitempty"`
+	Clinic       *string     `json:"clinic,omitempty"`
+	Files        []*File     `json:"files,omitempty"`
+}
+
+func (v *Vaccination) ToResponse() IResponse {
+	v.PatientID = 0
+	for _, f := range v.Files {
+		f.ToResponse()
+	}
+	return v
+}
+
+type AddVaccination struct {
+	Vaccine      string      `json:"vaccine" binding:"required,max=50"`
+	Name         *string     `json:"name,omitempty" binding:"omitempty,max=255"`
+	DoseNumber   int         `json:"dose_number" binding:"required,gt=0,lte=20"`
+	VaccinatedAt pgtype.Date `json:"vaccinated_at" binding:"required"`
+	Lot          *string     `json:"lot,omitempty" binding:"omitempty,max=50"`
+	Clinic       *string     `json:"clinic,omitempty" binding:"omitempty,max=255"`
+	Files        []int64     `json:"files,omitempty" binding:"omitempty,dive,gt=0"`
+}
+
+func (v *AddVaccination) Prepare() {
+	v.Vaccine = immunization.Normalize(v.Vaccine)
+}
+
+type UpdateVaccination AddVaccination
+
+func (v *UpdateVaccination) Prepare() {
+	(*AddVaccination)(v).Prepare()
+}
+
+type ListVaccinations struct {
+	Vaccinations []*Vaccination `json:"vaccinations"`
+}
+
+func (l *ListVaccinations) ToResponse() IResponse {
+	for _, v := range l.Vaccinations {
+		v.ToResponse()
+	}
+	return l
+}
+
+type VaccinationMessage struct {
+	PatientID   int64        `json:"patient_id"`
+	Vaccination *Vaccination `json:"vaccination"`
+}
+
+type ScheduledVaccination struct {
+	Vaccine       string      `json:"vaccine"`
+	Dose          int         `json:"dose"`
+	Title         string      `json:"title"`
+	DueDate       pgtype.Date `json:"due_date"`
+	CatchUpUntil  pgtype.Date `json:"catch_up_until"`
+	Status        string      `json:"status"`
+	VaccinationID *int64      `json:"vaccination_id,omitempty"`
+}
+
+type VaccinationSchedule struct {
+	Schedule []*ScheduledVaccination `json:"schedule"`
+}
+
+// NewVaccinationSchedule compares the immunization calendar with the recorded vaccinations,
+// a calendar dose is done when a vaccination with the same vaccine and dose number exists.
+// A dose not done is overdue only until its catch-up window closes, then it is missed.
+func NewVaccinationSchedule(birthday time.Time, vs []*Vaccination, now time.Time) *VaccinationSchedule {
+	type key struct {
+		vaccine string
+		dose    int
+	}
+
+	done := make(map[key]int64, len(vs))
+	for _, v := range vs {
+		done[key{v.Vaccine, v.DoseNumber}] = v.ID
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	birthday = time.Date(birthday.Year(), birthday.Month(), birthday.Day(), 0, 0, 0, 0, time.UTC)
+
+	calendar := immunization.Calendar()
+	s := &VaccinationSchedule{Schedule: make([]*ScheduledVaccination, 0, len(calendar))}
+	for _, d := range calendar {
+		sv := &ScheduledVaccination{
+			Vaccine:      d.Vaccine,
+			Dose:         d.Dose,
+			Title:        d.Title,
+			DueDate:      pgtype.Date{Time: d.Due(birthday), Valid: true},
+			CatchUpUntil: pgtype.Date{Time: d.CatchUpUntil(birthday), Valid: true},
+			Status:       VaccinationStatusUpcoming,
+		}
+
+		if id, ok := done[key{d.Vaccine, d.Dose}]; ok {
+			sv.Status = VaccinationStatusDone
+			sv.VaccinationID = &id
+		} else if !sv.CatchUpUntil.Time.After(today) {
+			sv.Status = VaccinationStatusMissed
+		} else if sv.DueDate.Time.Before(today) {
+			sv.Status = VaccinationStatusOverdue
+		}
+
+		s.Schedule = append(s.Schedule, sv)
+	}
+
+	return s
+}
+
+func (s *VaccinationSchedule) Overdue() *VaccinationSchedule {
+	overdue := &VaccinationSchedule{Schedule: make([]*ScheduledVaccination, 0)}
+	for _, v := range s.Schedule {
+		if v.Status == VaccinationStatusOverdue {
+			overdue.Schedule = append(overdue.Schedule, v)
+		}
+	}
+
+	return overdue
+}
@@ -1,12 +1,15 @@
 package model
 
 import (
+	"github.com/Hvaekar/med-account/pkg/namematch"
 	"github.com/Hvaekar/med-account/pkg/storage"
+	"strings"
 	"time"
 )
 
 type Specialist struct {
 	ID              int64     `json:"id"`
+	AccountID       int64     `json:"-"`
 	UpdatedAt       time.Time `json:"updated_at"`
 	FirstName       *string   `json:"first_name,omitempty"`
 	FatherName      *string   `json:"father_name,omitempty"`
@@ -27,9 +30,12 @@ type Specialist struct {
 	ListAssociations
 	ListPatents
 	ListPublicationLinks
-	//EducationalCourses []EducationalCourse `json:"educational_courses"`
-	//Teaching     []Teaching        `json:"teaching"`
-	//Speeches     []Speech          `json:"speeches"`
+	ListLicences
+	ListEducationalCourses
+	ListTeachings
+	ListSpeeches
+	CME    *CMESummary       `json:"cme,omitempty"`
+	Rating *SpecialistRating `json:"rating,omitempty"`
 }
 
 func (s *Specialist) ToResponse() IResponse {
@@ -39,14 +45,59 @@ func (s *Specialist) ToResponse() IResponse {
 	s.ListAssociations.ToResponse()
 	s.ListPatents.ToResponse()
 	s.ListPublicationLinks.ToResponse()
+	s.ListLicences.ToResponse()
+	s.ListEducationalCourses.ToResponse()
+	s.ListTeachings.ToResponse()
+	s.ListSpeeches.ToResponse()
 	return s
 }
 
+func (s *Specialist) ApplyPrivacy(settings *PrivacySettings, relation PrivacyRelation) {
+	if relation.Self {
+		return
+	}
+
+	if !relation.Allows(settings.Names) {
+		s.FirstName = nil
+		s.FatherName = nil
+		s.LastName = nil
+	}
+
+	if !relation.Allows(settings.Photo) {
+		s.Photo = nil
+	}
+
+	if !relation.Allows(settings.Specialist) {
+		s.Phone = nil
+		s.Email = nil
+		s.About = nil
+		s.Educations = nil
+		s.Experiences = nil
+		s.Associations = nil
+		s.Patents = nil
+		s.PublicationLinks = nil
+		s.Licences = nil
+		s.EducationalCourses = nil
+		s.Teachings = nil
+		s.Speeches = nil
+		s.CME = nil
+	}
+}
+
 type ListSpecialistsRequest struct {
-	OrderBy string  `json:"order_by" form:"order_by" url:"order_by" binding:"omitempty,min=1"`
-	Limit   uint64  `json:"limit" form:"limit" url:"limit" binding:"omitempty,gt=0"`
-	Page    uint64  `json:"page" form:"page" url:"page" binding:"omitempty,gt=0"`
-	IDList  []int64 `json:"id_list" form:"page" url:"page" binding:"omitempty,gt=0"`
+	OrderBy           string   `json:"order_by" form:"order_by" url:"order_by" binding:"omitempty,min=1"`
+	Limit             uint64   `json:"limit" form:"limit" url:"limit" binding:"omitempty,gt=0"`
+	Page              uint64   `json:"page" form:"page" url:"page" binding:"omitempty,gt=0"`
+	IDList            []int64  `json:"id_list" form:"page" url:"page" binding:"omitempty,gt=0"`
+	Query             string   `json:"query,omitempty" form:"query" url:"query,omitempty" binding:"omitempty,max=255"`
+	Specializations   []int64  `json:"specializations,omitempty" form:"specializations" url:"specializations,omitempty" binding:"omitempty,dive,gt=0"`
+	CuresDiseases     []int64  `json:"cures_diseases,omitempty" form:"cures_diseases" url:"cures_diseases,omitempty" binding:"omitempty,dive,gt=0"`
+	Services          []int64  `json:"services,omitempty" form:"services" url:"services,omitempty" binding:"omitempty,dive,gt=0"`
+	MedicalCategories []string `json:"medical_categories,omitempty" form:"medical_categories" url:"medical_categories,omitempty" binding:"omitempty,dive,oneof=0 1 2 3"`
+	TreatsAdults      *bool    `json:"treats_adults,omitempty" form:"treats_adults" url:"treats_adults,omitempty"`
+	TreatsChildren    *bool    `json:"treats_children,omitempty" form:"treats_children" url:"treats_children,omitempty"`
+	Languages         []string `json:"languages,omitempty" form:"languages" url:"languages,omitempty" binding:"omitempty,dive,len=2"`
+	Cities            []int64  `json:"cities,omitempty" form:"cities" url:"cities,omitempty" binding:"omitempty,dive,gt=0"`
 }
 
 func (l *ListSpecialistsRequest) Prepare() {
@@ -65,8 +116,46 @@ func (l *ListSpecialistsRequest) Offset() uint64 {
 	return l.Limit * (l.Page - 1)
 }
 
+// NameQuery turns the query into a prefix full-text query over normalized names,
+// so it matches names written both in Cyrillic and in Latin. Empty when there is nothing to search.
+func (l *ListSpecialistsRequest) NameQuery() string {
+	terms := make([]string, 0)
+	for _, v := range strings.Fields(l.Query) {
+		if t := namematch.Normalize(v); t != "" {
+			terms = append(terms, t+":*")
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
+
 type ListSpecialists struct {
-	Specialists []*Specialist `json:"specialists"`
+	Specialists []*Specialist     `json:"specialists"`
+	Facets      *SpecialistFacets `json:"facets,omitempty"`
+}
+
+// SpecialistFacets counts the specialists matching the search for every value of a filter,
+// the filter itself is left out so the counts show what choosing another value gives.
+type SpecialistFacets struct {
+	Total             int64           `json:"total"`
+	Specializations   []*FacetIDCount `json:"specializations"`
+	CuresDiseases     []*FacetIDCount `json:"cures_diseases"`
+	Services          []*FacetIDCount `json:"services"`
+	MedicalCategories []*FacetCount   `json:"medical_categories"`
+	TreatsAdults      int64           `json:"treats_adults"`
+	TreatsChildren    int64           `json:"treats_children"`
+	Languages         []*FacetCount   `json:"languages"`
+	Cities            []*FacetIDCount `json:"cities"`
+}
+
+type FacetIDCount struct {
+	ID    int64 `json:"id"`
+	Count int64 `json:"count"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
 }
 
 type AddSpecialistProfile struct {
@@ -10,6 +10,7 @@ type Association struct {
 	AssociationID *int64  `json:"association_id,omitempty"`
 	Name          string  `json:"name"`
 	JobTitle      *string `json:"job_title,omitempty"`
+	Verified      bool    `json:"verified"`
 }
 
 func (a *Association) ToResponse() IResponse {
@@ -23,6 +24,7 @@ type AssociationJoin struct {
 	AssociationID *int64  `json:"association_id"`
 	Name          *string `json:"name"`
 	JobTitle      *string `json:"job_title"`
+	Verified      *bool   `json:"verified"`
 }
 
 func (e AssociationJoin) ConvertToAssociation() Association {
@@ -32,6 +34,7 @@ func (e AssociationJoin) ConvertToAssociation() Association {
 		AssociationID: e.AssociationID,
 		Name:          *e.Name,
 		JobTitle:      e.JobTitle,
+		Verified:      *e.Verified,
 	}
 }
 
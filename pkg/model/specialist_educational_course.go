@@ -0,0 +1,154 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type EducationalCourse struct {
+	ID              int64       `json:"id"`
+	ProfileID       int64       `json:"-"`
+	CourseID        *int64      `json:"course_id,omitempty"`
+	Name            string      `json:"name"`
+	InstitutionID   *int64      `json:"institution_id,omitempty"`
+	InstitutionName *string     `json:"institution_name,omitempty"`
+	FormID          *int64      `json:"form_id,omitempty"`
+	Graduation      pgtype.Date `json:"graduation"`
+	Points          float64     `json:"points"`
+	Verified        bool        `json:"verified"`
+	Files           []*File     `json:"files"`
+}
+
+func (e *EducationalCourse) ToResponse() IResponse {
+	e.ProfileID = 0
+	for _, v := range e.Files {
+		v.ToResponse()
+	}
+	return e
+}
+
+type AddEducationalCourse struct {
+	CourseID        *int64      `json:"course_id" binding:"omitempty,gt=0"`
+	Name            string      `json:"name" binding:"required,max=255"`
+	InstitutionID   *int64      `json:"institution_id" binding:"omitempty,gt=0"`
+	InstitutionName *string     `json:"institution_name" binding:"omitempty,max=100"`
+	FormID          *int64      `json:"form_id" binding:"omitempty,gt=0"`
+	Graduation      pgtype.Date `json:"graduation" binding:"required"`
+	Points          float64     `json:"points" binding:"gte=0,lte=9999"`
+	Files           []*File     `json:"files"`
+}
+
+type UpdateEducationalCourse AddEducationalCourse
+
+type ListEducationalCourses struct {
+	EducationalCourses []*EducationalCourse `json:"educational_courses"`
+}
+
+func (l *ListEducationalCourses) ToResponse() IResponse {
+	for _, v := range l.EducationalCourses {
+		v.ToResponse()
+	}
+	return l
+}
+
+type CoursePointsRequest struct {
+	From *pgtype.Date `json:"from" form:"from" url:"from"`
+	To   *pgtype.Date `json:"to" form:"to" url:"to"`
+}
+
+type CoursePoints struct {
+	Year           int     `json:"year"`
+	Courses        int64   `json:"courses"`
+	Points         float64 `json:"points"`
+	VerifiedPoints float64 `json:"verified_points"`
+}
+
+type ListCoursePoints struct {
+	Periods []*CoursePoints `json:"periods"`
+	Total   float64         `json:"total"`
+}
+
+type CMESummary struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Points         float64   `json:"points"`
+	VerifiedPoints float64   `json:"verified_points"`
+	RequiredPoints float64   `json:"required_points"`
+	Met            bool      `json:"met"`
+}
+
+// NewCMESummary sums course points over the last periodYears calendar years including the current one.
+func NewCMESummary(courses []*EducationalCourse, requiredPoints float64, periodYears int, now time.Time) *CMESummary {
+	if periodYears < 1 {
+		periodYears = 1
+	}
+
+	cme := CMESummary{
+		From:           time.Date(now.Year()-periodYears+1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(now.Year(), time.December, 31, 0, 0, 0, 0, time.UTC),
+		RequiredPoints: requiredPoints,
+	}
+
+	for _, v := range courses {
+		if v.Graduation.Time.Before(cme.From) || v.Graduation.Time.After(cme.To) {
+			continue
+		}
+
+		cme.Points += v.Points
+		if v.Verified {
+			cme.VerifiedPoints += v.Points
+		}
+	}
+
+	cme.Met = cme.Points >= cme.RequiredPoints
+
+	return &cme
+}
+
+type UpdateEducationalCourseFields map[string]interface{}
+
+func (f UpdateEducationalCourseFields) DBColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"course_id":        struct{}{},
+		"name":             struct{}{},
+		"institution_id":   struct{}{},
+		"institution_name": struct{}{},
+		"form_id":          struct{}{},
+		"graduation":       struct{}{},
+		"points":           struct{}{},
+	}
+}
+
+func (f UpdateEducationalCourseFields) Prepare() {
+	for k, v := range f {
+		columns := f.DBColumns()
+		if _, ok := columns[k]; !ok {
+			delete(f, k)
+			continue
+		}
+
+		switch k {
+		case "course_id", "institution_id", "form_id":
+			if v == nil {
+				f[k] = storage.NullInt64(nil)
+				continue
+			}
+
+			val := int64(v.(float64))
+
+			f[k] = storage.NullInt64(&val)
+		case "institution_name":
+			if v == nil {
+				f[k] = storage.NullString(nil)
+				continue
+			}
+
+			val := v.(string)
+
+			f[k] = storage.NullString(&val)
+		default:
+			f[k] = v
+		}
+	}
+}
@@ -10,9 +10,11 @@ type Experience struct {
 	ID              int64        `json:"id"`
 	ProfileID       int64        `json:"-"`
 	CompanyID       *int64       `json:"company_id,omitempty"`
+	OrganizationID  *int64       `json:"organization_id,omitempty"`
 	Company         string       `json:"company"`
 	Start           pgtype.Date  `json:"start"`
 	Finish          *pgtype.Date `json:"finish,omitempty"`
+	Verified        bool         `json:"verified"`
 	Specializations []int64      `json:"specializations"`
 }
 
@@ -22,27 +24,32 @@ func (e *Experience) ToResponse() IResponse {
 }
 
 type ExperienceJoin struct {
-	ID        *int64       `json:"id"`
-	ProfileID *int64       `json:"profile_id"`
-	CompanyID *int64       `json:"company_id"`
-	Company   *string      `json:"company"`
-	Start     *pgtype.Date `json:"start"`
-	Finish    *pgtype.Date `json:"finish"`
+	ID             *int64       `json:"id"`
+	ProfileID      *int64       `json:"profile_id"`
+	CompanyID      *int64       `json:"company_id"`
+	OrganizationID *int64       `json:"organization_id"`
+	Company        *string      `json:"company"`
+	Start          *pgtype.Date `json:"start"`
+	Finish         *pgtype.Date `json:"finish"`
+	Verified       *bool        `json:"verified"`
 }
 
 func (e ExperienceJoin) ConvertToExperience() Experience {
 	return Experience{
-		ID:        *e.ID,
-		ProfileID: *e.ProfileID,
-		CompanyID: e.CompanyID,
-		Company:   *e.Company,
-		Start:     *e.Start,
-		Finish:    e.Finish,
+		ID:             *e.ID,
+		ProfileID:      *e.ProfileID,
+		CompanyID:      e.CompanyID,
+		OrganizationID: e.OrganizationID,
+		Company:        *e.Company,
+		Start:          *e.Start,
+		Finish:         e.Finish,
+		Verified:       *e.Verified,
 	}
 }
 
 type AddExperience struct {
 	CompanyID       *int64       `json:"company_id" binding:"omitempty,gt=0"`
+	OrganizationID  *int64       `json:"organization_id" binding:"omitempty,gt=0"`
 	Company         string       `json:"company" binding:"required,max=255"`
 	Start           pgtype.Date  `json:"start" binding:"required"`
 	Finish          *pgtype.Date `json:"finish"`
@@ -66,10 +73,11 @@ type UpdateExperienceFields map[string]interface{}
 
 func (f UpdateExperienceFields) DBColumns() map[string]interface{} {
 	return map[string]interface{}{
-		"company_id": struct{}{},
-		"company":    struct{}{},
-		"start":      struct{}{},
-		"finish":     struct{}{},
+		"company_id":      struct{}{},
+		"organization_id": struct{}{},
+		"company":         struct{}{},
+		"start":           struct{}{},
+		"finish":          struct{}{},
 	}
 }
 
@@ -82,7 +90,7 @@ func (f UpdateExperienceFields) Prepare() {
 		}
 
 		switch k {
-		case "company_id":
+		case "company_id", "organization_id":
 			if v == nil {
 				f[k] = storage.NullInt64(nil)
 				continue
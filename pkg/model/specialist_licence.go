@@ -0,0 +1,109 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+const (
+	LicenceStatusActive    = "active"
+	LicenceStatusSuspended = "suspended"
+	LicenceStatusClosed    = "closed"
+	LicenceStatusExpired   = "expired"
+)
+
+type Licence struct {
+	ID        int64        `json:"id"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	ProfileID int64        `json:"-"`
+	Number    string       `json:"number"`
+	Status    string       `json:"status"`
+	FullName  string       `json:"full_name"`
+	Practice  string       `json:"practice"`
+	Issued    *pgtype.Date `json:"issued,omitempty"`
+	Expires   *pgtype.Date `json:"expires,omitempty"`
+	Verified  bool         `json:"verified"`
+	CheckedAt *time.Time   `json:"checked_at,omitempty"`
+}
+
+func (l *Licence) ToResponse() IResponse {
+	l.ProfileID = 0
+	return l
+}
+
+type AddLicence struct {
+	Number   string       `json:"number" binding:"required,max=100"`
+	FullName string       `json:"full_name" binding:"required,max=255"`
+	Practice string       `json:"practice" binding:"required,max=255"`
+	Issued   *pgtype.Date `json:"issued"`
+	Expires  *pgtype.Date `json:"expires"`
+}
+
+type UpdateLicence AddLicence
+
+type UpdateLicenceStatus struct {
+	Status string `json:"status" binding:"required,oneof=active suspended closed"`
+}
+
+type ListLicences struct {
+	Licences []*Licence `json:"licences"`
+}
+
+func (l *ListLicences) ToResponse() IResponse {
+	for _, v := range l.Licences {
+		v.ToResponse()
+	}
+	return l
+}
+
+type LicenceMessage struct {
+	ProfileID int64    `json:"profile_id"`
+	Licence   *Licence `json:"licence"`
+}
+
+type UpdateLicenceFields map[string]interface{}
+
+func (f UpdateLicenceFields) DBColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"number":    struct{}{},
+		"full_name": struct{}{},
+		"practice":  struct{}{},
+		"issued":    struct{}{},
+		"expires":   struct{}{},
+	}
+}
+
+func (f UpdateLicenceFields) Prepare() {
+	for k, v := range f {
+		columns := f.DBColumns()
+		if _, ok := columns[k]; !ok {
+			delete(f, k)
+			continue
+		}
+
+		switch k {
+		case "issued", "expires":
+			if v == nil {
+				f[k] = storage.NullDatePGX(nil)
+				continue
+			}
+
+			t, err := time.Parse("2006-01-02", v.(string))
+			if err != nil {
+				f[k] = storage.NullDatePGX(nil)
+				continue
+			}
+
+			val := pgtype.Date{
+				Time:  t,
+				Valid: true,
+			}
+
+			f[k] = storage.NullDatePGX(&val)
+		default:
+			f[k] = v
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package model
+
+import "time"
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+type Review struct {
+	ID              int64         `json:"id"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	SpecialistID    int64         `json:"specialist_id"`
+	PatientID       int64         `json:"patient_id,omitempty"`
+	AuthorAccountID int64         `json:"-"`
+	AuthorName      *string       `json:"author_name,omitempty"`
+	Ratings         ReviewRatings `json:"ratings"`
+	Rating          float64       `json:"rating"` // average of the criteria
+	Text            *string       `json:"text,omitempty"`
+	Anonymous       bool          `json:"anonymous"`
+	Status          string        `json:"status"`
+	ReviewerID      *int64        `json:"reviewer_id,omitempty"`
+	ReviewerComment *string       `json:"reviewer_comment,omitempty"`
+	ReviewedAt      *time.Time    `json:"reviewed_at,omitempty"`
+	Reply           *string       `json:"reply,omitempty"`
+	RepliedAt       *time.Time    `json:"replied_at,omitempty"`
+}
+
+// ApplyPrivacy hides the author of an anonymous review and the moderation details from everyone except the author
+func (r *Review) ApplyPrivacy(settings *PrivacySettings, relation PrivacyRelation) {
+	if relation.Self {
+		return
+	}
+
+	if r.Anonymous {
+		r.PatientID = 0
+		r.AuthorName = nil
+	}
+
+	if !relation.Allows(settings.Names) {
+		r.AuthorName = nil
+	}
+
+	r.ReviewerID = nil
+	r.ReviewerComment = nil
+}
+
+type ReviewRatings struct {
+	Professionalism int64 `json:"professionalism" binding:"required,min=1,max=5"`
+	Attentiveness   int64 `json:"attentiveness" binding:"required,min=1,max=5"`
+	Communication   int64 `json:"communication" binding:"required,min=1,max=5"`
+	Punctuality     int64 `json:"punctuality" binding:"required,min=1,max=5"`
+}
+
+type AddReview struct {
+	SpecialistID int64         `json:"specialist_id" binding:"required,gt=0"`
+	Ratings      ReviewRatings `json:"ratings" binding:"required"`
+	Text         *string       `json:"text" binding:"omitempty,max=2000"`
+	Anonymous    bool          `json:"anonymous"`
+}
+
+// UpdateReview sends the review to moderation again
+type UpdateReview struct {
+	Ratings   ReviewRatings `json:"ratings" binding:"required"`
+	Text      *string       `json:"text" binding:"omitempty,max=2000"`
+	Anonymous bool          `json:"anonymous"`
+}
+
+type ModerateReview struct {
+	Comment *string `json:"comment"`
+}
+
+type ReplyReview struct {
+	Text string `json:"text" binding:"required,max=2000"`
+}
+
+type ListReviewsRequest struct {
+	SpecialistID int64  `json:"specialist_id" form:"specialist_id" url:"specialist_id" binding:"omitempty,gt=0"`
+	Status       string `json:"status" form:"status" url:"status" binding:"omitempty,oneof=pending approved rejected"`
+	OrderBy      string `json:"order_by" form:"order_by" url:"order_by" binding:"omitempty,min=1"`
+	Limit        uint64 `json:"limit" form:"limit" url:"limit" binding:"omitempty,gt=0"`
+	Page         uint64 `json:"page" form:"page" url:"page" binding:"omitempty,gt=0"`
+}
+
+func (l *ListReviewsRequest) Prepare() {
+	if l.Status == "" {
+		l.Status = ReviewStatusPending
+	}
+	if l.OrderBy == "" {
+		l.OrderBy = "created_at DESC"
+	}
+	if l.Limit == 0 {
+		l.Limit = defaultLimit
+	}
+	if l.Page == 0 {
+		l.Page = defaultPage
+	}
+}
+
+func (l *ListReviewsRequest) Offset() uint64 {
+	return l.Limit * (l.Page - 1)
+}
+
+type ListReviews struct {
+	Reviews []*Review `json:"reviews"`
+}
+
+// SpecialistRating sums up the approved reviews, the averages are zero without reviews
+type SpecialistRating struct {
+	Count           int64   `json:"count"`
+	Average         float64 `json:"average"`
+	Professionalism float64 `json:"professionalism"`
+	Attentiveness   float64 `json:"attentiveness"`
+	Communication   float64 `json:"communication"`
+	Punctuality     float64 `json:"punctuality"`
+}
+
+type ReviewMessage struct {
+	SpecialistID int64   `json:"specialist_id"`
+	Review       *Review `json:"review"`
+}
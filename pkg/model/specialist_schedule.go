@@ -0,0 +1,397 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"sort"
+	"time"
+)
+
+const (
+	ScheduleExceptionVacation  = "vacation"
+	ScheduleExceptionSickLeave = "sick_leave"
+	ScheduleExceptionDayOff    = "day_off"
+	ScheduleExceptionOther     = "other"
+
+	maxAvailabilityDays = 31
+	minutesPerDay       = 24 * 60
+)
+
+var (
+	ErrScheduleLocation     = errors.New("schedule location must be either an organization or an address")
+	ErrScheduleTimezone     = errors.New("unknown timezone")
+	ErrScheduleHours        = errors.New("working hours must end after they start")
+	ErrScheduleHoursOverlap = errors.New("working hours of the same weekday overlap")
+	ErrScheduleException    = errors.New("exception must end after it starts, start and end time are set together")
+	ErrAvailabilityRange    = errors.New("availability range must end after it starts and be no longer than 31 days")
+)
+
+// Clock is the time of day in minutes from midnight, written as "15:04" in json. 24:00 is the end of the day.
+type Clock int64
+
+func (t Clock) String() string {
+	return fmt.Sprintf("%02d:%02d", t/60, t%60)
+}
+
+func (t Clock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *Clock) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	var h, m int64
+	if _, err := fmt.Sscanf(s, "%02d:%02d", &h, &m); err != nil || len(s) != 5 || h < 0 || m < 0 || m > 59 || h*60+m > minutesPerDay {
+		return fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+
+	*t = Clock(h*60 + m)
+
+	return nil
+}
+
+type Schedule struct {
+	ID             int64           `json:"id"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	ProfileID      int64           `json:"-"`
+	OrganizationID *int64          `json:"organization_id,omitempty"`
+	AddressID      *int64          `json:"address_id,omitempty"`
+	Timezone       string          `json:"timezone"`
+	SlotDuration   int64           `json:"slot_duration"` // minutes
+	Hours          []*WorkingHours `json:"hours"`
+}
+
+func (s *Schedule) ToResponse() IResponse {
+	s.ProfileID = 0
+	return s
+}
+
+type WorkingHours struct {
+	Weekday int64 `json:"weekday" binding:"required,min=1,max=7"` // 1 is monday, 7 is sunday
+	Start   Clock `json:"start"`
+	End     Clock `json:"end"`
+}
+
+// AddSchedule sets the weekly working hours at one location, the hours are the local time of the timezone
+type AddSchedule struct {
+	OrganizationID *int64          `json:"organization_id" binding:"omitempty,gt=0"`
+	AddressID      *int64          `json:"address_id" binding:"omitempty,gt=0"`
+	Timezone       string          `json:"timezone" binding:"required,max=64"`
+	SlotDuration   int64           `json:"slot_duration" binding:"required,min=5,max=480"`
+	Hours          []*WorkingHours `json:"hours" binding:"required,dive"`
+}
+
+func (r *AddSchedule) Validate() error {
+	if (r.OrganizationID == nil) == (r.AddressID == nil) {
+		return ErrScheduleLocation
+	}
+
+	if _, err := LoadTimezone(r.Timezone); err != nil {
+		return err
+	}
+
+	hours := make([]*WorkingHours, len(r.Hours))
+	copy(hours, r.Hours)
+	sort.Slice(hours, func(i, j int) bool {
+		if hours[i].Weekday != hours[j].Weekday {
+			return hours[i].Weekday < hours[j].Weekday
+		}
+		return hours[i].Start < hours[j].Start
+	})
+
+	for i, v := range hours {
+		if v.Start >= v.End {
+			return ErrScheduleHours
+		}
+
+		if i > 0 && hours[i-1].Weekday == v.Weekday && hours[i-1].End > v.Start {
+			return ErrScheduleHoursOverlap
+		}
+	}
+
+	return nil
+}
+
+type UpdateSchedule AddSchedule
+
+func (r *UpdateSchedule) Validate() error {
+	return (*AddSchedule)(r).Validate()
+}
+
+type ListSchedules struct {
+	Schedules []*Schedule `json:"schedules"`
+}
+
+func (l *ListSchedules) ToResponse() IResponse {
+	for _, v := range l.Schedules {
+		v.ToResponse()
+	}
+	return l
+}
+
+type ScheduleMessage struct {
+	SpecialistID int64     `json:"specialist_id"`
+	Schedule     *Schedule `json:"schedule"`
+}
+
+// ScheduleException closes the dates for booking, the whole days unless the time is set
+type ScheduleException struct {
+	ID         int64       `json:"id"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	ProfileID  int64       `json:"-"`
+	ScheduleID *int64      `json:"schedule_id,omitempty"`
+	Type       string      `json:"type"`
+	StartDate  pgtype.Date `json:"start_date"`
+	EndDate    pgtype.Date `json:"end_date"`
+	Start      *Clock      `json:"start,omitempty"`
+	End        *Clock      `json:"end,omitempty"`
+	Comment    *string     `json:"comment,omitempty"`
+}
+
+func (e *ScheduleException) ToResponse() IResponse {
+	e.ProfileID = 0
+	return e
+}
+
+// covers tells if the exception closes the minutes of the date at the schedule
+func (e *ScheduleException) covers(scheduleID int64, date time.Time, start Clock, end Clock) bool {
+	if e.ScheduleID != nil && *e.ScheduleID != scheduleID {
+		return false
+	}
+
+	if date.Before(e.StartDate.Time) || date.After(e.EndDate.Time) {
+		return false
+	}
+
+	return e.Start == nil || (start < *e.End && end > *e.Start)
+}
+
+type AddScheduleException struct {
+	ScheduleID *int64       `json:"schedule_id" binding:"omitempty,gt=0"`
+	Type       string       `json:"type" binding:"required,oneof=vacation sick_leave day_off other"`
+	StartDate  pgtype.Date  `json:"start_date" binding:"required"`
+	EndDate    *pgtype.Date `json:"end_date"`
+	Start      *Clock       `json:"start"`
+	End        *Clock       `json:"end"`
+	Comment    *string      `json:"comment" binding:"omitempty,max=255"`
+}
+
+// Validate sets the end date to the start date when missing
+func (r *AddScheduleException) Validate() error {
+	if !r.StartDate.Valid {
+		return ErrScheduleException
+	}
+
+	if r.EndDate == nil || !r.EndDate.Valid {
+		r.EndDate = &r.StartDate
+	}
+
+	if r.EndDate.Time.Before(r.StartDate.Time) {
+		return ErrScheduleException
+	}
+
+	if (r.Start == nil) != (r.End == nil) || (r.Start != nil && *r.Start >= *r.End) {
+		return ErrScheduleException
+	}
+
+	return nil
+}
+
+type UpdateScheduleException AddScheduleException
+
+func (r *UpdateScheduleException) Validate() error {
+	return (*AddScheduleException)(r).Validate()
+}
+
+type ListScheduleExceptions struct {
+	Exceptions []*ScheduleException `json:"exceptions"`
+}
+
+func (l *ListScheduleExceptions) ToResponse() IResponse {
+	for _, v := range l.Exceptions {
+		v.ToResponse()
+	}
+	return l
+}
+
+type ScheduleExceptionMessage struct {
+	SpecialistID int64              `json:"specialist_id"`
+	Exception    *ScheduleException `json:"exception"`
+}
+
+// AvailabilityRequest takes the dates in the timezone of every schedule, the slots are shown in Timezone when set
+type AvailabilityRequest struct {
+	From       pgtype.Date `json:"from" binding:"required"`
+	To         pgtype.Date `json:"to" binding:"required"`
+	ScheduleID *int64      `json:"schedule_id,omitempty" binding:"omitempty,gt=0"`
+	Timezone   *string     `json:"timezone,omitempty" binding:"omitempty,max=64"`
+}
+
+func (r *AvailabilityRequest) Validate() error {
+	if !r.From.Valid || !r.To.Valid || r.To.Time.Before(r.From.Time) || r.To.Time.Sub(r.From.Time) >= maxAvailabilityDays*24*time.Hour {
+		return ErrAvailabilityRange
+	}
+
+	if r.Timezone != nil {
+		if _, err := LoadTimezone(*r.Timezone); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type Availability struct {
+	Slots []*Slot `json:"slots"`
+}
+
+type Slot struct {
+	ScheduleID int64     `json:"schedule_id"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+}
+
+// NewAvailability expands the weekly hours of the schedules into the free slots of the requested dates.
+// The slots closed by an exception or starting before now are left out.
+func NewAvailability(schedules []*Schedule, exceptions []*ScheduleException, req *AvailabilityRequest, now time.Time) (*Availability, error) {
+	var out *time.Location
+	if req.Timezone != nil {
+		loc, err := LoadTimezone(*req.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		out = loc
+	}
+
+	a := Availability{Slots: make([]*Slot, 0)}
+	for _, sc := range schedules {
+		if req.ScheduleID != nil && *req.ScheduleID != sc.ID {
+			continue
+		}
+
+		loc, err := LoadTimezone(sc.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		for d := req.From.Time; !d.After(req.To.Time); d = d.AddDate(0, 0, 1) {
+			weekday := int64(d.Weekday())
+			if weekday == 0 {
+				weekday = 7
+			}
+
+			for _, h := range sc.Hours {
+				if h.Weekday != weekday {
+					continue
+				}
+
+				for m := h.Start; m+Clock(sc.SlotDuration) <= h.End; m += Clock(sc.SlotDuration) {
+					end := m + Clock(sc.SlotDuration)
+
+					// the wall clocks are resolved from the offsets of the location: a slot skipped by a DST switch or
+					// running across it is left out, a slot in the repeated hour is offered once
+					start, finish, ok := slotTimes(d, m, end, time.Duration(sc.SlotDuration)*time.Minute, loc)
+					if !ok {
+						continue
+					}
+
+					slot := Slot{
+						ScheduleID: sc.ID,
+						Start:      start,
+						End:        finish,
+					}
+
+					if slot.Start.Before(now) || closed(exceptions, sc.ID, d, m, end) {
+						continue
+					}
+
+					if out != nil {
+						slot.Start = slot.Start.In(out)
+						slot.End = slot.End.In(out)
+					}
+
+					a.Slots = append(a.Slots, &slot)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(a.Slots, func(i, j int) bool {
+		if !a.Slots[i].Start.Equal(a.Slots[j].Start) {
+			return a.Slots[i].Start.Before(a.Slots[j].Start)
+		}
+		return a.Slots[i].ScheduleID < a.Slots[j].ScheduleID
+	})
+
+	return &a, nil
+}
+
+// slotTimes returns the earliest start and end of the wall clocks on the date in the location that are the duration apart,
+// false when there are none.
+func slotTimes(date time.Time, start Clock, end Clock, duration time.Duration, loc *time.Location) (time.Time, time.Time, bool) {
+	for _, s := range wallClock(date, start, loc) {
+		for _, e := range wallClock(date, end, loc) {
+			if e.Sub(s) == duration {
+				return s, e, true
+			}
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// wallClock returns the times the clock on the date occurs in the location, in order: none when a DST switch skips it,
+// two when it is repeated. The offsets are taken a day before and after the clock, two switches within a day are not seen.
+func wallClock(date time.Time, clock Clock, loc *time.Location) []time.Time {
+	wall := time.Date(date.Year(), date.Month(), date.Day(), 0, int(clock), 0, 0, time.UTC)
+
+	ts := make([]time.Time, 0, 2)
+	for _, probe := range []time.Time{wall.Add(-24 * time.Hour), wall.Add(24 * time.Hour)} {
+		_, offset := probe.In(loc).Zone()
+
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		y, mo, d := t.Date()
+		if y != wall.Year() || mo != wall.Month() || d != wall.Day() || t.Hour() != wall.Hour() || t.Minute() != wall.Minute() {
+			continue
+		}
+
+		if len(ts) == 0 || !t.Equal(ts[0]) {
+			ts = append(ts, t)
+		}
+	}
+
+	sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+
+	return ts
+}
+
+func closed(exceptions []*ScheduleException, scheduleID int64, date time.Time, start Clock, end Clock) bool {
+	for _, v := range exceptions {
+		if v.covers(scheduleID, date, start, end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadTimezone accepts IANA names only, the local zone of the server is not a valid choice
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrScheduleTimezone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrScheduleTimezone
+	}
+
+	return loc, nil
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Speech struct {
+	ID        int64       `json:"id"`
+	ProfileID int64       `json:"-"`
+	Event     string      `json:"event"`
+	Topic     string      `json:"topic"`
+	Location  *string     `json:"location,omitempty"`
+	Date      pgtype.Date `json:"date"`
+	Link      *string     `json:"link,omitempty"`
+}
+
+func (s *Speech) ToResponse() IResponse {
+	s.ProfileID = 0
+	return s
+}
+
+type SpeechJoin struct {
+	ID        *int64       `json:"id"`
+	ProfileID *int64       `json:"profile_id"`
+	Event     *string      `json:"event"`
+	Topic     *string      `json:"topic"`
+	Location  *string      `json:"location"`
+	Date      *pgtype.Date `json:"date"`
+	Link      *string      `json:"link"`
+}
+
+func (e SpeechJoin) ConvertToSpeech() Speech {
+	return Speech{
+		ID:        *e.ID,
+		ProfileID: *e.ProfileID,
+		Event:     *e.Event,
+		Topic:     *e.Topic,
+		Location:  e.Location,
+		Date:      *e.Date,
+		Link:      e.Link,
+	}
+}
+
+type AddSpeech struct {
+	Event    string      `json:"event" binding:"required,max=255"`
+	Topic    string      `json:"topic" binding:"required,max=255"`
+	Location *string     `json:"location" binding:"omitempty,max=255"`
+	Date     pgtype.Date `json:"date" binding:"required"`
+	Link     *string     `json:"link" binding:"omitempty,http_url,max=255"`
+}
+
+type UpdateSpeech AddSpeech
+
+type ListSpeeches struct {
+	Speeches []*Speech `json:"speeches"`
+}
+
+func (l *ListSpeeches) ToResponse() IResponse {
+	for _, v := range l.Speeches {
+		v.ToResponse()
+	}
+	return l
+}
+
+type UpdateSpeechFields map[string]interface{}
+
+func (f UpdateSpeechFields) DBColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"event":    struct{}{},
+		"topic":    struct{}{},
+		"location": struct{}{},
+		"date":     struct{}{},
+		"link":     struct{}{},
+	}
+}
+
+func (f UpdateSpeechFields) Prepare() {
+	for k, v := range f {
+		columns := f.DBColumns()
+		if _, ok := columns[k]; !ok {
+			delete(f, k)
+			continue
+		}
+
+		switch k {
+		case "location", "link":
+			if v == nil {
+				f[k] = storage.NullString(nil)
+				continue
+			}
+
+			val := v.(string)
+
+			f[k] = storage.NullString(&val)
+		default:
+			f[k] = v
+		}
+	}
+}
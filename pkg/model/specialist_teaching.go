@@ -0,0 +1,133 @@
+package model
+
+import (
+	"github.com/Hvaekar/med-account/pkg/storage"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type Teaching struct {
+	ID            int64        `json:"id"`
+	ProfileID     int64        `json:"-"`
+	InstitutionID *int64       `json:"institution_id,omitempty"`
+	Institution   string       `json:"institution"`
+	Position      string       `json:"position"`
+	Subject       *string      `json:"subject,omitempty"`
+	Start         pgtype.Date  `json:"start"`
+	Finish        *pgtype.Date `json:"finish,omitempty"`
+}
+
+func (t *Teaching) ToResponse() IResponse {
+	t.ProfileID = 0
+	return t
+}
+
+type TeachingJoin struct {
+	ID            *int64       `json:"id"`
+	ProfileID     *int64       `json:"profile_id"`
+	InstitutionID *int64       `json:"institution_id"`
+	Institution   *string      `json:"institution"`
+	Position      *string      `json:"position"`
+	Subject       *string      `json:"subject"`
+	Start         *pgtype.Date `json:"start"`
+	Finish        *pgtype.Date `json:"finish"`
+}
+
+func (e TeachingJoin) ConvertToTeaching() Teaching {
+	return Teaching{
+		ID:            *e.ID,
+		ProfileID:     *e.ProfileID,
+		InstitutionID: e.InstitutionID,
+		Institution:   *e.Institution,
+		Position:      *e.Position,
+		Subject:       e.Subject,
+		Start:         *e.Start,
+		Finish:        e.Finish,
+	}
+}
+
+type AddTeaching struct {
+	InstitutionID *int64       `json:"institution_id" binding:"omitempty,gt=0"`
+	Institution   string       `json:"institution" binding:"required,max=255"`
+	Position      string       `json:"position" binding:"required,max=255"`
+	Subject       *string      `json:"subject" binding:"omitempty,max=255"`
+	Start         pgtype.Date  `json:"start" binding:"required"`
+	Finish        *pgtype.Date `json:"finish"`
+}
+
+type UpdateTeaching AddTeaching
+
+type ListTeachings struct {
+	Teachings []*Teaching `json:"teaching"`
+}
+
+func (l *ListTeachings) ToResponse() IResponse {
+	for _, v := range l.Teachings {
+		v.ToResponse()
+	}
+	return l
+}
+
+type UpdateTeachingFields map[string]interface{}
+
+func (f UpdateTeachingFields) DBColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"institution_id": struct{}{},
+		"institution":    struct{}{},
+		"position":       struct{}{},
+		"subject":        struct{}{},
+		"start":          struct{}{},
+		"finish":         struct{}{},
+	}
+}
+
+func (f UpdateTeachingFields) Prepare() {
+	for k, v := range f {
+		columns := f.DBColumns()
+		if _, ok := columns[k]; !ok {
+			delete(f, k)
+			continue
+		}
+
+		switch k {
+		case "institution_id":
+			if v == nil {
+				f[k] = storage.NullInt64(nil)
+				continue
+			}
+
+			val := int64(v.(float64))
+
+			f[k] = storage.NullInt64(&val)
+		case "subject":
+			if v == nil {
+				f[k] = storage.NullString(nil)
+				continue
+			}
+
+			val := v.(string)
+
+			f[k] = storage.NullString(&val)
+		case "finish":
+			if v == nil {
+				f[k] = storage.NullDatePGX(nil)
+				continue
+			}
+
+			t, err := time.Parse("2006-01-02", v.(string))
+			if err != nil {
+				f[k] = storage.NullDatePGX(nil)
+				continue
+			}
+
+			val := pgtype.Date{
+				Time:  t,
+				Valid: true,
+			}
+
+			f[k] = storage.NullDatePGX(&val)
+		default:
+			f[k] = v
+		}
+	}
+}
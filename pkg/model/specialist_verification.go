@@ -0,0 +1,85 @@
+package model
+
+import "time"
+
+const (
+	VerificationEntityEducation   = "education"
+	VerificationEntityAssociation = "association"
+	VerificationEntityExperience  = "experience"
+	VerificationEntityCourse      = "educational_course"
+
+	VerificationStatusPending  = "pending"
+	VerificationStatusApproved = "approved"
+	VerificationStatusRejected = "rejected"
+)
+
+type Verification struct {
+	ID              int64      `json:"id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	ProfileID       int64      `json:"profile_id"`
+	Entity          string     `json:"entity"`
+	EntityID        int64      `json:"entity_id"`
+	Status          string     `json:"status"`
+	Comment         *string    `json:"comment,omitempty"`
+	ReviewerID      *int64     `json:"reviewer_id,omitempty"`
+	ReviewerComment *string    `json:"reviewer_comment,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	Files           []*File    `json:"files"`
+}
+
+func (v *Verification) ToResponse() IResponse {
+	for _, f := range v.Files {
+		f.ToResponse()
+	}
+	return v
+}
+
+type AddVerification struct {
+	Entity   string  `json:"entity" binding:"required,oneof=education association experience educational_course"`
+	EntityID int64   `json:"entity_id" binding:"required,gt=0"`
+	Comment  *string `json:"comment"`
+	Files    []int64 `json:"files" binding:"required,min=1,dive,gt=0"`
+}
+
+type ReviewVerification struct {
+	Comment *string `json:"comment"`
+}
+
+type ListVerificationsRequest struct {
+	Status  string `json:"status" form:"status" url:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Entity  string `json:"entity" form:"entity" url:"entity" binding:"omitempty,oneof=education association experience educational_course"`
+	OrderBy string `json:"order_by" form:"order_by" url:"order_by" binding:"omitempty,min=1"`
+	Limit   uint64 `json:"limit" form:"limit" url:"limit" binding:"omitempty,gt=0"`
+	Page    uint64 `json:"page" form:"page" url:"page" binding:"omitempty,gt=0"`
+}
+
+func (l *ListVerificationsRequest) Prepare() {
+	if l.Status == "" {
+		l.Status = VerificationStatusPending
+	}
+	if l.OrderBy == "" {
+		l.OrderBy = "created_at"
+	}
+	if l.Limit == 0 {
+		l.Limit = defaultLimit
+	}
+	if l.Page == 0 {
+		l.Page = defaultPage
+	}
+}
+
+func (l *ListVerificationsRequest) Offset() uint64 {
+	return l.Limit * (l.Page - 1)
+}
+
+type ListVerifications struct {
+	Verifications []*Verification `json:"verifications"`
+}
+
+func (l *ListVerifications) ToResponse() IResponse {
+	for _, v := range l.Verifications {
+		v.ToResponse()
+	}
+	return l
+}
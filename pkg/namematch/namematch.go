@@ -0,0 +1,136 @@
+package namematch
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cyrillic follows the Ukrainian national transliteration with the Russian-only letters added,
+// folding below evens out the spellings other systems produce (Galina/Halyna, Yuriy/Iurii).
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ie", 'ж': "zh",
+	'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n",
+	'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ь': "", 'ю': "iu", 'я': "ia",
+	'ё': "e", 'ъ': "", 'ы': "y", 'э': "e",
+}
+
+var latin = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ä': "a", 'ą': "a", 'ć': "c", 'č': "ch", 'ę': "e", 'é': "e",
+	'è': "e", 'ë': "e", 'í': "i", 'ï': "i", 'ł': "l", 'ń': "n", 'ó': "o", 'ö': "o", 'ś': "s",
+	'š': "sh", 'ú': "u", 'ü': "u", 'ý': "y", 'ź': "z", 'ż': "z", 'ž': "zh",
+}
+
+var folding = strings.NewReplacer(
+	"kh", "h",
+	"g", "h",
+	"w", "v",
+	"ph", "f",
+	"j", "i",
+	"y", "i",
+)
+
+// Normalize brings a name written in Cyrillic or Latin to a lower case latin form
+// where the usual transliteration variants are spelled the same.
+func Normalize(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if v, ok := cyrillic[r]; ok {
+			b.WriteString(v)
+			continue
+		}
+
+		if v, ok := latin[r]; ok {
+			b.WriteString(v)
+			continue
+		}
+
+		if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+			continue
+		}
+
+		// apostrophes, hyphens and spaces of compound names are dropped
+		if unicode.IsLetter(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	folded := []rune(folding.Replace(b.String()))
+	out := make([]rune, 0, len(folded))
+	for i, r := range folded {
+		if i > 0 && folded[i-1] == r {
+			continue
+		}
+		out = append(out, r)
+	}
+
+	return string(out)
+}
+
+// Similarity is the Jaro-Winkler similarity of the normalized names, 0 when any of them is empty.
+func Similarity(a, b string) float64 {
+	return jaroWinkler([]rune(Normalize(a)), []rune(Normalize(b)))
+}
+
+func jaroWinkler(a, b []rune) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := max(len(a), len(b))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+
+	var matches int
+	for i := range a {
+		from := max(0, i-window)
+		to := min(len(b), i+window+1)
+
+		for j := from; j < to; j++ {
+			if matchedB[j] || a[i] != b[j] {
+				continue
+			}
+
+			matchedA[i] = true
+			matchedB[j] = true
+			matches++
+			break
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	var transpositions, k int
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+
+		for !matchedB[k] {
+			k++
+		}
+
+		if a[i] != b[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	var prefix int
+	for prefix < min(4, len(a), len(b)) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,148 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"github.com/Hvaekar/med-account/cmd/account/worker"
+	"github.com/Hvaekar/med-account/internal/account"
+	"github.com/Hvaekar/med-account/pkg/export"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"testing"
+)
+
+type ExportTestSuite struct {
+	TestSuite
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (s *ExportTestSuite) TestAddExport() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	e, err := s.client.AddExport(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(model.ExportStatusPending, e.Status)
+	s.Nil(e.ReadyAt)
+
+	_, err = s.client.AddExport(s.ctx, s.token.Access)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "409")
+
+	es, err := s.client.GetExports(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(es.Exports, 2)
+	s.Equal(e.ID, es.Exports[0].ID)
+	s.Equal(model.ExportStatusExpired, es.Exports[1].Status)
+}
+
+func (s *ExportTestSuite) TestGetExport() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	e, err := s.client.GetExport(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.ExportStatusExpired, e.Status)
+	s.Equal(int64(20480), *e.Size)
+
+	_, err = s.client.GetExport(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+}
+
+func (s *ExportTestSuite) TestDownloadExportNotReady() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	_, err := s.client.DownloadExport(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	e, err := s.client.AddExport(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	_, err = s.client.DownloadExport(s.ctx, s.token.Access, e.ID)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *ExportTestSuite) TestBuildExport() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	// uploaded files are fetched from s3 directly, so the archive is built without them
+	s.Require().NoError(s.db.TruncateTables(s.ctx, "account_files"))
+
+	e, err := s.client.AddExport(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	var archive bytes.Buffer
+	s.uploaderAPIMock.On("UploadWithContext", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, err := io.Copy(&archive, args.Get(1).(*s3manager.UploadInput).Body)
+			s.Require().NoError(err)
+		}).
+		Return(nil, nil).
+		Once()
+
+	psql := account.NewPostgresStorage(s.db.(*postgres.Postgres))
+	s.Require().NoError(worker.NewAccountExporter(s.log, &s.cfg.Export, psql, s.s3, s.mb).Export(s.ctx))
+
+	e, err = s.client.GetExport(s.ctx, s.token.Access, e.ID)
+	s.Require().NoError(err)
+
+	s.Equal(model.ExportStatusReady, e.Status)
+	s.Equal(int64(archive.Len()), *e.Size)
+	s.NotNil(e.ExpiresAt)
+
+	zr, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	s.Require().NoError(err)
+
+	files := map[string]*zip.File{}
+	for _, v := range zr.File {
+		files[v.Name] = v
+	}
+
+	s.Contains(files, export.IndexName)
+	s.Contains(files, "account.json")
+	s.Contains(files, "privacy.json")
+	s.Contains(files, "specialist.json")
+	s.Contains(files, "files.json")
+	s.Contains(files, "patients/1.json")
+
+	r, err := files["patients/1.json"].Open()
+	s.Require().NoError(err)
+	defer r.Close()
+
+	var p map[string]interface{}
+	s.Require().NoError(json.NewDecoder(r).Decode(&p))
+
+	s.Equal(float64(1), p["id"])
+	s.Equal(model.ExportRelationSelf, p["relation"])
+	s.NotEmpty(p["policies"])
+	s.NotEmpty(p["records"])
+	s.NotEmpty(p["reviews"])
+	s.NotEmpty(p["history"])
+
+	sr, err := files["specialist.json"].Open()
+	s.Require().NoError(err)
+	defer sr.Close()
+
+	var sp map[string]interface{}
+	s.Require().NoError(json.NewDecoder(sr).Decode(&sp))
+
+	s.NotEmpty(sp["schedules"])
+	s.NotEmpty(sp["exceptions"])
+}
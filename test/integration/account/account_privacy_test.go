@@ -0,0 +1,182 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PrivacyTestSuite struct {
+	TestSuite
+}
+
+func TestPrivacySuite(t *testing.T) {
+	suite.Run(t, new(PrivacyTestSuite))
+}
+
+func (s *PrivacyTestSuite) TestGetPrivacySettings() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	ps, err := s.client.GetPrivacySettings(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(model.PrivacyUsers, ps.Names)
+	s.Equal(model.PrivacyOnlyMe, ps.Birthday)
+	s.Equal(model.PrivacySpecialists, ps.Photo)
+	s.Equal(model.PrivacyAdmins, ps.Contacts)
+	s.Equal(model.PrivacyUsers, ps.Languages)
+	s.Equal(model.PrivacyPublic, ps.Specialist)
+}
+
+func (s *PrivacyTestSuite) TestGetPrivacySettingsDefault() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+	s.Require().NoError(s.db.TruncateTables(s.ctx, "account_privacy_settings"))
+
+	ps, err := s.client.GetPrivacySettings(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(model.DefaultPrivacySettings().Names, ps.Names)
+	s.Equal(model.DefaultPrivacySettings().Birthday, ps.Birthday)
+	s.Equal(model.DefaultPrivacySettings().Contacts, ps.Contacts)
+	s.Equal(model.DefaultPrivacySettings().Specialist, ps.Specialist)
+}
+
+func (s *PrivacyTestSuite) TestUpdatePrivacySettings() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdatePrivacySettings{
+		Names:      model.PrivacyPublic,
+		Birthday:   model.PrivacyAdmins,
+		Photo:      model.PrivacyPublic,
+		Contacts:   model.PrivacyOnlyMe,
+		Languages:  model.PrivacySpecialists,
+		Specialist: model.PrivacyUsers,
+	}
+
+	ps, err := s.client.UpdatePrivacySettings(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Names, ps.Names)
+	s.Equal(req.Birthday, ps.Birthday)
+	s.Equal(req.Photo, ps.Photo)
+	s.Equal(req.Contacts, ps.Contacts)
+	s.Equal(req.Languages, ps.Languages)
+	s.Equal(req.Specialist, ps.Specialist)
+}
+
+func (s *PrivacyTestSuite) TestUpdatePrivacySettingsBadReq() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdatePrivacySettings{
+		Names:      "friends",
+		Birthday:   model.PrivacyAdmins,
+		Photo:      model.PrivacyPublic,
+		Contacts:   model.PrivacyOnlyMe,
+		Languages:  model.PrivacySpecialists,
+		Specialist: model.PrivacyUsers,
+	}
+
+	_, err := s.client.UpdatePrivacySettings(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *PrivacyTestSuite) TestGetAccountAsAdmin() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	account, err := s.client.GetAccount(s.ctx, *token, 1)
+	s.Require().NoError(err)
+
+	s.NotNil(account.FirstName)
+	s.NotNil(account.Photo)
+	s.Nil(account.Birthday)
+	s.Require().Len(account.Emails, 1)
+	s.True(account.Emails[0].Open)
+	s.Nil(account.Profiles.Patients)
+}
+
+func (s *PrivacyTestSuite) TestGetAccountAsUser() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	// remove account 2 from admins of patient profile 1
+	s.Require().NoError(s.client.DeleteAdmin(s.ctx, s.token.Access, 2))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	account, err := s.client.GetAccount(s.ctx, *token, 1)
+	s.Require().NoError(err)
+
+	s.NotNil(account.FirstName)
+	s.Nil(account.Photo)
+	s.Nil(account.Birthday)
+	s.Len(account.Emails, 0)
+	s.Len(account.Phones, 0)
+	s.Len(account.Addresses, 0)
+	s.NotEmpty(account.Languages)
+}
+
+func (s *PrivacyTestSuite) TestGetAccountMe() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	account, err := s.client.GetAccount(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.NotNil(account.Birthday)
+	s.Len(account.Emails, 3)
+}
+
+func (s *PrivacyTestSuite) TestGetSpecialistAsSpecialist() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	specialist, err := s.client.GetSpecialist(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Equal(int64(2), specialist.ID)
+	s.Nil(specialist.About)
+}
+
+func (s *PrivacyTestSuite) TestGetPatientAsAdmin() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	patient, err := s.client.GetPatient(s.ctx, *token, 1)
+	s.Require().NoError(err)
+
+	s.NotNil(patient.FirstName)
+	s.NotNil(patient.Phone)
+	s.Nil(patient.Birthday)
+}
@@ -4,8 +4,10 @@ import (
 	"github.com/Hvaekar/med-account/pkg/jwt"
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type ProfileTestSuite struct {
@@ -106,6 +108,221 @@ func (s *ProfileTestSuite) TestDeletePatientProfile() {
 	}
 }
 
+func (s *ProfileTestSuite) TestAddDependentPatientProfile() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddDependentPatient{
+		FirstName: "Child",
+		LastName:  "Account1",
+		Sex:       "woman",
+		Birthday:  pgtype.Date{Time: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	patient, err := s.client.AddDependentPatientProfile(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(patient.ID)
+	s.Equal(req.FirstName, *patient.FirstName)
+	s.Equal(req.Sex, *patient.Sex)
+	s.Equal(req.Birthday.Time, patient.Birthday.Time)
+
+	list, err := s.client.GetProfiles(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Patients, 3)
+	for _, p := range list.Patients {
+		if p.ID == patient.ID {
+			s.Equal(req.FirstName, *p.FirstName)
+			s.True(p.PermissionEdit)
+			s.True(p.Verified)
+		}
+	}
+
+	newToken, _, err := s.client.SelectPatientProfile(s.ctx, s.token.Access, patient.ID)
+	s.Require().NoError(err)
+
+	selected, err := s.client.GetPatientProfile(s.ctx, newToken.Access)
+	s.Require().NoError(err)
+
+	s.Equal(patient.ID, selected.ID)
+	s.Equal(req.LastName, *selected.LastName)
+
+	req.Birthday = pgtype.Date{Time: time.Now().AddDate(1, 0, 0), Valid: true}
+	_, err = s.client.AddDependentPatientProfile(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *ProfileTestSuite) TestUpdateDependentPatientProfile() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddDependentPatient{
+		FirstName: "Child",
+		LastName:  "Account1",
+		Sex:       "woman",
+		Birthday:  pgtype.Date{Time: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	patient, err := s.client.AddDependentPatientProfile(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	fatherName := "Ivanovych"
+	update := model.UpdateDependentPatient{
+		FirstName:  "Son",
+		FatherName: &fatherName,
+		LastName:   "Account1",
+		Sex:        "man",
+		Birthday:   pgtype.Date{Time: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	patient, err = s.client.UpdateDependentPatientProfile(s.ctx, s.token.Access, patient.ID, &update)
+	s.Require().NoError(err)
+
+	s.Equal(update.FirstName, *patient.FirstName)
+	s.Equal(fatherName, *patient.FatherName)
+	s.Equal(update.Sex, *patient.Sex)
+	s.Equal(update.Birthday.Time, patient.Birthday.Time)
+
+	// not an admin of the dependent
+	payload := model.TokenPayload{
+		AccountID: 2,
+		PatientID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	_, err = s.client.UpdateDependentPatientProfile(s.ctx, *token, patient.ID, &update)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+
+	// a profile of a registered account
+	_, err = s.client.UpdateDependentPatientProfile(s.ctx, s.token.Access, 1, &update)
+	s.Require().Error(err)
+
+	update.Birthday = pgtype.Date{Time: time.Now().AddDate(1, 0, 0), Valid: true}
+	_, err = s.client.UpdateDependentPatientProfile(s.ctx, s.token.Access, patient.ID, &update)
+	s.Require().Error(err)
+}
+
+func (s *ProfileTestSuite) TestDeleteDependentPatientProfileWithOtherAdmins() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddDependentPatient{
+		FirstName: "Child",
+		LastName:  "Account1",
+		Sex:       "woman",
+		Birthday:  pgtype.Date{Time: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	patient, err := s.client.AddDependentPatientProfile(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	dependentToken, _, err := s.client.SelectPatientProfile(s.ctx, s.token.Access, patient.ID)
+	s.Require().NoError(err)
+
+	pEdit := true
+	_, err = s.client.AddAdmin(s.ctx, dependentToken.Access, &model.AddAdmin{AdminID: 2, PermissionEdit: &pEdit})
+	s.Require().NoError(err)
+
+	err = s.client.DeletePatientProfile(s.ctx, s.token.Access, patient.ID)
+	s.Require().NoError(err)
+
+	payload := model.TokenPayload{
+		AccountID: 2,
+		PatientID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	// the other admin keeps the profile and takes over the ownership
+	list, err := s.client.GetProfiles(s.ctx, *token)
+	s.Require().NoError(err)
+
+	s.True(containsAccountPatient(list.Patients, patient.ID))
+
+	_, err = s.client.HandOverPatientProfile(s.ctx, *token, patient.ID)
+	s.Require().NoError(err)
+
+	list, err = s.client.GetProfiles(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.False(containsAccountPatient(list.Patients, patient.ID))
+
+	// the last admin removes the profile
+	err = s.client.DeletePatientProfile(s.ctx, *token, patient.ID)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetPatient(s.ctx, *token, patient.ID)
+	s.Require().Error(err)
+}
+
+func (s *ProfileTestSuite) TestHandOverPatientProfile() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddDependentPatient{
+		FirstName: "Child",
+		LastName:  "Account1",
+		Sex:       "man",
+		Birthday:  pgtype.Date{Time: time.Date(2010, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	patient, err := s.client.AddDependentPatientProfile(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	payload := model.TokenPayload{
+		AccountID: 2,
+		PatientID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	_, err = s.client.HandOverPatientProfile(s.ctx, *token, patient.ID)
+	s.Require().Error(err)
+
+	_, err = s.client.HandOverPatientProfile(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+
+	ho, err := s.client.HandOverPatientProfile(s.ctx, s.token.Access, patient.ID)
+	s.Require().NoError(err)
+
+	s.Equal(patient.ID, ho.PatientID)
+	s.Len(ho.Code, 64)
+
+	register := model.RegisterRequest{
+		Login:        "childaccount",
+		Password:     "test_password",
+		HandoverCode: &ho.Code,
+	}
+
+	childToken, _, err := s.client.Register(s.ctx, &register)
+	s.Require().NoError(err)
+
+	child, err := s.client.GetPatientProfile(s.ctx, childToken.Access)
+	s.Require().NoError(err)
+
+	s.Equal(patient.ID, child.ID)
+	s.Equal(req.FirstName, *child.FirstName)
+	s.Equal(req.Birthday.Time, child.Birthday.Time)
+
+	list, err := s.client.GetProfiles(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Patients, 3)
+
+	register.Login = "childaccount2"
+	_, _, err = s.client.Register(s.ctx, &register)
+	s.Require().Error(err)
+
+	_, err = s.client.HandOverPatientProfile(s.ctx, s.token.Access, patient.ID)
+	s.Require().Error(err)
+}
+
 func (s *ProfileTestSuite) TestAddSpecialistProfile() {
 	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
 	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
@@ -179,3 +396,13 @@ func (s *ProfileTestSuite) TestAddSpecialistProfileBadReq() {
 	s.Require().Error(err)
 	s.Equal("unexpected status code: 400, error: invalid input field", err.Error())
 }
+
+func containsAccountPatient(patients []*model.AccountPatient, id int64) bool {
+	for _, v := range patients {
+		if v.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,250 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/fhir"
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	fhirIDRegexp   = regexp.MustCompile(`^[A-Za-z0-9\-.]{1,64}$`)
+	fhirDateRegexp = regexp.MustCompile(`^[0-9]{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12][0-9]|3[01]))?)?$`)
+)
+
+type FHIRTestSuite struct {
+	TestSuite
+}
+
+func TestFHIRSuite(t *testing.T) {
+	suite.Run(t, new(FHIRTestSuite))
+}
+
+func (s *FHIRTestSuite) serviceToken() string {
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	return *token
+}
+
+// validate checks the parts of the FHIR R4 JSON structure our resources use.
+func (s *FHIRTestSuite) validate(r map[string]interface{}) {
+	rt, ok := r["resourceType"].(string)
+	s.Require().True(ok, "resourceType is required")
+
+	if rt != "Bundle" && rt != "OperationOutcome" {
+		s.Regexp(fhirIDRegexp, r["id"])
+	}
+
+	references := func(field string, target string) {
+		ref, ok := r[field].(map[string]interface{})
+		s.Require().True(ok, "%s.%s is required", rt, field)
+		s.Regexp("^"+target+"/[0-9]+$", ref["reference"])
+	}
+
+	identifiers := func() {
+		ids, ok := r["identifier"].([]interface{})
+		s.Require().True(ok, "%s.identifier must be an array", rt)
+		for _, v := range ids {
+			id := v.(map[string]interface{})
+			s.IsType("", id["value"])
+			s.IsType("", id["system"])
+		}
+	}
+
+	switch rt {
+	case "Bundle":
+		s.Equal(fhir.BundleTypeSearchSet, r["type"])
+
+		entries, _ := r["entry"].([]interface{})
+		s.Equal(float64(len(entries)), r["total"])
+
+		for _, v := range entries {
+			e := v.(map[string]interface{})
+			res := e["resource"].(map[string]interface{})
+
+			s.True(strings.HasPrefix(e["fullUrl"].(string), "http"))
+			s.True(strings.HasSuffix(e["fullUrl"].(string), "/"+res["resourceType"].(string)+"/"+res["id"].(string)))
+			s.Equal(fhir.SearchModeMatch, e["search"].(map[string]interface{})["mode"])
+
+			s.validate(res)
+		}
+	case "Patient":
+		identifiers()
+		s.IsType(true, r["active"])
+		if g, ok := r["gender"]; ok {
+			s.Contains([]interface{}{"male", "female", "other", "unknown"}, g)
+		}
+		if d, ok := r["birthDate"]; ok {
+			s.Regexp(fhirDateRegexp, d)
+		}
+		if names, ok := r["name"]; ok {
+			for _, v := range names.([]interface{}) {
+				n := v.(map[string]interface{})
+				if given, ok := n["given"]; ok {
+					s.IsType([]interface{}{}, given)
+				}
+			}
+		}
+		if contacts, ok := r["contact"]; ok {
+			for _, v := range contacts.([]interface{}) {
+				s.NotEmpty(v.(map[string]interface{})["relationship"])
+			}
+		}
+	case "Observation":
+		s.Contains([]interface{}{"registered", "preliminary", "final", "amended"}, r["status"])
+		s.NotEmpty(r["code"].(map[string]interface{})["coding"])
+		references("subject", "Patient")
+
+		_, quantity := r["valueQuantity"]
+		_, concept := r["valueCodeableConcept"]
+		s.True(quantity != concept, "observation must have exactly one value")
+		if quantity {
+			q := r["valueQuantity"].(map[string]interface{})
+			s.IsType(float64(0), q["value"])
+			s.Equal(fhir.SystemUCUM, q["system"])
+		}
+	case "Device":
+		s.Contains([]interface{}{"active", "inactive", "entered-in-error", "unknown"}, r["status"])
+		references("patient", "Patient")
+	case "Practitioner":
+		identifiers()
+		s.IsType(true, r["active"])
+		if qs, ok := r["qualification"]; ok {
+			for _, v := range qs.([]interface{}) {
+				q := v.(map[string]interface{})
+				s.NotNil(q["code"])
+				if p, ok := q["period"]; ok {
+					for _, d := range p.(map[string]interface{}) {
+						s.Regexp(fhirDateRegexp, d)
+					}
+				}
+			}
+		}
+	case "PractitionerRole":
+		references("practitioner", "Practitioner")
+	default:
+		s.Failf("unexpected resource type", "%s", rt)
+	}
+}
+
+func (s *FHIRTestSuite) TestGetPatient() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	r, err := s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient/1", nil)
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Equal("Patient", r["resourceType"])
+	s.Equal("1", r["id"])
+	s.Equal("male", r["gender"])
+	s.Equal("2000-01-01", r["birthDate"])
+	s.Len(r["contact"], 2)
+
+	r, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient/1", url.Values{"_format": {"application/fhir+json"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+
+	_, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient/1", url.Values{"_format": {"xml"}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "406")
+
+	_, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient/999", nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+
+	_, err = s.client.GetFHIR(s.ctx, s.token.Access, "Patient/1", nil)
+	s.Require().Error(err)
+}
+
+func (s *FHIRTestSuite) TestSearchPatients() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	r, err := s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient", url.Values{"identifier": {fhir.SystemPatient + "|2"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Equal(float64(1), r["total"])
+
+	r, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient", url.Values{"identifier": {"urn:other|2"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Equal(float64(0), r["total"])
+
+	r, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient", url.Values{"identifier": {"999"}})
+	s.Require().NoError(err)
+
+	s.Equal(float64(0), r["total"])
+
+	_, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Patient", nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *FHIRTestSuite) TestSearchObservationsAndDevices() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	r, err := s.client.GetFHIR(s.ctx, s.serviceToken(), "Observation", url.Values{"patient": {"Patient/1"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Equal(float64(6), r["total"])
+
+	r, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Observation", url.Values{"subject": {"2"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Equal(float64(0), r["total"])
+
+	r, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Device", url.Values{"patient": {"1"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Equal(float64(2), r["total"])
+}
+
+func (s *FHIRTestSuite) TestPractitioner() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	r, err := s.client.GetFHIR(s.ctx, s.serviceToken(), "Practitioner/1", nil)
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Len(r["identifier"], 3)
+	s.Len(r["qualification"], 2)
+
+	r, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "Practitioner", url.Values{"identifier": {fhir.SystemLicence + "|LC-000003"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+	s.Require().Equal(float64(1), r["total"])
+	s.Equal("2", r["entry"].([]interface{})[0].(map[string]interface{})["resource"].(map[string]interface{})["id"])
+
+	r, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "PractitionerRole", url.Values{"practitioner": {"Practitioner/1"}})
+	s.Require().NoError(err)
+
+	s.validate(r)
+	role := r["entry"].([]interface{})[0].(map[string]interface{})["resource"].(map[string]interface{})
+	s.Len(role["specialty"], 3)
+	s.Equal("2018-01-01", role["period"].(map[string]interface{})["start"])
+
+	_, err = s.client.GetFHIR(s.ctx, s.serviceToken(), "PractitionerRole/999", nil)
+	s.Require().Error(err)
+}
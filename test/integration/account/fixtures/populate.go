@@ -18,21 +18,56 @@ func PopulateDB(c context.Context, db *sql.DB) error {
 		"account_phones.json",
 		"account_addresses.json",
 		"account_languages.json",
+		"account_privacy_settings.json",
+		"account_roles.json",
+		"account_exports.json",
+		"organizations.json",
+		"organization_licences.json",
+		"organization_members.json",
+		"organization_invitations.json",
 		"patient_profiles.json",
 		"accounts_patient_profiles.json",
 		"patient_disability_files.json",
 		"patient_metal_components.json",
+		"patient_metal_component_files.json",
+		"patient_measurements.json",
 		"specialist_profiles.json",
 		"specialist_specializations.json",
 		"specialist_cures_diseases.json",
+		"disease_icd10_codes.json",
 		"specialist_services.json",
 		"specialist_educations.json",
 		"specialist_education_files.json",
+		"specialist_educational_courses.json",
+		"specialist_educational_course_files.json",
 		"specialist_experiences.json",
 		"specialist_experience_specializations.json",
 		"specialist_associations.json",
 		"specialist_patents.json",
 		"specialist_publication_links.json",
+		"specialist_teachings.json",
+		"specialist_speeches.json",
+		"specialist_licences.json",
+		"specialist_verifications.json",
+		"specialist_verification_files.json",
+		"specialist_schedules.json",
+		"specialist_schedule_hours.json",
+		"specialist_schedule_exceptions.json",
+		"specialist_reviews.json",
+		"patient_specialists.json",
+		"patient_allergies.json",
+		"patient_conditions.json",
+		"patient_medications.json",
+		"patient_vaccinations.json",
+		"patient_vaccination_files.json",
+		"patient_emergency_contacts.json",
+		"patient_insurance_policies.json",
+		"patient_insurance_policy_files.json",
+		"patient_family_history.json",
+		"patient_lifestyle_records.json",
+		"patient_lifestyle_hazards.json",
+		"patient_duplicates.json",
+		"patient_history.json",
 	}
 
 	for _, f := range files {
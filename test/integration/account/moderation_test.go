@@ -0,0 +1,158 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ModerationTestSuite struct {
+	TestSuite
+}
+
+func TestModerationSuite(t *testing.T) {
+	suite.Run(t, new(ModerationTestSuite))
+}
+
+func (s *ModerationTestSuite) moderatorToken() string {
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	return *token
+}
+
+func (s *ModerationTestSuite) TestGetVerificationsQueue() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetModerationVerifications(s.ctx, s.moderatorToken(), &model.ListVerificationsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Verifications, 1)
+	s.Equal(int64(1), list.Verifications[0].ID)
+
+	list, err = s.client.GetModerationVerifications(s.ctx, s.moderatorToken(), &model.ListVerificationsRequest{Status: model.VerificationStatusRejected})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Verifications, 1)
+	s.Equal(int64(2), list.Verifications[0].ID)
+}
+
+func (s *ModerationTestSuite) TestGetVerificationsQueueNotModerator() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	_, err := s.client.GetModerationVerifications(s.ctx, s.token.Access, &model.ListVerificationsRequest{})
+	s.Require().Error(err)
+}
+
+func (s *ModerationTestSuite) TestApproveVerification() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	verification, err := s.client.ApproveVerification(s.ctx, s.moderatorToken(), 1, &model.ReviewVerification{})
+	s.Require().NoError(err)
+
+	s.Equal(model.VerificationStatusApproved, verification.Status)
+	s.Require().NotNil(verification.ReviewerID)
+	s.Equal(int64(2), *verification.ReviewerID)
+	s.NotNil(verification.ReviewedAt)
+
+	association, err := s.client.GetAssociation(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.True(association.Verified)
+
+	// already reviewed
+	_, err = s.client.ApproveVerification(s.ctx, s.moderatorToken(), 1, &model.ReviewVerification{})
+	s.Require().Error(err)
+}
+
+func (s *ModerationTestSuite) TestApproveCourseVerification() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddVerification{
+		Entity:   model.VerificationEntityCourse,
+		EntityID: 2,
+		Files:    []int64{1},
+	}
+
+	verification, err := s.client.AddVerification(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	_, err = s.client.ApproveVerification(s.ctx, s.moderatorToken(), verification.ID, &model.ReviewVerification{})
+	s.Require().NoError(err)
+
+	course, err := s.client.GetEducationalCourse(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.True(course.Verified)
+}
+
+func (s *ModerationTestSuite) TestRejectVerification() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	_, err := s.client.RejectVerification(s.ctx, s.moderatorToken(), 1, &model.ReviewVerification{})
+	s.Require().Error(err)
+
+	comment := "Certificate has expired"
+	verification, err := s.client.RejectVerification(s.ctx, s.moderatorToken(), 1, &model.ReviewVerification{Comment: &comment})
+	s.Require().NoError(err)
+
+	s.Equal(model.VerificationStatusRejected, verification.Status)
+	s.Equal(comment, *verification.ReviewerComment)
+
+	association, err := s.client.GetAssociation(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.False(association.Verified)
+}
+
+func (s *ModerationTestSuite) TestAddAccountRoleNotAdmin() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddAccountRole{
+		AccountID: 1,
+		Role:      model.AccountRoleModerator,
+	}
+
+	s.Require().Error(s.client.AddAccountRole(s.ctx, s.moderatorToken(), &req))
+}
+
+func (s *ModerationTestSuite) TestUpdateDiseaseICD10Codes() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	codes, err := s.client.GetDiseaseICD10Codes(s.ctx, s.moderatorToken(), 6)
+	s.Require().NoError(err)
+
+	s.Equal([]string{"J45", "J46"}, codes.Codes)
+
+	codes, err = s.client.UpdateDiseaseICD10Codes(s.ctx, s.moderatorToken(), 6, &model.UpdateDiseaseICD10Codes{Codes: []string{"j450", "J45.1"}})
+	s.Require().NoError(err)
+
+	s.Equal(int64(6), codes.DiseaseID)
+	s.Equal([]string{"J45.0", "J45.1"}, codes.Codes)
+
+	_, err = s.client.UpdateDiseaseICD10Codes(s.ctx, s.moderatorToken(), 6, &model.UpdateDiseaseICD10Codes{Codes: []string{"J45.ZZZZ"}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	_, err = s.client.UpdateDiseaseICD10Codes(s.ctx, s.token.Access, 6, &model.UpdateDiseaseICD10Codes{Codes: []string{"J45"}})
+	s.Require().Error(err)
+
+	_, err = s.client.GetDiseaseICD10Codes(s.ctx, s.moderatorToken(), 7)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+}
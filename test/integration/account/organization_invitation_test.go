@@ -0,0 +1,146 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type OrganizationInvitationTestSuite struct {
+	TestSuite
+}
+
+func TestOrganizationInvitationSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationInvitationTestSuite))
+}
+
+func (s *OrganizationInvitationTestSuite) TestAddInvitation() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	// remove account 2 from members to invite it again
+	s.Require().NoError(s.client.DeleteOrganizationMember(s.ctx, s.token.Access, 1, 2))
+
+	req := model.AddOrganizationInvitation{
+		AccountID: 2,
+		Role:      model.OrganizationRoleSpecialist,
+	}
+
+	invitation, err := s.client.AddOrganizationInvitation(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(invitation.ID)
+	s.Equal(req.AccountID, invitation.AccountID)
+	s.Equal(req.Role, invitation.Role)
+	s.Equal(model.InvitationStatusPending, invitation.Status)
+	s.Equal("Some clinic", invitation.OrganizationName)
+
+	_, err = s.client.AddOrganizationInvitation(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationInvitationTestSuite) TestGetOrganizationInvitations() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	list, err := s.client.GetOrganizationInvitations(s.ctx, *token, 2)
+	s.Require().NoError(err)
+
+	s.Len(list.Invitations, 1)
+
+	_, err = s.client.GetOrganizationInvitations(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationInvitationTestSuite) TestGetAccountInvitations() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetAccountInvitations(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Invitations, 1)
+	s.Equal("Other clinic", list.Invitations[0].OrganizationName)
+}
+
+func (s *OrganizationInvitationTestSuite) TestAcceptInvitation() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	member, err := s.client.AcceptInvitation(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(int64(1), member.AccountID)
+	s.Equal(model.OrganizationRoleSpecialist, member.Role)
+
+	account, err := s.client.GetMe(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(account.Organizations, 2)
+
+	_, err = s.client.AcceptInvitation(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationInvitationTestSuite) TestAcceptInvitationExistingMember() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddOrganizationInvitation{
+		AccountID: 2,
+		Role:      model.OrganizationRoleSpecialist,
+	}
+
+	invitation, err := s.client.AddOrganizationInvitation(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	_, err = s.client.AcceptInvitation(s.ctx, *token, invitation.ID)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "409")
+
+	list, err := s.client.GetAccountInvitations(s.ctx, *token)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Invitations, 1)
+	s.Equal(model.InvitationStatusPending, list.Invitations[0].Status)
+
+	member, err := s.client.GetOrganizationMember(s.ctx, s.token.Access, 1, 2)
+	s.Require().NoError(err)
+
+	s.Equal(model.OrganizationRoleManager, member.Role)
+}
+
+func (s *OrganizationInvitationTestSuite) TestDeclineInvitation() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	invitation, err := s.client.DeclineInvitation(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.InvitationStatusDeclined, invitation.Status)
+
+	list, err := s.client.GetAccountInvitations(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Invitations, 0)
+}
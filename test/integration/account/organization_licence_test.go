@@ -0,0 +1,82 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type OrganizationLicenceTestSuite struct {
+	TestSuite
+}
+
+func TestOrganizationLicenceSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationLicenceTestSuite))
+}
+
+func (s *OrganizationLicenceTestSuite) TestAddLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	issued, err := time.ParseInLocation("2006-01-02", "2023-05-01", time.UTC)
+	s.Require().NoError(err)
+	req := model.AddOrganizationLicence{
+		Number: "LIC-0003",
+		Issued: pgtype.Date{Time: issued, Valid: true},
+	}
+
+	licence, err := s.client.AddOrganizationLicence(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(licence.ID)
+	s.Equal(req.Number, licence.Number)
+	s.Equal(req.Issued, licence.Issued)
+
+	_, err = s.client.AddOrganizationLicence(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+
+	_, err = s.client.AddOrganizationLicence(s.ctx, s.token.Access, 2, &req)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationLicenceTestSuite) TestGetLicences() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetOrganizationLicences(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Len(list.Licences, 2)
+}
+
+func (s *OrganizationLicenceTestSuite) TestUpdateLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	issued, err := time.ParseInLocation("2006-01-02", "2020-01-10", time.UTC)
+	s.Require().NoError(err)
+	description := "Updated licence description"
+	req := model.UpdateOrganizationLicence{
+		Number:      "LIC-0002",
+		Issued:      pgtype.Date{Time: issued, Valid: true},
+		Description: &description,
+	}
+
+	licence, err := s.client.UpdateOrganizationLicence(s.ctx, s.token.Access, 1, 2, &req)
+	s.Require().NoError(err)
+
+	s.Equal(*req.Description, *licence.Description)
+}
+
+func (s *OrganizationLicenceTestSuite) TestDeleteLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.Require().NoError(s.client.DeleteOrganizationLicence(s.ctx, s.token.Access, 1, 1))
+
+	_, err := s.client.GetOrganizationLicence(s.ctx, s.token.Access, 1, 1)
+	s.Require().Error(err)
+}
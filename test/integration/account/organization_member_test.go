@@ -0,0 +1,107 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type OrganizationMemberTestSuite struct {
+	TestSuite
+}
+
+func TestOrganizationMemberSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationMemberTestSuite))
+}
+
+func (s *OrganizationMemberTestSuite) TestGetMembers() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetOrganizationMembers(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Len(list.Members, 2)
+
+	_, err = s.client.GetOrganizationMembers(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationMemberTestSuite) TestGetMember() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	member, err := s.client.GetOrganizationMember(s.ctx, s.token.Access, 1, 2)
+	s.Require().NoError(err)
+
+	s.Equal(int64(2), member.AccountID)
+	s.Equal(model.OrganizationRoleManager, member.Role)
+}
+
+func (s *OrganizationMemberTestSuite) TestUpdateMember() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateOrganizationMember{
+		Role: model.OrganizationRoleReceptionist,
+	}
+
+	member, err := s.client.UpdateOrganizationMember(s.ctx, s.token.Access, 1, 2, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Role, member.Role)
+}
+
+func (s *OrganizationMemberTestSuite) TestUpdateMemberLastOwner() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateOrganizationMember{
+		Role: model.OrganizationRoleManager,
+	}
+
+	_, err := s.client.UpdateOrganizationMember(s.ctx, s.token.Access, 1, 1, &req)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationMemberTestSuite) TestUpdateMemberManagerGrantsOwner() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	req := model.UpdateOrganizationMember{
+		Role: model.OrganizationRoleOwner,
+	}
+
+	_, err = s.client.UpdateOrganizationMember(s.ctx, *token, 1, 2, &req)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationMemberTestSuite) TestDeleteMember() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.Require().NoError(s.client.DeleteOrganizationMember(s.ctx, s.token.Access, 1, 2))
+
+	list, err := s.client.GetOrganizationMembers(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Len(list.Members, 1)
+}
+
+func (s *OrganizationMemberTestSuite) TestDeleteMemberLastOwner() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.Require().Error(s.client.DeleteOrganizationMember(s.ctx, s.token.Access, 1, 1))
+}
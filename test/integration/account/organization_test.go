@@ -0,0 +1,198 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type OrganizationTestSuite struct {
+	TestSuite
+}
+
+func TestOrganizationSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationTestSuite))
+}
+
+func (s *OrganizationTestSuite) TestAddOrganization() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	address := "Another street, 5"
+	var logoID int64 = 2
+	req := model.AddOrganization{
+		Name:       "New clinic",
+		Address:    &address,
+		LogoFileID: &logoID,
+	}
+
+	organization, err := s.client.AddOrganization(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(organization.ID)
+	s.Equal(req.Name, organization.Name)
+	s.Equal(*req.Address, *organization.Address)
+	s.Require().NotNil(organization.Logo)
+	s.Equal(logoID, organization.Logo.ID)
+
+	members, err := s.client.GetOrganizationMembers(s.ctx, s.token.Access, organization.ID)
+	s.Require().NoError(err)
+
+	s.Require().Len(members.Members, 1)
+	s.Equal(int64(1), members.Members[0].AccountID)
+	s.Equal(model.OrganizationRoleOwner, members.Members[0].Role)
+}
+
+func (s *OrganizationTestSuite) TestAddOrganizationForeignLogo() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	var logoID int64 = 1
+	req := model.AddOrganization{
+		Name:       "New clinic",
+		LogoFileID: &logoID,
+	}
+
+	_, err = s.client.AddOrganization(s.ctx, *token, &req)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationTestSuite) TestGetOrganizations() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetOrganizations(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Organizations, 1)
+	s.Equal(int64(1), list.Organizations[0].ID)
+
+	account, err := s.client.GetMe(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(account.Organizations, 1)
+	s.Equal(model.OrganizationRoleOwner, account.Organizations[0].Role)
+}
+
+func (s *OrganizationTestSuite) TestGetOrganization() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	organization, err := s.client.GetOrganization(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("Some clinic", organization.Name)
+	s.Require().NotNil(organization.Logo)
+	s.Equal("example.jpg", organization.Logo.Name)
+	s.Len(organization.Licences, 2)
+
+	// organization profiles are visible to non-members
+	organization, err = s.client.GetOrganization(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Equal("Other clinic", organization.Name)
+	s.Nil(organization.Logo)
+}
+
+func (s *OrganizationTestSuite) TestUpdateOrganization() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	phone := "+380507654321"
+	req := model.UpdateOrganization{
+		Name:  "Some renamed clinic",
+		Phone: &phone,
+	}
+
+	organization, err := s.client.UpdateOrganization(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Name, organization.Name)
+	s.Equal(*req.Phone, *organization.Phone)
+	s.Nil(organization.Logo)
+	s.Nil(organization.Address)
+}
+
+func (s *OrganizationTestSuite) TestUpdateOrganizationNotMember() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateOrganization{
+		Name: "Some renamed clinic",
+	}
+
+	_, err := s.client.UpdateOrganization(s.ctx, s.token.Access, 2, &req)
+	s.Require().Error(err)
+}
+
+func (s *OrganizationTestSuite) TestDeleteOrganization() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.Require().NoError(s.client.DeleteOrganization(s.ctx, s.token.Access, 1))
+
+	_, err := s.client.GetOrganization(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+
+	experience, err := s.client.GetExperience(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Nil(experience.OrganizationID)
+}
+
+func (s *OrganizationTestSuite) TestDeleteOrganizationNotOwner() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	s.Require().Error(s.client.DeleteOrganization(s.ctx, *token, 1))
+}
+
+func (s *OrganizationTestSuite) TestGetOrganizationExperiences() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetOrganizationExperiences(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Experiences, 1)
+	s.Equal(int64(1), list.Experiences[0].ID)
+}
+
+func (s *OrganizationTestSuite) TestAddExperienceForeignOrganization() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var orgID int64 = 2
+	start, err := time.ParseInLocation("2006-01-02", "2020-08-23", time.UTC)
+	s.Require().NoError(err)
+	req := model.AddExperience{
+		OrganizationID: &orgID,
+		Company:        "Other clinic",
+		Start:          pgtype.Date{Time: start, Valid: true},
+	}
+
+	_, err = s.client.AddExperience(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
@@ -0,0 +1,157 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type AllergyTestSuite struct {
+	TestSuite
+}
+
+func TestAllergySuite(t *testing.T) {
+	suite.Run(t, new(AllergyTestSuite))
+}
+
+func (s *AllergyTestSuite) TestAddAllergy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	allergen := "Lactose"
+	req := model.AddAllergy{
+		Allergen:     &allergen,
+		ReactionType: "intolerance",
+		Severity:     model.AllergySeverityModerate,
+	}
+
+	a, err := s.client.AddAllergy(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(a.ID)
+	s.Equal(*req.Allergen, *a.Allergen)
+	s.Equal(req.ReactionType, a.ReactionType)
+	s.Equal(req.Severity, a.Severity)
+	s.False(a.Confirmed)
+
+	req.Allergen = nil
+	_, err = s.client.AddAllergy(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *AllergyTestSuite) TestAddAllergyNoPermission() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: 3,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	var allergenID int64 = 20
+	req := model.AddAllergy{
+		AllergenID:   &allergenID,
+		ReactionType: "respiratory",
+		Severity:     model.AllergySeverityMild,
+	}
+
+	_, err = s.client.AddAllergy(s.ctx, *token, &req)
+	s.Require().Error(err)
+}
+
+func (s *AllergyTestSuite) TestGetAllergies() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetAllergies(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Allergies, 2)
+
+	patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(patient.Allergies, 2)
+}
+
+func (s *AllergyTestSuite) TestGetAllergy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	a, err := s.client.GetAllergy(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.AllergySeverityLifeThreatening, a.Severity)
+	s.True(a.Confirmed)
+	s.Require().NotNil(a.ConfirmedBy)
+	s.Equal(int64(1), *a.ConfirmedBy)
+
+	_, err = s.client.GetAllergy(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *AllergyTestSuite) TestUpdateAllergy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var allergenID int64 = 10
+	req := model.UpdateAllergy{
+		AllergenID:   &allergenID,
+		ReactionType: "skin",
+		Severity:     model.AllergySeveritySevere,
+	}
+
+	a, err := s.client.UpdateAllergy(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.ReactionType, a.ReactionType)
+	s.Nil(a.Allergen)
+	s.False(a.Confirmed)
+	s.Nil(a.ConfirmedBy)
+
+	_, err = s.client.UpdateAllergy(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *AllergyTestSuite) TestDeleteAllergy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteAllergy(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetAllergy(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+
+	err = s.client.DeleteAllergy(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *AllergyTestSuite) TestConfirmAllergy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetPatientAllergies(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Len(list.Allergies, 1)
+
+	a, err := s.client.ConfirmAllergy(s.ctx, s.token.Access, 2, 3)
+	s.Require().NoError(err)
+
+	s.True(a.Confirmed)
+	s.Require().NotNil(a.ConfirmedBy)
+	s.Equal(int64(1), *a.ConfirmedBy)
+	s.NotNil(a.ConfirmedAt)
+
+	_, err = s.client.ConfirmAllergy(s.ctx, s.token.Access, 2, 1)
+	s.Require().Error(err)
+
+	_, err = s.client.ConfirmAllergy(s.ctx, s.token.Access, 1, 1)
+	s.Require().Error(err)
+}
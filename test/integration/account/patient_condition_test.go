@@ -0,0 +1,182 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ConditionTestSuite struct {
+	TestSuite
+}
+
+func TestConditionSuite(t *testing.T) {
+	suite.Run(t, new(ConditionTestSuite))
+}
+
+func (s *ConditionTestSuite) TestAddCondition() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	onset, err := time.ParseInLocation("2006-01-02", "2022-03-15", time.UTC)
+	s.Require().NoError(err)
+	var diseaseID int64 = 4
+	req := model.AddCondition{
+		ICD10:     "m54.5",
+		DiseaseID: &diseaseID,
+		Onset:     &pgtype.Date{Time: onset, Valid: true},
+	}
+
+	cd, err := s.client.AddCondition(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(cd.ID)
+	s.Equal("M54.5", cd.ICD10)
+	s.Equal(model.ConditionStatusActive, cd.Status)
+	s.Equal(req.Onset.Time, cd.Onset.Time)
+	s.Nil(cd.ConfirmedBy)
+
+	req.ICD10 = "X99.1"
+	_, err = s.client.AddCondition(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	req.ICD10 = "hypertension"
+	_, err = s.client.AddCondition(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	for _, code := range []string{"J45.ZZZZ", "Z99.9999", "A09.ZZ", "C97", "I10.0"} {
+		req.ICD10 = code
+		_, err = s.client.AddCondition(s.ctx, s.token.Access, &req)
+		s.Require().Error(err, code)
+		s.Contains(err.Error(), "400")
+	}
+}
+
+func (s *ConditionTestSuite) TestGetConditions() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetConditions(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Conditions, 3)
+	s.Equal(model.ConditionStatusResolved, list.Conditions[2].Status)
+}
+
+func (s *ConditionTestSuite) TestGetCondition() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	cd, err := s.client.GetCondition(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("I10", cd.ICD10)
+	s.Require().NotNil(cd.ConfirmedBy)
+	s.Equal(int64(1), *cd.ConfirmedBy)
+
+	_, err = s.client.GetCondition(s.ctx, s.token.Access, 4)
+	s.Require().Error(err)
+}
+
+func (s *ConditionTestSuite) TestUpdateCondition() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var diseaseID int64 = 1
+	status := model.ConditionStatusResolved
+	req := model.UpdateCondition{
+		ICD10:     "I10",
+		DiseaseID: &diseaseID,
+		Status:    &status,
+	}
+
+	cd, err := s.client.UpdateCondition(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(status, cd.Status)
+	s.NotNil(cd.ConfirmedBy)
+
+	req.ICD10 = "I11.9"
+	cd, err = s.client.UpdateCondition(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Nil(cd.ConfirmedBy)
+
+	_, err = s.client.UpdateCondition(s.ctx, s.token.Access, 4, &req)
+	s.Require().Error(err)
+}
+
+func (s *ConditionTestSuite) TestDeleteCondition() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteCondition(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetCondition(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+
+	err = s.client.DeleteCondition(s.ctx, s.token.Access, 4)
+	s.Require().Error(err)
+}
+
+func (s *ConditionTestSuite) TestGetConditionSpecialists() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetConditionSpecialists(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Specialists, 2)
+	for _, v := range list.Specialists {
+		s.Equal([]int64{1}, v.DiseaseIDs)
+	}
+}
+
+func (s *ConditionTestSuite) TestGetConditionSpecialistsByICD10() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+	moderatorToken, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	// condition 2 is E11.9 with a disease no specialist cures
+	_, err = s.client.UpdateDiseaseICD10Codes(s.ctx, *moderatorToken, 5, &model.UpdateDiseaseICD10Codes{Codes: []string{"e11"}})
+	s.Require().NoError(err)
+
+	list, err := s.client.GetConditionSpecialists(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Specialists, 2)
+	for _, v := range list.Specialists {
+		s.Equal([]int64{1, 5}, v.DiseaseIDs)
+	}
+}
+
+func (s *ConditionTestSuite) TestConfirmCondition() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetPatientConditions(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Len(list.Conditions, 1)
+
+	cd, err := s.client.ConfirmCondition(s.ctx, s.token.Access, 2, 4)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(cd.ConfirmedBy)
+	s.Equal(int64(1), *cd.ConfirmedBy)
+
+	_, err = s.client.ConfirmCondition(s.ctx, s.token.Access, 1, 1)
+	s.Require().Error(err)
+}
@@ -0,0 +1,286 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/cmd/account/worker"
+	"github.com/Hvaekar/med-account/internal/account"
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type DuplicateTestSuite struct {
+	TestSuite
+}
+
+func TestDuplicateSuite(t *testing.T) {
+	suite.Run(t, new(DuplicateTestSuite))
+}
+
+func (s *DuplicateTestSuite) moderatorToken() string {
+	payload := model.TokenPayload{
+		AccountID: 2,
+		PatientID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	return *token
+}
+
+// addDependent creates a dependent of account 1 spelling the name of its own profile in Cyrillic
+func (s *DuplicateTestSuite) addDependent() *model.Patient {
+	fatherName := "Фулл"
+	req := model.AddDependentPatient{
+		FirstName:  "Соме",
+		FatherName: &fatherName,
+		LastName:   "Наме",
+		Sex:        "man",
+		Birthday:   pgtype.Date{Time: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	p, err := s.client.AddDependentPatientProfile(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	return p
+}
+
+func (s *DuplicateTestSuite) detect() {
+	psql := account.NewPostgresStorage(s.db.(*postgres.Postgres))
+	s.Require().NoError(worker.NewDuplicateDetector(s.log, &s.cfg.Duplicate, psql, s.mb).Detect(s.ctx))
+}
+
+func (s *DuplicateTestSuite) findDuplicate(profileID int64, duplicateID int64) *model.PatientDuplicate {
+	list, err := s.client.GetModerationDuplicates(s.ctx, s.moderatorToken(), &model.ListPatientDuplicatesRequest{})
+	s.Require().NoError(err)
+
+	for _, v := range list.Duplicates {
+		if v.ProfileID != nil && *v.ProfileID == profileID && v.DuplicateProfileID != nil && *v.DuplicateProfileID == duplicateID {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func (s *DuplicateTestSuite) TestDetectDuplicates() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	dependent := s.addDependent()
+
+	// same birthday, but another person
+	_, err := s.client.AddDependentPatientProfile(s.ctx, s.token.Access, &model.AddDependentPatient{
+		FirstName: "Petro",
+		LastName:  "Kovalenko",
+		Sex:       "man",
+		Birthday:  pgtype.Date{Time: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	})
+	s.Require().NoError(err)
+
+	s.detect()
+
+	list, err := s.client.GetModerationDuplicates(s.ctx, s.moderatorToken(), &model.ListPatientDuplicatesRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Duplicates, 2)
+
+	d := s.findDuplicate(1, dependent.ID)
+	s.Require().NotNil(d)
+	s.GreaterOrEqual(d.Score, s.cfg.Duplicate.Threshold)
+	s.Equal(1.0, d.NameScore)
+	s.True(d.BirthdayMatch)
+	s.True(d.SexMatch)
+	s.Equal(model.DuplicateStatusPending, d.Status)
+	s.Len(d.Profiles, 2)
+
+	// checked pairs are not reported again
+	s.detect()
+
+	list, err = s.client.GetModerationDuplicates(s.ctx, s.moderatorToken(), &model.ListPatientDuplicatesRequest{})
+	s.Require().NoError(err)
+
+	s.Len(list.Duplicates, 2)
+}
+
+func (s *DuplicateTestSuite) TestDetectDuplicatesChangedProfiles() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	dependent := s.addDependent()
+
+	psql := account.NewPostgresStorage(s.db.(*postgres.Postgres))
+	cfg := s.cfg.Duplicate
+	cfg.ScanOverlap = 0
+
+	// the first run scores every pair, none reaches the threshold
+	cfg.Threshold = 1.1
+	s.Require().NoError(worker.NewDuplicateDetector(s.log, &cfg, psql, s.mb).Detect(s.ctx))
+
+	// the pair is not compared again while both profiles stay the same
+	cfg.Threshold = s.cfg.Duplicate.Threshold
+	s.Require().NoError(worker.NewDuplicateDetector(s.log, &cfg, psql, s.mb).Detect(s.ctx))
+
+	s.Nil(s.findDuplicate(1, dependent.ID))
+
+	fatherName := "Фулл"
+	_, err := s.client.UpdateDependentPatientProfile(s.ctx, s.token.Access, dependent.ID, &model.UpdateDependentPatient{
+		FirstName:  "Соме",
+		FatherName: &fatherName,
+		LastName:   "Наме",
+		Sex:        "man",
+		Birthday:   pgtype.Date{Time: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(worker.NewDuplicateDetector(s.log, &cfg, psql, s.mb).Detect(s.ctx))
+
+	s.NotNil(s.findDuplicate(1, dependent.ID))
+}
+
+func (s *DuplicateTestSuite) TestGetDuplicates() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetModerationDuplicates(s.ctx, s.moderatorToken(), &model.ListPatientDuplicatesRequest{Status: model.DuplicateStatusDismissed})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Duplicates, 1)
+	s.Equal(int64(2), list.Duplicates[0].ID)
+
+	d, err := s.client.GetModerationDuplicate(s.ctx, s.moderatorToken(), 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.DuplicateStatusPending, d.Status)
+	s.Len(d.Profiles, 2)
+
+	_, err = s.client.GetModerationDuplicates(s.ctx, s.token.Access, &model.ListPatientDuplicatesRequest{})
+	s.Require().Error(err)
+}
+
+func (s *DuplicateTestSuite) TestMergeDuplicate() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	dependent := s.addDependent()
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: dependent.ID,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	mc, err := s.client.AddMetalComponent(s.ctx, *token, &model.AddMetalComponent{OrganID: 1})
+	s.Require().NoError(err)
+
+	before, err := s.client.GetMetalComponents(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.detect()
+
+	d := s.findDuplicate(1, dependent.ID)
+	s.Require().NotNil(d)
+
+	_, err = s.client.MergeDuplicate(s.ctx, s.moderatorToken(), d.ID, &model.MergePatientDuplicate{SurvivorID: 2})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	merged, err := s.client.MergeDuplicate(s.ctx, s.moderatorToken(), d.ID, &model.MergePatientDuplicate{SurvivorID: 1})
+	s.Require().NoError(err)
+
+	s.Equal(model.DuplicateStatusMerged, merged.Status)
+	s.Require().NotNil(merged.SurvivorID)
+	s.Equal(int64(1), *merged.SurvivorID)
+	s.Require().NotNil(merged.ReviewerID)
+	s.Equal(int64(2), *merged.ReviewerID)
+	s.Nil(merged.DuplicateProfileID)
+	s.Len(merged.Profiles, 1)
+
+	after, err := s.client.GetMetalComponents(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(after.MetalComponents, len(before.MetalComponents)+1)
+
+	moved, err := s.client.GetMetalComponent(s.ctx, s.token.Access, mc.ID)
+	s.Require().NoError(err)
+
+	s.Equal(mc.ID, moved.ID)
+
+	_, err = s.client.GetPatientProfile(s.ctx, *token)
+	s.Require().Error(err)
+}
+
+func (s *DuplicateTestSuite) TestMergeDuplicateHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	dependent := s.addDependent()
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: dependent.ID,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	mc, err := s.client.AddMetalComponent(s.ctx, *token, &model.AddMetalComponent{OrganID: 1})
+	s.Require().NoError(err)
+
+	s.detect()
+
+	d := s.findDuplicate(1, dependent.ID)
+	s.Require().NotNil(d)
+
+	_, err = s.client.MergeDuplicate(s.ctx, s.moderatorToken(), d.ID, &model.MergePatientDuplicate{SurvivorID: 1})
+	s.Require().NoError(err)
+
+	resource := model.PatientHistoryResourceMetalComponent
+	list, err := s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{Resource: &resource, ResourceID: &mc.ID})
+	s.Require().NoError(err)
+
+	// the history moved with the component is followed by the merge made by the moderator
+	s.Require().Len(list.History, 2)
+	s.Equal(model.PatientHistoryActionCreate, list.History[0].Action)
+	s.Require().NotNil(list.History[0].AccountID)
+	s.Equal(int64(2), *list.History[0].AccountID)
+	s.Require().NotNil(list.History[1].AccountID)
+	s.Equal(int64(1), *list.History[1].AccountID)
+}
+
+func (s *DuplicateTestSuite) TestMergeDuplicateAccount() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	_, err := s.client.MergeDuplicate(s.ctx, s.moderatorToken(), 1, &model.MergePatientDuplicate{SurvivorID: 2})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	_, err = s.client.MergeDuplicate(s.ctx, s.moderatorToken(), 2, &model.MergePatientDuplicate{SurvivorID: 1})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *DuplicateTestSuite) TestDismissDuplicate() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	d, err := s.client.DismissDuplicate(s.ctx, s.moderatorToken(), 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.DuplicateStatusDismissed, d.Status)
+	s.Require().NotNil(d.ReviewerID)
+	s.Equal(int64(2), *d.ReviewerID)
+	s.NotNil(d.ReviewedAt)
+
+	_, err = s.client.DismissDuplicate(s.ctx, s.moderatorToken(), 1)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+}
@@ -0,0 +1,144 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type EmergencyContactTestSuite struct {
+	TestSuite
+}
+
+func TestEmergencyContactSuite(t *testing.T) {
+	suite.Run(t, new(EmergencyContactTestSuite))
+}
+
+func (s *EmergencyContactTestSuite) TestAddEmergencyContact() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddEmergencyContact{
+		Name:     "Friend",
+		Relation: "friend",
+		Phone:    "+380509990000",
+	}
+
+	e, err := s.client.AddEmergencyContact(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(e.ID)
+	s.Equal(req.Name, e.Name)
+	s.Equal(int64(3), e.Priority)
+	s.Nil(e.AccountID)
+
+	var accountID int64 = 999
+	req.AccountID = &accountID
+	_, err = s.client.AddEmergencyContact(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	req.AccountID = nil
+	req.Relation = "neighbour"
+	_, err = s.client.AddEmergencyContact(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *EmergencyContactTestSuite) TestGetEmergencyContacts() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetEmergencyContacts(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.EmergencyContacts, 2)
+	s.Equal(int64(2), list.EmergencyContacts[0].ID)
+
+	p, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(p.EmergencyContacts, 2)
+	s.Equal(int64(2), p.EmergencyContacts[0].ID)
+}
+
+func (s *EmergencyContactTestSuite) TestGetEmergencyContact() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	e, err := s.client.GetEmergencyContact(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("parent", e.Relation)
+	s.Require().NotNil(e.AccountID)
+	s.Equal(int64(2), *e.AccountID)
+
+	_, err = s.client.GetEmergencyContact(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *EmergencyContactTestSuite) TestUpdateEmergencyContact() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var priority int64 = 1
+	req := model.UpdateEmergencyContact{
+		Name:     "Mother",
+		Relation: "parent",
+		Phone:    "+380501112234",
+		Priority: &priority,
+	}
+
+	e, err := s.client.UpdateEmergencyContact(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Phone, e.Phone)
+	s.Equal(priority, e.Priority)
+	s.Nil(e.AccountID)
+
+	req.Priority = nil
+	e, err = s.client.UpdateEmergencyContact(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(priority, e.Priority)
+
+	_, err = s.client.UpdateEmergencyContact(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *EmergencyContactTestSuite) TestDeleteEmergencyContact() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteEmergencyContact(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetEmergencyContact(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+
+	err = s.client.DeleteEmergencyContact(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *EmergencyContactTestSuite) TestGetEmergencySummary() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	summary, err := s.client.GetEmergencySummary(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(int64(1), summary.PatientID)
+	s.Require().Len(summary.EmergencyContacts, 2)
+	s.Equal(int64(2), summary.EmergencyContacts[0].ID)
+	s.Len(summary.Allergies, 2)
+	s.Len(summary.Conditions, 2)
+	s.Len(summary.Medications, 2)
+
+	summary, err = s.client.GetPatientEmergencySummary(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Require().Len(summary.EmergencyContacts, 1)
+	s.Len(summary.Conditions, 1)
+
+	_, err = s.client.GetPatientEmergencySummary(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
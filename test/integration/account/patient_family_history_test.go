@@ -0,0 +1,167 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type FamilyHistoryTestSuite struct {
+	TestSuite
+}
+
+func TestFamilyHistorySuite(t *testing.T) {
+	suite.Run(t, new(FamilyHistoryTestSuite))
+}
+
+func (s *FamilyHistoryTestSuite) TestAddFamilyHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var onsetAge int64 = 40
+	req := model.AddFamilyHistory{
+		Relative: model.FamilyRelativeGrandparent,
+		ICD10:    "c50.9",
+		OnsetAge: &onsetAge,
+		Deceased: true,
+	}
+
+	f, err := s.client.AddFamilyHistory(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(f.ID)
+	s.Equal("C50.9", f.ICD10)
+	s.Equal(req.Relative, f.Relative)
+	s.Equal(onsetAge, *f.OnsetAge)
+	s.True(f.Deceased)
+	s.Nil(f.RelativeProfileID)
+
+	req.ICD10 = "X99.1"
+	_, err = s.client.AddFamilyHistory(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	req.ICD10 = "C50.9"
+	req.Relative = "neighbour"
+	_, err = s.client.AddFamilyHistory(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *FamilyHistoryTestSuite) TestAddFamilyHistoryRelativeProfile() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var relativeID int64 = 3
+	req := model.AddFamilyHistory{
+		Relative:          model.FamilyRelativeChild,
+		RelativeProfileID: &relativeID,
+		ICD10:             "J45.0",
+	}
+
+	f, err := s.client.AddFamilyHistory(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.Equal(relativeID, *f.RelativeProfileID)
+
+	// the account has not verified the administration of profile 2 yet
+	relativeID = 2
+	_, err = s.client.AddFamilyHistory(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	relativeID = 1
+	_, err = s.client.AddFamilyHistory(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *FamilyHistoryTestSuite) TestAddFamilyHistoryNoPermission() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: 3,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	req := model.AddFamilyHistory{
+		Relative: model.FamilyRelativeMother,
+		ICD10:    "I10",
+	}
+
+	_, err = s.client.AddFamilyHistory(s.ctx, *token, &req)
+	s.Require().Error(err)
+}
+
+func (s *FamilyHistoryTestSuite) TestGetFamilyHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetFamilyHistory(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.FamilyHistory, 2)
+
+	patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(patient.FamilyHistory, 2)
+}
+
+func (s *FamilyHistoryTestSuite) TestGetFamilyHistoryEntry() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	f, err := s.client.GetFamilyHistoryEntry(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Equal(model.FamilyRelativeFather, f.Relative)
+	s.True(f.Deceased)
+
+	_, err = s.client.GetFamilyHistoryEntry(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *FamilyHistoryTestSuite) TestUpdateFamilyHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	name := "Type 2 diabetes mellitus"
+	var onsetAge int64 = 55
+	req := model.UpdateFamilyHistory{
+		Relative: model.FamilyRelativeMother,
+		ICD10:    "E11.9",
+		Name:     &name,
+		OnsetAge: &onsetAge,
+	}
+
+	f, err := s.client.UpdateFamilyHistory(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(name, *f.Name)
+	s.Equal(onsetAge, *f.OnsetAge)
+
+	_, err = s.client.UpdateFamilyHistory(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+}
+
+func (s *FamilyHistoryTestSuite) TestDeleteFamilyHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.Require().NoError(s.client.DeleteFamilyHistory(s.ctx, s.token.Access, 1))
+
+	list, err := s.client.GetFamilyHistory(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.FamilyHistory, 1)
+
+	s.Require().Error(s.client.DeleteFamilyHistory(s.ctx, s.token.Access, 3))
+}
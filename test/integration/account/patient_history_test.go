@@ -0,0 +1,179 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type PatientHistoryTestSuite struct {
+	TestSuite
+}
+
+func TestPatientHistorySuite(t *testing.T) {
+	suite.Run(t, new(PatientHistoryTestSuite))
+}
+
+func (s *PatientHistoryTestSuite) TestGetPatientHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.History, 2)
+	s.Equal(int64(2), list.History[0].ID)
+	s.Equal(model.PatientHistoryActionUpdate, list.History[0].Action)
+	s.Equal("moderate", list.History[0].Changes["severity"].Old)
+	s.Equal("mild", list.History[0].Changes["severity"].New)
+
+	resource := model.PatientHistoryResourceAllergy
+	var resourceID int64 = 1
+	list, err = s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{Resource: &resource, ResourceID: &resourceID})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.History, 1)
+	s.Equal(model.PatientHistoryActionCreate, list.History[0].Action)
+}
+
+func (s *PatientHistoryTestSuite) TestGetPatientHistoryNoPermission() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: 3,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetPatientHistory(s.ctx, *token, &model.ListPatientHistoryRequest{})
+	s.Require().Error(err)
+}
+
+func (s *PatientHistoryTestSuite) TestUpdateRecordsHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	allergen := "Cat dander"
+	req := model.UpdateAllergy{
+		Allergen:     &allergen,
+		ReactionType: "respiratory",
+		Severity:     model.AllergySeverityMild,
+	}
+
+	_, err := s.client.UpdateAllergy(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	resource := model.PatientHistoryResourceAllergy
+	var resourceID int64 = 2
+	list, err := s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{Resource: &resource, ResourceID: &resourceID})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.History, 2)
+
+	h := list.History[0]
+	s.Equal(model.PatientHistoryActionUpdate, h.Action)
+	s.Equal(int64(1), *h.AccountID)
+	s.Require().Len(h.Changes, 1)
+	s.Equal("skin", h.Changes["reaction_type"].Old)
+	s.Equal("respiratory", h.Changes["reaction_type"].New)
+	s.Equal("respiratory", h.Snapshot["reaction_type"])
+
+	// the same values again are not a change
+	_, err = s.client.UpdateAllergy(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	list, err = s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{Resource: &resource, ResourceID: &resourceID})
+	s.Require().NoError(err)
+
+	s.Len(list.History, 2)
+}
+
+func (s *PatientHistoryTestSuite) TestAddMeasurementRecordsProfileHistory() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddMeasurement{
+		Type:  model.MeasurementTypeHeight,
+		Value: 185,
+	}
+
+	m, err := s.client.AddMeasurement(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	resource := model.PatientHistoryResourceProfile
+	list, err := s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{Resource: &resource})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.History, 1)
+
+	h := list.History[0]
+	s.Equal(model.PatientHistoryActionUpdate, h.Action)
+	s.Require().NotNil(h.AccountID)
+	s.Equal(int64(1), *h.AccountID)
+	s.Require().Contains(h.Changes, "height")
+	s.Equal(float64(185), h.Changes["height"].New)
+
+	resource = model.PatientHistoryResourceMeasurement
+	list, err = s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{Resource: &resource, ResourceID: &m.ID})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.History, 1)
+	s.Equal(model.PatientHistoryActionCreate, list.History[0].Action)
+}
+
+func (s *PatientHistoryTestSuite) TestGetPatientSnapshot() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	at := time.Date(2023, 2, 15, 0, 0, 0, 0, time.UTC)
+	ps, err := s.client.GetPatientSnapshot(s.ctx, s.token.Access, &model.PatientSnapshotRequest{At: &at})
+	s.Require().NoError(err)
+
+	s.Equal(float64(1), ps.Profile["id"])
+
+	allergies := ps.Resources[model.PatientHistoryResourceAllergy]
+	s.Require().Len(allergies, 2)
+	s.Equal("moderate", allergies[1]["severity"])
+
+	at = time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+	ps, err = s.client.GetPatientSnapshot(s.ctx, s.token.Access, &model.PatientSnapshotRequest{At: &at})
+	s.Require().NoError(err)
+
+	allergies = ps.Resources[model.PatientHistoryResourceAllergy]
+	s.Require().Len(allergies, 1)
+	s.Equal(float64(2), allergies[0]["id"])
+}
+
+func (s *PatientHistoryTestSuite) TestGetPatientSnapshotDeleted() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	at := time.Now()
+
+	s.Require().NoError(s.client.DeleteEmergencyContact(s.ctx, s.token.Access, 1))
+
+	ps, err := s.client.GetPatientSnapshot(s.ctx, s.token.Access, &model.PatientSnapshotRequest{At: &at})
+	s.Require().NoError(err)
+
+	found := false
+	for _, v := range ps.Resources[model.PatientHistoryResourceEmergencyContact] {
+		if v["id"] == float64(1) {
+			found = true
+		}
+	}
+	s.True(found)
+
+	now := time.Now()
+	ps, err = s.client.GetPatientSnapshot(s.ctx, s.token.Access, &model.PatientSnapshotRequest{At: &now})
+	s.Require().NoError(err)
+
+	for _, v := range ps.Resources[model.PatientHistoryResourceEmergencyContact] {
+		s.NotEqual(float64(1), v["id"])
+	}
+}
@@ -0,0 +1,179 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type InsuranceTestSuite struct {
+	TestSuite
+}
+
+func TestInsuranceSuite(t *testing.T) {
+	suite.Run(t, new(InsuranceTestSuite))
+}
+
+func (s *InsuranceTestSuite) serviceToken() string {
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	return *token
+}
+
+func (s *InsuranceTestSuite) date(value string) pgtype.Date {
+	t, err := time.ParseInLocation("2006-01-02", value, time.UTC)
+	s.Require().NoError(err)
+
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+func (s *InsuranceTestSuite) TestAddInsurancePolicy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddInsurancePolicy{
+		Insurer:      "ARX",
+		PolicyNumber: "VHI-2024-12",
+		CoverageType: model.InsuranceCoverageVoluntary,
+		ValidFrom:    s.date("2024-01-01"),
+		Files:        []int64{2},
+	}
+
+	in, err := s.client.AddInsurancePolicy(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(in.ID)
+	s.Equal(req.PolicyNumber, in.PolicyNumber)
+	s.Nil(in.ValidTo)
+	s.Require().Len(in.Files, 1)
+
+	req.PolicyNumber = "UA-0002"
+	req.CoverageType = model.InsuranceCoverageCompulsory
+	req.Files = nil
+	_, err = s.client.AddInsurancePolicy(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	validTo := s.date("2022-07-01")
+	req.CoverageType = model.InsuranceCoverageVoluntary
+	req.ValidFrom = s.date("2022-06-01")
+	req.ValidTo = &validTo
+	_, err = s.client.AddInsurancePolicy(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	validTo = s.date("2019-12-31")
+	req.PolicyNumber = "VHI-2022-77"
+	req.ValidFrom = s.date("2019-01-01")
+	_, err = s.client.AddInsurancePolicy(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	validTo = s.date("2018-12-31")
+	_, err = s.client.AddInsurancePolicy(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	validTo = s.date("2019-12-31")
+	req.PolicyNumber = "VHI-2019-1"
+	req.Files = []int64{3}
+	_, err = s.client.AddInsurancePolicy(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *InsuranceTestSuite) TestGetInsurancePolicies() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetInsurancePolicies(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Policies, 2)
+	s.Equal(int64(2), list.Policies[0].ID)
+	s.Len(list.Policies[1].Files, 1)
+}
+
+func (s *InsuranceTestSuite) TestGetInsurancePolicy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	in, err := s.client.GetInsurancePolicy(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.InsuranceCoverageCompulsory, in.CoverageType)
+
+	_, err = s.client.GetInsurancePolicy(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *InsuranceTestSuite) TestUpdateInsurancePolicy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	validTo := s.date("2023-12-31")
+	req := model.UpdateInsurancePolicy{
+		Insurer:      "ARX",
+		PolicyNumber: "VHI-2022-77",
+		CoverageType: model.InsuranceCoverageVoluntary,
+		ValidFrom:    s.date("2022-01-01"),
+		ValidTo:      &validTo,
+	}
+
+	in, err := s.client.UpdateInsurancePolicy(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(in.ValidTo)
+	s.Equal(validTo.Time, in.ValidTo.Time)
+
+	req.CoverageType = model.InsuranceCoverageCompulsory
+	_, err = s.client.UpdateInsurancePolicy(s.ctx, s.token.Access, 2, &req)
+	s.Require().Error(err)
+
+	req.CoverageType = model.InsuranceCoverageVoluntary
+	_, err = s.client.UpdateInsurancePolicy(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *InsuranceTestSuite) TestDeleteInsurancePolicy() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteInsurancePolicy(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetInsurancePolicy(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+
+	err = s.client.DeleteInsurancePolicy(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *InsuranceTestSuite) TestGetActiveCoverage() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetActiveCoverage(s.ctx, s.serviceToken(), 1)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Policies, 1)
+	s.Equal(int64(1), list.Policies[0].ID)
+	s.Equal(int64(1), list.Policies[0].PatientID)
+
+	list, err = s.client.GetActiveCoverage(s.ctx, s.serviceToken(), 2)
+	s.Require().NoError(err)
+
+	s.Empty(list.Policies)
+
+	_, err = s.client.GetActiveCoverage(s.ctx, s.serviceToken(), 999)
+	s.Require().Error(err)
+
+	_, err = s.client.GetActiveCoverage(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+}
@@ -0,0 +1,220 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type LifestyleTestSuite struct {
+	TestSuite
+}
+
+func TestLifestyleSuite(t *testing.T) {
+	suite.Run(t, new(LifestyleTestSuite))
+}
+
+func (s *LifestyleTestSuite) TestAddLifestyleRecord() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	status := model.SmokingStatusFormer
+	packYears := 6.5
+	var alcohol int64 = 4
+	req := model.AddLifestyleRecord{
+		SmokingStatus:       &status,
+		PackYears:           &packYears,
+		AlcoholUnits:        &alcohol,
+		OccupationalHazards: []string{model.OccupationalHazardScreenWork, model.OccupationalHazardProlongedSitting},
+	}
+
+	r, err := s.client.AddLifestyleRecord(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(r.ID)
+	s.True(r.EffectiveDate.Valid)
+	s.Equal(status, *r.SmokingStatus)
+	s.Equal(packYears, *r.PackYears)
+	s.Equal(alcohol, *r.AlcoholUnits)
+	s.Nil(r.Diet)
+	s.ElementsMatch(req.OccupationalHazards, r.OccupationalHazards)
+	s.Require().NotNil(r.EnteredBy)
+	s.Equal(int64(1), *r.EnteredBy)
+
+	current, err := s.client.GetCurrentLifestyle(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(current.Smoking)
+	s.Equal(packYears, *current.Smoking.PackYears)
+	s.Require().NotNil(current.ActivityMinutes)
+	s.Equal(int64(150), current.ActivityMinutes.Value)
+	s.Require().NotNil(current.Diet)
+	s.Equal(model.DietOmnivore, current.Diet.Type)
+}
+
+func (s *LifestyleTestSuite) TestAddLifestyleRecordInvalid() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	_, err := s.client.AddLifestyleRecord(s.ctx, s.token.Access, &model.AddLifestyleRecord{})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	status := model.SmokingStatusNever
+	packYears := 2.0
+	_, err = s.client.AddLifestyleRecord(s.ctx, s.token.Access, &model.AddLifestyleRecord{SmokingStatus: &status, PackYears: &packYears})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	_, err = s.client.AddLifestyleRecord(s.ctx, s.token.Access, &model.AddLifestyleRecord{
+		OccupationalHazards: []string{model.OccupationalHazardNone, model.OccupationalHazardNoise},
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	_, err = s.client.AddLifestyleRecord(s.ctx, s.token.Access, &model.AddLifestyleRecord{OccupationalHazards: []string{"asbestos"}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	diet := model.DietVegan
+	future := pgtype.Date{Time: time.Now().AddDate(0, 0, 2), Valid: true}
+	_, err = s.client.AddLifestyleRecord(s.ctx, s.token.Access, &model.AddLifestyleRecord{Diet: &diet, EffectiveDate: &future})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *LifestyleTestSuite) TestAddLifestyleRecordNoPermission() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: 3,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	diet := model.DietVegan
+	_, err = s.client.AddLifestyleRecord(s.ctx, *token, &model.AddLifestyleRecord{Diet: &diet})
+	s.Require().Error(err)
+}
+
+func (s *LifestyleTestSuite) TestGetLifestyleRecords() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetLifestyleRecords(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Records, 2)
+	s.Equal(int64(2), list.Records[0].ID)
+	s.ElementsMatch([]string{model.OccupationalHazardDust, model.OccupationalHazardNoise}, list.Records[1].OccupationalHazards)
+}
+
+func (s *LifestyleTestSuite) TestGetCurrentLifestyle() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	current, err := s.client.GetCurrentLifestyle(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(current.Smoking)
+	s.Equal(model.SmokingStatusFormer, current.Smoking.Status)
+	s.Equal(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), current.Smoking.EffectiveDate.Time)
+
+	// values missing from the latest record are taken from the earlier one
+	s.Require().NotNil(current.AlcoholUnits)
+	s.Equal(int64(10), current.AlcoholUnits.Value)
+	s.Equal(time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC), current.AlcoholUnits.EffectiveDate.Time)
+	s.Require().NotNil(current.OccupationalHazards)
+	s.Len(current.OccupationalHazards.Hazards, 2)
+
+	patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(patient.CurrentLifestyle)
+	s.Equal(model.SmokingStatusFormer, patient.CurrentLifestyle.Smoking.Status)
+}
+
+func (s *LifestyleTestSuite) TestGetLifestyleRecord() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	r, err := s.client.GetLifestyleRecord(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.SmokingStatusCurrent, *r.SmokingStatus)
+	s.Equal(5.5, *r.PackYears)
+
+	_, err = s.client.GetLifestyleRecord(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *LifestyleTestSuite) TestUpdateLifestyleRecord() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	date := pgtype.Date{Time: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	status := model.SmokingStatusFormer
+	var activity int64 = 90
+	req := model.UpdateLifestyleRecord{
+		EffectiveDate:   &date,
+		SmokingStatus:   &status,
+		ActivityMinutes: &activity,
+	}
+
+	r, err := s.client.UpdateLifestyleRecord(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Equal(date.Time, r.EffectiveDate.Time)
+	s.Nil(r.PackYears)
+	s.Equal(activity, *r.ActivityMinutes)
+
+	resource := model.PatientHistoryResourceLifestyle
+	history, err := s.client.GetPatientHistory(s.ctx, s.token.Access, &model.ListPatientHistoryRequest{Resource: &resource})
+	s.Require().NoError(err)
+
+	s.Len(history.History, 1)
+
+	_, err = s.client.UpdateLifestyleRecord(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+}
+
+func (s *LifestyleTestSuite) TestDeleteLifestyleRecord() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.Require().NoError(s.client.DeleteLifestyleRecord(s.ctx, s.token.Access, 1))
+
+	list, err := s.client.GetLifestyleRecords(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Records, 1)
+
+	s.Require().Error(s.client.DeleteLifestyleRecord(s.ctx, s.token.Access, 3))
+}
+
+func (s *LifestyleTestSuite) TestGetPatientLifestyle() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetPatientLifestyleRecords(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Len(list.Records, 1)
+
+	current, err := s.client.GetPatientCurrentLifestyle(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(current.OccupationalHazards)
+	s.Empty(current.OccupationalHazards.Hazards)
+
+	_, err = s.client.GetPatientLifestyleRecords(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+}
@@ -0,0 +1,221 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type MeasurementTestSuite struct {
+	TestSuite
+}
+
+func TestMeasurementSuite(t *testing.T) {
+	suite.Run(t, new(MeasurementTestSuite))
+}
+
+func (s *MeasurementTestSuite) TestAddMeasurement() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	unit := "m"
+	req := model.AddMeasurement{
+		Type:  model.MeasurementTypeHeight,
+		Value: 1.85,
+		Unit:  &unit,
+	}
+
+	m, err := s.client.AddMeasurement(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(m.ID)
+	s.Equal(float64(185), m.Value)
+	s.Equal("cm", m.Unit)
+	s.Equal(model.MeasurementSourceManual, m.Source)
+	s.Require().NotNil(m.EnteredBy)
+	s.Equal(int64(1), *m.EnteredBy)
+
+	patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(float64(185), *patient.Height)
+}
+
+func (s *MeasurementTestSuite) TestAddMeasurementInPast() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	measuredAt := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+	req := model.AddMeasurement{
+		Type:       model.MeasurementTypeWeight,
+		Value:      90,
+		MeasuredAt: &measuredAt,
+	}
+
+	_, err := s.client.AddMeasurement(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(float64(80), *patient.Weight)
+}
+
+func (s *MeasurementTestSuite) TestAddMeasurementInvalid() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	unit := "lb"
+	req := model.AddMeasurement{
+		Type:  model.MeasurementTypeHeight,
+		Value: 180,
+		Unit:  &unit,
+	}
+
+	_, err := s.client.AddMeasurement(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	req = model.AddMeasurement{
+		Type:  model.MeasurementTypeWeight,
+		Value: 700,
+	}
+
+	_, err = s.client.AddMeasurement(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	measuredAt := time.Now().Add(24 * time.Hour)
+	req = model.AddMeasurement{
+		Type:       model.MeasurementTypeWeight,
+		Value:      80,
+		MeasuredAt: &measuredAt,
+	}
+
+	_, err = s.client.AddMeasurement(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *MeasurementTestSuite) TestGetMeasurements() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetMeasurements(s.ctx, s.token.Access, &model.ListMeasurementsRequest{})
+	s.Require().NoError(err)
+
+	s.Len(list.Measurements, 5)
+
+	t := model.MeasurementTypeWeight
+	from := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	list, err = s.client.GetMeasurements(s.ctx, s.token.Access, &model.ListMeasurementsRequest{Type: &t, From: &from})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Measurements, 2)
+	s.Equal(int64(5), list.Measurements[0].ID)
+	s.Equal(int64(4), list.Measurements[1].ID)
+}
+
+func (s *MeasurementTestSuite) TestGetMeasurementChart() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.MeasurementChartRequest{
+		Type:     model.MeasurementTypeWeight,
+		Interval: "year",
+	}
+
+	chart, err := s.client.GetMeasurementChart(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.Equal("kg", chart.Unit)
+	s.Require().Len(chart.Points, 2)
+	s.Equal(int64(1), chart.Points[0].Count)
+	s.Equal(float64(82), chart.Points[0].Last)
+	s.Equal(int64(2), chart.Points[1].Count)
+	s.Equal(float64(80), chart.Points[1].Min)
+	s.Equal(float64(81), chart.Points[1].Max)
+	s.Equal(80.5, chart.Points[1].Avg)
+	s.Equal(float64(80), chart.Points[1].Last)
+
+	req.Type = "pulse"
+	_, err = s.client.GetMeasurementChart(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *MeasurementTestSuite) TestGetMeasurement() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	m, err := s.client.GetMeasurement(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(float64(178), m.Value)
+	s.Equal(model.MeasurementSourceClinic, m.Source)
+
+	_, err = s.client.GetMeasurement(s.ctx, s.token.Access, 6)
+	s.Require().Error(err)
+}
+
+func (s *MeasurementTestSuite) TestDeleteMeasurement() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteMeasurement(s.ctx, s.token.Access, 5)
+	s.Require().NoError(err)
+
+	patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(float64(81), *patient.Weight)
+
+	err = s.client.DeleteMeasurement(s.ctx, s.token.Access, 6)
+	s.Require().Error(err)
+}
+
+func (s *MeasurementTestSuite) TestUpdatePatientProfileAddsMeasurements() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	height := 181.5
+	weight := 80.0
+	req := model.UpdatePatientProfile{
+		Height: &height,
+		Weight: &weight,
+	}
+
+	_, err := s.client.UpdatePatient(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	t := model.MeasurementTypeHeight
+	list, err := s.client.GetMeasurements(s.ctx, s.token.Access, &model.ListMeasurementsRequest{Type: &t})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Measurements, 3)
+	s.Equal(height, list.Measurements[0].Value)
+	s.Equal(model.MeasurementSourceProfile, list.Measurements[0].Source)
+
+	t = model.MeasurementTypeWeight
+	list, err = s.client.GetMeasurements(s.ctx, s.token.Access, &model.ListMeasurementsRequest{Type: &t})
+	s.Require().NoError(err)
+
+	s.Len(list.Measurements, 3)
+}
+
+func (s *MeasurementTestSuite) TestGetPatientMeasurementsSpecialist() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetPatientMeasurements(s.ctx, s.token.Access, 2, &model.ListMeasurementsRequest{})
+	s.Require().NoError(err)
+
+	s.Len(list.Measurements, 1)
+
+	chart, err := s.client.GetPatientMeasurementChart(s.ctx, s.token.Access, 2, &model.MeasurementChartRequest{Type: model.MeasurementTypeWeight})
+	s.Require().NoError(err)
+
+	s.Equal("day", chart.Interval)
+	s.Len(chart.Points, 1)
+
+	_, err = s.client.GetPatientMeasurements(s.ctx, s.token.Access, 3, &model.ListMeasurementsRequest{})
+	s.Require().Error(err)
+}
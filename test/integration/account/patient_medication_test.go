@@ -0,0 +1,172 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type MedicationTestSuite struct {
+	TestSuite
+}
+
+func TestMedicationSuite(t *testing.T) {
+	suite.Run(t, new(MedicationTestSuite))
+}
+
+func (s *MedicationTestSuite) TestAddMedication() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	start, err := time.ParseInLocation("2006-01-02", "2024-03-01", time.UTC)
+	s.Require().NoError(err)
+	substance := "ibuprofen"
+	var prescribedBy int64 = 2
+	req := model.AddMedication{
+		Name:            "Nurofen",
+		ActiveSubstance: &substance,
+		Dose:            "200 mg",
+		Frequency:       "twice a day",
+		Start:           pgtype.Date{Time: start, Valid: true},
+		PrescribedBy:    &prescribedBy,
+	}
+
+	m, err := s.client.AddMedication(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(m.ID)
+	s.Equal(req.Name, m.Name)
+	s.Equal(start, m.Start.Time)
+	s.Nil(m.Finish)
+	s.Require().NotNil(m.PrescribedBy)
+	s.Equal(prescribedBy, *m.PrescribedBy)
+
+	req.Finish = &pgtype.Date{Time: start.AddDate(0, 0, -1), Valid: true}
+	_, err = s.client.AddMedication(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+
+	req.Finish = nil
+	prescribedBy = 999
+	_, err = s.client.AddMedication(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *MedicationTestSuite) TestGetMedications() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetMedications(s.ctx, s.token.Access, &model.ListMedicationsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Medications, 3)
+	s.Equal(int64(1), list.Medications[0].ID)
+
+	status := model.MedicationStatusCurrent
+	list, err = s.client.GetMedications(s.ctx, s.token.Access, &model.ListMedicationsRequest{Status: &status})
+	s.Require().NoError(err)
+
+	s.Len(list.Medications, 2)
+
+	status = model.MedicationStatusStopped
+	list, err = s.client.GetMedications(s.ctx, s.token.Access, &model.ListMedicationsRequest{Status: &status})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Medications, 1)
+	s.Equal(int64(3), list.Medications[0].ID)
+	s.Require().NotNil(list.Medications[0].StopReason)
+}
+
+func (s *MedicationTestSuite) TestGetMedication() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	m, err := s.client.GetMedication(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("Lisinopril", m.Name)
+
+	_, err = s.client.GetMedication(s.ctx, s.token.Access, 4)
+	s.Require().Error(err)
+}
+
+func (s *MedicationTestSuite) TestUpdateMedication() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	start, err := time.ParseInLocation("2006-01-02", "2023-05-01", time.UTC)
+	s.Require().NoError(err)
+	req := model.UpdateMedication{
+		Name:      "Metformin",
+		Dose:      "1000 mg",
+		Frequency: "once a day",
+		Start:     pgtype.Date{Time: start, Valid: true},
+	}
+
+	m, err := s.client.UpdateMedication(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Dose, m.Dose)
+	s.Nil(m.ActiveSubstance)
+
+	_, err = s.client.UpdateMedication(s.ctx, s.token.Access, 4, &req)
+	s.Require().Error(err)
+}
+
+func (s *MedicationTestSuite) TestStopMedication() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	reason := "side effects"
+	m, err := s.client.StopMedication(s.ctx, s.token.Access, 2, &model.StopMedication{Reason: &reason})
+	s.Require().NoError(err)
+
+	s.Require().NotNil(m.Finish)
+	s.Require().NotNil(m.StopReason)
+	s.Equal(reason, *m.StopReason)
+
+	status := model.MedicationStatusCurrent
+	list, err := s.client.GetMedications(s.ctx, s.token.Access, &model.ListMedicationsRequest{Status: &status})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Medications, 1)
+	s.Equal(int64(1), list.Medications[0].ID)
+
+	finish, err := time.ParseInLocation("2006-01-02", "2020-01-01", time.UTC)
+	s.Require().NoError(err)
+	_, err = s.client.StopMedication(s.ctx, s.token.Access, 1, &model.StopMedication{Finish: &pgtype.Date{Time: finish, Valid: true}})
+	s.Require().Error(err)
+
+	_, err = s.client.StopMedication(s.ctx, s.token.Access, 4, &model.StopMedication{})
+	s.Require().Error(err)
+}
+
+func (s *MedicationTestSuite) TestDeleteMedication() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteMedication(s.ctx, s.token.Access, 3)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetMedication(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+
+	err = s.client.DeleteMedication(s.ctx, s.token.Access, 4)
+	s.Require().Error(err)
+}
+
+func (s *MedicationTestSuite) TestGetPatientMedications() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetPatientMedications(s.ctx, s.token.Access, 2, &model.ListMedicationsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Medications, 1)
+	s.Equal("Ventolin", list.Medications[0].Name)
+
+	_, err = s.client.GetPatientMedications(s.ctx, s.token.Access, 3, &model.ListMedicationsRequest{})
+	s.Require().Error(err)
+}
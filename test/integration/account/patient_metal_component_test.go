@@ -4,8 +4,10 @@ import (
 	"github.com/Hvaekar/med-account/pkg/jwt"
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type MetalComponentTestSuite struct {
@@ -171,3 +173,129 @@ func (s *MetalComponentTestSuite) TestDeleteMetalComponentNoPermission() {
 
 	s.Len(list.MetalComponents, 1)
 }
+
+func (s *MetalComponentTestSuite) TestAddImplant() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	manufacturer := "Cochlear"
+	deviceModel := "Nucleus CI632"
+	safety := model.MRIConditional
+	conditions := "1.5T only with the magnet removed"
+	implanted := pgtype.Date{Time: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	req := model.AddMetalComponent{
+		Type:          model.ImplantTypeCochlearImplant,
+		OrganID:       5,
+		Manufacturer:  &manufacturer,
+		Model:         &deviceModel,
+		Implanted:     &implanted,
+		MRISafety:     &safety,
+		MRIConditions: &conditions,
+		Files:         []int64{1},
+	}
+
+	mc, err := s.client.AddMetalComponent(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.Equal(model.ImplantTypeCochlearImplant, mc.Type)
+	s.Equal(manufacturer, *mc.Manufacturer)
+	s.Equal(deviceModel, *mc.Model)
+	s.Equal("2021-06-01", mc.Implanted.Time.Format("2006-01-02"))
+	s.Equal(model.MRIConditional, *mc.MRISafety)
+	s.Equal(conditions, *mc.MRIConditions)
+	s.Require().Len(mc.Files, 1)
+	s.Equal(int64(1), mc.Files[0].ID)
+
+	req = model.AddMetalComponent{OrganID: 5}
+
+	mc, err = s.client.AddMetalComponent(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.Equal(model.ImplantTypeOther, mc.Type)
+	s.Nil(mc.MRISafety)
+}
+
+func (s *MetalComponentTestSuite) TestAddImplantBadReq() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	safety := model.MRIConditional
+	req := model.AddMetalComponent{
+		Type:      model.ImplantTypePacemaker,
+		OrganID:   3,
+		MRISafety: &safety,
+	}
+
+	_, err := s.client.AddMetalComponent(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	implanted := pgtype.Date{Time: time.Now().AddDate(0, 1, 0), Valid: true}
+	req = model.AddMetalComponent{OrganID: 3, Implanted: &implanted}
+
+	_, err = s.client.AddMetalComponent(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	req = model.AddMetalComponent{Type: "magnet", OrganID: 3}
+
+	_, err = s.client.AddMetalComponent(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *MetalComponentTestSuite) TestGetMRIScreening() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	ms, err := s.client.GetMRIScreening(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(model.MRIScreeningConditional, ms.Status)
+	s.Len(ms.Safe, 1)
+	s.Require().Len(ms.Conditional, 1)
+	s.NotNil(ms.Conditional[0].MRIConditions)
+	s.Len(ms.Conditional[0].Files, 1)
+
+	_, err = s.client.AddMetalComponent(s.ctx, s.token.Access, &model.AddMetalComponent{Type: model.ImplantTypeForeignBody, OrganID: 7})
+	s.Require().NoError(err)
+
+	ms, err = s.client.GetMRIScreening(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(model.MRIScreeningUnknown, ms.Status)
+	s.Len(ms.Unknown, 1)
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: 3,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	ms, err = s.client.GetMRIScreening(s.ctx, *token)
+	s.Require().NoError(err)
+
+	s.Equal(model.MRIScreeningUnknown, ms.Status)
+}
+
+func (s *MetalComponentTestSuite) TestGetPatientMRIScreening() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	ms, err := s.client.GetPatientMRIScreening(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Equal(model.MRIScreeningContraindicated, ms.Status)
+	s.Require().Len(ms.Unsafe, 1)
+	s.Equal(model.ImplantTypeAneurysmClip, ms.Unsafe[0].Type)
+
+	list, err := s.client.GetPatientMetalComponents(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Len(list.MetalComponents, 1)
+
+	_, err = s.client.GetPatientMRIScreening(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
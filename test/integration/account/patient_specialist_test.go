@@ -0,0 +1,93 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PatientSpecialistTestSuite struct {
+	TestSuite
+}
+
+func TestPatientSpecialistSuite(t *testing.T) {
+	suite.Run(t, new(PatientSpecialistTestSuite))
+}
+
+func (s *PatientSpecialistTestSuite) TestAddPatientSpecialist() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddPatientSpecialist{
+		SpecialistID: 2,
+	}
+
+	specialist, err := s.client.AddPatientSpecialist(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.SpecialistID, specialist.ID)
+}
+
+func (s *PatientSpecialistTestSuite) TestAddPatientSpecialistNotFound() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddPatientSpecialist{
+		SpecialistID: 100,
+	}
+
+	_, err := s.client.AddPatientSpecialist(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Equal("unexpected status code: 500, error: foreign key constraint fail", err.Error())
+}
+
+func (s *PatientSpecialistTestSuite) TestGetPatientSpecialists() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	list, err := s.client.GetPatientSpecialists(s.ctx, *token)
+	s.Require().NoError(err)
+	s.Require().Len(list.Specialists, 1)
+	s.Equal(int64(1), list.Specialists[0].ID)
+	s.Equal("Some", *list.Specialists[0].FirstName)
+}
+
+func (s *PatientSpecialistTestSuite) TestGetPatientSpecialist() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	_, err := s.client.GetPatientSpecialist(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+	s.Equal("unexpected status code: 404, error: not found", err.Error())
+}
+
+func (s *PatientSpecialistTestSuite) TestDeletePatientSpecialist() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.client.DeletePatientSpecialist(s.ctx, *token, 1))
+
+	list, err := s.client.GetPatientSpecialists(s.ctx, *token)
+	s.Require().NoError(err)
+	s.Len(list.Specialists, 0)
+}
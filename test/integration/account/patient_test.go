@@ -1,11 +1,14 @@
 package account
 
 import (
+	"github.com/Hvaekar/med-account/pkg/health"
 	"github.com/Hvaekar/med-account/pkg/jwt"
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type PatientTestSuite struct {
@@ -26,6 +29,65 @@ func (s *PatientTestSuite) TestGetPatientProfile() {
 	s.Equal(int64(1), patient.ID)
 }
 
+func (s *PatientTestSuite) TestGetPatientProfileIndicators() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(patient.Indicators)
+	s.Equal(health.AgeMonths(patient.Birthday.Time, time.Now())/12, *patient.Indicators.Age)
+	s.Equal(24.7, *patient.Indicators.BMI)
+	s.Equal(health.BMINormal, *patient.Indicators.BMICategory)
+	s.Nil(patient.Indicators.HeightForAge)
+}
+
+func (s *PatientTestSuite) TestGetPatientProfileChildIndicators() {
+	// WHO medians for boys, ages between and on the rows of the yearly tables
+	cases := []struct {
+		months int
+		height float64
+		weight float64
+	}{
+		{months: 6, height: 67.6236, weight: 7.934},
+		{months: 18, height: 82.2587, weight: 10.9385},
+		{months: 30, height: 91.9327, weight: 13.3},
+		{months: 36, height: 96.0835, weight: 14.3429},
+	}
+
+	for _, v := range cases {
+		s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+		s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+		now := time.Now().UTC()
+		birthday := time.Date(now.Year(), now.Month()-time.Month(v.months), 1, 0, 0, 0, 0, time.UTC)
+		sex := "man"
+		_, err := s.client.UpdateAccountMain(s.ctx, s.token.Access, &model.UpdateAccount{
+			Login:    "account1",
+			Sex:      &sex,
+			Birthday: &pgtype.Date{Time: birthday, Valid: true},
+		})
+		s.Require().NoError(err)
+
+		_, err = s.client.UpdatePatient(s.ctx, s.token.Access, &model.UpdatePatientProfile{Height: &v.height, Weight: &v.weight})
+		s.Require().NoError(err)
+
+		patient, err := s.client.GetPatientProfile(s.ctx, s.token.Access)
+		s.Require().NoError(err)
+
+		s.Require().NotNil(patient.Indicators)
+		s.Equal(v.months, *patient.Indicators.AgeMonths)
+		s.Equal(v.months/12, *patient.Indicators.Age)
+		s.Nil(patient.Indicators.BMICategory)
+		s.Require().NotNil(patient.Indicators.HeightForAge)
+		s.InDelta(0, patient.Indicators.HeightForAge.ZScore, 0.01, "height at %d months", v.months)
+		s.InDelta(50, patient.Indicators.HeightForAge.Percentile, 0.5, "height at %d months", v.months)
+		s.Require().NotNil(patient.Indicators.WeightForAge)
+		s.InDelta(0, patient.Indicators.WeightForAge.ZScore, 0.01, "weight at %d months", v.months)
+	}
+}
+
 func (s *PatientTestSuite) TestUpdatePatientProfile() {
 	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
 	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
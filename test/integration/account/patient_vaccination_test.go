@@ -0,0 +1,184 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/immunization"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type VaccinationTestSuite struct {
+	TestSuite
+}
+
+func TestVaccinationSuite(t *testing.T) {
+	suite.Run(t, new(VaccinationTestSuite))
+}
+
+func (s *VaccinationTestSuite) TestAddVaccination() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	date, err := time.ParseInLocation("2006-01-02", "2000-03-01", time.UTC)
+	s.Require().NoError(err)
+	lot := "B1234"
+	req := model.AddVaccination{
+		Vaccine:      "HepB",
+		DoseNumber:   2,
+		VaccinatedAt: pgtype.Date{Time: date, Valid: true},
+		Lot:          &lot,
+		Files:        []int64{2},
+	}
+
+	v, err := s.client.AddVaccination(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(v.ID)
+	s.Equal("hepb", v.Vaccine)
+	s.Equal(date, v.VaccinatedAt.Time)
+	s.Require().Len(v.Files, 1)
+	s.Equal(int64(2), v.Files[0].ID)
+
+	req.Files = []int64{3}
+	_, err = s.client.AddVaccination(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *VaccinationTestSuite) TestGetVaccinations() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetVaccinations(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Vaccinations, 2)
+	s.Equal(int64(2), list.Vaccinations[0].ID)
+	s.Len(list.Vaccinations[1].Files, 1)
+}
+
+func (s *VaccinationTestSuite) TestGetVaccination() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	v, err := s.client.GetVaccination(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("hepb", v.Vaccine)
+	s.Require().NotNil(v.Lot)
+
+	_, err = s.client.GetVaccination(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *VaccinationTestSuite) TestUpdateVaccination() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	date, err := time.ParseInLocation("2006-01-02", "2000-01-05", time.UTC)
+	s.Require().NoError(err)
+	req := model.UpdateVaccination{
+		Vaccine:      "bcg",
+		DoseNumber:   1,
+		VaccinatedAt: pgtype.Date{Time: date, Valid: true},
+		Files:        []int64{1, 2},
+	}
+
+	v, err := s.client.UpdateVaccination(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Equal(date, v.VaccinatedAt.Time)
+	s.Len(v.Files, 2)
+
+	req.Files = nil
+	v, err = s.client.UpdateVaccination(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Empty(v.Files)
+
+	_, err = s.client.UpdateVaccination(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *VaccinationTestSuite) TestDeleteVaccination() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteVaccination(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetVaccination(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+
+	err = s.client.DeleteVaccination(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *VaccinationTestSuite) TestGetVaccinationSchedule() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	schedule, err := s.client.GetVaccinationSchedule(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(schedule.Schedule, len(immunization.Calendar()))
+	for _, v := range schedule.Schedule {
+		switch {
+		case v.Vaccine == "hepb" && v.Dose == 1:
+			s.Equal(model.VaccinationStatusDone, v.Status)
+			s.Require().NotNil(v.VaccinationID)
+			s.Equal(int64(1), *v.VaccinationID)
+		case v.Vaccine == "bcg":
+			s.Equal(model.VaccinationStatusDone, v.Status)
+		default:
+			// the patient is an adult, catch-up windows of childhood doses are closed
+			s.Equal(model.VaccinationStatusMissed, v.Status)
+		}
+	}
+}
+
+func (s *VaccinationTestSuite) TestGetOverdueVaccinations() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	schedule, err := s.client.GetOverdueVaccinations(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Empty(schedule.Schedule)
+
+	now := time.Now().UTC()
+	birthday := time.Date(now.Year()-3, now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.client.UpdateAccountMain(s.ctx, s.token.Access, &model.UpdateAccount{
+		Login:    "account1",
+		Birthday: &pgtype.Date{Time: birthday, Valid: true},
+	})
+	s.Require().NoError(err)
+
+	schedule, err = s.client.GetOverdueVaccinations(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	// doses up to 18 months except hepb 1 and bcg
+	s.Len(schedule.Schedule, 14)
+	for _, v := range schedule.Schedule {
+		s.True(v.CatchUpUntil.Time.After(now))
+	}
+
+	_, err = s.client.GetPatientOverdueVaccinations(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+}
+
+func (s *VaccinationTestSuite) TestGetPatientVaccinations() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetPatientVaccinations(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Vaccinations, 1)
+	s.Equal("mmr", list.Vaccinations[0].Vaccine)
+
+	_, err = s.client.GetPatientVaccinations(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
@@ -0,0 +1,164 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type EducationalCourseTestSuite struct {
+	TestSuite
+}
+
+func TestEducationalCourseSuite(t *testing.T) {
+	suite.Run(t, new(EducationalCourseTestSuite))
+}
+
+func (s *EducationalCourseTestSuite) TestAddEducationalCourse() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	graduation, err := time.ParseInLocation("2006-01-02", "2024-04-15", time.UTC)
+	s.Require().NoError(err)
+	institution := "Shupyk National Healthcare University"
+	req := model.AddEducationalCourse{
+		Name:            "Pediatric cardiology update",
+		InstitutionName: &institution,
+		Graduation:      pgtype.Date{Time: graduation, Valid: true},
+		Points:          12.5,
+		Files:           []*model.File{{ID: 1}, {ID: 3}},
+	}
+
+	course, err := s.client.AddEducationalCourse(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(course.ID)
+	s.Equal(req.Name, course.Name)
+	s.Equal(req.Graduation, course.Graduation)
+	s.Equal(req.Points, course.Points)
+	s.False(course.Verified)
+	s.Len(course.Files, 1)
+
+	req.Points = -1
+	_, err = s.client.AddEducationalCourse(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *EducationalCourseTestSuite) TestGetEducationalCourses() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetEducationalCourses(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.EducationalCourses, 3)
+	s.Equal(int64(3), list.EducationalCourses[0].ID)
+}
+
+func (s *EducationalCourseTestSuite) TestGetEducationalCourse() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	course, err := s.client.GetEducationalCourse(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(float64(20), course.Points)
+	s.True(course.Verified)
+	s.Len(course.Files, 1)
+
+	_, err = s.client.GetEducationalCourse(s.ctx, s.token.Access, 4)
+	s.Require().Error(err)
+}
+
+func (s *EducationalCourseTestSuite) TestUpdateEducationalCourse() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	graduation, err := time.ParseInLocation("2006-01-02", "2023-03-12", time.UTC)
+	s.Require().NoError(err)
+	req := model.UpdateEducationalCourse{
+		Name:       "Modern approaches to arterial hypertension",
+		Graduation: pgtype.Date{Time: graduation, Valid: true},
+		Points:     25,
+	}
+
+	course, err := s.client.UpdateEducationalCourse(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Points, course.Points)
+	s.Equal(req.Graduation, course.Graduation)
+	s.False(course.Verified)
+	s.Empty(course.Files)
+
+	_, err = s.client.UpdateEducationalCourse(s.ctx, s.token.Access, 4, &req)
+	s.Require().Error(err)
+}
+
+func (s *EducationalCourseTestSuite) TestDeleteEducationalCourse() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteEducationalCourse(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetEducationalCourse(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+
+	err = s.client.DeleteEducationalCourse(s.ctx, s.token.Access, 4)
+	s.Require().Error(err)
+}
+
+func (s *EducationalCourseTestSuite) TestGetCoursePoints() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	points, err := s.client.GetCoursePoints(s.ctx, s.token.Access, &model.CoursePointsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(points.Periods, 2)
+	s.Equal(2023, points.Periods[0].Year)
+	s.Equal(int64(2), points.Periods[0].Courses)
+	s.Equal(35.5, points.Periods[0].Points)
+	s.Equal(float64(20), points.Periods[0].VerifiedPoints)
+	s.Equal(2024, points.Periods[1].Year)
+	s.Equal(45.5, points.Total)
+
+	from, err := time.ParseInLocation("2006-01-02", "2024-01-01", time.UTC)
+	s.Require().NoError(err)
+
+	points, err = s.client.GetCoursePoints(s.ctx, s.token.Access, &model.CoursePointsRequest{From: &pgtype.Date{Time: from, Valid: true}})
+	s.Require().NoError(err)
+
+	s.Len(points.Periods, 1)
+	s.Equal(float64(10), points.Total)
+}
+
+func (s *EducationalCourseTestSuite) TestCMESummary() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	now := time.Now().UTC()
+	req := model.AddEducationalCourse{
+		Name:       "Annual cardiology congress",
+		Graduation: pgtype.Date{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), Valid: true},
+		Points:     s.cfg.CME.RequiredPoints,
+	}
+
+	specialist, err := s.client.GetSpecialistProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(specialist.CME)
+	s.False(specialist.CME.Met)
+
+	_, err = s.client.AddEducationalCourse(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	specialist, err = s.client.GetSpecialistProfile(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Equal(s.cfg.CME.RequiredPoints, specialist.CME.Points)
+	s.True(specialist.CME.Met)
+}
@@ -0,0 +1,316 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/internal/account"
+	"github.com/Hvaekar/med-account/pkg/licence"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/pkg/storage/postgres"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type LicenceTestSuite struct {
+	TestSuite
+}
+
+func TestLicenceSuite(t *testing.T) {
+	suite.Run(t, new(LicenceTestSuite))
+}
+
+func (s *LicenceTestSuite) TestAddLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.licenceStub.Add(&licence.Result{Number: "LC-000005", Status: model.LicenceStatusActive, FullName: "Ivan Ivanov", Practice: "Neurology"})
+
+	expires, err := time.ParseInLocation("2006-01-02", "2030-01-01", time.UTC)
+	s.Require().NoError(err)
+	req := model.AddLicence{
+		Number:   "LC-000005",
+		FullName: "Ivan Ivanov",
+		Practice: "Neurology",
+		Expires:  &pgtype.Date{Time: expires, Valid: true},
+	}
+
+	l, err := s.client.AddLicence(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(l.ID)
+	s.Equal(req.Number, l.Number)
+	s.Equal(req.Expires, l.Expires)
+	s.Equal(model.LicenceStatusActive, l.Status)
+	s.True(l.Verified)
+	s.NotNil(l.CheckedAt)
+
+	_, err = s.client.AddLicence(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *LicenceTestSuite) TestAddLicenceExistingNumber() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddLicence{
+		Number:   "LC-000003",
+		FullName: "Ivan Ivanov",
+		Practice: "Surgery",
+	}
+
+	_, err := s.client.AddLicence(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *LicenceTestSuite) TestAddLicenceNotInRegistry() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddLicence{
+		Number:   "LC-999999",
+		FullName: "Ivan Ivanov",
+		Practice: "Surgery",
+	}
+
+	_, err := s.client.AddLicence(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *LicenceTestSuite) TestAddLicenceSuspendedInRegistry() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddLicence{
+		Number:   "LC-000004",
+		FullName: "Ivan Ivanov",
+		Practice: "Surgery",
+	}
+
+	l, err := s.client.AddLicence(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.False(l.Verified)
+	s.NotNil(l.CheckedAt)
+}
+
+func (s *LicenceTestSuite) TestGetLicences() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetLicences(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Licences, 2)
+}
+
+func (s *LicenceTestSuite) TestGetLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	l, err := s.client.GetLicence(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("LC-000001", l.Number)
+	s.True(l.Verified)
+
+	_, err = s.client.GetLicence(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *LicenceTestSuite) TestUpdateLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateLicence{
+		Number:   "LC-000001",
+		FullName: "Ivan Petrenko",
+		Practice: "Cardiology",
+	}
+
+	l, err := s.client.UpdateLicence(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.FullName, l.FullName)
+	s.False(l.Verified)
+
+	req.Number = "LC-000003"
+	_, err = s.client.UpdateLicence(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+
+	_, err = s.client.UpdateLicence(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *LicenceTestSuite) TestUpdateLicenceStatus() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateLicenceStatus{Status: model.LicenceStatusSuspended}
+
+	l, err := s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(model.LicenceStatusSuspended, l.Status)
+
+	req.Status = model.LicenceStatusExpired
+	_, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+}
+
+func (s *LicenceTestSuite) TestReactivateLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateLicenceStatus{Status: model.LicenceStatusSuspended}
+
+	l, err := s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(model.LicenceStatusSuspended, l.Status)
+
+	// the registry confirms the licence again
+	req.Status = model.LicenceStatusActive
+	l, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(model.LicenceStatusActive, l.Status)
+	s.True(l.Verified)
+	s.NotNil(l.CheckedAt)
+}
+
+func (s *LicenceTestSuite) TestCloseLicenceFinal() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateLicenceStatus{Status: model.LicenceStatusClosed}
+
+	l, err := s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(model.LicenceStatusClosed, l.Status)
+
+	req.Status = model.LicenceStatusSuspended
+	_, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	req.Status = model.LicenceStatusActive
+	_, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *LicenceTestSuite) TestReactivateExpiredLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	psql := account.NewPostgresStorage(s.db.(*postgres.Postgres))
+	_, err := psql.ExpireLicences(s.ctx)
+	s.Require().NoError(err)
+
+	l, err := s.client.GetLicence(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+	s.Require().Equal(model.LicenceStatusExpired, l.Status)
+
+	req := model.UpdateLicenceStatus{Status: model.LicenceStatusActive}
+	_, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 2, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	// renewed licence can be reactivated
+	_, err = s.client.UpdateLicence(s.ctx, s.token.Access, 2, &model.UpdateLicence{
+		Number:   l.Number,
+		FullName: l.FullName,
+		Practice: l.Practice,
+		Issued:   l.Issued,
+		Expires:  &pgtype.Date{Time: time.Now().AddDate(5, 0, 0), Valid: true},
+	})
+	s.Require().NoError(err)
+
+	l, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Equal(model.LicenceStatusActive, l.Status)
+}
+
+func (s *LicenceTestSuite) TestReactivateLicenceRegistryUnavailable() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateLicenceStatus{Status: model.LicenceStatusSuspended}
+	_, err := s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.licenceStub.SetError(licence.ErrUnavailable)
+	defer s.licenceStub.SetError(nil)
+
+	req.Status = model.LicenceStatusActive
+	_, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "503")
+
+	l, err := s.client.GetLicence(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(model.LicenceStatusSuspended, l.Status)
+}
+
+func (s *LicenceTestSuite) TestReactivateLicenceInactiveInRegistry() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.licenceStub.Add(&licence.Result{Number: "LC-000006", Status: model.LicenceStatusActive, FullName: "Ivan Ivanov", Practice: "Neurology"})
+
+	l, err := s.client.AddLicence(s.ctx, s.token.Access, &model.AddLicence{
+		Number:   "LC-000006",
+		FullName: "Ivan Ivanov",
+		Practice: "Neurology",
+	})
+	s.Require().NoError(err)
+	s.True(l.Verified)
+
+	req := model.UpdateLicenceStatus{Status: model.LicenceStatusSuspended}
+	_, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, l.ID, &req)
+	s.Require().NoError(err)
+
+	s.licenceStub.Add(&licence.Result{Number: "LC-000006", Status: model.LicenceStatusSuspended, FullName: "Ivan Ivanov", Practice: "Neurology"})
+
+	req.Status = model.LicenceStatusActive
+	_, err = s.client.UpdateLicenceStatus(s.ctx, s.token.Access, l.ID, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	l, err = s.client.GetLicence(s.ctx, s.token.Access, l.ID)
+	s.Require().NoError(err)
+
+	s.Equal(model.LicenceStatusSuspended, l.Status)
+}
+
+func (s *LicenceTestSuite) TestCheckLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	l, err := s.client.CheckLicence(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.True(l.Verified)
+	s.NotNil(l.CheckedAt)
+
+	_, err = s.client.CheckLicence(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *LicenceTestSuite) TestDeleteLicence() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteLicence(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetLicence(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+
+	err = s.client.DeleteLicence(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
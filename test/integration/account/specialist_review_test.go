@@ -0,0 +1,340 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ReviewTestSuite struct {
+	TestSuite
+}
+
+func TestReviewSuite(t *testing.T) {
+	suite.Run(t, new(ReviewTestSuite))
+}
+
+// patientToken is the account 2 at its own patient profile, the profile is a patient of the specialist 1
+func (s *ReviewTestSuite) patientToken() string {
+	payload := model.TokenPayload{
+		AccountID:    2,
+		PatientID:    2,
+		SpecialistID: 2,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	return *token
+}
+
+func (s *ReviewTestSuite) TestAddReview() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	text := "Explained everything"
+	req := model.AddReview{
+		SpecialistID: 1,
+		Ratings:      model.ReviewRatings{Professionalism: 5, Attentiveness: 5, Communication: 5, Punctuality: 3},
+		Text:         &text,
+		Anonymous:    true,
+	}
+
+	// one review per specialist
+	_, err := s.client.AddReview(s.ctx, s.patientToken(), &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "409")
+
+	s.Require().NoError(s.client.DeleteReview(s.ctx, s.patientToken(), 1))
+
+	r, err := s.client.AddReview(s.ctx, s.patientToken(), &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(r.ID)
+	s.Equal(int64(2), r.PatientID)
+	s.Equal(req.Ratings, r.Ratings)
+	s.Equal(4.5, r.Rating)
+	s.Equal(text, *r.Text)
+	s.True(r.Anonymous)
+	s.Equal(model.ReviewStatusPending, r.Status)
+
+	// pending reviews are not counted
+	sp, err := s.client.GetSpecialist(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(sp.Rating)
+	s.Equal(int64(1), sp.Rating.Count)
+}
+
+func (s *ReviewTestSuite) TestAddReviewNotAllowed() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddReview{
+		SpecialistID: 1,
+		Ratings:      model.ReviewRatings{Professionalism: 5, Attentiveness: 5, Communication: 5, Punctuality: 5},
+	}
+
+	// the patient 1 has not added the specialist
+	_, err := s.client.AddReview(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "403")
+
+	// the account 2 is the specialist 2 itself
+	_, err = s.client.AddPatientSpecialist(s.ctx, s.patientToken(), &model.AddPatientSpecialist{SpecialistID: 2})
+	s.Require().NoError(err)
+
+	_, err = s.client.ConfirmSpecialistPatient(s.ctx, s.patientToken(), 2)
+	s.Require().NoError(err)
+
+	req.SpecialistID = 2
+	_, err = s.client.AddReview(s.ctx, s.patientToken(), &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "403")
+
+	req.Ratings.Punctuality = 6
+	_, err = s.client.AddReview(s.ctx, s.patientToken(), &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *ReviewTestSuite) TestAddReviewNotConfirmed() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddReview{
+		SpecialistID: 2,
+		Ratings:      model.ReviewRatings{Professionalism: 4, Attentiveness: 4, Communication: 4, Punctuality: 4},
+	}
+
+	// the patient 1 adds the specialist 2 alone
+	_, err := s.client.AddPatientSpecialist(s.ctx, s.token.Access, &model.AddPatientSpecialist{SpecialistID: 2})
+	s.Require().NoError(err)
+
+	_, err = s.client.AddReview(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "403")
+
+	// only the specialist of the link can confirm it
+	_, err = s.client.ConfirmSpecialistPatient(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+
+	ps, err := s.client.ConfirmSpecialistPatient(s.ctx, s.patientToken(), 1)
+	s.Require().NoError(err)
+
+	s.NotNil(ps.ConfirmedAt)
+
+	s.Require().NoError(s.client.DeleteReview(s.ctx, s.token.Access, 3))
+
+	r, err := s.client.AddReview(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.Equal(int64(1), r.PatientID)
+}
+
+func (s *ReviewTestSuite) TestGetReviews() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetReviews(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Reviews, 1)
+	s.Equal(int64(3), list.Reviews[0].ID)
+	s.Equal("Some", *list.Reviews[0].AuthorName)
+
+	r, err := s.client.GetReview(s.ctx, s.patientToken(), 1)
+	s.Require().NoError(err)
+
+	s.Equal(int64(1), r.SpecialistID)
+	s.Equal(4.5, r.Rating)
+	s.Require().NotNil(r.ReviewerID)
+
+	_, err = s.client.GetReview(s.ctx, s.patientToken(), 3)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+}
+
+func (s *ReviewTestSuite) TestUpdateReview() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.UpdateReview{
+		Ratings:   model.ReviewRatings{Professionalism: 2, Attentiveness: 2, Communication: 2, Punctuality: 2},
+		Anonymous: true,
+	}
+
+	r, err := s.client.UpdateReview(s.ctx, s.patientToken(), 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(2.0, r.Rating)
+	s.Nil(r.Text)
+	s.True(r.Anonymous)
+	s.Equal(model.ReviewStatusPending, r.Status)
+	s.Nil(r.ReviewerID)
+
+	// the changed review waits for moderation again
+	list, err := s.client.GetSpecialistReviews(s.ctx, s.token.Access, 1, &model.ListReviewsRequest{})
+	s.Require().NoError(err)
+	s.Len(list.Reviews, 1)
+
+	_, err = s.client.UpdateReview(s.ctx, s.patientToken(), 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *ReviewTestSuite) TestDeleteReview() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteReview(s.ctx, s.patientToken(), 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetReview(s.ctx, s.patientToken(), 1)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+
+	err = s.client.DeleteReview(s.ctx, s.patientToken(), 3)
+	s.Require().Error(err)
+}
+
+func (s *ReviewTestSuite) TestGetSpecialistReviews() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetSpecialistReviews(s.ctx, s.token.Access, 1, &model.ListReviewsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Reviews, 2)
+
+	// the author of an anonymous review is hidden
+	s.Equal(int64(2), list.Reviews[0].ID)
+	s.Empty(list.Reviews[0].PatientID)
+	s.Nil(list.Reviews[0].AuthorName)
+	s.Nil(list.Reviews[0].ReviewerID)
+	s.Equal("Sorry for the delay", *list.Reviews[0].Reply)
+	s.Equal(int64(2), list.Reviews[1].PatientID)
+
+	list, err = s.client.GetSpecialistReviews(s.ctx, s.token.Access, 1, &model.ListReviewsRequest{OrderBy: "rating DESC"})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Reviews, 2)
+	s.Equal(int64(1), list.Reviews[0].ID)
+
+	// pending reviews are not shown, the names follow the privacy of the author
+	list, err = s.client.GetSpecialistReviews(s.ctx, s.patientToken(), 2, &model.ListReviewsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Reviews, 1)
+	s.Equal("Some", *list.Reviews[0].AuthorName)
+}
+
+func (s *ReviewTestSuite) TestSpecialistRating() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	sp, err := s.client.GetSpecialist(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Require().NotNil(sp.Rating)
+	s.Equal(int64(2), sp.Rating.Count)
+	s.Equal(3.75, sp.Rating.Average)
+	s.Equal(4.0, sp.Rating.Professionalism)
+	s.Equal(3.5, sp.Rating.Attentiveness)
+	s.Equal(4.5, sp.Rating.Communication)
+	s.Equal(3.0, sp.Rating.Punctuality)
+
+	list, err := s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{})
+	s.Require().NoError(err)
+
+	for _, v := range list.Specialists {
+		s.Require().NotNil(v.Rating)
+
+		switch v.ID {
+		case 1:
+			s.Equal(int64(2), v.Rating.Count)
+		case 2:
+			s.Equal(int64(1), v.Rating.Count)
+			s.Equal(4.0, v.Rating.Average)
+		default:
+			s.Empty(v.Rating.Count)
+		}
+	}
+}
+
+func (s *ReviewTestSuite) TestReplyReview() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	r, err := s.client.ReplyReview(s.ctx, s.token.Access, 1, &model.ReplyReview{Text: "Thank you"})
+	s.Require().NoError(err)
+
+	s.Equal("Thank you", *r.Reply)
+	s.NotNil(r.RepliedAt)
+
+	r, err = s.client.DeleteReviewReply(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Nil(r.Reply)
+	s.Nil(r.RepliedAt)
+	s.Empty(r.PatientID)
+
+	list, err := s.client.GetSpecialistProfileReviews(s.ctx, s.token.Access, &model.ListReviewsRequest{})
+	s.Require().NoError(err)
+	s.Len(list.Reviews, 2)
+
+	// the review of another specialist
+	_, err = s.client.ReplyReview(s.ctx, s.token.Access, 3, &model.ReplyReview{Text: "Thank you"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+
+	// pending reviews can not be replied
+	_, err = s.client.ReplyReview(s.ctx, s.patientToken(), 4, &model.ReplyReview{Text: "Thank you"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+}
+
+func (s *ReviewTestSuite) TestModerateReview() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetModerationReviews(s.ctx, s.patientToken(), &model.ListReviewsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Reviews, 1)
+	s.Equal(int64(4), list.Reviews[0].ID)
+
+	r, err := s.client.GetModerationReview(s.ctx, s.patientToken(), 2)
+	s.Require().NoError(err)
+
+	// moderators see the author of an anonymous review
+	s.Equal(int64(3), r.PatientID)
+
+	// a rejection has to be explained
+	_, err = s.client.RejectReview(s.ctx, s.patientToken(), 4, &model.ModerateReview{})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	r, err = s.client.ApproveReview(s.ctx, s.patientToken(), 4, &model.ModerateReview{})
+	s.Require().NoError(err)
+
+	s.Equal(model.ReviewStatusApproved, r.Status)
+	s.Equal(int64(2), *r.ReviewerID)
+
+	sp, err := s.client.GetSpecialist(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Equal(int64(2), sp.Rating.Count)
+	s.Equal(2.5, sp.Rating.Average)
+
+	// only pending reviews are moderated
+	comment := "Offensive"
+	_, err = s.client.RejectReview(s.ctx, s.patientToken(), 4, &model.ModerateReview{Comment: &comment})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+
+	// moderators only
+	_, err = s.client.GetModerationReviews(s.ctx, s.token.Access, &model.ListReviewsRequest{})
+	s.Require().Error(err)
+}
@@ -0,0 +1,390 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ScheduleTestSuite struct {
+	TestSuite
+}
+
+func TestScheduleSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleTestSuite))
+}
+
+func (s *ScheduleTestSuite) TestAddSchedule() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var addressID int64 = 1
+	req := model.AddSchedule{
+		AddressID:    &addressID,
+		Timezone:     "Europe/Kyiv",
+		SlotDuration: 20,
+		Hours: []*model.WorkingHours{
+			{Weekday: 2, Start: 9 * 60, End: 12 * 60},
+			{Weekday: 2, Start: 13 * 60, End: 17 * 60},
+		},
+	}
+
+	sc, err := s.client.AddSchedule(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(sc.ID)
+	s.Nil(sc.OrganizationID)
+	s.Equal(addressID, *sc.AddressID)
+	s.Equal(req.Timezone, sc.Timezone)
+	s.Equal(req.SlotDuration, sc.SlotDuration)
+	s.Require().Len(sc.Hours, 2)
+	s.Equal(model.Clock(13*60), sc.Hours[1].Start)
+
+	// one schedule per location
+	_, err = s.client.AddSchedule(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "409")
+}
+
+func (s *ScheduleTestSuite) TestAddScheduleInvalid() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var organizationID int64 = 2
+	var addressID int64 = 3
+	var ownAddressID int64 = 1
+
+	cases := []model.AddSchedule{
+		// not a member of the organization
+		{OrganizationID: &organizationID, Timezone: "Europe/Kyiv", SlotDuration: 30, Hours: []*model.WorkingHours{{Weekday: 1, Start: 540, End: 600}}},
+		// address of another account
+		{AddressID: &addressID, Timezone: "Europe/Kyiv", SlotDuration: 30, Hours: []*model.WorkingHours{{Weekday: 1, Start: 540, End: 600}}},
+		// both locations
+		{OrganizationID: &organizationID, AddressID: &ownAddressID, Timezone: "Europe/Kyiv", SlotDuration: 30, Hours: []*model.WorkingHours{{Weekday: 1, Start: 540, End: 600}}},
+		// unknown timezone
+		{AddressID: &ownAddressID, Timezone: "Europe/Nowhere", SlotDuration: 30, Hours: []*model.WorkingHours{{Weekday: 1, Start: 540, End: 600}}},
+		// overlapping hours
+		{AddressID: &ownAddressID, Timezone: "Europe/Kyiv", SlotDuration: 30, Hours: []*model.WorkingHours{{Weekday: 1, Start: 540, End: 720}, {Weekday: 1, Start: 660, End: 780}}},
+		// hours end before they start
+		{AddressID: &ownAddressID, Timezone: "Europe/Kyiv", SlotDuration: 30, Hours: []*model.WorkingHours{{Weekday: 1, Start: 600, End: 540}}},
+	}
+
+	for _, req := range cases {
+		_, err := s.client.AddSchedule(s.ctx, s.token.Access, &req)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "400")
+	}
+}
+
+func (s *ScheduleTestSuite) TestGetSchedules() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetSchedules(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Schedules, 2)
+	s.Equal(int64(1), *list.Schedules[0].OrganizationID)
+	s.Len(list.Schedules[0].Hours, 5)
+	s.Equal(int64(2), *list.Schedules[1].AddressID)
+
+	list, err = s.client.GetSpecialistSchedules(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Schedules, 1)
+	s.Equal("Europe/Warsaw", list.Schedules[0].Timezone)
+}
+
+func (s *ScheduleTestSuite) TestGetSchedule() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	sc, err := s.client.GetSchedule(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal(int64(30), sc.SlotDuration)
+	s.Equal(model.Clock(540), sc.Hours[0].Start)
+
+	_, err = s.client.GetSchedule(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *ScheduleTestSuite) TestUpdateSchedule() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var organizationID int64 = 1
+	req := model.UpdateSchedule{
+		OrganizationID: &organizationID,
+		Timezone:       "Europe/Warsaw",
+		SlotDuration:   15,
+		Hours:          []*model.WorkingHours{{Weekday: 7, Start: 0, End: 24 * 60}},
+	}
+
+	sc, err := s.client.UpdateSchedule(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Timezone, sc.Timezone)
+	s.Equal(req.SlotDuration, sc.SlotDuration)
+	s.Require().Len(sc.Hours, 1)
+	s.Equal(model.Clock(24*60), sc.Hours[0].End)
+
+	// the address already has a schedule
+	var addressID int64 = 2
+	req.OrganizationID = nil
+	req.AddressID = &addressID
+	_, err = s.client.UpdateSchedule(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "409")
+
+	_, err = s.client.UpdateSchedule(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *ScheduleTestSuite) TestDeleteSchedule() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteSchedule(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetSchedule(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+
+	// the exceptions of the schedule are deleted with it
+	list, err := s.client.GetScheduleExceptions(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+	s.Len(list.Exceptions, 1)
+
+	err = s.client.DeleteSchedule(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *ScheduleTestSuite) TestAddScheduleException() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddScheduleException{
+		Type:      model.ScheduleExceptionDayOff,
+		StartDate: pgtype.Date{Time: time.Date(2030, 6, 10, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	e, err := s.client.AddScheduleException(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(e.ID)
+	s.Nil(e.ScheduleID)
+	s.Equal(req.Type, e.Type)
+	s.Equal(req.StartDate.Time, e.EndDate.Time)
+	s.Nil(e.Start)
+
+	// time is set without the end
+	var scheduleID int64 = 1
+	start := model.Clock(600)
+	req.ScheduleID = &scheduleID
+	req.Start = &start
+	_, err = s.client.AddScheduleException(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	// schedule of another specialist
+	end := model.Clock(660)
+	scheduleID = 3
+	req.End = &end
+	_, err = s.client.AddScheduleException(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *ScheduleTestSuite) TestGetScheduleExceptions() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetScheduleExceptions(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Exceptions, 2)
+	s.Equal(int64(2), list.Exceptions[0].ID)
+	s.Equal(model.Clock(600), *list.Exceptions[0].End)
+	s.Equal(model.ScheduleExceptionVacation, list.Exceptions[1].Type)
+
+	e, err := s.client.GetScheduleException(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+	s.Equal("Summer vacation", *e.Comment)
+
+	_, err = s.client.GetScheduleException(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *ScheduleTestSuite) TestUpdateScheduleException() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	end := pgtype.Date{Time: time.Date(2030, 7, 21, 0, 0, 0, 0, time.UTC), Valid: true}
+	req := model.UpdateScheduleException{
+		Type:      model.ScheduleExceptionVacation,
+		StartDate: pgtype.Date{Time: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		EndDate:   &end,
+	}
+
+	e, err := s.client.UpdateScheduleException(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(end.Time, e.EndDate.Time)
+	s.Nil(e.Comment)
+
+	_, err = s.client.UpdateScheduleException(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *ScheduleTestSuite) TestDeleteScheduleException() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteScheduleException(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetScheduleException(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "404")
+
+	err = s.client.DeleteScheduleException(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *ScheduleTestSuite) TestGetAvailability() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AvailabilityRequest{
+		From: pgtype.Date{Time: time.Date(2030, 6, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+		To:   pgtype.Date{Time: time.Date(2030, 6, 9, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	a, err := s.client.GetAvailability(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	// 5 days of 8 slots at the organization without the 2 closed on monday and 2 slots at the address on saturday
+	s.Require().Len(a.Slots, 40)
+	s.Equal(int64(1), a.Slots[0].ScheduleID)
+	s.True(time.Date(2030, 6, 3, 7, 0, 0, 0, time.UTC).Equal(a.Slots[0].Start))
+	s.Equal(30*time.Minute, a.Slots[0].End.Sub(a.Slots[0].Start))
+	s.Equal(int64(2), a.Slots[len(a.Slots)-1].ScheduleID)
+	s.True(time.Date(2030, 6, 8, 8, 0, 0, 0, time.UTC).Equal(a.Slots[len(a.Slots)-1].Start))
+
+	// the slots are shown in the requested timezone
+	tz := "America/New_York"
+	var scheduleID int64 = 2
+	req.Timezone = &tz
+	req.ScheduleID = &scheduleID
+	a, err = s.client.GetAvailability(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Require().Len(a.Slots, 2)
+	s.Equal("2030-06-08T03:00:00-04:00", a.Slots[0].Start.Format(time.RFC3339))
+
+	// vacation
+	req = model.AvailabilityRequest{
+		From: pgtype.Date{Time: time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		To:   pgtype.Date{Time: time.Date(2030, 7, 14, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	a, err = s.client.GetAvailability(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+	s.Empty(a.Slots)
+
+	// sick leave of the specialist 2
+	req = model.AvailabilityRequest{
+		From: pgtype.Date{Time: time.Date(2030, 6, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+		To:   pgtype.Date{Time: time.Date(2030, 6, 10, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	a, err = s.client.GetAvailability(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Require().Len(a.Slots, 4)
+	s.True(time.Date(2030, 6, 10, 6, 0, 0, 0, time.UTC).Equal(a.Slots[0].Start))
+}
+
+func (s *ScheduleTestSuite) TestGetAvailabilityDST() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	var addressID int64 = 1
+	sc, err := s.client.AddSchedule(s.ctx, s.token.Access, &model.AddSchedule{
+		AddressID:    &addressID,
+		Timezone:     "Europe/Kyiv",
+		SlotDuration: 30,
+		Hours:        []*model.WorkingHours{{Weekday: 7, Start: 2 * 60, End: 5 * 60}},
+	})
+	s.Require().NoError(err)
+
+	// the clocks go from 03:00 to 04:00
+	req := model.AvailabilityRequest{
+		From:       pgtype.Date{Time: time.Date(2030, 3, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		To:         pgtype.Date{Time: time.Date(2030, 3, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+		ScheduleID: &sc.ID,
+	}
+	a, err := s.client.GetAvailability(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Require().Len(a.Slots, 4)
+	s.True(time.Date(2030, 3, 31, 0, 30, 0, 0, time.UTC).Equal(a.Slots[1].Start))
+	s.True(time.Date(2030, 3, 31, 1, 0, 0, 0, time.UTC).Equal(a.Slots[2].Start))
+	for _, v := range a.Slots {
+		s.Equal(30*time.Minute, v.End.Sub(v.Start))
+	}
+
+	// the clocks go from 04:00 back to 03:00, the repeated hour is offered once
+	req.From.Time = time.Date(2030, 10, 27, 0, 0, 0, 0, time.UTC)
+	req.To.Time = req.From.Time
+	a, err = s.client.GetAvailability(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Require().Len(a.Slots, 6)
+	for i, v := range a.Slots {
+		s.Equal(30*time.Minute, v.End.Sub(v.Start))
+		if i > 0 {
+			s.False(v.Start.Before(a.Slots[i-1].End))
+		}
+	}
+}
+
+func (s *ScheduleTestSuite) TestGetAvailabilityInvalid() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AvailabilityRequest{
+		From: pgtype.Date{Time: time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		To:   pgtype.Date{Time: time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	_, err := s.client.GetAvailability(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+
+	tz := "Local"
+	req.To = req.From
+	req.Timezone = &tz
+	_, err = s.client.GetAvailability(s.ctx, s.token.Access, 1, &req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "400")
+}
+
+func (s *ScheduleTestSuite) TestScheduleNoSpecialist() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	payload := model.TokenPayload{
+		AccountID: 1,
+		PatientID: 1,
+	}
+
+	token, err := jwt.GenerateJWT(s.cfg.JWT.AccessTokenExpiresAt, payload, s.cfg.JWT.AccessTokenSecretKey)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetSchedules(s.ctx, *token)
+	s.Require().Error(err)
+}
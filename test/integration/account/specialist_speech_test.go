@@ -0,0 +1,108 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type SpeechTestSuite struct {
+	TestSuite
+}
+
+func TestSpeechSuite(t *testing.T) {
+	suite.Run(t, new(SpeechTestSuite))
+}
+
+func (s *SpeechTestSuite) TestAddSpeech() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	date, err := time.ParseInLocation("2006-01-02", "2024-03-15", time.UTC)
+	s.Require().NoError(err)
+	link := "https://example.com/some"
+	req := model.AddSpeech{
+		Event: "National Therapy Forum",
+		Topic: "Statins in primary prevention",
+		Date:  pgtype.Date{Time: date, Valid: true},
+		Link:  &link,
+	}
+
+	speech, err := s.client.AddSpeech(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(speech.ID)
+	s.Equal(req.Event, speech.Event)
+	s.Equal(req.Topic, speech.Topic)
+	s.Equal(req.Date, speech.Date)
+	s.Equal(*req.Link, *speech.Link)
+
+	invalidLink := "not a link"
+	req.Link = &invalidLink
+	_, err = s.client.AddSpeech(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *SpeechTestSuite) TestGetSpeeches() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetSpeeches(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Speeches, 2)
+}
+
+func (s *SpeechTestSuite) TestGetSpeech() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	speech, err := s.client.GetSpeech(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("Ukrainian Cardiology Congress", speech.Event)
+
+	_, err = s.client.GetSpeech(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
+
+func (s *SpeechTestSuite) TestUpdateSpeech() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	date, err := time.ParseInLocation("2006-01-02", "2023-08-27", time.UTC)
+	s.Require().NoError(err)
+	location := "Amsterdam"
+	req := model.UpdateSpeech{
+		Event:    "ESC Congress",
+		Topic:    "Remote monitoring after myocardial infarction",
+		Location: &location,
+		Date:     pgtype.Date{Time: date, Valid: true},
+	}
+
+	speech, err := s.client.UpdateSpeech(s.ctx, s.token.Access, 2, &req)
+	s.Require().NoError(err)
+
+	s.Equal(*req.Location, *speech.Location)
+	s.Equal(req.Date, speech.Date)
+
+	_, err = s.client.UpdateSpeech(s.ctx, s.token.Access, 3, &req)
+	s.Require().Error(err)
+}
+
+func (s *SpeechTestSuite) TestDeleteSpeech() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteSpeech(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetSpeech(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+
+	err = s.client.DeleteSpeech(s.ctx, s.token.Access, 3)
+	s.Require().Error(err)
+}
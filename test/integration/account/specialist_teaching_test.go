@@ -0,0 +1,106 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type TeachingTestSuite struct {
+	TestSuite
+}
+
+func TestTeachingSuite(t *testing.T) {
+	suite.Run(t, new(TeachingTestSuite))
+}
+
+func (s *TeachingTestSuite) TestAddTeaching() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	start, err := time.ParseInLocation("2006-01-02", "2020-09-01", time.UTC)
+	s.Require().NoError(err)
+	subject := "Cardiology"
+	req := model.AddTeaching{
+		Institution: "Kharkiv National Medical University",
+		Position:    "Assistant",
+		Subject:     &subject,
+		Start:       pgtype.Date{Time: start, Valid: true},
+	}
+
+	teaching, err := s.client.AddTeaching(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(teaching.ID)
+	s.Equal(req.Institution, teaching.Institution)
+	s.Equal(req.Position, teaching.Position)
+	s.Equal(*req.Subject, *teaching.Subject)
+	s.Equal(req.Start, teaching.Start)
+	s.Nil(teaching.Finish)
+}
+
+func (s *TeachingTestSuite) TestGetTeachings() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetTeachings(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Teachings, 1)
+}
+
+func (s *TeachingTestSuite) TestGetTeaching() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	teaching, err := s.client.GetTeaching(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	s.Equal("Associate professor", teaching.Position)
+
+	_, err = s.client.GetTeaching(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+}
+
+func (s *TeachingTestSuite) TestUpdateTeaching() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	start, err := time.ParseInLocation("2006-01-02", "2015-09-01", time.UTC)
+	s.Require().NoError(err)
+	finish, err := time.ParseInLocation("2006-01-02", "2023-06-30", time.UTC)
+	s.Require().NoError(err)
+	req := model.UpdateTeaching{
+		Institution: "Bogomolets National Medical University",
+		Position:    "Professor",
+		Start:       pgtype.Date{Time: start, Valid: true},
+		Finish:      &pgtype.Date{Time: finish, Valid: true},
+	}
+
+	teaching, err := s.client.UpdateTeaching(s.ctx, s.token.Access, 1, &req)
+	s.Require().NoError(err)
+
+	s.Equal(req.Position, teaching.Position)
+	s.Nil(teaching.Subject)
+	s.Equal(req.Finish, teaching.Finish)
+
+	_, err = s.client.UpdateTeaching(s.ctx, s.token.Access, 2, &req)
+	s.Require().Error(err)
+}
+
+func (s *TeachingTestSuite) TestDeleteTeaching() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	err := s.client.DeleteTeaching(s.ctx, s.token.Access, 1)
+	s.Require().NoError(err)
+
+	_, err = s.client.GetTeaching(s.ctx, s.token.Access, 1)
+	s.Require().Error(err)
+
+	err = s.client.DeleteTeaching(s.ctx, s.token.Access, 2)
+	s.Require().Error(err)
+}
@@ -24,6 +24,8 @@ func (s *SpecialistTestSuite) TestGetSpecialistProfile() {
 	s.Require().NoError(err)
 
 	s.Equal(int64(1), specialist.ID)
+	s.Len(specialist.Teachings, 1)
+	s.Len(specialist.Speeches, 2)
 }
 
 func (s *SpecialistTestSuite) TestUpdateSpecialistProfileMain() {
@@ -121,6 +123,79 @@ func (s *SpecialistTestSuite) TestGetSpecialists() {
 	}
 }
 
+func (s *SpecialistTestSuite) TestSearchSpecialists() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{})
+	s.Require().NoError(err)
+
+	s.Require().NotNil(list.Facets)
+	s.Equal(int64(2), list.Facets.Total)
+	s.Equal(int64(1), list.Facets.TreatsAdults)
+	s.Equal(int64(1), list.Facets.TreatsChildren)
+	s.Len(list.Facets.Specializations, 5)
+	s.Equal([]*model.FacetIDCount{{ID: 1, Count: 2}, {ID: 2, Count: 2}, {ID: 3, Count: 2}, {ID: 4, Count: 2}, {ID: 5, Count: 2}}, list.Facets.Services)
+	s.Equal([]*model.FacetIDCount{{ID: 1, Count: 2}}, list.Facets.Cities)
+	s.Equal([]*model.FacetCount{{Value: "en", Count: 1}, {Value: "ru", Count: 1}}, list.Facets.Languages)
+	s.Len(list.Facets.MedicalCategories, 2)
+
+	// the name is matched in any script and by the beginning of the words
+	for _, query := range []string{"Some", "соме наме", "nam", "Full Name"} {
+		list, err = s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{Query: query})
+		s.Require().NoError(err)
+
+		s.Require().Len(list.Specialists, 1, query)
+		s.Equal(int64(1), list.Specialists[0].ID)
+		s.Equal(int64(1), list.Facets.Total)
+	}
+
+	list, err = s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{Query: "Other"})
+	s.Require().NoError(err)
+
+	s.Len(list.Specialists, 0)
+	s.Equal(int64(0), list.Facets.Total)
+
+	treatsChildren := true
+	list, err = s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{TreatsChildren: &treatsChildren})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Specialists, 1)
+	s.Equal(int64(2), list.Specialists[0].ID)
+	s.Equal(int64(0), list.Facets.TreatsAdults)
+	s.Equal(int64(1), list.Facets.TreatsChildren)
+
+	list, err = s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{Specializations: []int64{11, 12}})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Specialists, 1)
+	s.Equal(int64(2), list.Specialists[0].ID)
+	// the own filter is not applied to its facet
+	s.Len(list.Facets.Specializations, 5)
+	s.Equal([]*model.FacetCount{{Value: "1", Count: 1}}, list.Facets.MedicalCategories)
+
+	list, err = s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{
+		Languages:         []string{"en"},
+		Cities:            []int64{1},
+		MedicalCategories: []string{"3"},
+		CuresDiseases:     []int64{1, 5},
+		Services:          []int64{2},
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(list.Specialists, 1)
+	s.Equal(int64(1), list.Specialists[0].ID)
+	s.Len(list.Specialists[0].Specializations, 3)
+
+	list, err = s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{Languages: []string{"uk"}})
+	s.Require().NoError(err)
+
+	s.Len(list.Specialists, 0)
+
+	_, err = s.client.GetSpecialists(s.ctx, s.token.Access, &model.ListSpecialistsRequest{MedicalCategories: []string{"4"}})
+	s.Require().Error(err)
+}
+
 func (s *SpecialistTestSuite) TestGetSpecialist() {
 	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
 	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
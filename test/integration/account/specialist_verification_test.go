@@ -0,0 +1,102 @@
+package account
+
+import (
+	"github.com/Hvaekar/med-account/pkg/model"
+	"github.com/Hvaekar/med-account/test/integration/account/fixtures"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type VerificationTestSuite struct {
+	TestSuite
+}
+
+func TestVerificationSuite(t *testing.T) {
+	suite.Run(t, new(VerificationTestSuite))
+}
+
+func (s *VerificationTestSuite) TestAddVerification() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	comment := "Diploma scan"
+	req := model.AddVerification{
+		Entity:   model.VerificationEntityExperience,
+		EntityID: 1,
+		Comment:  &comment,
+		Files:    []int64{1, 2},
+	}
+
+	verification, err := s.client.AddVerification(s.ctx, s.token.Access, &req)
+	s.Require().NoError(err)
+
+	s.NotEmpty(verification.ID)
+	s.Equal(req.Entity, verification.Entity)
+	s.Equal(req.EntityID, verification.EntityID)
+	s.Equal(model.VerificationStatusPending, verification.Status)
+	s.Len(verification.Files, 2)
+
+	// only one pending request per entity
+	_, err = s.client.AddVerification(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *VerificationTestSuite) TestAddVerificationForeignEntity() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddVerification{
+		Entity:   model.VerificationEntityEducation,
+		EntityID: 2,
+		Files:    []int64{1},
+	}
+
+	_, err := s.client.AddVerification(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *VerificationTestSuite) TestAddVerificationNoFiles() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	req := model.AddVerification{
+		Entity:   model.VerificationEntityExperience,
+		EntityID: 1,
+	}
+
+	_, err := s.client.AddVerification(s.ctx, s.token.Access, &req)
+	s.Require().Error(err)
+}
+
+func (s *VerificationTestSuite) TestGetVerifications() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	list, err := s.client.GetVerifications(s.ctx, s.token.Access)
+	s.Require().NoError(err)
+
+	s.Len(list.Verifications, 2)
+}
+
+func (s *VerificationTestSuite) TestGetVerification() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	verification, err := s.client.GetVerification(s.ctx, s.token.Access, 2)
+	s.Require().NoError(err)
+
+	s.Equal(model.VerificationStatusRejected, verification.Status)
+	s.Require().NotNil(verification.ReviewerComment)
+	s.Require().NotNil(verification.ReviewedAt)
+	s.Len(verification.Files, 1)
+}
+
+func (s *VerificationTestSuite) TestDeleteVerification() {
+	s.Require().NoError(s.db.TruncateTables(s.ctx, truncateTables...))
+	s.Require().NoError(fixtures.PopulateDB(s.ctx, s.db.GetDB()))
+
+	s.Require().NoError(s.client.DeleteVerification(s.ctx, s.token.Access, 1))
+
+	// reviewed requests stay in history
+	s.Require().Error(s.client.DeleteVerification(s.ctx, s.token.Access, 2))
+}
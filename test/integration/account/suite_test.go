@@ -12,6 +12,7 @@ import (
 	"github.com/Hvaekar/med-account/pkg/dockertest/postgrestest"
 	"github.com/Hvaekar/med-account/pkg/dockertest/zookeepertest"
 	"github.com/Hvaekar/med-account/pkg/jwt"
+	"github.com/Hvaekar/med-account/pkg/licence"
 	"github.com/Hvaekar/med-account/pkg/logger"
 	"github.com/Hvaekar/med-account/pkg/model"
 	"github.com/Hvaekar/med-account/pkg/storage"
@@ -31,21 +32,57 @@ var truncateTables = []string{
 	"account_phones",
 	"account_addresses",
 	"account_languages",
+	"account_privacy_settings",
+	"account_roles",
+	"account_exports",
+	"organizations",
+	"organization_licences",
+	"organization_members",
+	"organization_invitations",
 	"patient_profiles",
 	"accounts_patient_profiles",
 	"patient_disability_files",
 	"patient_metal_components",
+	"patient_metal_component_files",
+	"patient_measurements",
 	"specialist_profiles",
 	"specialist_specializations",
 	"specialist_cures_diseases",
+	"disease_icd10_codes",
 	"specialist_services",
 	"specialist_educations",
 	"specialist_education_files",
+	"specialist_educational_courses",
+	"specialist_educational_course_files",
 	"specialist_experiences",
 	"specialist_experience_specializations",
 	"specialist_associations",
 	"specialist_patents",
 	"specialist_publication_links",
+	"specialist_teachings",
+	"specialist_speeches",
+	"specialist_licences",
+	"specialist_verifications",
+	"specialist_verification_files",
+	"specialist_schedules",
+	"specialist_schedule_hours",
+	"specialist_schedule_exceptions",
+	"specialist_reviews",
+	"patient_specialists",
+	"patient_allergies",
+	"patient_conditions",
+	"patient_medications",
+	"patient_vaccinations",
+	"patient_vaccination_files",
+	"patient_emergency_contacts",
+	"patient_insurance_policies",
+	"patient_insurance_policy_files",
+	"patient_family_history",
+	"patient_lifestyle_records",
+	"patient_lifestyle_hazards",
+	"patient_duplicates",
+	"patient_duplicate_scans",
+	"patient_history",
 }
 
 type TestSuite struct {
@@ -53,6 +90,7 @@ type TestSuite struct {
 	ctx    context.Context
 	cm     *dockertest.ContainerManager
 	cfg    *config.Config
+	log    logger.Logger
 	db     storage.Storage
 	s3     *amazon.S3
 	mb     broker.MessageBroker
@@ -61,6 +99,7 @@ type TestSuite struct {
 
 	uploaderAPIMock   *amazon.MockUploaderAPI
 	downloaderAPIMock *amazon.MockDownloaderAPI
+	licenceStub       *licence.Stub
 }
 
 func (s *TestSuite) SetupSuite() {
@@ -74,6 +113,18 @@ func (s *TestSuite) SetupSuite() {
 	// change log level to debug
 	cfg.Logger.Level = "debug"
 
+	// disable licence reminders in background
+	cfg.Licence.RemindInterval = 0
+
+	// disable insurance reminders in background
+	cfg.Insurance.RemindInterval = 0
+
+	// disable export archives in background
+	cfg.Export.Interval = 0
+
+	// disable duplicate detection in background
+	cfg.Duplicate.Interval = 0
+
 	s.cfg = cfg
 
 	// init logger
@@ -81,6 +132,8 @@ func (s *TestSuite) SetupSuite() {
 	err = log.Init(cfg)
 	s.Require().NoError(err)
 
+	s.log = log
+
 	// add context
 	s.ctx = context.Background()
 
@@ -124,8 +177,16 @@ func (s *TestSuite) SetupSuite() {
 	aws := amazon.NewAWS(&cfg.AWS)
 	_ = aws.CreateSession()
 
+	// licence registry stub
+	s.licenceStub = licence.NewStub(
+		&licence.Result{Number: "LC-000001", Status: model.LicenceStatusActive, FullName: "Ivan Ivanov", Practice: "Cardiology"},
+		&licence.Result{Number: "LC-000002", Status: model.LicenceStatusActive, FullName: "Ivan Ivanov", Practice: "Therapy"},
+		&licence.Result{Number: "LC-000003", Status: model.LicenceStatusActive, FullName: "Ivan Ivanov", Practice: "Surgery"},
+		&licence.Result{Number: "LC-000004", Status: model.LicenceStatusSuspended, FullName: "Ivan Ivanov", Practice: "Surgery"},
+	)
+
 	// create app
-	handler, db, s3, mb, err := commands.CreateApp(cfg, log, aws, s.uploaderAPIMock, s.downloaderAPIMock)
+	handler, db, s3, mb, err := commands.CreateApp(s.ctx, cfg, log, aws, s.uploaderAPIMock, s.downloaderAPIMock, s.licenceStub)
 	s.Require().NoError(err)
 
 	s.db = db